@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Msg_UpdateParams_FullMethodName = "/configmodule.v1.Msg/UpdateParams"
+	Msg_UpdateParams_FullMethodName       = "/configmodule.v1.Msg/UpdateParams"
+	Msg_RegisterAttestator_FullMethodName = "/configmodule.v1.Msg/RegisterAttestator"
 )
 
 // MsgClient is the client API for Msg service.
@@ -29,6 +30,9 @@ type MsgClient interface {
 	// UpdateParams defines a governance operation for updating the
 	// configmodule module parameters.
 	UpdateParams(ctx context.Context, in *MsgUpdateParams, opts ...grpc.CallOption) (*MsgUpdateParamsResponse, error)
+	// RegisterAttestator registers the attestation public key of a validator's
+	// attestator.
+	RegisterAttestator(ctx context.Context, in *MsgRegisterAttestator, opts ...grpc.CallOption) (*MsgRegisterAttestatorResponse, error)
 }
 
 type msgClient struct {
@@ -48,6 +52,15 @@ func (c *msgClient) UpdateParams(ctx context.Context, in *MsgUpdateParams, opts
 	return out, nil
 }
 
+func (c *msgClient) RegisterAttestator(ctx context.Context, in *MsgRegisterAttestator, opts ...grpc.CallOption) (*MsgRegisterAttestatorResponse, error) {
+	out := new(MsgRegisterAttestatorResponse)
+	err := c.cc.Invoke(ctx, Msg_RegisterAttestator_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MsgServer is the server API for Msg service.
 // All implementations must embed UnimplementedMsgServer
 // for forward compatibility
@@ -55,6 +68,9 @@ type MsgServer interface {
 	// UpdateParams defines a governance operation for updating the
 	// configmodule module parameters.
 	UpdateParams(context.Context, *MsgUpdateParams) (*MsgUpdateParamsResponse, error)
+	// RegisterAttestator registers the attestation public key of a validator's
+	// attestator.
+	RegisterAttestator(context.Context, *MsgRegisterAttestator) (*MsgRegisterAttestatorResponse, error)
 	mustEmbedUnimplementedMsgServer()
 }
 
@@ -65,6 +81,9 @@ type UnimplementedMsgServer struct {
 func (UnimplementedMsgServer) UpdateParams(context.Context, *MsgUpdateParams) (*MsgUpdateParamsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateParams not implemented")
 }
+func (UnimplementedMsgServer) RegisterAttestator(context.Context, *MsgRegisterAttestator) (*MsgRegisterAttestatorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterAttestator not implemented")
+}
 func (UnimplementedMsgServer) mustEmbedUnimplementedMsgServer() {}
 
 // UnsafeMsgServer may be embedded to opt out of forward compatibility for this service.
@@ -96,6 +115,24 @@ func _Msg_UpdateParams_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Msg_RegisterAttestator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRegisterAttestator)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RegisterAttestator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Msg_RegisterAttestator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RegisterAttestator(ctx, req.(*MsgRegisterAttestator))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Msg_ServiceDesc is the grpc.ServiceDesc for Msg service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -107,6 +144,10 @@ var Msg_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateParams",
 			Handler:    _Msg_UpdateParams_Handler,
 		},
+		{
+			MethodName: "RegisterAttestator",
+			Handler:    _Msg_RegisterAttestator_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "configmodule/v1/tx.proto",
@@ -24,12 +24,17 @@ func (am AppModule) AutoCLIOptions() *autocliv1.ModuleOptions {
 			},
 		},
 		Tx: &autocliv1.ServiceCommandDescriptor{
-			Service: configmodulev1.Msg_ServiceDesc.ServiceName,
+			Service:              configmodulev1.Msg_ServiceDesc.ServiceName,
+			EnhanceCustomCommand: true,
 			RpcCommandOptions: []*autocliv1.RpcCommandOptions{
 				{
 					RpcMethod: "UpdateParams",
 					Skip:      true, // skipped because authority gated
 				},
+				{
+					RpcMethod: "RegisterAttestator",
+					Skip:      true, // custom command in client/cli, since it takes a registration file
+				},
 			},
 		},
 	}
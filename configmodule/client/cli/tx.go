@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+
+	"github.com/cosmos/interchain-attestation/configmodule/types"
+	coretypes "github.com/cosmos/interchain-attestation/core/types"
+)
+
+// GetTxCmd returns the transaction commands for the attestationconfig module
+func GetTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      fmt.Sprintf("%s transactions subcommands", types.ModuleName),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(NewRegisterAttestatorCmd())
+
+	return cmd
+}
+
+// NewRegisterAttestatorCmd returns a command that registers the attestator of the validator signing the transaction
+func NewRegisterAttestatorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "register-attestator [registration-json-file]",
+		Short: "Register the attestation public key of your validator's attestator",
+		Long: `Register the attestation public key of your validator's attestator.
+The registration file can be generated with the sidecar: attestation-sidecar attestation-key registration`,
+		Example: fmt.Sprintf("%s tx %s register-attestator registration.json --from mykey", version.AppName, types.ModuleName),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			registration, err := coretypes.ParseAndValidateAttestationRegistrationJSONFromFile(clientCtx.Codec, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgRegisterAttestator{
+				ValidatorAddress:     sdk.ValAddress(clientCtx.GetFromAddress()).String(),
+				AttestatorId:         registration.AttestatorID,
+				AttestationPublicKey: registration.AttestationPublicKey,
+				ProofOfPossession:    registration.ProofOfPossession,
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
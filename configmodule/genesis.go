@@ -11,6 +11,16 @@ func InitGenesis(ctx sdk.Context, k keeper.Keeper, data types.GenesisState) {
 	if err := k.Params.Set(ctx, *data.Params); err != nil {
 		panic(err)
 	}
+
+	for _, attestator := range data.Attestators {
+		// attestators from genesis skip MsgRegisterAttestator, so their proof of possession is checked here as well
+		if err := attestator.Validate(); err != nil {
+			panic(err)
+		}
+		if err := k.SetAttestator(ctx, attestator); err != nil {
+			panic(err)
+		}
+	}
 }
 
 func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
@@ -19,7 +29,16 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
 		panic(err)
 	}
 
+	var attestators []types.Attestator
+	if err := k.Attestators.Walk(ctx, nil, func(_ []byte, attestator types.Attestator) (bool, error) {
+		attestators = append(attestators, attestator)
+		return false, nil
+	}); err != nil {
+		panic(err)
+	}
+
 	return &types.GenesisState{
-		Params: &params,
+		Params:      &params,
+		Attestators: attestators,
 	}
 }
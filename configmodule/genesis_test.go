@@ -5,11 +5,25 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	"github.com/cosmos/interchain-attestation/configmodule"
 	"github.com/cosmos/interchain-attestation/configmodule/types"
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
 )
 
 func TestGenesis(t *testing.T) {
+	attestator, err := types.NewAttestator([]byte("attestator-0"), secp256k1.GenPrivKey().PubKey(), "cosmosvaloper1gp957czryfgyvxwn3tfnyy2f0t9g2p4pqeemx8", nil)
+	require.NoError(t, err)
+
+	blsPrivKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	proofOfPossession, err := blsPrivKey.ProofOfPossession()
+	require.NoError(t, err)
+	blsAttestator, err := types.NewAttestator([]byte("attestator-1"), blsPrivKey.PubKey(), "cosmosvaloper1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc56kct20", proofOfPossession)
+	require.NoError(t, err)
+
 	testCases := []struct {
 		name    string
 		genesis types.GenesisState
@@ -25,6 +39,13 @@ func TestGenesis(t *testing.T) {
 				Params: &types.Params{},
 			},
 		},
+		{
+			name: "with attestators",
+			genesis: types.GenesisState{
+				Params:      &types.Params{},
+				Attestators: []types.Attestator{attestator, blsAttestator},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -37,6 +58,32 @@ func TestGenesis(t *testing.T) {
 
 			exportedGenesis := configmodule.ExportGenesis(ctx, suite.Keeper)
 			require.Equal(t, tc.genesis.Params, exportedGenesis.Params)
+			require.Equal(t, tc.genesis.Attestators, exportedGenesis.Attestators)
+
+			for _, attestator := range tc.genesis.Attestators {
+				valAddr, err := sdk.ValAddressFromBech32(attestator.ValidatorAddress)
+				require.NoError(t, err)
+				attestatorID, err := suite.Keeper.ValidatorAttestators.Get(ctx, valAddr)
+				require.NoError(t, err)
+				require.Equal(t, attestator.AttestatorId, attestatorID)
+			}
 		})
 	}
 }
+
+func TestInitGenesisInvalidProofOfPossession(t *testing.T) {
+	blsPrivKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	attestator, err := types.NewAttestator([]byte("attestator-0"), blsPrivKey.PubKey(), "cosmosvaloper1gp957czryfgyvxwn3tfnyy2f0t9g2p4pqeemx8", []byte("invalid proof"))
+	require.NoError(t, err)
+
+	suite := createTestSuite(t)
+	ctx := suite.App.BaseApp.NewContext(false)
+
+	require.PanicsWithError(t, "invalid attestator 61747465737461746F722D30: invalid proof of possession for BLS12-381 attestation public key", func() {
+		configmodule.InitGenesis(ctx, suite.Keeper, types.GenesisState{
+			Params:      &types.Params{},
+			Attestators: []types.Attestator{attestator},
+		})
+	})
+}
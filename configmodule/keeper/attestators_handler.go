@@ -2,7 +2,16 @@ package keeper
 
 import (
 	"context"
+	"fmt"
 
+	"cosmossdk.io/errors"
+	"cosmossdk.io/math"
+
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/cosmos/interchain-attestation/configmodule/types"
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
 	"github.com/cosmos/interchain-attestation/core/lightclient"
 )
 
@@ -14,9 +23,115 @@ func NewAttestatorHandler(k Keeper) lightclient.AttestatorsController {
 	return AttestatorHandler{k: k}
 }
 
-// TODO: Implement properly
+// SufficientAttestations returns true if the validators that registered the attestators have more than 2/3 of the
+// bonded tokens. Every validator is only counted once, and validators that are not bonded (anymore) don't count.
 func (a AttestatorHandler) SufficientAttestations(ctx context.Context, attestatorIds [][]byte) (bool, error) {
-	// TODO implement me
-	// Just return true for now until we implement the actual logic
-	return true, nil
+	totalBondedTokens, err := a.k.stakingKeeper.TotalBondedTokens(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !totalBondedTokens.IsPositive() {
+		return false, nil
+	}
+
+	attestedTokens := math.ZeroInt()
+	seenValidators := make(map[string]bool)
+	for _, attestatorID := range attestatorIds {
+		attestator, err := a.k.Attestators.Get(ctx, attestatorID)
+		if err != nil {
+			return false, errors.Wrapf(types.ErrInvalidAttestator, "attestator %X not registered: %s", attestatorID, err)
+		}
+		if seenValidators[attestator.ValidatorAddress] {
+			continue
+		}
+		seenValidators[attestator.ValidatorAddress] = true
+
+		valAddr, err := a.k.validatorAddressCodec.StringToBytes(attestator.ValidatorAddress)
+		if err != nil {
+			return false, err
+		}
+		validator, err := a.k.stakingKeeper.GetValidator(ctx, valAddr)
+		if errors.IsOf(err, stakingtypes.ErrNoValidatorFound) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		attestedTokens = attestedTokens.Add(validator.GetBondedTokens())
+	}
+
+	return attestedTokens.MulRaw(3).GT(totalBondedTokens.MulRaw(2)), nil
+}
+
+// VerifySignatures verifies the signatures against the registered attestation public keys.
+// Aggregate signatures are only supported if all the attestators have BLS12-381 keys.
+func (a AttestatorHandler) VerifySignatures(ctx context.Context, signBytes []byte, attestatorIds [][]byte, signatures [][]byte, aggregateSignature []byte) error {
+	if len(aggregateSignature) != 0 {
+		blsPubKeys := make([]*bls12381.PubKey, len(attestatorIds))
+		for i, attestatorID := range attestatorIds {
+			pubKey, err := a.attestatorPubKey(ctx, attestatorID)
+			if err != nil {
+				return err
+			}
+			blsPubKey, ok := pubKey.(*bls12381.PubKey)
+			if !ok {
+				return errors.Wrapf(types.ErrInvalidAttestator, "aggregate signatures not supported for %s keys (attestator %X)", pubKey.Type(), attestatorID)
+			}
+			blsPubKeys[i] = blsPubKey
+		}
+
+		if !bls12381.VerifyAggregateSignature(blsPubKeys, signBytes, aggregateSignature) {
+			return fmt.Errorf("invalid aggregate signature")
+		}
+
+		return nil
+	}
+
+	if len(signatures) != len(attestatorIds) {
+		return fmt.Errorf("expected %d signatures, got %d", len(attestatorIds), len(signatures))
+	}
+
+	for i, attestatorID := range attestatorIds {
+		pubKey, err := a.attestatorPubKey(ctx, attestatorID)
+		if err != nil {
+			return err
+		}
+		if !pubKey.VerifySignature(signBytes, signatures[i]) {
+			return fmt.Errorf("invalid signature from attestator %X", attestatorID)
+		}
+	}
+
+	return nil
+}
+
+// AttestatorSet returns the ids of the registered attestators, ordered by id
+func (a AttestatorHandler) AttestatorSet(ctx context.Context) ([][]byte, error) {
+	var attestatorSet [][]byte
+	if err := a.k.Attestators.Walk(ctx, nil, func(attestatorID []byte, _ types.Attestator) (bool, error) {
+		attestatorSet = append(attestatorSet, attestatorID)
+		return false, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return attestatorSet, nil
+}
+
+// AttestatorOperator returns the operator address of the validator that registered the attestator
+func (a AttestatorHandler) AttestatorOperator(ctx context.Context, attestatorID []byte) ([]byte, error) {
+	attestator, err := a.k.Attestators.Get(ctx, attestatorID)
+	if err != nil {
+		return nil, errors.Wrapf(types.ErrInvalidAttestator, "attestator %X not registered: %s", attestatorID, err)
+	}
+
+	return a.k.validatorAddressCodec.StringToBytes(attestator.ValidatorAddress)
+}
+
+func (a AttestatorHandler) attestatorPubKey(ctx context.Context, attestatorID []byte) (cryptotypes.PubKey, error) {
+	attestator, err := a.k.Attestators.Get(ctx, attestatorID)
+	if err != nil {
+		return nil, errors.Wrapf(types.ErrInvalidAttestator, "attestator %X not registered: %s", attestatorID, err)
+	}
+
+	return attestator.GetPubKey()
 }
@@ -1,10 +1,193 @@
 package keeper_test
 
-import "github.com/cosmos/interchain-attestation/configmodule/keeper"
+import (
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/interchain-attestation/configmodule/keeper"
+	"github.com/cosmos/interchain-attestation/configmodule/types"
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
+)
 
 func (s *KeeperTestSuite) TestSufficientAttestations() {
 	attestatorsHandler := keeper.NewAttestatorHandler(s.keeper)
 
-	// TODO: Test when implemented properly, right now it just always returns true
-	s.Require().True(attestatorsHandler.SufficientAttestations(s.ctx, nil))
+	// without any bonded tokens, there is never a quorum
+	s.registerAttestator([]byte("unbonded"), secp256k1.GenPrivKey().PubKey())
+	sufficient, err := attestatorsHandler.SufficientAttestations(s.ctx, [][]byte{[]byte("unbonded")})
+	s.Require().NoError(err)
+	s.Require().False(sufficient)
+
+	for id, tokens := range map[string]int64{"val-40": 40, "val-30": 30, "val-20": 20, "val-10": 10} {
+		s.registerValidatorAttestator([]byte(id), s.addBondedValidator(tokens))
+	}
+	// a second attestator of the same validator doesn't add to its share
+	attestator, err := s.keeper.Attestators.Get(s.ctx, []byte("val-40"))
+	s.Require().NoError(err)
+	attestator.AttestatorId = []byte("val-40-other")
+	s.Require().NoError(s.keeper.Attestators.Set(s.ctx, attestator.AttestatorId, attestator))
+
+	testCases := []struct {
+		name          string
+		attestatorIDs [][]byte
+		expSufficient bool
+		expErr        string
+	}{
+		{"no attestators", nil, false, ""},
+		{"single attestator below quorum", [][]byte{[]byte("val-40")}, false, ""},
+		{"exactly 2/3 is not enough", [][]byte{[]byte("val-40"), []byte("val-20"), []byte("unbonded")}, false, ""},
+		{"same validator counted once", [][]byte{[]byte("val-40"), []byte("val-40-other"), []byte("val-20")}, false, ""},
+		{"more than 2/3", [][]byte{[]byte("val-40"), []byte("val-30")}, true, ""},
+		{"all validators", [][]byte{[]byte("val-40"), []byte("val-30"), []byte("val-20"), []byte("val-10")}, true, ""},
+		{"unregistered attestator", [][]byte{[]byte("unknown")}, false, "not registered"},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			sufficient, err := attestatorsHandler.SufficientAttestations(s.ctx, tc.attestatorIDs)
+			if tc.expErr != "" {
+				s.Require().ErrorContains(err, tc.expErr)
+				return
+			}
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expSufficient, sufficient)
+		})
+	}
+}
+
+func (s *KeeperTestSuite) TestVerifySignatures() {
+	signBytes := []byte("sign bytes")
+
+	secpPrivKey := secp256k1.GenPrivKey()
+	secpSignature, err := secpPrivKey.Sign(signBytes)
+	s.Require().NoError(err)
+
+	var blsIDs [][]byte
+	var blsSignatures [][]byte
+	for _, id := range []string{"bls-1", "bls-2", "bls-3"} {
+		blsPrivKey, err := bls12381.GenPrivKey()
+		s.Require().NoError(err)
+		s.registerAttestator([]byte(id), blsPrivKey.PubKey())
+
+		signature, err := blsPrivKey.Sign(signBytes)
+		s.Require().NoError(err)
+		blsIDs = append(blsIDs, []byte(id))
+		blsSignatures = append(blsSignatures, signature)
+	}
+	s.registerAttestator([]byte("secp"), secpPrivKey.PubKey())
+
+	aggregateSignature, err := bls12381.AggregateSignatures(blsSignatures)
+	s.Require().NoError(err)
+	partialAggregateSignature, err := bls12381.AggregateSignatures(blsSignatures[:2])
+	s.Require().NoError(err)
+
+	testCases := []struct {
+		name               string
+		attestatorIDs      [][]byte
+		signatures         [][]byte
+		aggregateSignature []byte
+		expErr             string
+	}{
+		{
+			"valid: individual signatures",
+			append([][]byte{[]byte("secp")}, blsIDs...),
+			append([][]byte{secpSignature}, blsSignatures...),
+			nil,
+			"",
+		},
+		{
+			"valid: aggregate signature",
+			blsIDs,
+			nil,
+			aggregateSignature,
+			"",
+		},
+		{
+			"invalid: invalid individual signature",
+			[][]byte{[]byte("secp"), blsIDs[0]},
+			[][]byte{secpSignature, blsSignatures[1]},
+			nil,
+			"invalid signature from attestator",
+		},
+		{
+			"invalid: missing signatures",
+			blsIDs,
+			blsSignatures[:2],
+			nil,
+			"expected 3 signatures, got 2",
+		},
+		{
+			"invalid: unregistered attestator",
+			[][]byte{[]byte("unknown")},
+			[][]byte{secpSignature},
+			nil,
+			"not registered",
+		},
+		{
+			"invalid: aggregate signature missing an attestator",
+			blsIDs,
+			nil,
+			partialAggregateSignature,
+			"invalid aggregate signature",
+		},
+		{
+			"invalid: aggregate signature with secp256k1 attestator",
+			append([][]byte{[]byte("secp")}, blsIDs...),
+			nil,
+			aggregateSignature,
+			"aggregate signatures not supported for secp256k1 keys",
+		},
+	}
+
+	attestatorsHandler := keeper.NewAttestatorHandler(s.keeper)
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			err := attestatorsHandler.VerifySignatures(s.ctx, signBytes, tc.attestatorIDs, tc.signatures, tc.aggregateSignature)
+			if tc.expErr != "" {
+				s.Require().ErrorContains(err, tc.expErr)
+			} else {
+				s.Require().NoError(err)
+			}
+		})
+	}
+}
+
+func (s *KeeperTestSuite) TestAttestatorSet() {
+	attestatorsHandler := keeper.NewAttestatorHandler(s.keeper)
+
+	attestatorSet, err := attestatorsHandler.AttestatorSet(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Empty(attestatorSet)
+
+	for _, id := range []string{"c", "a", "b"} {
+		s.registerAttestator([]byte(id), secp256k1.GenPrivKey().PubKey())
+	}
+
+	attestatorSet, err = attestatorsHandler.AttestatorSet(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal([][]byte{[]byte("a"), []byte("b"), []byte("c")}, attestatorSet)
+}
+
+func (s *KeeperTestSuite) TestAttestatorOperator() {
+	attestatorsHandler := keeper.NewAttestatorHandler(s.keeper)
+
+	_, err := attestatorsHandler.AttestatorOperator(s.ctx, []byte("unknown"))
+	s.Require().ErrorContains(err, "not registered")
+
+	s.registerAttestator([]byte("attestator"), secp256k1.GenPrivKey().PubKey())
+	operator, err := attestatorsHandler.AttestatorOperator(s.ctx, []byte("attestator"))
+	s.Require().NoError(err)
+	s.Require().Equal(testValidatorAddress, sdk.ValAddress(operator).String())
+}
+
+func (s *KeeperTestSuite) registerAttestator(attestatorID []byte, pubKey cryptotypes.PubKey) {
+	attestator, err := types.NewAttestator(attestatorID, pubKey, testValidatorAddress, nil)
+	s.Require().NoError(err)
+	s.Require().NoError(s.keeper.Attestators.Set(s.ctx, attestatorID, attestator))
+}
+
+func (s *KeeperTestSuite) registerValidatorAttestator(attestatorID []byte, validatorAddress string) {
+	attestator, err := types.NewAttestator(attestatorID, secp256k1.GenPrivKey().PubKey(), validatorAddress, nil)
+	s.Require().NoError(err)
+	s.Require().NoError(s.keeper.Attestators.Set(s.ctx, attestatorID, attestator))
 }
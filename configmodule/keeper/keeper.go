@@ -1,11 +1,16 @@
 package keeper
 
 import (
+	"bytes"
+	"context"
+
 	"cosmossdk.io/collections"
 	addresscodec "cosmossdk.io/core/address"
 	"cosmossdk.io/core/store"
+	"cosmossdk.io/errors"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/cosmos/interchain-attestation/configmodule/types"
 )
@@ -23,6 +28,11 @@ type Keeper struct {
 
 	Schema collections.Schema
 	Params collections.Item[types.Params]
+	// Attestators are the registered attestators, keyed by attestator id
+	Attestators collections.Map[[]byte, types.Attestator]
+	// ValidatorAttestators is the attestator id of every validator with an attestator, keyed by operator address.
+	// A validator can only have one attestator.
+	ValidatorAttestators collections.Map[sdk.ValAddress, []byte]
 }
 
 func NewKeeper(
@@ -41,6 +51,8 @@ func NewKeeper(
 		authority:             authority,
 		stakingKeeper:         stakingKeeper,
 		Params:                collections.NewItem(sb, types.ParamsKey, "params", codec.CollValue[types.Params](cdc)),
+		Attestators:           collections.NewMap(sb, types.AttestatorsKey, "attestators", collections.BytesKey, codec.CollValue[types.Attestator](cdc)),
+		ValidatorAttestators:  collections.NewMap(sb, types.ValidatorAttestatorsKey, "validator_attestators", sdk.ValAddressKey, collections.BytesValue),
 	}
 
 	schema, err := sb.Build()
@@ -56,3 +68,28 @@ func NewKeeper(
 func (k Keeper) GetAuthority() string {
 	return k.authority
 }
+
+// SetAttestator stores the attestator as the attestator of its validator. An attestator the validator registered
+// before (with another attestator id) is removed, since a validator can only have one attestator.
+func (k Keeper) SetAttestator(ctx context.Context, attestator types.Attestator) error {
+	valAddr, err := k.validatorAddressCodec.StringToBytes(attestator.ValidatorAddress)
+	if err != nil {
+		return errors.Wrapf(err, "invalid validator address %s", attestator.ValidatorAddress)
+	}
+
+	previousAttestatorID, err := k.ValidatorAttestators.Get(ctx, valAddr)
+	switch {
+	case err == nil && !bytes.Equal(previousAttestatorID, attestator.AttestatorId):
+		if err := k.Attestators.Remove(ctx, previousAttestatorID); err != nil {
+			return err
+		}
+	case err != nil && !errors.IsOf(err, collections.ErrNotFound):
+		return err
+	}
+
+	if err := k.Attestators.Set(ctx, attestator.AttestatorId, attestator); err != nil {
+		return err
+	}
+
+	return k.ValidatorAttestators.Set(ctx, valAddr, attestator.AttestatorId)
+}
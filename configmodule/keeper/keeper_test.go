@@ -1,11 +1,13 @@
 package keeper_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
 
+	"cosmossdk.io/math"
 	storetypes "cosmossdk.io/store/types"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
@@ -42,6 +44,8 @@ type KeeperTestSuite struct {
 	msgSrvr     types.MsgServer
 
 	mockValidator stakingtypes.Validator
+	// validators are the validators of the mocked staking keeper, by operator address
+	validators map[string]stakingtypes.Validator
 }
 
 func TestKeeperTestSuite(t *testing.T) {
@@ -64,11 +68,23 @@ func (suite *KeeperTestSuite) SetupTest() {
 	mockValidator, err := stakingtypes.NewValidator(testValidatorAddress, consPubKey, stakingtypes.Description{})
 	suite.Require().NoError(err)
 	suite.mockValidator = mockValidator
+	suite.validators = map[string]stakingtypes.Validator{testValidatorAddress: mockValidator}
 
 	validatorAddressCodec := address.NewBech32Codec("cosmosvaloper")
-	valAddr, err := validatorAddressCodec.StringToBytes(testValidatorAddress)
-	suite.Require().NoError(err)
-	stakingKeeper.EXPECT().GetValidator(ctx, valAddr).Return(mockValidator, nil).AnyTimes()
+	stakingKeeper.EXPECT().GetValidator(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, valAddr sdk.ValAddress) (stakingtypes.Validator, error) {
+		validator, ok := suite.validators[valAddr.String()]
+		if !ok {
+			return stakingtypes.Validator{}, stakingtypes.ErrNoValidatorFound
+		}
+		return validator, nil
+	}).AnyTimes()
+	stakingKeeper.EXPECT().TotalBondedTokens(gomock.Any()).DoAndReturn(func(_ context.Context) (math.Int, error) {
+		totalBondedTokens := math.ZeroInt()
+		for _, validator := range suite.validators {
+			totalBondedTokens = totalBondedTokens.Add(validator.GetBondedTokens())
+		}
+		return totalBondedTokens, nil
+	}).AnyTimes()
 
 	k := keeper.NewKeeper(
 		storeService,
@@ -95,3 +111,15 @@ func (suite *KeeperTestSuite) SetupTest() {
 	suite.queryClient = queryClient
 	suite.msgSrvr = msgSrvr
 }
+
+// addBondedValidator adds a bonded validator with the given tokens to the staking keeper, and returns its operator address
+func (suite *KeeperTestSuite) addBondedValidator(tokens int64) string {
+	validatorAddress := sdk.ValAddress(ed25519.GenPrivKey().PubKey().Address()).String()
+	validator, err := stakingtypes.NewValidator(validatorAddress, ed25519.GenPrivKey().PubKey(), stakingtypes.Description{})
+	suite.Require().NoError(err)
+	validator.Status = stakingtypes.Bonded
+	validator.Tokens = math.NewInt(tokens)
+	suite.validators[validatorAddress] = validator
+
+	return validatorAddress
+}
@@ -3,9 +3,13 @@ package keeper
 import (
 	"context"
 
+	"cosmossdk.io/collections"
 	"cosmossdk.io/errors"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	"github.com/cosmos/interchain-attestation/configmodule/types"
+	coretypes "github.com/cosmos/interchain-attestation/core/types"
 )
 
 type msgServer struct {
@@ -36,3 +40,49 @@ func (m msgServer) UpdateParams(ctx context.Context, msg *types.MsgUpdateParams)
 
 	return &types.MsgUpdateParamsResponse{}, nil
 }
+
+func (m msgServer) RegisterAttestator(ctx context.Context, msg *types.MsgRegisterAttestator) (*types.MsgRegisterAttestatorResponse, error) {
+	valAddr, err := m.validatorAddressCodec.StringToBytes(msg.ValidatorAddress)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid validator address %s", msg.ValidatorAddress)
+	}
+
+	if _, err := m.stakingKeeper.GetValidator(ctx, sdk.ValAddress(valAddr)); err != nil {
+		return nil, errors.Wrapf(types.ErrUnauthorized, "%s is not a validator: %s", msg.ValidatorAddress, err)
+	}
+
+	if msg.AttestationPublicKey == nil {
+		return nil, errors.Wrap(types.ErrInvalidAttestator, "attestation public key cannot be empty")
+	}
+
+	registration := coretypes.AttestatorRegistration{
+		AttestatorID:         msg.AttestatorId,
+		AttestationPublicKey: msg.AttestationPublicKey,
+		ProofOfPossession:    msg.ProofOfPossession,
+	}
+	if err := registration.Validate(); err != nil {
+		return nil, errors.Wrap(types.ErrInvalidAttestator, err.Error())
+	}
+
+	// the attestator id can be registered again by the same validator (e.g. to rotate the attestation key), but not by another one
+	existing, err := m.Attestators.Get(ctx, msg.AttestatorId)
+	switch {
+	case err == nil && existing.ValidatorAddress != msg.ValidatorAddress:
+		return nil, errors.Wrapf(types.ErrAttestatorAlreadyExists, "attestator %X is registered by validator %s", msg.AttestatorId, existing.ValidatorAddress)
+	case err != nil && !errors.IsOf(err, collections.ErrNotFound):
+		return nil, err
+	}
+
+	// a validator only has one attestator, so a previously registered attestator of the validator is replaced
+	attestator := types.Attestator{
+		AttestatorId:      msg.AttestatorId,
+		PublicKey:         msg.AttestationPublicKey,
+		ValidatorAddress:  msg.ValidatorAddress,
+		ProofOfPossession: msg.ProofOfPossession,
+	}
+	if err := m.SetAttestator(ctx, attestator); err != nil {
+		return nil, errors.Wrapf(err, "failed to register attestator")
+	}
+
+	return &types.MsgRegisterAttestatorResponse{}, nil
+}
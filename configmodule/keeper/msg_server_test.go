@@ -1,7 +1,15 @@
 package keeper_test
 
 import (
+	"cosmossdk.io/collections"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	"github.com/cosmos/interchain-attestation/configmodule/types"
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
 )
 
 func (suite *KeeperTestSuite) TestMsgUpdateParams() {
@@ -56,3 +64,173 @@ func (suite *KeeperTestSuite) TestMsgUpdateParams() {
 		})
 	}
 }
+
+func (suite *KeeperTestSuite) TestMsgRegisterAttestator() {
+	secpPubKey, err := codectypes.NewAnyWithValue(secp256k1.GenPrivKey().PubKey())
+	suite.Require().NoError(err)
+
+	blsPrivKey, err := bls12381.GenPrivKey()
+	suite.Require().NoError(err)
+	blsPubKey, err := codectypes.NewAnyWithValue(blsPrivKey.PubKey())
+	suite.Require().NoError(err)
+	proofOfPossession, err := blsPrivKey.ProofOfPossession()
+	suite.Require().NoError(err)
+
+	otherBLSPrivKey, err := bls12381.GenPrivKey()
+	suite.Require().NoError(err)
+	otherProofOfPossession, err := otherBLSPrivKey.ProofOfPossession()
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name   string
+		input  *types.MsgRegisterAttestator
+		expErr string
+	}{
+		{
+			"valid: secp256k1 attestation key",
+			&types.MsgRegisterAttestator{
+				ValidatorAddress:     testValidatorAddress,
+				AttestatorId:         []byte("secp256k1-attestator"),
+				AttestationPublicKey: secpPubKey,
+			},
+			"",
+		},
+		{
+			"valid: bls12381 attestation key",
+			&types.MsgRegisterAttestator{
+				ValidatorAddress:     testValidatorAddress,
+				AttestatorId:         []byte("bls-attestator"),
+				AttestationPublicKey: blsPubKey,
+				ProofOfPossession:    proofOfPossession,
+			},
+			"",
+		},
+		{
+			"invalid: bls12381 attestation key without proof of possession",
+			&types.MsgRegisterAttestator{
+				ValidatorAddress:     testValidatorAddress,
+				AttestatorId:         []byte("bls-attestator"),
+				AttestationPublicKey: blsPubKey,
+			},
+			"invalid proof of possession",
+		},
+		{
+			"invalid: proof of possession for another bls12381 key",
+			&types.MsgRegisterAttestator{
+				ValidatorAddress:     testValidatorAddress,
+				AttestatorId:         []byte("bls-attestator"),
+				AttestationPublicKey: blsPubKey,
+				ProofOfPossession:    otherProofOfPossession,
+			},
+			"invalid proof of possession",
+		},
+		{
+			"invalid: empty attestator id",
+			&types.MsgRegisterAttestator{
+				ValidatorAddress:     testValidatorAddress,
+				AttestationPublicKey: secpPubKey,
+			},
+			"attestator id cannot be empty",
+		},
+		{
+			"invalid: empty public key",
+			&types.MsgRegisterAttestator{
+				ValidatorAddress: testValidatorAddress,
+				AttestatorId:     []byte("secp256k1-attestator"),
+			},
+			"attestation public key cannot be empty",
+		},
+		{
+			"invalid: not a validator",
+			&types.MsgRegisterAttestator{
+				ValidatorAddress:     "cosmosvaloper1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc56kct20",
+				AttestatorId:         []byte("secp256k1-attestator"),
+				AttestationPublicKey: secpPubKey,
+			},
+			"is not a validator",
+		},
+		{
+			"invalid: invalid validator address",
+			&types.MsgRegisterAttestator{
+				ValidatorAddress:     "invalid",
+				AttestatorId:         []byte("secp256k1-attestator"),
+				AttestationPublicKey: secpPubKey,
+			},
+			"invalid validator address",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+
+			resp, err := suite.msgSrvr.RegisterAttestator(suite.ctx, tc.input)
+			if tc.expErr != "" {
+				suite.Require().Error(err)
+				suite.Require().Contains(err.Error(), tc.expErr)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().NotNil(resp)
+
+			attestator, err := suite.keeper.Attestators.Get(suite.ctx, tc.input.AttestatorId)
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.input.ValidatorAddress, attestator.ValidatorAddress)
+			suite.Require().Equal(tc.input.ProofOfPossession, attestator.ProofOfPossession)
+			pubKey, err := attestator.GetPubKey()
+			suite.Require().NoError(err)
+			suite.Require().True(pubKey.Equals(tc.input.AttestationPublicKey.GetCachedValue().(cryptotypes.PubKey)))
+
+			valAddr, err := sdk.ValAddressFromBech32(tc.input.ValidatorAddress)
+			suite.Require().NoError(err)
+			attestatorID, err := suite.keeper.ValidatorAttestators.Get(suite.ctx, valAddr)
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.input.AttestatorId, attestatorID)
+
+			// The same validator may register the same attestator again
+			_, err = suite.msgSrvr.RegisterAttestator(suite.ctx, tc.input)
+			suite.Require().NoError(err)
+
+			// Another validator cannot take over the attestator
+			otherInput := *tc.input
+			otherInput.ValidatorAddress = suite.addBondedValidator(1)
+			_, err = suite.msgSrvr.RegisterAttestator(suite.ctx, &otherInput)
+			suite.Require().ErrorIs(err, types.ErrAttestatorAlreadyExists)
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestMsgRegisterAttestatorReplacesPreviousAttestator() {
+	firstPubKey, err := codectypes.NewAnyWithValue(secp256k1.GenPrivKey().PubKey())
+	suite.Require().NoError(err)
+	secondPubKey, err := codectypes.NewAnyWithValue(secp256k1.GenPrivKey().PubKey())
+	suite.Require().NoError(err)
+
+	_, err = suite.msgSrvr.RegisterAttestator(suite.ctx, &types.MsgRegisterAttestator{
+		ValidatorAddress:     testValidatorAddress,
+		AttestatorId:         []byte("first-attestator"),
+		AttestationPublicKey: firstPubKey,
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.msgSrvr.RegisterAttestator(suite.ctx, &types.MsgRegisterAttestator{
+		ValidatorAddress:     testValidatorAddress,
+		AttestatorId:         []byte("second-attestator"),
+		AttestationPublicKey: secondPubKey,
+	})
+	suite.Require().NoError(err)
+
+	// The first attestator is replaced by the second one
+	_, err = suite.keeper.Attestators.Get(suite.ctx, []byte("first-attestator"))
+	suite.Require().ErrorIs(err, collections.ErrNotFound)
+
+	attestator, err := suite.keeper.Attestators.Get(suite.ctx, []byte("second-attestator"))
+	suite.Require().NoError(err)
+	suite.Require().Equal(testValidatorAddress, attestator.ValidatorAddress)
+
+	valAddr, err := sdk.ValAddressFromBech32(testValidatorAddress)
+	suite.Require().NoError(err)
+	attestatorID, err := suite.keeper.ValidatorAttestators.Get(suite.ctx, valAddr)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]byte("second-attestator"), attestatorID)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/spf13/cobra"
 
 	"cosmossdk.io/client/v2/autocli"
 	"cosmossdk.io/core/appmodule"
@@ -23,6 +24,7 @@ import (
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
 	modulev1 "github.com/cosmos/interchain-attestation/configmodule/api/configmodule/module/v1"
+	"github.com/cosmos/interchain-attestation/configmodule/client/cli"
 	"github.com/cosmos/interchain-attestation/configmodule/keeper"
 	"github.com/cosmos/interchain-attestation/configmodule/types"
 )
@@ -39,7 +41,8 @@ var (
 	_ module.HasConsensusVersion = (*AppModule)(nil)
 	_ module.HasServices         = (*AppModule)(nil)
 
-	_ autocli.HasAutoCLIConfig = (*AppModule)(nil)
+	_ autocli.HasAutoCLIConfig   = (*AppModule)(nil)
+	_ autocli.HasCustomTxCommand = (*AppModule)(nil)
 
 	_ appmodule.AppModule = (*AppModule)(nil)
 )
@@ -77,6 +80,11 @@ func (AppModuleBasic) RegisterInterfaces(registry codectypes.InterfaceRegistry)
 	types.RegisterInterfaces(registry)
 }
 
+// GetTxCmd returns the custom tx commands for the attestationconfig module, which autocli enhances with the rest.
+func (AppModuleBasic) GetTxCmd() *cobra.Command {
+	return cli.GetTxCmd()
+}
+
 // ----------------------------------------------------------------------------
 // AppModule
 // ----------------------------------------------------------------------------
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+
+	coretypes "github.com/cosmos/interchain-attestation/core/types"
+)
+
+var (
+	_ codectypes.UnpackInterfacesMessage = Attestator{}
+	_ codectypes.UnpackInterfacesMessage = MsgRegisterAttestator{}
+)
+
+// NewAttestator creates a new Attestator with the given public key (and proof of possession, required for BLS12-381 keys)
+func NewAttestator(attestatorID []byte, pubKey cryptotypes.PubKey, validatorAddress string, proofOfPossession []byte) (Attestator, error) {
+	pubKeyAny, err := codectypes.NewAnyWithValue(pubKey)
+	if err != nil {
+		return Attestator{}, err
+	}
+
+	return Attestator{
+		AttestatorId:      attestatorID,
+		PublicKey:         pubKeyAny,
+		ValidatorAddress:  validatorAddress,
+		ProofOfPossession: proofOfPossession,
+	}, nil
+}
+
+// Validate checks that the attestator has an id and a public key, and that BLS12-381 keys come with a valid proof of possession
+func (a Attestator) Validate() error {
+	if len(a.AttestatorId) == 0 {
+		return errors.New("attestator id cannot be empty")
+	}
+	if _, err := a.GetPubKey(); err != nil {
+		return fmt.Errorf("invalid public key for attestator %X: %w", a.AttestatorId, err)
+	}
+
+	registration := coretypes.AttestatorRegistration{
+		AttestatorID:         a.AttestatorId,
+		AttestationPublicKey: a.PublicKey,
+		ProofOfPossession:    a.ProofOfPossession,
+	}
+	if err := registration.Validate(); err != nil {
+		return fmt.Errorf("invalid attestator %X: %w", a.AttestatorId, err)
+	}
+
+	return nil
+}
+
+// GetPubKey returns the attestation public key of the attestator
+func (a Attestator) GetPubKey() (cryptotypes.PubKey, error) {
+	if a.PublicKey == nil {
+		return nil, fmt.Errorf("public key cannot be empty")
+	}
+
+	pubKey, ok := a.PublicKey.GetCachedValue().(cryptotypes.PubKey)
+	if !ok {
+		return nil, fmt.Errorf("expecting cryptotypes.PubKey, got %T", a.PublicKey.GetCachedValue())
+	}
+
+	return pubKey, nil
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage.UnpackInterfaces
+func (a Attestator) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	var pubKey cryptotypes.PubKey
+	return unpacker.UnpackAny(a.PublicKey, &pubKey)
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage.UnpackInterfaces
+func (m MsgRegisterAttestator) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	var pubKey cryptotypes.PubKey
+	return unpacker.UnpackAny(m.AttestationPublicKey, &pubKey)
+}
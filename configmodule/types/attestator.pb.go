@@ -0,0 +1,503 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: configmodule/v1/attestator.proto
+
+package types
+
+import (
+	fmt "fmt"
+	_ "github.com/cosmos/cosmos-proto"
+	types "github.com/cosmos/cosmos-sdk/codec/types"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	proto "github.com/cosmos/gogoproto/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// Attestator is a registered attestator whose signatures the light client
+// verifies attestation claims against.
+type Attestator struct {
+	// attestator_id is the id the attestator uses in its attestations
+	AttestatorId []byte `protobuf:"bytes,1,opt,name=attestator_id,json=attestatorId,proto3" json:"attestator_id,omitempty"`
+	// public_key is the attestation public key of the attestator
+	PublicKey *types.Any `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// validator_address is the operator address of the validator that
+	// registered the attestator
+	ValidatorAddress string `protobuf:"bytes,3,opt,name=validator_address,json=validatorAddress,proto3" json:"validator_address,omitempty"`
+	// proof_of_possession is a signature over the public key, required for
+	// BLS12-381 keys to prevent rogue key attacks on aggregate signatures
+	ProofOfPossession []byte `protobuf:"bytes,4,opt,name=proof_of_possession,json=proofOfPossession,proto3" json:"proof_of_possession,omitempty"`
+}
+
+func (m *Attestator) Reset()         { *m = Attestator{} }
+func (m *Attestator) String() string { return proto.CompactTextString(m) }
+func (*Attestator) ProtoMessage()    {}
+func (*Attestator) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d1aabbaa6a62f312, []int{0}
+}
+func (m *Attestator) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Attestator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Attestator.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Attestator) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Attestator.Merge(m, src)
+}
+func (m *Attestator) XXX_Size() int {
+	return m.Size()
+}
+func (m *Attestator) XXX_DiscardUnknown() {
+	xxx_messageInfo_Attestator.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Attestator proto.InternalMessageInfo
+
+func (m *Attestator) GetAttestatorId() []byte {
+	if m != nil {
+		return m.AttestatorId
+	}
+	return nil
+}
+
+func (m *Attestator) GetPublicKey() *types.Any {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *Attestator) GetValidatorAddress() string {
+	if m != nil {
+		return m.ValidatorAddress
+	}
+	return ""
+}
+
+func (m *Attestator) GetProofOfPossession() []byte {
+	if m != nil {
+		return m.ProofOfPossession
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Attestator)(nil), "configmodule.v1.Attestator")
+}
+
+func init() { proto.RegisterFile("configmodule/v1/attestator.proto", fileDescriptor_d1aabbaa6a62f312) }
+
+var fileDescriptor_d1aabbaa6a62f312 = []byte{
+	// 344 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x91, 0xbf, 0x4e, 0xeb, 0x30,
+	0x14, 0x87, 0x9b, 0xde, 0x2b, 0xa4, 0x9a, 0x22, 0x68, 0xe8, 0x10, 0x2a, 0x11, 0x05, 0x58, 0xba,
+	0xd4, 0x51, 0x61, 0x64, 0x6a, 0x37, 0x54, 0x01, 0x55, 0x11, 0x0c, 0x2c, 0x51, 0xfe, 0x38, 0xa9,
+	0x45, 0xea, 0x13, 0xd9, 0x4e, 0x24, 0x6f, 0x3c, 0x02, 0x0f, 0xd3, 0x87, 0x40, 0x4c, 0x15, 0x13,
+	0x23, 0x6a, 0x5f, 0x04, 0x11, 0x37, 0x2d, 0xb0, 0xf9, 0x9c, 0xef, 0x67, 0x7f, 0xf6, 0x31, 0x72,
+	0x42, 0x60, 0x31, 0x4d, 0x66, 0x10, 0xe5, 0x29, 0x71, 0x8b, 0xbe, 0xeb, 0x4b, 0x49, 0x84, 0xf4,
+	0x25, 0x70, 0x9c, 0x71, 0x90, 0x60, 0xee, 0xff, 0x4c, 0xe0, 0xa2, 0xdf, 0x69, 0x27, 0x90, 0x40,
+	0xc9, 0xdc, 0xef, 0x95, 0x8e, 0x75, 0x8e, 0x42, 0x10, 0x33, 0x10, 0x9e, 0x06, 0xba, 0xa8, 0x50,
+	0x02, 0x90, 0xa4, 0xc4, 0x2d, 0xab, 0x20, 0x8f, 0x5d, 0x9f, 0x29, 0x8d, 0x4e, 0x9f, 0xeb, 0x08,
+	0x0d, 0x36, 0x46, 0xf3, 0x0c, 0xed, 0x6d, 0xfd, 0x1e, 0x8d, 0x2c, 0xc3, 0x31, 0xba, 0xcd, 0x49,
+	0x73, 0xdb, 0xbc, 0x8a, 0xcc, 0x6b, 0x84, 0xb2, 0x3c, 0x48, 0x69, 0xe8, 0x3d, 0x11, 0x65, 0xd5,
+	0x1d, 0xa3, 0xbb, 0x7b, 0xde, 0xc6, 0xda, 0x81, 0x2b, 0x07, 0x1e, 0x30, 0x35, 0xb4, 0xde, 0xe6,
+	0xbd, 0xf6, 0xfa, 0x2a, 0x21, 0x57, 0x99, 0x04, 0x3c, 0xce, 0x83, 0x11, 0x51, 0x93, 0x86, 0x3e,
+	0x61, 0x44, 0x94, 0x79, 0x83, 0x5a, 0x85, 0x9f, 0xd2, 0xa8, 0x54, 0xfa, 0x51, 0xc4, 0x89, 0x10,
+	0xd6, 0x3f, 0xc7, 0xe8, 0x36, 0x86, 0x27, 0xef, 0xf3, 0xde, 0xf1, 0x7a, 0xff, 0x43, 0x95, 0x19,
+	0xe8, 0xc8, 0x9d, 0xe4, 0x94, 0x25, 0x93, 0x83, 0xe2, 0x4f, 0xdf, 0xc4, 0xe8, 0x30, 0xe3, 0x00,
+	0xb1, 0x07, 0xb1, 0x97, 0x81, 0x10, 0x44, 0x08, 0x0a, 0xcc, 0xfa, 0x5f, 0xbe, 0xa4, 0x55, 0xa2,
+	0xdb, 0x78, 0xbc, 0x01, 0xc3, 0xfb, 0xd7, 0xa5, 0x6d, 0x2c, 0x96, 0xb6, 0xf1, 0xb9, 0xb4, 0x8d,
+	0x97, 0x95, 0x5d, 0x5b, 0xac, 0xec, 0xda, 0xc7, 0xca, 0xae, 0x3d, 0x5e, 0x26, 0x54, 0x4e, 0xf3,
+	0x00, 0x87, 0x30, 0x5b, 0x0f, 0xd4, 0xa5, 0x4c, 0x12, 0x1e, 0x4e, 0x7d, 0xca, 0x7a, 0xd5, 0x4c,
+	0x28, 0x30, 0xf7, 0xd7, 0x27, 0x4a, 0x95, 0x11, 0x11, 0xec, 0x94, 0x93, 0xb8, 0xf8, 0x1a, 0x00,
+	0xae, 0x38, 0xce, 0x01, 0xe1, 0x01, 0x00, 0x00,
+}
+
+func (m *Attestator) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Attestator) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Attestator) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ProofOfPossession) > 0 {
+		i -= len(m.ProofOfPossession)
+		copy(dAtA[i:], m.ProofOfPossession)
+		i = encodeVarintAttestator(dAtA, i, uint64(len(m.ProofOfPossession)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ValidatorAddress) > 0 {
+		i -= len(m.ValidatorAddress)
+		copy(dAtA[i:], m.ValidatorAddress)
+		i = encodeVarintAttestator(dAtA, i, uint64(len(m.ValidatorAddress)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.PublicKey != nil {
+		{
+			size, err := m.PublicKey.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAttestator(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.AttestatorId) > 0 {
+		i -= len(m.AttestatorId)
+		copy(dAtA[i:], m.AttestatorId)
+		i = encodeVarintAttestator(dAtA, i, uint64(len(m.AttestatorId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintAttestator(dAtA []byte, offset int, v uint64) int {
+	offset -= sovAttestator(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Attestator) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AttestatorId)
+	if l > 0 {
+		n += 1 + l + sovAttestator(uint64(l))
+	}
+	if m.PublicKey != nil {
+		l = m.PublicKey.Size()
+		n += 1 + l + sovAttestator(uint64(l))
+	}
+	l = len(m.ValidatorAddress)
+	if l > 0 {
+		n += 1 + l + sovAttestator(uint64(l))
+	}
+	l = len(m.ProofOfPossession)
+	if l > 0 {
+		n += 1 + l + sovAttestator(uint64(l))
+	}
+	return n
+}
+
+func sovAttestator(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozAttestator(x uint64) (n int) {
+	return sovAttestator(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Attestator) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAttestator
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Attestator: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Attestator: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AttestatorId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestator
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAttestator
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestator
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AttestatorId = append(m.AttestatorId[:0], dAtA[iNdEx:postIndex]...)
+			if m.AttestatorId == nil {
+				m.AttestatorId = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestator
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAttestator
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestator
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PublicKey == nil {
+				m.PublicKey = &types.Any{}
+			}
+			if err := m.PublicKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestator
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAttestator
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestator
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProofOfPossession", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestator
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAttestator
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestator
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProofOfPossession = append(m.ProofOfPossession[:0], dAtA[iNdEx:postIndex]...)
+			if m.ProofOfPossession == nil {
+				m.ProofOfPossession = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAttestator(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAttestator
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipAttestator(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowAttestator
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAttestator
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAttestator
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthAttestator
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupAttestator
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthAttestator
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthAttestator        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowAttestator          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupAttestator = fmt.Errorf("proto: unexpected end of group")
+)
@@ -4,11 +4,15 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/msgservice"
+
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
 )
 
 func RegisterInterfaces(registry types.InterfaceRegistry) {
 	registry.RegisterImplementations((*sdk.Msg)(nil),
 		&MsgUpdateParams{},
+		&MsgRegisterAttestator{},
 	)
+	bls12381.RegisterInterfaces(registry)
 	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
 }
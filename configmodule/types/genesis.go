@@ -2,8 +2,13 @@ package types
 
 import (
 	"errors"
+	"fmt"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 )
 
+var _ codectypes.UnpackInterfacesMessage = GenesisState{}
+
 // NewGenesisState creates a new genesis state.
 func NewGenesisState(params Params) *GenesisState {
 	return &GenesisState{
@@ -23,8 +28,36 @@ func (gs GenesisState) Validate() error {
 	}
 
 	if err := gs.Params.Validate(); err != nil {
-		return nil
-		// return fmt.Errorf("params failed validation: %w", err)
+		return fmt.Errorf("params failed validation: %w", err)
+	}
+
+	seenAttestators := make(map[string]bool, len(gs.Attestators))
+	seenValidators := make(map[string]bool, len(gs.Attestators))
+	for _, attestator := range gs.Attestators {
+		if err := attestator.Validate(); err != nil {
+			return err
+		}
+		if seenAttestators[string(attestator.AttestatorId)] {
+			return fmt.Errorf("duplicate attestator %X", attestator.AttestatorId)
+		}
+		seenAttestators[string(attestator.AttestatorId)] = true
+
+		// a validator can only have one attestator
+		if seenValidators[attestator.ValidatorAddress] {
+			return fmt.Errorf("validator %s has more than one attestator", attestator.ValidatorAddress)
+		}
+		seenValidators[attestator.ValidatorAddress] = true
+	}
+
+	return nil
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage.UnpackInterfaces
+func (gs GenesisState) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	for _, attestator := range gs.Attestators {
+		if err := attestator.UnpackInterfaces(unpacker); err != nil {
+			return err
+		}
 	}
 
 	return nil
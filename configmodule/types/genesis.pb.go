@@ -28,6 +28,8 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type GenesisState struct {
 	// params defines all the paramaters of configmodule module.
 	Params *Params `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
+	// attestators are the registered attestators
+	Attestators []Attestator `protobuf:"bytes,2,rep,name=attestators,proto3" json:"attestators"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -70,6 +72,13 @@ func (m *GenesisState) GetParams() *Params {
 	return nil
 }
 
+func (m *GenesisState) GetAttestators() []Attestator {
+	if m != nil {
+		return m.Attestators
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*GenesisState)(nil), "configmodule.v1.GenesisState")
 }
@@ -77,21 +86,23 @@ func init() {
 func init() { proto.RegisterFile("configmodule/v1/genesis.proto", fileDescriptor_d904d6e8f6c1737d) }
 
 var fileDescriptor_d904d6e8f6c1737d = []byte{
-	// 216 bytes of a gzipped FileDescriptorProto
+	// 254 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x4d, 0xce, 0xcf, 0x4b,
 	0xcb, 0x4c, 0xcf, 0xcd, 0x4f, 0x29, 0xcd, 0x49, 0xd5, 0x2f, 0x33, 0xd4, 0x4f, 0x4f, 0xcd, 0x4b,
 	0x2d, 0xce, 0x2c, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x47, 0x96, 0xd6, 0x2b, 0x33,
 	0x94, 0x12, 0x4c, 0xcc, 0xcd, 0xcc, 0xcb, 0xd7, 0x07, 0x93, 0x10, 0x35, 0x52, 0x22, 0xe9, 0xf9,
 	0xe9, 0xf9, 0x60, 0xa6, 0x3e, 0x88, 0x05, 0x15, 0x95, 0x41, 0x37, 0xb8, 0x20, 0xb1, 0x28, 0x31,
-	0x17, 0x6a, 0xae, 0x92, 0x3d, 0x17, 0x8f, 0x3b, 0xc4, 0xa2, 0xe0, 0x92, 0xc4, 0x92, 0x54, 0x21,
-	0x7d, 0x2e, 0x36, 0x88, 0xbc, 0x04, 0xa3, 0x02, 0xa3, 0x06, 0xb7, 0x91, 0xb8, 0x1e, 0x9a, 0xc5,
-	0x7a, 0x01, 0x60, 0xe9, 0x20, 0xa8, 0x32, 0xa7, 0xd0, 0x13, 0x8f, 0xe4, 0x18, 0x2f, 0x3c, 0x92,
-	0x63, 0x7c, 0xf0, 0x48, 0x8e, 0x71, 0xc2, 0x63, 0x39, 0x86, 0x0b, 0x8f, 0xe5, 0x18, 0x6e, 0x3c,
-	0x96, 0x63, 0x88, 0xb2, 0x4e, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0x4f,
-	0xce, 0x2f, 0xce, 0xcd, 0x2f, 0xd6, 0xcf, 0xcc, 0x2b, 0x49, 0x2d, 0x4a, 0xce, 0x48, 0xcc, 0xcc,
-	0xd3, 0x4d, 0x2c, 0x29, 0x49, 0x2d, 0x2e, 0x49, 0x2c, 0xc9, 0xcc, 0xcf, 0xd3, 0x47, 0x71, 0x61,
-	0x49, 0x65, 0x41, 0x6a, 0x71, 0x12, 0x1b, 0xd8, 0x79, 0xc6, 0x80, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x23, 0x88, 0xf8, 0x9c, 0x17, 0x01, 0x00, 0x00,
+	0x17, 0x6a, 0xae, 0x94, 0x02, 0xba, 0x6c, 0x62, 0x49, 0x49, 0x6a, 0x71, 0x49, 0x62, 0x49, 0x7e,
+	0x11, 0x44, 0x85, 0x52, 0x0b, 0x23, 0x17, 0x8f, 0x3b, 0xc4, 0x2d, 0xc1, 0x25, 0x89, 0x25, 0xa9,
+	0x42, 0xfa, 0x5c, 0x6c, 0x10, 0x23, 0x24, 0x18, 0x15, 0x18, 0x35, 0xb8, 0x8d, 0xc4, 0xf5, 0xd0,
+	0xdc, 0xa6, 0x17, 0x00, 0x96, 0x0e, 0x82, 0x2a, 0x13, 0x72, 0xe6, 0xe2, 0x46, 0x98, 0x5a, 0x2c,
+	0xc1, 0xa4, 0xc0, 0xac, 0xc1, 0x6d, 0x24, 0x8d, 0xa1, 0xcb, 0x11, 0xae, 0xc6, 0x89, 0xe5, 0xc4,
+	0x3d, 0x79, 0x86, 0x20, 0x64, 0x5d, 0x4e, 0xa1, 0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7,
+	0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x84, 0xc7, 0x72, 0x0c, 0x17, 0x1e, 0xcb, 0x31, 0xdc, 0x78, 0x2c,
+	0xc7, 0x10, 0x65, 0x9d, 0x9e, 0x59, 0x92, 0x51, 0x9a, 0xa4, 0x97, 0x9c, 0x9f, 0xab, 0x9f, 0x9c,
+	0x5f, 0x9c, 0x9b, 0x5f, 0xac, 0x9f, 0x99, 0x57, 0x92, 0x5a, 0x94, 0x9c, 0x91, 0x98, 0x99, 0xa7,
+	0x0b, 0x33, 0x23, 0x33, 0x3f, 0x4f, 0x1f, 0xc5, 0xaf, 0x25, 0x95, 0x05, 0xa9, 0xc5, 0x49, 0x6c,
+	0x60, 0x4f, 0x1a, 0x03, 0x06, 0x00, 0x72, 0xf4, 0xf1, 0xee, 0x7f, 0x01, 0x00, 0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -114,6 +125,20 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Attestators) > 0 {
+		for iNdEx := len(m.Attestators) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Attestators[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
 	if m.Params != nil {
 		{
 			size, err := m.Params.MarshalToSizedBuffer(dAtA[:i])
@@ -150,6 +175,12 @@ func (m *GenesisState) Size() (n int) {
 		l = m.Params.Size()
 		n += 1 + l + sovGenesis(uint64(l))
 	}
+	if len(m.Attestators) > 0 {
+		for _, e := range m.Attestators {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -224,6 +255,40 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attestators", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attestators = append(m.Attestators, Attestator{})
+			if err := m.Attestators[len(m.Attestators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
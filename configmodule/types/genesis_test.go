@@ -5,10 +5,28 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+
 	"github.com/cosmos/interchain-attestation/configmodule/types"
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
 )
 
 func TestGenesisValidate(t *testing.T) {
+	attestator, err := types.NewAttestator([]byte("attestator-0"), secp256k1.GenPrivKey().PubKey(), "cosmosvaloper1gp957czryfgyvxwn3tfnyy2f0t9g2p4pqeemx8", nil)
+	require.NoError(t, err)
+	sameValidatorAttestator, err := types.NewAttestator([]byte("attestator-2"), secp256k1.GenPrivKey().PubKey(), attestator.ValidatorAddress, nil)
+	require.NoError(t, err)
+
+	blsPrivKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	proofOfPossession, err := blsPrivKey.ProofOfPossession()
+	require.NoError(t, err)
+	blsAttestator, err := types.NewAttestator([]byte("attestator-1"), blsPrivKey.PubKey(), "cosmosvaloper1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc56kct20", proofOfPossession)
+	require.NoError(t, err)
+	blsAttestatorWithoutProof, err := types.NewAttestator([]byte("attestator-1"), blsPrivKey.PubKey(), "", nil)
+	require.NoError(t, err)
+
 	tests := []struct {
 		name     string
 		genesis  *types.GenesisState
@@ -26,7 +44,71 @@ func TestGenesisValidate(t *testing.T) {
 			},
 			"params cannot be nil",
 		},
-		// TODO: Invalid params when that exists...
+		{
+			"valid: with attestators",
+			&types.GenesisState{
+				Params:      &types.Params{},
+				Attestators: []types.Attestator{attestator},
+			},
+			"",
+		},
+		{
+			"invalid: duplicate attestators",
+			&types.GenesisState{
+				Params:      &types.Params{},
+				Attestators: []types.Attestator{attestator, attestator},
+			},
+			"duplicate attestator",
+		},
+		{
+			"invalid: empty attestator id",
+			&types.GenesisState{
+				Params:      &types.Params{},
+				Attestators: []types.Attestator{{PublicKey: attestator.PublicKey}},
+			},
+			"attestator id cannot be empty",
+		},
+		{
+			"invalid: attestator without public key",
+			&types.GenesisState{
+				Params:      &types.Params{},
+				Attestators: []types.Attestator{{AttestatorId: []byte("attestator-0"), PublicKey: &codectypes.Any{}}},
+			},
+			"invalid public key",
+		},
+		{
+			"valid: with BLS12-381 attestator",
+			&types.GenesisState{
+				Params:      &types.Params{},
+				Attestators: []types.Attestator{attestator, blsAttestator},
+			},
+			"",
+		},
+		{
+			"invalid: BLS12-381 attestator without proof of possession",
+			&types.GenesisState{
+				Params:      &types.Params{},
+				Attestators: []types.Attestator{blsAttestatorWithoutProof},
+			},
+			"invalid proof of possession",
+		},
+		{
+			"invalid: two attestators of the same validator",
+			&types.GenesisState{
+				Params:      &types.Params{},
+				Attestators: []types.Attestator{attestator, sameValidatorAttestator},
+			},
+			"has more than one attestator",
+		},
+		{
+			"invalid: attestators are still validated after valid params",
+			&types.GenesisState{
+				Params:      types.DefaultGenesisState().Params,
+				Attestators: []types.Attestator{blsAttestatorWithoutProof},
+			},
+			"invalid proof of possession",
+		},
+		// TODO: Invalid params (which must fail with "params failed validation") when params have fields to validate...
 	}
 
 	for _, tt := range tests {
@@ -12,6 +12,7 @@ const (
 
 var (
 	// ParamsKey is the prefix for configmodule parameters
-	ParamsKey      = collections.NewPrefix(0)
-	AttestatorsKey = collections.NewPrefix(1)
+	ParamsKey               = collections.NewPrefix(0)
+	AttestatorsKey          = collections.NewPrefix(1)
+	ValidatorAttestatorsKey = collections.NewPrefix(2)
 )
@@ -7,7 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	_ "github.com/cosmos/cosmos-proto"
-	_ "github.com/cosmos/cosmos-sdk/codec/types"
+	types "github.com/cosmos/cosmos-sdk/codec/types"
 	_ "github.com/cosmos/cosmos-sdk/types/msgservice"
 	_ "github.com/cosmos/cosmos-sdk/types/tx/amino"
 	_ "github.com/cosmos/gogoproto/gogoproto"
@@ -128,38 +128,166 @@ func (m *MsgUpdateParamsResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_MsgUpdateParamsResponse proto.InternalMessageInfo
 
+// MsgRegisterAttestator is the Msg/RegisterAttestator request type.
+type MsgRegisterAttestator struct {
+	// validator_address is the operator address of the validator running the
+	// attestator.
+	ValidatorAddress string `protobuf:"bytes,1,opt,name=validator_address,json=validatorAddress,proto3" json:"validator_address,omitempty"`
+	// attestator_id is the id the attestator uses in its attestations
+	AttestatorId []byte `protobuf:"bytes,2,opt,name=attestator_id,json=attestatorId,proto3" json:"attestator_id,omitempty"`
+	// attestation_public_key is the public key the attestator signs
+	// attestations with
+	AttestationPublicKey *types.Any `protobuf:"bytes,3,opt,name=attestation_public_key,json=attestationPublicKey,proto3" json:"attestation_public_key,omitempty"`
+	// proof_of_possession is a signature over the public key, required for
+	// BLS12-381 keys to prevent rogue key attacks on aggregate signatures
+	ProofOfPossession []byte `protobuf:"bytes,4,opt,name=proof_of_possession,json=proofOfPossession,proto3" json:"proof_of_possession,omitempty"`
+}
+
+func (m *MsgRegisterAttestator) Reset()         { *m = MsgRegisterAttestator{} }
+func (m *MsgRegisterAttestator) String() string { return proto.CompactTextString(m) }
+func (*MsgRegisterAttestator) ProtoMessage()    {}
+func (*MsgRegisterAttestator) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7537773bb85d69b9, []int{2}
+}
+func (m *MsgRegisterAttestator) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgRegisterAttestator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgRegisterAttestator.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgRegisterAttestator) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgRegisterAttestator.Merge(m, src)
+}
+func (m *MsgRegisterAttestator) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgRegisterAttestator) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgRegisterAttestator.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgRegisterAttestator proto.InternalMessageInfo
+
+func (m *MsgRegisterAttestator) GetValidatorAddress() string {
+	if m != nil {
+		return m.ValidatorAddress
+	}
+	return ""
+}
+
+func (m *MsgRegisterAttestator) GetAttestatorId() []byte {
+	if m != nil {
+		return m.AttestatorId
+	}
+	return nil
+}
+
+func (m *MsgRegisterAttestator) GetAttestationPublicKey() *types.Any {
+	if m != nil {
+		return m.AttestationPublicKey
+	}
+	return nil
+}
+
+func (m *MsgRegisterAttestator) GetProofOfPossession() []byte {
+	if m != nil {
+		return m.ProofOfPossession
+	}
+	return nil
+}
+
+// MsgRegisterAttestatorResponse defines the response structure for executing a
+// MsgRegisterAttestator message.
+type MsgRegisterAttestatorResponse struct {
+}
+
+func (m *MsgRegisterAttestatorResponse) Reset()         { *m = MsgRegisterAttestatorResponse{} }
+func (m *MsgRegisterAttestatorResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgRegisterAttestatorResponse) ProtoMessage()    {}
+func (*MsgRegisterAttestatorResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7537773bb85d69b9, []int{3}
+}
+func (m *MsgRegisterAttestatorResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgRegisterAttestatorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgRegisterAttestatorResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgRegisterAttestatorResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgRegisterAttestatorResponse.Merge(m, src)
+}
+func (m *MsgRegisterAttestatorResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgRegisterAttestatorResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgRegisterAttestatorResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgRegisterAttestatorResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*MsgUpdateParams)(nil), "configmodule.v1.MsgUpdateParams")
 	proto.RegisterType((*MsgUpdateParamsResponse)(nil), "configmodule.v1.MsgUpdateParamsResponse")
+	proto.RegisterType((*MsgRegisterAttestator)(nil), "configmodule.v1.MsgRegisterAttestator")
+	proto.RegisterType((*MsgRegisterAttestatorResponse)(nil), "configmodule.v1.MsgRegisterAttestatorResponse")
 }
 
 func init() { proto.RegisterFile("configmodule/v1/tx.proto", fileDescriptor_7537773bb85d69b9) }
 
 var fileDescriptor_7537773bb85d69b9 = []byte{
-	// 364 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x48, 0xce, 0xcf, 0x4b,
-	0xcb, 0x4c, 0xcf, 0xcd, 0x4f, 0x29, 0xcd, 0x49, 0xd5, 0x2f, 0x33, 0xd4, 0x2f, 0xa9, 0xd0, 0x2b,
-	0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x47, 0x96, 0xd1, 0x2b, 0x33, 0x94, 0x12, 0x4c, 0xcc, 0xcd,
-	0xcc, 0xcb, 0xd7, 0x07, 0x93, 0x10, 0x35, 0x52, 0x22, 0xe9, 0xf9, 0xe9, 0xf9, 0x60, 0xa6, 0x3e,
-	0x88, 0x05, 0x15, 0x15, 0x4f, 0xce, 0x2f, 0xce, 0xcd, 0x2f, 0xd6, 0xcf, 0x2d, 0x4e, 0x07, 0x99,
-	0x98, 0x5b, 0x9c, 0x0e, 0x95, 0x90, 0x84, 0x48, 0xc4, 0x43, 0x74, 0x40, 0x38, 0x30, 0xa9, 0xf4,
-	0xfc, 0xfc, 0xf4, 0x9c, 0x54, 0x7d, 0x30, 0x2f, 0xa9, 0x34, 0x4d, 0x3f, 0x31, 0xaf, 0x12, 0x2a,
-	0x25, 0x83, 0xee, 0xc4, 0x82, 0xc4, 0xa2, 0xc4, 0x5c, 0xa8, 0x46, 0xa5, 0xa9, 0x8c, 0x5c, 0xfc,
-	0xbe, 0xc5, 0xe9, 0xa1, 0x05, 0x29, 0x89, 0x25, 0xa9, 0x01, 0x60, 0x19, 0x21, 0x33, 0x2e, 0xce,
-	0xc4, 0xd2, 0x92, 0x8c, 0xfc, 0xa2, 0xcc, 0x92, 0x4a, 0x09, 0x46, 0x05, 0x46, 0x0d, 0x4e, 0x27,
-	0x89, 0x4b, 0x5b, 0x74, 0x45, 0xa0, 0x36, 0x3a, 0xa6, 0xa4, 0x14, 0xa5, 0x16, 0x17, 0x07, 0x97,
-	0x14, 0x65, 0xe6, 0xa5, 0x07, 0x21, 0x94, 0x0a, 0x59, 0x71, 0xb1, 0x41, 0xcc, 0x96, 0x60, 0x52,
-	0x60, 0xd4, 0xe0, 0x36, 0x12, 0xd7, 0x43, 0x0b, 0x03, 0x3d, 0x88, 0x05, 0x4e, 0x9c, 0x27, 0xee,
-	0xc9, 0x33, 0xac, 0x78, 0xbe, 0x41, 0x8b, 0x31, 0x08, 0xaa, 0xc3, 0x8a, 0xaf, 0xe9, 0xf9, 0x06,
-	0x2d, 0x84, 0x59, 0x4a, 0x92, 0x5c, 0xe2, 0x68, 0xce, 0x0a, 0x4a, 0x2d, 0x2e, 0xc8, 0xcf, 0x2b,
-	0x4e, 0x35, 0xca, 0xe0, 0x62, 0xf6, 0x2d, 0x4e, 0x17, 0x8a, 0xe2, 0xe2, 0x41, 0x71, 0xb5, 0x02,
-	0x86, 0x6d, 0x68, 0x06, 0x48, 0x69, 0x10, 0x52, 0x01, 0xb3, 0x42, 0x8a, 0xb5, 0x01, 0xe4, 0x38,
-	0xa7, 0xd0, 0x13, 0x8f, 0xe4, 0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0x71, 0xc2,
-	0x63, 0x39, 0x86, 0x0b, 0x8f, 0xe5, 0x18, 0x6e, 0x3c, 0x96, 0x63, 0x88, 0xb2, 0x4e, 0xcf, 0x2c,
-	0xc9, 0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0x85, 0x46, 0x84, 0x7e, 0x66, 0x5e, 0x49, 0x6a, 0x51,
-	0x72, 0x46, 0x62, 0x66, 0x9e, 0x6e, 0x62, 0x49, 0x49, 0x6a, 0x71, 0x49, 0x62, 0x49, 0x66, 0x7e,
-	0x9e, 0x3e, 0x4a, 0xe8, 0x97, 0x54, 0x16, 0xa4, 0x16, 0x27, 0xb1, 0x81, 0x83, 0xde, 0x18, 0x10,
-	0x00, 0x00, 0xff, 0xff, 0x78, 0x38, 0xef, 0xdf, 0x3d, 0x02, 0x00, 0x00,
+	// 549 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0x8e, 0x5b, 0xa8, 0xd4, 0x25, 0x50, 0x62, 0x42, 0xe3, 0x46, 0xd4, 0x0d, 0x41, 0x42, 0x51,
+	0xa5, 0xac, 0xd5, 0x22, 0x71, 0x08, 0xa7, 0xe4, 0x86, 0xaa, 0x40, 0x64, 0x54, 0x0e, 0xbd, 0x58,
+	0x1b, 0x7b, 0xb3, 0x59, 0x11, 0x7b, 0x2c, 0xef, 0x3a, 0xc2, 0x37, 0xc4, 0x13, 0x70, 0xe1, 0x1d,
+	0x38, 0xf6, 0xd0, 0x13, 0x4f, 0x50, 0x71, 0xaa, 0x7a, 0x40, 0x9c, 0x10, 0x4a, 0x0e, 0x7d, 0x0d,
+	0x14, 0xff, 0x34, 0x89, 0x13, 0x09, 0x2e, 0x96, 0x67, 0xbf, 0x99, 0x6f, 0x66, 0xbe, 0x6f, 0x17,
+	0x69, 0x36, 0x78, 0x03, 0xce, 0x5c, 0x70, 0xc2, 0x11, 0x35, 0xc6, 0x47, 0x86, 0xfc, 0x88, 0xfd,
+	0x00, 0x24, 0xa8, 0x3b, 0x8b, 0x08, 0x1e, 0x1f, 0x55, 0x4b, 0xc4, 0xe5, 0x1e, 0x18, 0xf1, 0x37,
+	0xc9, 0xa9, 0x96, 0x19, 0x30, 0x88, 0x7f, 0x8d, 0xd9, 0x5f, 0x7a, 0x5a, 0xb1, 0x41, 0xb8, 0x20,
+	0x0c, 0x57, 0xb0, 0x19, 0xa3, 0x2b, 0x58, 0x0a, 0xec, 0x25, 0x80, 0x95, 0x54, 0x24, 0x41, 0x06,
+	0x31, 0x00, 0x36, 0xa2, 0x46, 0x1c, 0xf5, 0xc3, 0x81, 0x41, 0xbc, 0x28, 0x85, 0x9e, 0xe4, 0x47,
+	0xf4, 0x49, 0x40, 0xdc, 0xb4, 0xb0, 0xfe, 0x55, 0x41, 0x3b, 0x5d, 0xc1, 0x4e, 0x7d, 0x87, 0x48,
+	0xda, 0x8b, 0x11, 0xf5, 0x25, 0xda, 0x26, 0xa1, 0x1c, 0x42, 0xc0, 0x65, 0xa4, 0x29, 0x35, 0xa5,
+	0xb1, 0xdd, 0xd1, 0xae, 0x2f, 0x9a, 0xe5, 0xb4, 0x63, 0xdb, 0x71, 0x02, 0x2a, 0xc4, 0x3b, 0x19,
+	0x70, 0x8f, 0x99, 0xf3, 0x54, 0xb5, 0x85, 0xb6, 0x12, 0x6e, 0x6d, 0xa3, 0xa6, 0x34, 0xee, 0x1d,
+	0x57, 0x70, 0x4e, 0x03, 0x9c, 0x34, 0xe8, 0x6c, 0x5f, 0xfe, 0x3e, 0x28, 0x7c, 0xbb, 0x39, 0x3f,
+	0x54, 0xcc, 0xb4, 0xa2, 0xf5, 0xe0, 0xf3, 0xcd, 0xf9, 0xe1, 0x9c, 0xab, 0xbe, 0x87, 0x2a, 0xb9,
+	0xb1, 0x4c, 0x2a, 0x7c, 0xf0, 0x04, 0xad, 0x7f, 0xdf, 0x40, 0x8f, 0xbb, 0x82, 0x99, 0x94, 0x71,
+	0x21, 0x69, 0xd0, 0x96, 0x92, 0x0a, 0x49, 0x24, 0x04, 0xea, 0x1b, 0x54, 0x1a, 0x93, 0x11, 0x77,
+	0x66, 0x81, 0x45, 0x92, 0x31, 0xd3, 0x05, 0x9e, 0x5e, 0x5f, 0x34, 0xf7, 0xd3, 0x05, 0xde, 0x67,
+	0x39, 0xcb, 0x9b, 0x3c, 0x1c, 0xe7, 0xce, 0xd5, 0x67, 0xe8, 0x3e, 0xb9, 0x65, 0xb7, 0xb8, 0x13,
+	0xef, 0x55, 0x34, 0x8b, 0xf3, 0xc3, 0xd7, 0x8e, 0xea, 0xa0, 0xdd, 0x2c, 0xe6, 0xe0, 0x59, 0x7e,
+	0xd8, 0x1f, 0x71, 0xdb, 0xfa, 0x40, 0x23, 0x6d, 0x33, 0x56, 0xa1, 0x8c, 0x13, 0x6f, 0x70, 0xe6,
+	0x0d, 0x6e, 0x7b, 0x51, 0x47, 0xfb, 0x31, 0x17, 0xd4, 0x0e, 0x22, 0x5f, 0x02, 0xee, 0x85, 0xfd,
+	0x13, 0x1a, 0x99, 0xe5, 0x05, 0xb6, 0x5e, 0x4c, 0x76, 0x42, 0x23, 0x15, 0xa3, 0x47, 0x7e, 0x00,
+	0x30, 0xb0, 0x60, 0x60, 0xf9, 0x20, 0x04, 0x15, 0x82, 0x83, 0xa7, 0xdd, 0x89, 0x07, 0x2a, 0xc5,
+	0xd0, 0xdb, 0x41, 0xef, 0x16, 0x68, 0xed, 0xce, 0xf4, 0x5c, 0x55, 0xa3, 0x7e, 0x80, 0xf6, 0xd7,
+	0x6a, 0x97, 0xa9, 0x7b, 0xfc, 0x53, 0x41, 0x9b, 0x5d, 0xc1, 0xd4, 0x33, 0x54, 0x5c, 0xba, 0x14,
+	0xb5, 0x15, 0x33, 0x73, 0xfe, 0x54, 0x1b, 0xff, 0xca, 0xc8, 0x7a, 0xa8, 0x23, 0xa4, 0xae, 0x71,
+	0xef, 0xf9, 0xba, 0xfa, 0xd5, 0xbc, 0x2a, 0xfe, 0xbf, 0xbc, 0xac, 0x5b, 0xf5, 0xee, 0xa7, 0xd9,
+	0x4d, 0xeb, 0x9c, 0x5e, 0x4e, 0x74, 0xe5, 0x6a, 0xa2, 0x2b, 0x7f, 0x26, 0xba, 0xf2, 0x65, 0xaa,
+	0x17, 0xae, 0xa6, 0x7a, 0xe1, 0xd7, 0x54, 0x2f, 0x9c, 0xbd, 0x62, 0x5c, 0x0e, 0xc3, 0x3e, 0xb6,
+	0xc1, 0x4d, 0x5f, 0x95, 0xc1, 0x3d, 0x49, 0x03, 0x7b, 0x48, 0xb8, 0xd7, 0x5c, 0xb0, 0xc3, 0x58,
+	0x7a, 0x4a, 0x32, 0xf2, 0xa9, 0xe8, 0x6f, 0xc5, 0xb6, 0xbe, 0xf8, 0x3b, 0x00, 0x07, 0x6c, 0x7b,
+	0xb5, 0x0a, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -177,6 +305,9 @@ type MsgClient interface {
 	// UpdateParams defines a governance operation for updating the
 	// configmodule module parameters.
 	UpdateParams(ctx context.Context, in *MsgUpdateParams, opts ...grpc.CallOption) (*MsgUpdateParamsResponse, error)
+	// RegisterAttestator registers the attestation public key of a validator's
+	// attestator.
+	RegisterAttestator(ctx context.Context, in *MsgRegisterAttestator, opts ...grpc.CallOption) (*MsgRegisterAttestatorResponse, error)
 }
 
 type msgClient struct {
@@ -196,11 +327,23 @@ func (c *msgClient) UpdateParams(ctx context.Context, in *MsgUpdateParams, opts
 	return out, nil
 }
 
+func (c *msgClient) RegisterAttestator(ctx context.Context, in *MsgRegisterAttestator, opts ...grpc.CallOption) (*MsgRegisterAttestatorResponse, error) {
+	out := new(MsgRegisterAttestatorResponse)
+	err := c.cc.Invoke(ctx, "/configmodule.v1.Msg/RegisterAttestator", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MsgServer is the server API for Msg service.
 type MsgServer interface {
 	// UpdateParams defines a governance operation for updating the
 	// configmodule module parameters.
 	UpdateParams(context.Context, *MsgUpdateParams) (*MsgUpdateParamsResponse, error)
+	// RegisterAttestator registers the attestation public key of a validator's
+	// attestator.
+	RegisterAttestator(context.Context, *MsgRegisterAttestator) (*MsgRegisterAttestatorResponse, error)
 }
 
 // UnimplementedMsgServer can be embedded to have forward compatible implementations.
@@ -210,6 +353,9 @@ type UnimplementedMsgServer struct {
 func (*UnimplementedMsgServer) UpdateParams(ctx context.Context, req *MsgUpdateParams) (*MsgUpdateParamsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateParams not implemented")
 }
+func (*UnimplementedMsgServer) RegisterAttestator(ctx context.Context, req *MsgRegisterAttestator) (*MsgRegisterAttestatorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterAttestator not implemented")
+}
 
 func RegisterMsgServer(s grpc1.Server, srv MsgServer) {
 	s.RegisterService(&_Msg_serviceDesc, srv)
@@ -233,6 +379,24 @@ func _Msg_UpdateParams_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Msg_RegisterAttestator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRegisterAttestator)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RegisterAttestator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/configmodule.v1.Msg/RegisterAttestator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RegisterAttestator(ctx, req.(*MsgRegisterAttestator))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Msg_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "configmodule.v1.Msg",
 	HandlerType: (*MsgServer)(nil),
@@ -241,6 +405,10 @@ var _Msg_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateParams",
 			Handler:    _Msg_UpdateParams_Handler,
 		},
+		{
+			MethodName: "RegisterAttestator",
+			Handler:    _Msg_RegisterAttestator_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "configmodule/v1/tx.proto",
@@ -309,6 +477,85 @@ func (m *MsgUpdateParamsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *MsgRegisterAttestator) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgRegisterAttestator) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgRegisterAttestator) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ProofOfPossession) > 0 {
+		i -= len(m.ProofOfPossession)
+		copy(dAtA[i:], m.ProofOfPossession)
+		i = encodeVarintTx(dAtA, i, uint64(len(m.ProofOfPossession)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.AttestationPublicKey != nil {
+		{
+			size, err := m.AttestationPublicKey.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTx(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.AttestatorId) > 0 {
+		i -= len(m.AttestatorId)
+		copy(dAtA[i:], m.AttestatorId)
+		i = encodeVarintTx(dAtA, i, uint64(len(m.AttestatorId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ValidatorAddress) > 0 {
+		i -= len(m.ValidatorAddress)
+		copy(dAtA[i:], m.ValidatorAddress)
+		i = encodeVarintTx(dAtA, i, uint64(len(m.ValidatorAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MsgRegisterAttestatorResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgRegisterAttestatorResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgRegisterAttestatorResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTx(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTx(v)
 	base := offset
@@ -344,6 +591,40 @@ func (m *MsgUpdateParamsResponse) Size() (n int) {
 	return n
 }
 
+func (m *MsgRegisterAttestator) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ValidatorAddress)
+	if l > 0 {
+		n += 1 + l + sovTx(uint64(l))
+	}
+	l = len(m.AttestatorId)
+	if l > 0 {
+		n += 1 + l + sovTx(uint64(l))
+	}
+	if m.AttestationPublicKey != nil {
+		l = m.AttestationPublicKey.Size()
+		n += 1 + l + sovTx(uint64(l))
+	}
+	l = len(m.ProofOfPossession)
+	if l > 0 {
+		n += 1 + l + sovTx(uint64(l))
+	}
+	return n
+}
+
+func (m *MsgRegisterAttestatorResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
 func sovTx(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -515,6 +796,242 @@ func (m *MsgUpdateParamsResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *MsgRegisterAttestator) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgRegisterAttestator: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgRegisterAttestator: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AttestatorId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AttestatorId = append(m.AttestatorId[:0], dAtA[iNdEx:postIndex]...)
+			if m.AttestatorId == nil {
+				m.AttestatorId = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AttestationPublicKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AttestationPublicKey == nil {
+				m.AttestationPublicKey = &types.Any{}
+			}
+			if err := m.AttestationPublicKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProofOfPossession", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProofOfPossession = append(m.ProofOfPossession[:0], dAtA[iNdEx:postIndex]...)
+			if m.ProofOfPossession == nil {
+				m.ProofOfPossession = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MsgRegisterAttestatorResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgRegisterAttestatorResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgRegisterAttestatorResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTx(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
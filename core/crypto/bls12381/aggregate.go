@@ -0,0 +1,46 @@
+package bls12381
+
+import (
+	"fmt"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// AggregateSignatures aggregates the signatures into a single signature
+func AggregateSignatures(signatures [][]byte) ([]byte, error) {
+	if len(signatures) == 0 {
+		return nil, fmt.Errorf("no signatures to aggregate")
+	}
+
+	aggregate := new(blst.P2Aggregate)
+	if !aggregate.AggregateCompressed(signatures, true) {
+		return nil, fmt.Errorf("invalid signature in aggregate")
+	}
+
+	return aggregate.ToAffine().Compress(), nil
+}
+
+// VerifyAggregateSignature verifies an aggregate signature from all the public keys over the same message.
+// The public keys must have had their proof of possession verified (see PubKey.VerifyProofOfPossession),
+// otherwise the aggregate signature is vulnerable to rogue key attacks.
+func VerifyAggregateSignature(pubKeys []*PubKey, msg []byte, aggregateSignature []byte) bool {
+	if len(pubKeys) == 0 {
+		return false
+	}
+
+	publicKeys := make([]*blst.P1Affine, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		publicKey, err := pubKey.publicKey()
+		if err != nil {
+			return false
+		}
+		publicKeys[i] = publicKey
+	}
+
+	signature := new(blst.P2Affine).Uncompress(aggregateSignature)
+	if signature == nil {
+		return false
+	}
+
+	return signature.FastAggregateVerify(true, publicKeys, msg, dstSignature)
+}
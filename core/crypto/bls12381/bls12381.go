@@ -0,0 +1,194 @@
+package bls12381
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+
+	blst "github.com/supranational/blst/bindings/go"
+
+	cmtcrypto "github.com/cometbft/cometbft/crypto"
+
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+)
+
+const (
+	// KeyType is the type of the BLS12-381 attestation keys
+	KeyType = "bls12_381"
+	// PrivKeySize is the size of a serialized private key
+	PrivKeySize = 32
+	// PubKeySize is the size of a compressed public key (a point in G1)
+	PubKeySize = 48
+	// SignatureSize is the size of a compressed signature (a point in G2)
+	SignatureSize = 96
+)
+
+var (
+	// dstSignature is the domain separation tag for signatures (the proof of possession scheme, with public keys in G1)
+	dstSignature = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+	// dstProofOfPossession is the domain separation tag for proofs of possession
+	dstProofOfPossession = []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+)
+
+var (
+	_ cryptotypes.PrivKey = &PrivKey{}
+	_ cryptotypes.PubKey  = &PubKey{}
+)
+
+// GenPrivKey generates a new private key from a random seed
+func GenPrivKey() (*PrivKey, error) {
+	seed := make([]byte, 32)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+
+	return GenPrivKeyFromSecret(seed)
+}
+
+// GenPrivKeyFromSecret derives a private key from a secret of at least 32 bytes
+func GenPrivKeyFromSecret(secret []byte) (*PrivKey, error) {
+	secretKey := blst.KeyGen(secret)
+	if secretKey == nil {
+		return nil, fmt.Errorf("secret must be at least 32 bytes, got %d", len(secret))
+	}
+
+	return &PrivKey{Key: secretKey.Serialize()}, nil
+}
+
+// Bytes returns the serialized private key
+func (privKey *PrivKey) Bytes() []byte {
+	return privKey.Key
+}
+
+// Sign signs the message with the private key
+func (privKey *PrivKey) Sign(msg []byte) ([]byte, error) {
+	secretKey, err := privKey.secretKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return new(blst.P2Affine).Sign(secretKey, msg, dstSignature).Compress(), nil
+}
+
+// ProofOfPossession signs the public key, to prove that the private key is known.
+// It must be verified when a key is registered, since aggregate signatures are only safe for keys with a proof of possession.
+func (privKey *PrivKey) ProofOfPossession() ([]byte, error) {
+	secretKey, err := privKey.secretKey()
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeyBz := new(blst.P1Affine).From(secretKey).Compress()
+	return new(blst.P2Affine).Sign(secretKey, pubKeyBz, dstProofOfPossession).Compress(), nil
+}
+
+// PubKey returns the public key of the private key
+func (privKey *PrivKey) PubKey() cryptotypes.PubKey {
+	secretKey, err := privKey.secretKey()
+	if err != nil {
+		panic(err)
+	}
+
+	return &PubKey{Key: new(blst.P1Affine).From(secretKey).Compress()}
+}
+
+// Equals returns true if the other key is the same private key
+func (privKey *PrivKey) Equals(other cryptotypes.LedgerPrivKey) bool {
+	if privKey.Type() != other.Type() {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(privKey.Bytes(), other.Bytes()) == 1
+}
+
+// Type returns the key type
+func (privKey *PrivKey) Type() string {
+	return KeyType
+}
+
+func (privKey *PrivKey) secretKey() (*blst.SecretKey, error) {
+	if len(privKey.Key) != PrivKeySize {
+		return nil, fmt.Errorf("invalid private key size, expected %d, got %d", PrivKeySize, len(privKey.Key))
+	}
+
+	secretKey := new(blst.SecretKey).Deserialize(privKey.Key)
+	if secretKey == nil || !secretKey.Valid() {
+		return nil, fmt.Errorf("invalid private key")
+	}
+
+	return secretKey, nil
+}
+
+// Address returns the address of the public key
+func (pubKey *PubKey) Address() cryptotypes.Address {
+	return cmtcrypto.AddressHash(pubKey.Key)
+}
+
+// Bytes returns the compressed public key
+func (pubKey *PubKey) Bytes() []byte {
+	return pubKey.Key
+}
+
+// VerifySignature verifies a signature from the public key over the message
+func (pubKey *PubKey) VerifySignature(msg, sig []byte) bool {
+	publicKey, err := pubKey.publicKey()
+	if err != nil {
+		return false
+	}
+
+	signature := new(blst.P2Affine).Uncompress(sig)
+	if signature == nil {
+		return false
+	}
+
+	return signature.Verify(true, publicKey, false, msg, dstSignature)
+}
+
+// VerifyProofOfPossession verifies that the proof of possession was created by the private key of the public key
+func (pubKey *PubKey) VerifyProofOfPossession(proofOfPossession []byte) bool {
+	publicKey, err := pubKey.publicKey()
+	if err != nil {
+		return false
+	}
+
+	signature := new(blst.P2Affine).Uncompress(proofOfPossession)
+	if signature == nil {
+		return false
+	}
+
+	return signature.Verify(true, publicKey, false, pubKey.Key, dstProofOfPossession)
+}
+
+// Equals returns true if the other key is the same public key
+func (pubKey *PubKey) Equals(other cryptotypes.PubKey) bool {
+	if pubKey.Type() != other.Type() {
+		return false
+	}
+
+	return bytes.Equal(pubKey.Bytes(), other.Bytes())
+}
+
+// Type returns the key type
+func (pubKey *PubKey) Type() string {
+	return KeyType
+}
+
+// String returns a string representation of the public key
+func (pubKey *PubKey) String() string {
+	return fmt.Sprintf("PubKeyBLS12381{%X}", pubKey.Key)
+}
+
+// publicKey uncompresses and validates the public key (it must be in G1 and not the identity)
+func (pubKey *PubKey) publicKey() (*blst.P1Affine, error) {
+	if len(pubKey.Key) != PubKeySize {
+		return nil, fmt.Errorf("invalid public key size, expected %d, got %d", PubKeySize, len(pubKey.Key))
+	}
+
+	publicKey := new(blst.P1Affine).Uncompress(pubKey.Key)
+	if publicKey == nil || !publicKey.KeyValidate() {
+		return nil, fmt.Errorf("invalid public key")
+	}
+
+	return publicKey, nil
+}
@@ -0,0 +1,123 @@
+package bls12381_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	privKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	require.Len(t, privKey.Bytes(), bls12381.PrivKeySize)
+
+	pubKey := privKey.PubKey()
+	require.Len(t, pubKey.Bytes(), bls12381.PubKeySize)
+	require.Equal(t, bls12381.KeyType, pubKey.Type())
+
+	msg := []byte("attested data")
+	signature, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, signature, bls12381.SignatureSize)
+
+	require.True(t, pubKey.VerifySignature(msg, signature))
+	require.False(t, pubKey.VerifySignature([]byte("other data"), signature))
+	require.False(t, pubKey.VerifySignature(msg, signature[1:]))
+
+	otherPrivKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	require.False(t, otherPrivKey.PubKey().VerifySignature(msg, signature))
+	require.False(t, pubKey.Equals(otherPrivKey.PubKey()))
+	require.False(t, privKey.Equals(otherPrivKey))
+}
+
+func TestGenPrivKeyFromSecret(t *testing.T) {
+	secret := []byte("a secret that is at least 32 bytes long")
+	privKey, err := bls12381.GenPrivKeyFromSecret(secret)
+	require.NoError(t, err)
+
+	samePrivKey, err := bls12381.GenPrivKeyFromSecret(secret)
+	require.NoError(t, err)
+	require.True(t, privKey.Equals(samePrivKey))
+	require.True(t, privKey.PubKey().Equals(samePrivKey.PubKey()))
+
+	_, err = bls12381.GenPrivKeyFromSecret([]byte("too short"))
+	require.Error(t, err)
+}
+
+func TestProofOfPossession(t *testing.T) {
+	privKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	pubKey := privKey.PubKey().(*bls12381.PubKey)
+
+	proofOfPossession, err := privKey.ProofOfPossession()
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifyProofOfPossession(proofOfPossession))
+
+	// a regular signature over the public key is not a proof of possession
+	signature, err := privKey.Sign(pubKey.Bytes())
+	require.NoError(t, err)
+	require.False(t, pubKey.VerifyProofOfPossession(signature))
+
+	otherPrivKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	require.False(t, otherPrivKey.PubKey().(*bls12381.PubKey).VerifyProofOfPossession(proofOfPossession))
+}
+
+func TestAggregateSignatures(t *testing.T) {
+	msg := []byte("attested data")
+
+	var pubKeys []*bls12381.PubKey
+	var signatures [][]byte
+	for i := 0; i < 10; i++ {
+		privKey, err := bls12381.GenPrivKey()
+		require.NoError(t, err)
+		signature, err := privKey.Sign(msg)
+		require.NoError(t, err)
+
+		pubKeys = append(pubKeys, privKey.PubKey().(*bls12381.PubKey))
+		signatures = append(signatures, signature)
+	}
+
+	aggregateSignature, err := bls12381.AggregateSignatures(signatures)
+	require.NoError(t, err)
+	require.Len(t, aggregateSignature, bls12381.SignatureSize)
+
+	require.True(t, bls12381.VerifyAggregateSignature(pubKeys, msg, aggregateSignature))
+	require.False(t, bls12381.VerifyAggregateSignature(pubKeys, []byte("other data"), aggregateSignature))
+	require.False(t, bls12381.VerifyAggregateSignature(pubKeys[1:], msg, aggregateSignature))
+	require.False(t, bls12381.VerifyAggregateSignature(nil, msg, aggregateSignature))
+
+	_, err = bls12381.AggregateSignatures(nil)
+	require.Error(t, err)
+	_, err = bls12381.AggregateSignatures([][]byte{signatures[0], []byte("invalid")})
+	require.Error(t, err)
+}
+
+func TestCodec(t *testing.T) {
+	registry := codectypes.NewInterfaceRegistry()
+	bls12381.RegisterInterfaces(registry)
+	cdc := codec.NewProtoCodec(registry)
+
+	privKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+
+	pubKeyAny, err := codectypes.NewAnyWithValue(privKey.PubKey())
+	require.NoError(t, err)
+	bz, err := cdc.MarshalJSON(pubKeyAny)
+	require.NoError(t, err)
+
+	var pubKey cryptotypes.PubKey
+	require.NoError(t, cdc.UnmarshalInterfaceJSON(bz, &pubKey))
+	require.True(t, privKey.PubKey().Equals(pubKey))
+
+	// keys of other types are not equal, even with the same bytes
+	require.False(t, pubKey.Equals(&secp256k1.PubKey{Key: pubKey.Bytes()}))
+}
@@ -14,8 +14,10 @@ require (
 	github.com/cosmos/ibc-go/v9 v9.0.0-beta.1
 	github.com/grpc-ecosystem/grpc-gateway v1.16.0
 	github.com/pelletier/go-toml/v2 v2.2.2 // indirect
+	github.com/spf13/cast v1.6.0
 	github.com/spf13/cobra v1.8.1 // indirect
 	github.com/stretchr/testify v1.9.0
+	github.com/supranational/blst v0.3.14
 	golang.org/x/sync v0.7.0 // indirect
 	google.golang.org/grpc v1.65.0
 	google.golang.org/protobuf v1.34.2
@@ -129,7 +131,6 @@ require (
 	github.com/sasha-s/go-deadlock v0.3.1 // indirect
 	github.com/sourcegraph/conc v0.3.0 // indirect
 	github.com/spf13/afero v1.11.0 // indirect
-	github.com/spf13/cast v1.6.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
 	github.com/spf13/viper v1.19.0 // indirect
 	github.com/subosito/gotenv v1.6.0 // indirect
@@ -6,5 +6,16 @@ import (
 
 // TODO: Document the interface and its methods
 type AttestatorsController interface {
+	// SufficientAttestations returns true if the attestators have enough backing (e.g. stake of their validators) for
+	// their attestations to update a client
 	SufficientAttestations(ctx context.Context, attestatorIds [][]byte) (bool, error)
+	// VerifySignatures verifies the signatures of the attestators over signBytes. Either one signature per attestator is
+	// given (in the same order as attestatorIds), or a single aggregate signature of all the attestators.
+	VerifySignatures(ctx context.Context, signBytes []byte, attestatorIds [][]byte, signatures [][]byte, aggregateSignature []byte) error
+	// AttestatorSet returns the ids of all the registered attestators in a deterministic order.
+	// The index of an attestator in the set is its position in the participation bitmap of a claim.
+	AttestatorSet(ctx context.Context) ([][]byte, error)
+	// AttestatorOperator returns the address (bytes) of the operator that registered the attestator.
+	// Only the operator can submit attestations on behalf of the attestator with MsgSubmitAttestation.
+	AttestatorOperator(ctx context.Context, attestatorID []byte) ([]byte, error)
 }
@@ -65,6 +65,8 @@ func (cs ClientState) Initialize(cdc codec.BinaryCodec, clientStore storetypes.K
 
 	setClientState(clientStore, cdc, &cs)
 	setConsensusState(clientStore, cdc, consensusState, cs.LatestHeight)
+	// the client starts without packet commitments, which the first commitments delta can be based on
+	setHistoricalCommitments(clientStore, cs.LatestHeight, nil)
 
 	return nil
 }
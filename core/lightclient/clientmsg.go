@@ -1,24 +1,164 @@
 package lightclient
 
 import (
+	"bytes"
+
+	errorsmod "cosmossdk.io/errors"
+
 	"github.com/cosmos/ibc-go/v9/modules/core/exported"
 
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
 	"github.com/cosmos/interchain-attestation/core/types"
 )
 
 var _ exported.ClientMessage = (*AttestationClaim)(nil)
 
-func NewAttestationClaim(attestation []types.Attestation) *AttestationClaim {
-	return &AttestationClaim{
-		Attestations: attestation,
+// NewAttestationClaim creates a compact claim for the host chain from attestations that all attest to the same data.
+// The payload is only included once, followed by the attestator ids and their signatures.
+func NewAttestationClaim(hostChainID string, attestations []types.Attestation) (*AttestationClaim, error) {
+	if len(attestations) == 0 {
+		return nil, errorsmod.Wrap(ErrInvalidClientMsg, "empty attestations")
+	}
+
+	signBytes := types.GetAttestationSignBytes(hostChainID, attestations[0].Payload)
+	claim := &AttestationClaim{
+		Payload:       attestations[0].Payload,
+		AttestatorIds: make([][]byte, len(attestations)),
+	}
+	hasSignatures := false
+	for i, attestation := range attestations {
+		if !bytes.Equal(signBytes, types.GetAttestationSignBytes(hostChainID, attestation.Payload)) {
+			return nil, errorsmod.Wrapf(ErrInvalidClientMsg, "attestations must all be the same")
+		}
+
+		claim.AttestatorIds[i] = attestation.AttestatorId
+		hasSignatures = hasSignatures || len(attestation.Signature) != 0
 	}
+
+	if hasSignatures {
+		claim.Signatures = make([][]byte, len(attestations))
+		for i, attestation := range attestations {
+			claim.Signatures[i] = attestation.Signature
+		}
+	}
+
+	return claim, nil
 }
 
 func (m *AttestationClaim) ClientType() string {
 	return ModuleName
 }
 
+// AggregateSignatures replaces the individual BLS12-381 signatures of the claim with an aggregate signature, and the
+// attestator ids with a participation bitmap over the attestator set. It fails if any of the signatures can't be aggregated.
+func (m *AttestationClaim) AggregateSignatures(attestatorSet [][]byte) error {
+	if len(m.Signatures) == 0 || len(m.Signatures) != len(m.AttestatorIds) {
+		return errorsmod.Wrap(ErrInvalidClientMsg, "claim must have one signature per attestator to aggregate")
+	}
+
+	participationBitmap, err := NewParticipationBitmap(attestatorSet, m.AttestatorIds)
+	if err != nil {
+		return err
+	}
+	aggregateSignature, err := bls12381.AggregateSignatures(m.Signatures)
+	if err != nil {
+		return errorsmod.Wrap(ErrInvalidClientMsg, err.Error())
+	}
+
+	m.AttestatorIds = nil
+	m.Signatures = nil
+	m.AggregateSignature = aggregateSignature
+	m.ParticipationBitmap = participationBitmap
+
+	return nil
+}
+
+// ValidateBasic checks that the claim is well-formed. It does not verify the signatures or the payload contents.
 func (m *AttestationClaim) ValidateBasic() error {
-	// TODO implement me
-	panic("implement me")
+	if len(m.ParticipationBitmap) != 0 {
+		if len(m.AttestatorIds) != 0 {
+			return errorsmod.Wrap(ErrInvalidClientMsg, "attestator ids and participation bitmap cannot both be set")
+		}
+		if len(m.AggregateSignature) == 0 {
+			return errorsmod.Wrap(ErrInvalidClientMsg, "participation bitmap requires an aggregate signature")
+		}
+		if len(m.Signatures) != 0 {
+			return errorsmod.Wrap(ErrInvalidClientMsg, "signatures and aggregate signature cannot both be set")
+		}
+
+		return m.validatePayload()
+	}
+
+	if len(m.AttestatorIds) == 0 {
+		return errorsmod.Wrap(ErrInvalidClientMsg, "empty attestations")
+	}
+
+	seenAttestators := make(map[string]bool)
+	for _, attestatorID := range m.AttestatorIds {
+		if len(attestatorID) == 0 {
+			return errorsmod.Wrap(ErrInvalidClientMsg, "empty attestator id")
+		}
+		if seenAttestators[string(attestatorID)] {
+			return errorsmod.Wrapf(ErrInvalidClientMsg, "duplicate attestation from %s", string(attestatorID))
+		}
+		seenAttestators[string(attestatorID)] = true
+	}
+
+	if len(m.AggregateSignature) != 0 && len(m.Signatures) != 0 {
+		return errorsmod.Wrap(ErrInvalidClientMsg, "signatures and aggregate signature cannot both be set")
+	}
+	if len(m.Signatures) != 0 && len(m.Signatures) != len(m.AttestatorIds) {
+		return errorsmod.Wrapf(ErrInvalidClientMsg, "expected %d signatures, got %d", len(m.AttestatorIds), len(m.Signatures))
+	}
+
+	return m.validatePayload()
+}
+
+// validatePayload checks that a payload is set. Whether the payload type is supported is checked when verifying the claim.
+func (m *AttestationClaim) validatePayload() error {
+	if m.Payload.Unpack() == nil {
+		return errorsmod.Wrap(ErrInvalidClientMsg, "empty payload")
+	}
+
+	return nil
+}
+
+// NewParticipationBitmap creates a bitmap where bit i (least significant bit first) is set if the attestator at index i
+// of the attestator set is one of the attestator ids
+func NewParticipationBitmap(attestatorSet [][]byte, attestatorIDs [][]byte) ([]byte, error) {
+	indexes := make(map[string]int, len(attestatorSet))
+	for i, attestatorID := range attestatorSet {
+		indexes[string(attestatorID)] = i
+	}
+
+	bitmap := make([]byte, (len(attestatorSet)+7)/8)
+	for _, attestatorID := range attestatorIDs {
+		i, ok := indexes[string(attestatorID)]
+		if !ok {
+			return nil, errorsmod.Wrapf(ErrInvalidClientMsg, "attestator %s is not in the attestator set", string(attestatorID))
+		}
+		bitmap[i/8] |= 1 << (i % 8)
+	}
+
+	return bitmap, nil
+}
+
+// ParticipatingAttestators returns the ids of the attestators in the attestator set that are marked in the bitmap
+func ParticipatingAttestators(attestatorSet [][]byte, bitmap []byte) ([][]byte, error) {
+	if len(bitmap) != (len(attestatorSet)+7)/8 {
+		return nil, errorsmod.Wrapf(ErrInvalidClientMsg, "participation bitmap must be %d bytes for %d attestators, got %d", (len(attestatorSet)+7)/8, len(attestatorSet), len(bitmap))
+	}
+
+	var attestatorIDs [][]byte
+	for i := 0; i < len(bitmap)*8; i++ {
+		if bitmap[i/8]&(1<<(i%8)) == 0 {
+			continue
+		}
+		if i >= len(attestatorSet) {
+			return nil, errorsmod.Wrapf(ErrInvalidClientMsg, "participation bitmap has bit %d set, but there are only %d attestators", i, len(attestatorSet))
+		}
+		attestatorIDs = append(attestatorIDs, attestatorSet[i])
+	}
+
+	return attestatorIDs, nil
 }
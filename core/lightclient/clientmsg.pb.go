@@ -27,10 +27,25 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 // AttestationClaim is the clientMsg that is sent to the light client to update
-// the consensus state All the claims need to be for the same height and have
-// the same packet_commitments
+// the consensus state. The attested payload is included once, followed by the
+// attestators that attested to it and their signatures.
 type AttestationClaim struct {
-	Attestations []types.Attestation `protobuf:"bytes,1,rep,name=attestations,proto3" json:"attestations"`
+	// payload is the data all the attestators attested to
+	Payload types.AttestationPayload `protobuf:"bytes,7,opt,name=payload,proto3" json:"payload"`
+	// attestator_ids are the ids of the attestators that attested to the
+	// payload
+	AttestatorIds [][]byte `protobuf:"bytes,3,rep,name=attestator_ids,json=attestatorIds,proto3" json:"attestator_ids,omitempty"`
+	// signatures are the signatures of the attestators over the sign bytes of
+	// the payload, in the same order as attestator_ids. It is left empty when
+	// aggregate_signature is used.
+	Signatures [][]byte `protobuf:"bytes,4,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	// aggregate_signature is the aggregate of the signatures of all the
+	// attestators, for signature schemes that support aggregation.
+	AggregateSignature []byte `protobuf:"bytes,5,opt,name=aggregate_signature,json=aggregateSignature,proto3" json:"aggregate_signature,omitempty"`
+	// participation_bitmap can be used instead of attestator_ids together with
+	// aggregate_signature. Bit i (least significant bit first) is set if the
+	// attestator at index i of the attestator set (see AttestatorSet) attested.
+	ParticipationBitmap []byte `protobuf:"bytes,6,opt,name=participation_bitmap,json=participationBitmap,proto3" json:"participation_bitmap,omitempty"`
 }
 
 func (m *AttestationClaim) Reset()         { *m = AttestationClaim{} }
@@ -66,9 +81,37 @@ func (m *AttestationClaim) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_AttestationClaim proto.InternalMessageInfo
 
-func (m *AttestationClaim) GetAttestations() []types.Attestation {
+func (m *AttestationClaim) GetPayload() types.AttestationPayload {
 	if m != nil {
-		return m.Attestations
+		return m.Payload
+	}
+	return types.AttestationPayload{}
+}
+
+func (m *AttestationClaim) GetAttestatorIds() [][]byte {
+	if m != nil {
+		return m.AttestatorIds
+	}
+	return nil
+}
+
+func (m *AttestationClaim) GetSignatures() [][]byte {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+func (m *AttestationClaim) GetAggregateSignature() []byte {
+	if m != nil {
+		return m.AggregateSignature
+	}
+	return nil
+}
+
+func (m *AttestationClaim) GetParticipationBitmap() []byte {
+	if m != nil {
+		return m.ParticipationBitmap
 	}
 	return nil
 }
@@ -82,23 +125,30 @@ func init() {
 }
 
 var fileDescriptor_8256c76801ad19ea = []byte{
-	// 250 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x90, 0xb1, 0x4a, 0xc4, 0x40,
-	0x10, 0x86, 0x13, 0x14, 0x8b, 0x68, 0x21, 0xa7, 0x85, 0xa4, 0xd8, 0x13, 0x6d, 0x6c, 0xdc, 0x21,
-	0x5a, 0x59, 0x7a, 0xfa, 0x04, 0xda, 0x88, 0x5d, 0xb2, 0xac, 0x9b, 0x81, 0x6c, 0x26, 0x64, 0xe7,
-	0x02, 0xbe, 0x85, 0x8f, 0x75, 0xe5, 0x95, 0x56, 0x22, 0xc9, 0x8b, 0x48, 0x36, 0x1c, 0xee, 0xa5,
-	0x9b, 0xdd, 0xf9, 0xfe, 0x8f, 0x9f, 0x49, 0xae, 0x15, 0xb5, 0x1a, 0x2a, 0x34, 0x25, 0xab, 0x0a,
-	0x75, 0xcd, 0xd0, 0x65, 0x30, 0x4d, 0xd6, 0x19, 0xd9, 0xb4, 0xc4, 0xb4, 0x38, 0x1b, 0x21, 0x19,
-	0x40, 0xb2, 0xcb, 0xd2, 0x73, 0x43, 0x86, 0xfc, 0x1e, 0xc6, 0x69, 0x42, 0xd3, 0x25, 0x16, 0x0a,
-	0xbc, 0x73, 0xae, 0xdb, 0x01, 0x86, 0xc8, 0x54, 0x1a, 0xfc, 0xab, 0x58, 0x7f, 0x00, 0xa3, 0xd5,
-	0x8e, 0x73, 0xdb, 0xec, 0x00, 0x9f, 0xe6, 0xcf, 0x46, 0xbb, 0x31, 0x9c, 0x33, 0x8f, 0x6b, 0x46,
-	0xaa, 0x27, 0xe0, 0xea, 0x2d, 0x39, 0x7d, 0xfc, 0xff, 0x7c, 0xaa, 0x72, 0xb4, 0x8b, 0xe7, 0xe4,
-	0x24, 0x00, 0xdd, 0x45, 0x7c, 0x79, 0x70, 0x73, 0x7c, 0x97, 0x4a, 0x5f, 0xdc, 0xbb, 0x64, 0x97,
-	0xc9, 0x20, 0xb6, 0x3a, 0xdc, 0xfc, 0x2c, 0xa3, 0x97, 0xbd, 0xd4, 0xea, 0x75, 0xd3, 0x8b, 0x78,
-	0xdb, 0x8b, 0xf8, 0xb7, 0x17, 0xf1, 0xd7, 0x20, 0xa2, 0xed, 0x20, 0xa2, 0xef, 0x41, 0x44, 0xef,
-	0x0f, 0x06, 0xb9, 0x5c, 0x17, 0x52, 0x91, 0x05, 0x45, 0xce, 0x92, 0x03, 0xac, 0x59, 0xb7, 0xaa,
-	0xcc, 0xb1, 0xbe, 0x0d, 0x24, 0x30, 0xbf, 0x67, 0x71, 0xe4, 0x5b, 0xdf, 0xff, 0x05, 0x00, 0x00,
-	0xff, 0xff, 0x68, 0x58, 0x9a, 0x32, 0x6a, 0x01, 0x00, 0x00,
+	// 355 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x92, 0x31, 0x6f, 0xea, 0x30,
+	0x14, 0x85, 0x13, 0xc8, 0x03, 0xe4, 0xc7, 0x7b, 0x42, 0x81, 0x21, 0x62, 0x08, 0xb4, 0x55, 0x25,
+	0x96, 0xc6, 0x4a, 0x3b, 0x75, 0x84, 0x4e, 0x65, 0xaa, 0x60, 0xeb, 0x82, 0x9c, 0xe0, 0x9a, 0x2b,
+	0x25, 0x71, 0x14, 0x5f, 0x90, 0xf8, 0x0f, 0x1d, 0xfa, 0xb3, 0x18, 0x19, 0x3b, 0x55, 0x15, 0xfc,
+	0x91, 0x2a, 0x8e, 0x02, 0x29, 0xdb, 0xcd, 0x39, 0xdf, 0x39, 0xb9, 0xba, 0x32, 0xb9, 0x09, 0x65,
+	0xc6, 0x69, 0x04, 0x62, 0x85, 0x61, 0x04, 0x3c, 0x41, 0xba, 0xf1, 0x69, 0x31, 0xc5, 0x4a, 0x78,
+	0x69, 0x26, 0x51, 0xda, 0xdd, 0x1c, 0xf2, 0x2a, 0x90, 0xb7, 0xf1, 0xfb, 0x3d, 0x21, 0x85, 0xd4,
+	0x3e, 0xcd, 0xa7, 0x02, 0xed, 0x0f, 0x20, 0x08, 0xa9, 0xee, 0xbc, 0xac, 0x2b, 0x01, 0x21, 0xa5,
+	0x88, 0x38, 0xd5, 0x5f, 0xc1, 0xfa, 0x8d, 0x22, 0xc4, 0x5c, 0x21, 0x8b, 0xd3, 0x12, 0xd0, 0x69,
+	0xdc, 0xa6, 0x5c, 0xe5, 0x61, 0x86, 0x98, 0xdb, 0x08, 0x32, 0x29, 0x80, 0xeb, 0xf7, 0x1a, 0xe9,
+	0x8c, 0xcf, 0xea, 0x53, 0xc4, 0x20, 0xb6, 0xc7, 0xa4, 0x99, 0xb2, 0x6d, 0x24, 0xd9, 0xd2, 0x69,
+	0x0e, 0xcd, 0xd1, 0xdf, 0xfb, 0x2b, 0x4f, 0x2f, 0xad, 0x7b, 0xbc, 0x8d, 0xef, 0x55, 0x12, 0x2f,
+	0x05, 0x38, 0xb1, 0x76, 0x5f, 0x03, 0x63, 0x56, 0xe6, 0xec, 0x5b, 0xf2, 0xbf, 0xfc, 0x99, 0xcc,
+	0x16, 0xb0, 0x54, 0x4e, 0x7d, 0x58, 0x1f, 0xb5, 0x67, 0xff, 0xce, 0xea, 0xf3, 0x52, 0xd9, 0x2e,
+	0x21, 0x0a, 0x44, 0xc2, 0x70, 0x9d, 0x71, 0xe5, 0x58, 0x1a, 0xa9, 0x28, 0x36, 0x25, 0x5d, 0x26,
+	0x44, 0xc6, 0x05, 0x43, 0xbe, 0x38, 0xe9, 0xce, 0x9f, 0xa1, 0x39, 0x6a, 0xcf, 0xec, 0x93, 0x35,
+	0x2f, 0x1d, 0xdb, 0x27, 0xbd, 0x94, 0x65, 0x08, 0x21, 0xa4, 0x7a, 0xbd, 0x45, 0x00, 0x18, 0xb3,
+	0xd4, 0x69, 0xe8, 0x44, 0xf7, 0x97, 0x37, 0xd1, 0xd6, 0xd4, 0x6a, 0x99, 0x9d, 0xda, 0xd4, 0x6a,
+	0xd5, 0x3a, 0xf5, 0xc9, 0x7c, 0x77, 0x70, 0xcd, 0xfd, 0xc1, 0x35, 0xbf, 0x0f, 0xae, 0xf9, 0x71,
+	0x74, 0x8d, 0xfd, 0xd1, 0x35, 0x3e, 0x8f, 0xae, 0xf1, 0xfa, 0x28, 0x00, 0x57, 0xeb, 0xc0, 0x0b,
+	0x65, 0x4c, 0x43, 0xa9, 0x62, 0xa9, 0x28, 0x24, 0xc8, 0xb3, 0x70, 0xc5, 0x20, 0xb9, 0xab, 0x1c,
+	0x96, 0x5e, 0x3e, 0x82, 0xa0, 0xa1, 0x4f, 0xfd, 0xf0, 0x33, 0x00, 0x3a, 0x16, 0x21, 0x07, 0x1f,
+	0x02, 0x00, 0x00,
 }
 
 func (m *AttestationClaim) Marshal() (dAtA []byte, err error) {
@@ -121,18 +171,46 @@ func (m *AttestationClaim) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	if len(m.Attestations) > 0 {
-		for iNdEx := len(m.Attestations) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Attestations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintClientmsg(dAtA, i, uint64(size))
-			}
+	{
+		size, err := m.Payload.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintClientmsg(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x3a
+	if len(m.ParticipationBitmap) > 0 {
+		i -= len(m.ParticipationBitmap)
+		copy(dAtA[i:], m.ParticipationBitmap)
+		i = encodeVarintClientmsg(dAtA, i, uint64(len(m.ParticipationBitmap)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.AggregateSignature) > 0 {
+		i -= len(m.AggregateSignature)
+		copy(dAtA[i:], m.AggregateSignature)
+		i = encodeVarintClientmsg(dAtA, i, uint64(len(m.AggregateSignature)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Signatures) > 0 {
+		for iNdEx := len(m.Signatures) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Signatures[iNdEx])
+			copy(dAtA[i:], m.Signatures[iNdEx])
+			i = encodeVarintClientmsg(dAtA, i, uint64(len(m.Signatures[iNdEx])))
 			i--
-			dAtA[i] = 0xa
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.AttestatorIds) > 0 {
+		for iNdEx := len(m.AttestatorIds) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AttestatorIds[iNdEx])
+			copy(dAtA[i:], m.AttestatorIds[iNdEx])
+			i = encodeVarintClientmsg(dAtA, i, uint64(len(m.AttestatorIds[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
 		}
 	}
 	return len(dAtA) - i, nil
@@ -155,12 +233,28 @@ func (m *AttestationClaim) Size() (n int) {
 	}
 	var l int
 	_ = l
-	if len(m.Attestations) > 0 {
-		for _, e := range m.Attestations {
-			l = e.Size()
+	if len(m.AttestatorIds) > 0 {
+		for _, b := range m.AttestatorIds {
+			l = len(b)
+			n += 1 + l + sovClientmsg(uint64(l))
+		}
+	}
+	if len(m.Signatures) > 0 {
+		for _, b := range m.Signatures {
+			l = len(b)
 			n += 1 + l + sovClientmsg(uint64(l))
 		}
 	}
+	l = len(m.AggregateSignature)
+	if l > 0 {
+		n += 1 + l + sovClientmsg(uint64(l))
+	}
+	l = len(m.ParticipationBitmap)
+	if l > 0 {
+		n += 1 + l + sovClientmsg(uint64(l))
+	}
+	l = m.Payload.Size()
+	n += 1 + l + sovClientmsg(uint64(l))
 	return n
 }
 
@@ -199,9 +293,141 @@ func (m *AttestationClaim) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: AttestationClaim: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AttestatorIds", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClientmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClientmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClientmsg
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AttestatorIds = append(m.AttestatorIds, make([]byte, postIndex-iNdEx))
+			copy(m.AttestatorIds[len(m.AttestatorIds)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signatures", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClientmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClientmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClientmsg
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signatures = append(m.Signatures, make([]byte, postIndex-iNdEx))
+			copy(m.Signatures[len(m.Signatures)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregateSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClientmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClientmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClientmsg
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AggregateSignature = append(m.AggregateSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.AggregateSignature == nil {
+				m.AggregateSignature = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ParticipationBitmap", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClientmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClientmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClientmsg
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ParticipationBitmap = append(m.ParticipationBitmap[:0], dAtA[iNdEx:postIndex]...)
+			if m.ParticipationBitmap == nil {
+				m.ParticipationBitmap = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Attestations", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -228,8 +454,7 @@ func (m *AttestationClaim) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Attestations = append(m.Attestations, types.Attestation{})
-			if err := m.Attestations[len(m.Attestations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Payload.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -15,7 +15,7 @@ import (
 func TestCodec(t *testing.T) {
 	encodingCfg := moduletestutil.MakeTestEncodingConfig(lightclient.AppModuleBasic{})
 	attestators := generateAttestators(10)
-	validClientMsg := generateClientMsg(encodingCfg.Codec, attestators, 5)
+	validClientMsg := generateClientMsg(attestators, 5)
 
 	testCases := []struct {
 		name            string
@@ -37,6 +37,11 @@ func TestCodec(t *testing.T) {
 			validClientMsg,
 			&lightclient.AttestationClaim{},
 		},
+		{
+			"MsgSubmitAttestation",
+			lightclient.NewMsgSubmitAttestation(sdk.AccAddress(attestators[0].id).String(), generateAttestations(attestators[:1], 5)[0]),
+			&lightclient.MsgSubmitAttestation{},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -12,4 +12,7 @@ var (
 	ErrInvalidClientMsg          = errorsmod.Register(ModuleName, 5, "invalid client message")
 	ErrPacketCommitmentNotFound  = errorsmod.Register(ModuleName, 6, "packet commitment not found")
 	ErrInvalidUpdateMethod       = errorsmod.Register(ModuleName, 7, "invalid update method, can only be done through code")
+	ErrInvalidAttestation        = errorsmod.Register(ModuleName, 8, "invalid attestation")
+	ErrDuplicateAttestation      = errorsmod.Register(ModuleName, 9, "duplicate attestation")
+	ErrInvalidSigner             = errorsmod.Register(ModuleName, 10, "invalid signer")
 )
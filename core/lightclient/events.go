@@ -0,0 +1,11 @@
+package lightclient
+
+// Attestation light client events
+const (
+	EventTypeSubmitAttestation = "submit_attestation"
+
+	AttributeKeyClientID      = "client_id"
+	AttributeKeyAttestatorID  = "attestator_id"
+	AttributeKeyHeight        = "height"
+	AttributeKeyClientUpdated = "client_updated"
+)
@@ -4,5 +4,8 @@ package lightclient
 const (
 	ModuleName = "10-attestation"
 
-	PacketCommitmentStoreKey = "packetCommitment"
+	PacketCommitmentStoreKey            = "packetCommitment"
+	PendingAttestationStoreKey          = "pendingAttestation"
+	HistoricalCommitmentsStoreKey       = "historicalCommitments"
+	HistoricalCommitmentHeightsStoreKey = "historicalCommitmentHeights"
 )
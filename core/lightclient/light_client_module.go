@@ -3,6 +3,8 @@ package lightclient
 import (
 	"bytes"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	errorsmod "cosmossdk.io/errors"
@@ -14,11 +16,17 @@ import (
 	v2 "github.com/cosmos/ibc-go/v9/modules/core/23-commitment/types/v2"
 	host "github.com/cosmos/ibc-go/v9/modules/core/24-host"
 	"github.com/cosmos/ibc-go/v9/modules/core/exported"
+
+	"github.com/cosmos/interchain-attestation/core/types"
 )
 
 var _ exported.LightClientModule = (*LightClientModule)(nil)
 
-type TrustedClientUpdateFunc func(ctx sdk.Context, clientID string, clientMsg exported.ClientMessage) []exported.Height
+type TrustedClientUpdateFunc func(ctx sdk.Context, clientID string, clientMsg exported.ClientMessage) ([]exported.Height, error)
+
+// MaxPendingAttestationHeights is the maximum number of heights per client that pending attestations are stored for.
+// When an attestation for a new height is submitted and the limit is reached, the lowest pending height is pruned.
+const MaxPendingAttestationHeights = 32
 
 // LightClientModule implements the core IBC api.LightClientModule interface.
 type LightClientModule struct {
@@ -73,7 +81,7 @@ func (l *LightClientModule) VerifyClientMessage(ctx sdk.Context, clientID string
 		return errorsmod.Wrap(clienttypes.ErrClientNotFound, clientID)
 	}
 
-	return clientState.VerifyClientMessage(ctx, l.cdc, l.attestatorsHandler, clientMsg)
+	return clientState.VerifyClientMessage(ctx, l.cdc, clientStore, l.attestatorsHandler, clientMsg)
 }
 
 // TODO: implement this
@@ -94,15 +102,112 @@ func (l *LightClientModule) UpdateState(ctx sdk.Context, clientID string, client
 	panic(ErrInvalidUpdateMethod)
 }
 
-// TODO: Document
-func (l *LightClientModule) trustedUpdateState(ctx sdk.Context, clientID string, clientMsg exported.ClientMessage) []exported.Height {
+// trustedUpdateState is called by validators (through the vote extension flow) to update the client.
+// The client message is verified the same way as attestations submitted with MsgSubmitAttestation before the state is updated.
+// It returns an error (without updating the state) if the client does not exist or the client message is invalid.
+func (l *LightClientModule) trustedUpdateState(ctx sdk.Context, clientID string, clientMsg exported.ClientMessage) ([]exported.Height, error) {
+	clientStore := l.storeProvider.ClientStore(ctx, clientID)
+	clientState, found := getClientState(clientStore, l.cdc)
+	if !found {
+		return nil, errorsmod.Wrap(clienttypes.ErrClientNotFound, clientID)
+	}
+
+	if err := clientState.VerifyClientMessage(ctx, l.cdc, clientStore, l.attestatorsHandler, clientMsg); err != nil {
+		return nil, err
+	}
+
+	return clientState.UpdateState(ctx, l.cdc, clientStore, clientMsg), nil
+}
+
+// submitAttestation stores a single attestation submitted through a transaction (for chains without vote extensions).
+// The attestations are aggregated per client and height, and once enough attestators have submitted the same attestation
+// the resulting claim is verified and applied to the client. It returns true if the client was updated.
+func (l *LightClientModule) submitAttestation(ctx sdk.Context, signer sdk.AccAddress, attestation types.Attestation) (bool, error) {
+	operator, err := l.attestatorsHandler.AttestatorOperator(ctx, attestation.AttestatorId)
+	if err != nil {
+		return false, errorsmod.Wrapf(ErrInvalidSigner, "attestator %X is not registered: %s", attestation.AttestatorId, err)
+	}
+	if !bytes.Equal(signer, operator) {
+		return false, errorsmod.Wrapf(ErrInvalidSigner, "signer %s is not the operator of attestator %X", signer, attestation.AttestatorId)
+	}
+
+	clientID := attestation.Payload.ClientToUpdate()
 	clientStore := l.storeProvider.ClientStore(ctx, clientID)
 	clientState, found := getClientState(clientStore, l.cdc)
 	if !found {
-		panic(errorsmod.Wrap(clienttypes.ErrClientNotFound, clientID))
+		return false, errorsmod.Wrap(clienttypes.ErrClientNotFound, clientID)
+	}
+	if !clientState.FrozenHeight.IsZero() {
+		return false, errorsmod.Wrapf(clienttypes.ErrClientNotActive, "client %s is frozen", clientID)
+	}
+
+	height := attestation.Payload.AttestedHeight()
+	if height.LTE(clientState.LatestHeight) {
+		return false, errorsmod.Wrapf(ErrInvalidHeaderHeight, "attestation height %s must be greater than the latest client height %s", height, clientState.LatestHeight)
+	}
+	if hasPendingAttestation(clientStore, height, attestation.AttestatorId) {
+		return false, errorsmod.Wrapf(ErrDuplicateAttestation, "attestator %X has already submitted an attestation for height %s", attestation.AttestatorId, height)
+	}
+
+	// Nothing is stored before the attestation is verified, so that invalid attestations cannot fill up the pending store
+	attestationBytes := types.GetAttestationSignBytes(ctx.ChainID(), attestation.Payload)
+	if err := l.attestatorsHandler.VerifySignatures(ctx, attestationBytes, [][]byte{attestation.AttestatorId}, [][]byte{attestation.Signature}, nil); err != nil {
+		return false, errorsmod.Wrapf(ErrInvalidAttestation, "failed to verify attestation signature: %s", err)
+	}
+
+	pendingHeights := getPendingAttestationHeights(clientStore)
+	isPendingHeight := slices.ContainsFunc(pendingHeights, func(pendingHeight clienttypes.Height) bool { return pendingHeight.EQ(height) })
+	if !isPendingHeight && len(pendingHeights) >= MaxPendingAttestationHeights {
+		// the lowest heights are the most likely to never reach enough attestations
+		deletePendingAttestations(clientStore, pendingHeights[len(pendingHeights)-MaxPendingAttestationHeights])
 	}
+	setPendingAttestation(clientStore, l.cdc, attestation)
 
-	return clientState.UpdateState(ctx, l.cdc, clientStore, clientMsg)
+	// Only the attestations that match the submitted one count towards updating the client
+	var matchingAttestations []types.Attestation
+	var attestatorIDs [][]byte
+	for _, pendingAttestation := range getPendingAttestations(clientStore, l.cdc, height) {
+		if bytes.Equal(attestationBytes, types.GetAttestationSignBytes(ctx.ChainID(), pendingAttestation.Payload)) {
+			// the submitted attestation is the most recent one (a delta in it is relative to the current client state),
+			// so it goes first and its payload is used for the claim
+			if bytes.Equal(pendingAttestation.AttestatorId, attestation.AttestatorId) {
+				matchingAttestations = append([]types.Attestation{pendingAttestation}, matchingAttestations...)
+			} else {
+				matchingAttestations = append(matchingAttestations, pendingAttestation)
+			}
+			attestatorIDs = append(attestatorIDs, pendingAttestation.AttestatorId)
+		}
+	}
+
+	sufficient, err := l.attestatorsHandler.SufficientAttestations(ctx, attestatorIDs)
+	if err != nil {
+		return false, errorsmod.Wrapf(ErrInvalidAttestation, "failed to check sufficient attestations: %s", err)
+	}
+
+	if sufficient {
+		attestationClaim, err := NewAttestationClaim(ctx.ChainID(), matchingAttestations)
+		if err != nil {
+			return false, err
+		}
+		if err := clientState.VerifyClientMessage(ctx, l.cdc, clientStore, l.attestatorsHandler, attestationClaim); err != nil {
+			return false, err
+		}
+
+		clientState.UpdateState(ctx, l.cdc, clientStore, attestationClaim)
+		deletePendingAttestations(clientStore, height)
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			EventTypeSubmitAttestation,
+			sdk.NewAttribute(AttributeKeyClientID, clientID),
+			sdk.NewAttribute(AttributeKeyAttestatorID, fmt.Sprintf("%X", attestation.AttestatorId)),
+			sdk.NewAttribute(AttributeKeyHeight, height.String()),
+			sdk.NewAttribute(AttributeKeyClientUpdated, strconv.FormatBool(sufficient)),
+		),
+	)
+
+	return sufficient, nil
 }
 
 // VerifyMembership uses the packet commitment bytes (value) to verify the membership proof.
@@ -162,6 +267,23 @@ func (l *LightClientModule) LatestHeight(ctx sdk.Context, clientID string) expor
 	return clientState.LatestHeight
 }
 
+// PacketCommitments returns the latest height of the client and the packet commitments stored for it.
+// It is used to express new packet commitments as a delta relative to the current ones.
+func (l *LightClientModule) PacketCommitments(ctx sdk.Context, clientID string) (exported.Height, [][]byte, error) {
+	clientStore := l.storeProvider.ClientStore(ctx, clientID)
+	clientState, found := getClientState(clientStore, l.cdc)
+	if !found {
+		return nil, nil, errorsmod.Wrap(clienttypes.ErrClientNotFound, clientID)
+	}
+
+	return clientState.LatestHeight, getPacketCommitments(clientStore), nil
+}
+
+// AttestatorSet returns the ids of all the registered attestators, in the order used for participation bitmaps
+func (l *LightClientModule) AttestatorSet(ctx sdk.Context) ([][]byte, error) {
+	return l.attestatorsHandler.AttestatorSet(ctx)
+}
+
 // TimestampAtHeight returns the timestamp associated with the given height.
 func (l *LightClientModule) TimestampAtHeight(ctx sdk.Context, clientID string, height exported.Height) (uint64, error) {
 	clientStore := l.storeProvider.ClientStore(ctx, clientID)
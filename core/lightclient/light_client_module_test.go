@@ -85,7 +85,7 @@ func (s *AttestationLightClientTestSuite) TestLightClientModule_VerifyClientMess
 	err := s.lightClientModule.Initialize(s.ctx, clientID, clientStateBz, consensusStateBz)
 	s.Require().NoError(err)
 
-	clientMsg := generateClientMsg(s.encCfg.Codec, s.mockAttestators, 5)
+	clientMsg := generateClientMsg(s.mockAttestators, 5)
 
 	// test happy path
 	err = s.lightClientModule.VerifyClientMessage(s.ctx, clientID, clientMsg)
@@ -109,7 +109,7 @@ func (s *AttestationLightClientTestSuite) TestLightClientModule_UpdateState() {
 	expectedHeight := clienttypes.NewHeight(1, defaultHeight.RevisionHeight+1)
 	expectedTimestamp := time.Now()
 
-	clientMsg := generateClientMsg(s.encCfg.Codec, s.mockAttestators, 10, func(attestedData *types.IBCData) {
+	clientMsg := generateClientMsg(s.mockAttestators, 10, func(attestedData *types.IBCData) {
 		attestedData.Height = expectedHeight
 		attestedData.Timestamp = expectedTimestamp
 	})
@@ -131,36 +131,230 @@ func (s *AttestationLightClientTestSuite) TestLightClientModule_TrustedUpdateSta
 	expectedTimestamp := time.Now()
 
 	for i := 0; i < 25; i++ {
-		clientMsg := generateClientMsg(s.encCfg.Codec, s.mockAttestators, i, func(attestedData *types.IBCData) {
+		clientMsg := generateClientMsg(s.mockAttestators, i, func(attestedData *types.IBCData) {
 			attestedData.Height = expectedHeight
 			attestedData.Timestamp = expectedTimestamp
 		})
 
-		heights := s.trustedUpdateFunc(s.ctx, clientID, clientMsg)
+		heights, err := s.trustedUpdateFunc(s.ctx, clientID, clientMsg)
+		s.Require().NoError(err)
 		s.Require().Equal([]exported.Height{expectedHeight}, heights)
 
 		s.assertClientState(clientID, expectedHeight, expectedTimestamp)
-		s.assertPacketCommitmentStored(clientID, clientMsg)
+		s.assertPacketCommitmentStored(clientID, clientMsg.Payload.GetIbcDataV1().PacketCommitments)
 
-		expectedHeight = clienttypes.NewHeight(1, clientMsg.Attestations[0].AttestedData.Height.RevisionHeight+1)
+		expectedHeight = clienttypes.NewHeight(1, clientMsg.Payload.GetIbcDataV1().Height.RevisionHeight+1)
 		expectedTimestamp = expectedTimestamp.Add(2 * time.Second)
 	}
 
 	for i := 25; i != 0; i-- {
-		clientMsg := generateClientMsg(s.encCfg.Codec, s.mockAttestators, i, func(attestedData *types.IBCData) {
+		clientMsg := generateClientMsg(s.mockAttestators, i, func(attestedData *types.IBCData) {
 			attestedData.Height = expectedHeight
 			attestedData.Timestamp = expectedTimestamp
 		})
 
-		heights := s.trustedUpdateFunc(s.ctx, clientID, clientMsg)
+		heights, err := s.trustedUpdateFunc(s.ctx, clientID, clientMsg)
+		s.Require().NoError(err)
 		s.Require().Equal([]exported.Height{expectedHeight}, heights)
 
 		s.assertClientState(clientID, expectedHeight, expectedTimestamp)
-		s.assertPacketCommitmentStored(clientID, clientMsg)
+		s.assertPacketCommitmentStored(clientID, clientMsg.Payload.GetIbcDataV1().PacketCommitments)
 
-		expectedHeight = clienttypes.NewHeight(1, clientMsg.Attestations[0].AttestedData.Height.RevisionHeight+1)
+		expectedHeight = clienttypes.NewHeight(1, clientMsg.Payload.GetIbcDataV1().Height.RevisionHeight+1)
 		expectedTimestamp = expectedTimestamp.Add(2 * time.Second)
 	}
+
+	// the client message is verified before the state is updated
+	_, err = s.trustedUpdateFunc(s.ctx, clientID, &lightclient.AttestationClaim{})
+	s.Require().Error(err)
+	s.assertClientState(clientID, clienttypes.NewHeight(1, expectedHeight.RevisionHeight-1), expectedTimestamp.Add(-2*time.Second))
+
+	_, err = s.trustedUpdateFunc(s.ctx, "non-existent-client", generateClientMsg(s.mockAttestators, 1))
+	s.Require().ErrorIs(err, clienttypes.ErrClientNotFound)
+}
+
+func (s *AttestationLightClientTestSuite) TestLightClientModule_TrustedUpdateStateWithCommitmentsDelta() {
+	clientID := createClientID(0)
+	clientStateBz := s.encCfg.Codec.MustMarshal(initialClientState)
+	consensusStateBz := s.encCfg.Codec.MustMarshal(initialConsensusState)
+
+	err := s.lightClientModule.Initialize(s.ctx, clientID, clientStateBz, consensusStateBz)
+	s.Require().NoError(err)
+
+	// store an initial set of packet commitments in full
+	firstHeight := clienttypes.NewHeight(1, defaultHeight.RevisionHeight+1)
+	firstClientMsg := generateClientMsg(s.mockAttestators, 10, func(attestedData *types.IBCData) {
+		attestedData.Height = firstHeight
+	})
+	_, err = s.trustedUpdateFunc(s.ctx, clientID, firstClientMsg)
+	s.Require().NoError(err)
+
+	latestHeight, packetCommitments, err := s.lightClientModule.PacketCommitments(s.ctx, clientID)
+	s.Require().NoError(err)
+	s.Require().Equal(firstHeight, latestHeight)
+	s.Require().Len(packetCommitments, 10)
+
+	_, _, err = s.lightClientModule.PacketCommitments(s.ctx, "non-existent-client")
+	s.Require().Error(err)
+
+	// the next set drops the first half and adds new ones
+	secondHeight := clienttypes.NewHeight(1, firstHeight.RevisionHeight+1)
+	var secondPacketCommitments [][]byte
+	secondPacketCommitments = append(secondPacketCommitments, packetCommitments[5:]...)
+	secondPacketCommitments = append(secondPacketCommitments, []byte("new packet commitment 1"), []byte("new packet commitment 2"))
+	withDelta := func(baseHeight clienttypes.Height, commitmentSetHash []byte) func(attestedData *types.IBCData) {
+		return func(attestedData *types.IBCData) {
+			basePacketCommitments := packetCommitments
+			if baseHeight.EQ(defaultHeight) {
+				basePacketCommitments = nil
+			}
+			commitmentsDelta := types.NewCommitmentsDelta(baseHeight, basePacketCommitments, secondPacketCommitments)
+			attestedData.Height = secondHeight
+			attestedData.PacketCommitments = nil
+			attestedData.CommitmentsDelta = &commitmentsDelta
+			attestedData.CommitmentSetHash = commitmentSetHash
+		}
+	}
+
+	s.Run("unknown base height", func() {
+		clientMsg := generateClientMsg(s.mockAttestators, 0, withDelta(clienttypes.NewHeight(1, 1), types.CommitmentSetHash(secondPacketCommitments)))
+		err := s.lightClientModule.VerifyClientMessage(s.ctx, clientID, clientMsg)
+		s.Require().Error(err)
+		s.Require().Contains(err.Error(), "no consensus state stored for commitments delta base height")
+	})
+
+	s.Run("base height before the latest client height", func() {
+		// the delta from the initial (empty) packet commitments is still valid after the client has been updated
+		clientMsg := generateClientMsg(s.mockAttestators, 0, withDelta(defaultHeight, types.CommitmentSetHash(secondPacketCommitments)))
+		err := s.lightClientModule.VerifyClientMessage(s.ctx, clientID, clientMsg)
+		s.Require().NoError(err)
+	})
+
+	s.Run("missing commitment set hash", func() {
+		clientMsg := generateClientMsg(s.mockAttestators, 0, withDelta(firstHeight, nil))
+		err := s.lightClientModule.VerifyClientMessage(s.ctx, clientID, clientMsg)
+		s.Require().Error(err)
+		s.Require().Contains(err.Error(), "commitment set hash is required")
+	})
+
+	s.Run("commitment set hash mismatch", func() {
+		clientMsg := generateClientMsg(s.mockAttestators, 0, withDelta(firstHeight, types.CommitmentSetHash(packetCommitments)))
+		err := s.lightClientModule.VerifyClientMessage(s.ctx, clientID, clientMsg)
+		s.Require().Error(err)
+		s.Require().Contains(err.Error(), "does not result in the commitment set hash")
+	})
+
+	clientMsg := generateClientMsg(s.mockAttestators, 0, withDelta(firstHeight, types.CommitmentSetHash(secondPacketCommitments)))
+	heights, err := s.trustedUpdateFunc(s.ctx, clientID, clientMsg)
+	s.Require().NoError(err)
+	s.Require().Equal([]exported.Height{secondHeight}, heights)
+
+	_, storedPacketCommitments, err := s.lightClientModule.PacketCommitments(s.ctx, clientID)
+	s.Require().NoError(err)
+	s.Require().ElementsMatch(secondPacketCommitments, storedPacketCommitments)
+	s.assertPacketCommitmentStored(clientID, secondPacketCommitments)
+}
+
+func (s *AttestationLightClientTestSuite) TestLightClientModule_TrustedUpdateStateAtOlderHeight() {
+	clientID := createClientID(0)
+	err := s.lightClientModule.Initialize(s.ctx, clientID, s.encCfg.Codec.MustMarshal(initialClientState), s.encCfg.Codec.MustMarshal(initialConsensusState))
+	s.Require().NoError(err)
+
+	latestHeight := clienttypes.NewHeight(1, defaultHeight.RevisionHeight+2)
+	latestTimestamp := time.Now()
+	clientMsg := generateClientMsg(s.mockAttestators, 2, func(attestedData *types.IBCData) {
+		attestedData.Height = latestHeight
+		attestedData.Timestamp = latestTimestamp
+	})
+	_, err = s.trustedUpdateFunc(s.ctx, clientID, clientMsg)
+	s.Require().NoError(err)
+	latestPacketCommitments := clientMsg.Payload.GetIbcDataV1().PacketCommitments
+
+	olderHeight := clienttypes.NewHeight(1, defaultHeight.RevisionHeight+1)
+	olderPacketCommitments := generatePacketCommitments(5)
+	heights, err := s.trustedUpdateFunc(s.ctx, clientID, generateClientMsg(s.mockAttestators, 0, func(attestedData *types.IBCData) {
+		attestedData.Height = olderHeight
+		attestedData.Timestamp = latestTimestamp.Add(-time.Second)
+		attestedData.PacketCommitments = olderPacketCommitments
+	}))
+	s.Require().NoError(err)
+	s.Require().Equal([]exported.Height{olderHeight}, heights)
+
+	// the latest height and its packet commitments stay current
+	s.assertClientState(clientID, latestHeight, latestTimestamp)
+	s.assertPacketCommitmentStored(clientID, latestPacketCommitments)
+
+	// the packet commitments of the older height are stored as historical commitments, so a delta can be based on them
+	nextPacketCommitments := olderPacketCommitments[1:]
+	_, err = s.trustedUpdateFunc(s.ctx, clientID, generateClientMsg(s.mockAttestators, 0, func(attestedData *types.IBCData) {
+		commitmentsDelta := types.NewCommitmentsDelta(olderHeight, olderPacketCommitments, nextPacketCommitments)
+		attestedData.Height = clienttypes.NewHeight(1, latestHeight.RevisionHeight+1)
+		attestedData.Timestamp = latestTimestamp.Add(time.Second)
+		attestedData.PacketCommitments = nil
+		attestedData.CommitmentsDelta = &commitmentsDelta
+		attestedData.CommitmentSetHash = types.CommitmentSetHash(nextPacketCommitments)
+	}))
+	s.Require().NoError(err)
+	s.assertPacketCommitmentStored(clientID, nextPacketCommitments)
+}
+
+func (s *AttestationLightClientTestSuite) TestLightClientModule_HistoricalCommitmentsPruned() {
+	clientID := createClientID(0)
+	err := s.lightClientModule.Initialize(s.ctx, clientID, s.encCfg.Codec.MustMarshal(initialClientState), s.encCfg.Codec.MustMarshal(initialConsensusState))
+	s.Require().NoError(err)
+
+	height := defaultHeight
+	for i := 0; i < lightclient.MaxHistoricalCommitmentHeights; i++ {
+		height = clienttypes.NewHeight(1, height.RevisionHeight+1)
+		clientMsg := generateClientMsg(s.mockAttestators, 1, func(attestedData *types.IBCData) {
+			attestedData.Height = height
+		})
+		_, err = s.trustedUpdateFunc(s.ctx, clientID, clientMsg)
+		s.Require().NoError(err)
+	}
+
+	nextPacketCommitments := generatePacketCommitments(1)
+	withDeltaFromInitialHeight := func(attestedData *types.IBCData) {
+		commitmentsDelta := types.NewCommitmentsDelta(defaultHeight, nil, nextPacketCommitments)
+		attestedData.Height = clienttypes.NewHeight(1, height.RevisionHeight+1)
+		attestedData.PacketCommitments = nil
+		attestedData.CommitmentsDelta = &commitmentsDelta
+		attestedData.CommitmentSetHash = types.CommitmentSetHash(nextPacketCommitments)
+	}
+
+	// the initial height is the lowest of MaxHistoricalCommitmentHeights+1 heights, so its packet commitments have been pruned
+	err = s.lightClientModule.VerifyClientMessage(s.ctx, clientID, generateClientMsg(s.mockAttestators, 0, withDeltaFromInitialHeight))
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "no packet commitments stored for commitments delta base height")
+}
+
+func (s *AttestationLightClientTestSuite) TestLightClientModule_TrustedUpdateStateWithBlockData() {
+	clientID := createClientID(0)
+	// block numbers have no revision, which matches a chain id without a revision number
+	clientState := *initialClientState
+	clientState.ChainId = "evmchain"
+	clientState.LatestHeight = clienttypes.NewHeight(0, 100)
+	clientStateBz := s.encCfg.Codec.MustMarshal(&clientState)
+	consensusStateBz := s.encCfg.Codec.MustMarshal(initialConsensusState)
+
+	err := s.lightClientModule.Initialize(s.ctx, clientID, clientStateBz, consensusStateBz)
+	s.Require().NoError(err)
+
+	expectedTimestamp := time.Now()
+	clientMsg := generateBlockDataClientMsg(s.mockAttestators, 5, func(blockData *types.BlockData) {
+		blockData.ChainId = clientState.ChainId
+		blockData.ClientToUpdate = clientID
+		blockData.BlockNumber = 101
+		blockData.Timestamp = expectedTimestamp
+	})
+
+	heights, err := s.trustedUpdateFunc(s.ctx, clientID, clientMsg)
+	s.Require().NoError(err)
+	expectedHeight := clienttypes.NewHeight(0, 101)
+	s.Require().Equal([]exported.Height{expectedHeight}, heights)
+
+	s.assertClientState(clientID, expectedHeight, expectedTimestamp)
+	s.assertPacketCommitmentStored(clientID, clientMsg.Payload.GetBlockDataV1().PacketCommitments)
 }
 
 func (s *AttestationLightClientTestSuite) TestLightClientModule_VerifyMembership() {
@@ -171,12 +365,13 @@ func (s *AttestationLightClientTestSuite) TestLightClientModule_VerifyMembership
 	err := s.lightClientModule.Initialize(s.ctx, clientID, clientStateBz, consensusStateBz)
 	s.Require().NoError(err)
 
-	clientMsg := generateClientMsg(s.encCfg.Codec, s.mockAttestators, 5, func(attestedData *types.IBCData) {
+	clientMsg := generateClientMsg(s.mockAttestators, 5, func(attestedData *types.IBCData) {
 		attestedData.Height = clienttypes.NewHeight(1, defaultHeight.RevisionHeight+1)
 	})
-	s.trustedUpdateFunc(s.ctx, clientID, clientMsg)
+	_, err = s.trustedUpdateFunc(s.ctx, clientID, clientMsg)
+	s.Require().NoError(err)
 
-	for _, packetCommitment := range clientMsg.Attestations[0].AttestedData.PacketCommitments {
+	for _, packetCommitment := range clientMsg.Payload.GetIbcDataV1().PacketCommitments {
 		err = s.lightClientModule.VerifyMembership(s.ctx, clientID, nil, 0, 0, nil, nil, packetCommitment)
 		s.Require().NoError(err)
 	}
@@ -184,13 +379,14 @@ func (s *AttestationLightClientTestSuite) TestLightClientModule_VerifyMembership
 	err = s.lightClientModule.VerifyMembership(s.ctx, clientID, nil, 0, 0, nil, nil, []byte("non-existent-packet-commitment"))
 	s.Require().Error(err)
 
-	oldPacketCommitments := clientMsg.Attestations[0].AttestedData.PacketCommitments
+	oldPacketCommitments := clientMsg.Payload.GetIbcDataV1().PacketCommitments
 
 	// Update state with no packet commitments
-	clientMsg = generateClientMsg(s.encCfg.Codec, s.mockAttestators, 0, func(attestedData *types.IBCData) {
-		attestedData.Height = clienttypes.NewHeight(1, clientMsg.Attestations[0].AttestedData.Height.RevisionHeight+1)
+	clientMsg = generateClientMsg(s.mockAttestators, 0, func(attestedData *types.IBCData) {
+		attestedData.Height = clienttypes.NewHeight(1, clientMsg.Payload.GetIbcDataV1().Height.RevisionHeight+1)
 	})
-	s.trustedUpdateFunc(s.ctx, clientID, clientMsg)
+	_, err = s.trustedUpdateFunc(s.ctx, clientID, clientMsg)
+	s.Require().NoError(err)
 
 	for _, packetCommitment := range oldPacketCommitments {
 		err = s.lightClientModule.VerifyMembership(s.ctx, clientID, nil, 0, 0, nil, nil, packetCommitment)
@@ -217,12 +413,12 @@ func (s *AttestationLightClientTestSuite) assertClientState(clientID string, exp
 	s.Require().Equal(uint64(expectedTimestamp.UnixNano()), timestampAtHeight)
 }
 
-func (s *AttestationLightClientTestSuite) assertPacketCommitmentStored(clientID string, clientMsg *lightclient.AttestationClaim) {
+func (s *AttestationLightClientTestSuite) assertPacketCommitmentStored(clientID string, packetCommitments [][]byte) {
 	clientStore := s.storeProvider.ClientStore(s.ctx, clientID)
 	packetCommitmentStore := prefix.NewStore(clientStore, []byte(lightclient.PacketCommitmentStoreKey))
 
 	// verify packet commitments are stored
-	for _, packetCommitment := range clientMsg.Attestations[0].AttestedData.PacketCommitments {
+	for _, packetCommitment := range packetCommitments {
 		hasPacketCommitment := packetCommitmentStore.Has(packetCommitment)
 		s.Require().True(hasPacketCommitment)
 	}
@@ -233,5 +429,5 @@ func (s *AttestationLightClientTestSuite) assertPacketCommitmentStored(clientID
 	for ; iterator.Valid(); iterator.Next() {
 		numberOfPacketsStored++
 	}
-	s.Require().Equal(len(clientMsg.Attestations[0].AttestedData.PacketCommitments), numberOfPacketsStored)
+	s.Require().Equal(len(packetCommitments), numberOfPacketsStored)
 }
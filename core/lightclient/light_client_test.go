@@ -13,6 +13,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	"github.com/cosmos/cosmos-sdk/testutil"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
@@ -24,13 +25,15 @@ import (
 	host "github.com/cosmos/ibc-go/v9/modules/core/24-host"
 	ibcexported "github.com/cosmos/ibc-go/v9/modules/core/exported"
 
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
 	"github.com/cosmos/interchain-attestation/core/lightclient"
 	"github.com/cosmos/interchain-attestation/core/types"
 )
 
 const (
-	mockChainID  = "testchain-1"
-	mockClientID = "testclient-1"
+	mockChainID     = "testchain-1"
+	mockClientID    = "testclient-1"
+	mockHostChainID = "hostchain-1"
 )
 
 var (
@@ -69,7 +72,7 @@ func (s *AttestationLightClientTestSuite) SetupTest() {
 	key := storetypes.NewKVStoreKey(ibcexported.StoreKey)
 	s.storeProvider = clienttypes.NewStoreProvider(key)
 	s.testCtx = testutil.DefaultContextWithDB(s.T(), key, storetypes.NewTransientStoreKey("transient_test"))
-	s.ctx = s.testCtx.Ctx.WithBlockHeader(cmtproto.Header{Time: cmttime.Now()})
+	s.ctx = s.testCtx.Ctx.WithBlockHeader(cmtproto.Header{Time: cmttime.Now()}).WithChainID(mockHostChainID)
 	s.encCfg = moduletestutil.MakeTestEncodingConfig(lightclient.AppModuleBasic{})
 
 	s.mockAttestators = generateAttestators(10)
@@ -79,31 +82,86 @@ func (s *AttestationLightClientTestSuite) SetupTest() {
 }
 
 type mockAttestator struct {
-	id []byte
+	id      []byte
+	privKey cryptotypes.PrivKey
 }
 
 type mockAttestatorsHandler struct {
 	attestators            map[string]mockAttestator
-	sufficientAttestations func() (bool, error)
+	attestatorSet          [][]byte
+	sufficientAttestations func(attestatorIDs [][]byte) (bool, error)
 }
 
 var _ lightclient.AttestatorsController = &mockAttestatorsHandler{}
 
 func NewMockAttestatorsHandler(attestators []mockAttestator) mockAttestatorsHandler {
 	attestatorsMap := make(map[string]mockAttestator)
+	var attestatorSet [][]byte
 	for _, attestator := range attestators {
 		attestatorsMap[string(attestator.id)] = attestator
+		attestatorSet = append(attestatorSet, attestator.id)
 	}
 	return mockAttestatorsHandler{
-		attestators: attestatorsMap,
-		sufficientAttestations: func() (bool, error) {
+		attestators:   attestatorsMap,
+		attestatorSet: attestatorSet,
+		sufficientAttestations: func(_ [][]byte) (bool, error) {
 			return true, nil
 		},
 	}
 }
 
-func (m mockAttestatorsHandler) SufficientAttestations(_ context.Context, _ [][]byte) (bool, error) {
-	return m.sufficientAttestations()
+func (m mockAttestatorsHandler) SufficientAttestations(_ context.Context, attestatorIDs [][]byte) (bool, error) {
+	return m.sufficientAttestations(attestatorIDs)
+}
+
+func (m mockAttestatorsHandler) VerifySignatures(_ context.Context, signBytes []byte, attestatorIDs [][]byte, signatures [][]byte, aggregateSignature []byte) error {
+	if len(aggregateSignature) != 0 {
+		var pubKeys []*bls12381.PubKey
+		for _, attestatorID := range attestatorIDs {
+			attestator, ok := m.attestators[string(attestatorID)]
+			if !ok {
+				return fmt.Errorf("unknown attestator %s", string(attestatorID))
+			}
+			pubKey, ok := attestator.privKey.PubKey().(*bls12381.PubKey)
+			if !ok {
+				return fmt.Errorf("aggregate signatures not supported for %s", string(attestatorID))
+			}
+			pubKeys = append(pubKeys, pubKey)
+		}
+		if !bls12381.VerifyAggregateSignature(pubKeys, signBytes, aggregateSignature) {
+			return fmt.Errorf("invalid aggregate signature")
+		}
+
+		return nil
+	}
+	if len(signatures) != len(attestatorIDs) {
+		return fmt.Errorf("missing signatures")
+	}
+
+	for i, attestatorID := range attestatorIDs {
+		attestator, ok := m.attestators[string(attestatorID)]
+		if !ok {
+			return fmt.Errorf("unknown attestator %s", string(attestatorID))
+		}
+		if !attestator.privKey.PubKey().VerifySignature(signBytes, signatures[i]) {
+			return fmt.Errorf("invalid signature from %s", string(attestatorID))
+		}
+	}
+
+	return nil
+}
+
+func (m mockAttestatorsHandler) AttestatorSet(_ context.Context) ([][]byte, error) {
+	return m.attestatorSet, nil
+}
+
+// AttestatorOperator returns the attestator id, which is the validator address of the mock attestators
+func (m mockAttestatorsHandler) AttestatorOperator(_ context.Context, attestatorID []byte) ([]byte, error) {
+	if _, ok := m.attestators[string(attestatorID)]; !ok {
+		return nil, fmt.Errorf("unknown attestator %s", string(attestatorID))
+	}
+
+	return attestatorID, nil
 }
 
 func generateAttestators(n int) []mockAttestator {
@@ -112,13 +170,30 @@ func generateAttestators(n int) []mockAttestator {
 		privKey := secp256k1.GenPrivKey()
 		valAddr := sdk.ValAddress(privKey.PubKey().Address())
 		attestators[i] = mockAttestator{
-			id: valAddr,
+			id:      valAddr,
+			privKey: privKey,
 		}
 	}
 	return attestators
 }
 
-func generateClientMsg(_ codec.BinaryCodec, attestators []mockAttestator, numberOfPacketCommitments int, modifiers ...func(dataToAttestTo *types.IBCData)) *lightclient.AttestationClaim {
+func generateBLSAttestators(n int) []mockAttestator {
+	attestators := make([]mockAttestator, n)
+	for i := 0; i < n; i++ {
+		privKey, err := bls12381.GenPrivKey()
+		if err != nil {
+			panic(err)
+		}
+		attestators[i] = mockAttestator{
+			id:      sdk.ValAddress(privKey.PubKey().Address()),
+			privKey: privKey,
+		}
+	}
+	return attestators
+}
+
+// generateAttestations generates signed attestations from all the attestators for the same data
+func generateAttestations(attestators []mockAttestator, numberOfPacketCommitments int, modifiers ...func(dataToAttestTo *types.IBCData)) []types.Attestation {
 	attestations := make([]types.Attestation, len(attestators))
 	packetCommitments := generatePacketCommitments(numberOfPacketCommitments)
 	timestamp := time.Now()
@@ -140,14 +215,62 @@ func generateClientMsg(_ codec.BinaryCodec, attestators []mockAttestator, number
 			modifier(&attestationData)
 		}
 
+		signature, err := attestator.privKey.Sign(types.GetAttestationSignBytes(mockHostChainID, types.NewIBCDataPayload(attestationData)))
+		if err != nil {
+			panic(err)
+		}
+
 		attestations[i] = types.Attestation{
 			AttestatorId: attestator.id,
-			AttestedData: attestationData,
+			Payload:      types.NewIBCDataPayload(attestationData),
+			Signature:    signature,
 		}
 	}
-	return &lightclient.AttestationClaim{
-		Attestations: attestations,
+	return attestations
+}
+
+func generateClientMsg(attestators []mockAttestator, numberOfPacketCommitments int, modifiers ...func(dataToAttestTo *types.IBCData)) *lightclient.AttestationClaim {
+	clientMsg, err := lightclient.NewAttestationClaim(mockHostChainID, generateAttestations(attestators, numberOfPacketCommitments, modifiers...))
+	if err != nil {
+		panic(err)
+	}
+	return clientMsg
+}
+
+// generateBlockDataClientMsg generates a claim from all the attestators for the same block data
+func generateBlockDataClientMsg(attestators []mockAttestator, numberOfPacketCommitments int, modifiers ...func(dataToAttestTo *types.BlockData)) *lightclient.AttestationClaim {
+	blockData := types.BlockData{
+		ChainId:           mockChainID,
+		BlockNumber:       defaultHeight.RevisionHeight,
+		BlockHash:         []byte("block hash"),
+		Timestamp:         time.Now(),
+		StorageRoot:       []byte("storage root"),
+		PacketCommitments: generatePacketCommitments(numberOfPacketCommitments),
+	}
+	for _, modifier := range modifiers {
+		modifier(&blockData)
+	}
+
+	payload := types.NewBlockDataPayload(blockData)
+	attestations := make([]types.Attestation, len(attestators))
+	for i, attestator := range attestators {
+		signature, err := attestator.privKey.Sign(types.GetAttestationSignBytes(mockHostChainID, payload))
+		if err != nil {
+			panic(err)
+		}
+
+		attestations[i] = types.Attestation{
+			AttestatorId: attestator.id,
+			Payload:      payload,
+			Signature:    signature,
+		}
+	}
+
+	clientMsg, err := lightclient.NewAttestationClaim(mockHostChainID, attestations)
+	if err != nil {
+		panic(err)
 	}
+	return clientMsg
 }
 
 func generatePacketCommitments(n int) [][]byte {
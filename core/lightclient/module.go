@@ -14,6 +14,7 @@ import (
 var (
 	_ module.AppModuleBasic = (*AppModuleBasic)(nil)
 	_ appmodule.AppModule   = (*AppModule)(nil)
+	_ module.HasServices    = (*AppModule)(nil)
 )
 
 type AppModuleBasic struct{}
@@ -47,6 +48,11 @@ func NewAppModule(lightClientModule LightClientModule) AppModule {
 	}
 }
 
+// RegisterServices registers the attestation light client Msg service, used by chains without vote extensions.
+func (am AppModule) RegisterServices(cfg module.Configurator) {
+	RegisterMsgServer(cfg.MsgServer(), NewMsgServerImpl(am.lightClientModule))
+}
+
 // IsAppModule implements the appmodule.AppModule interface.
 func (AppModuleBasic) IsAppModule() {}
 
@@ -0,0 +1,35 @@
+package lightclient
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var _ MsgServer = msgServer{}
+
+type msgServer struct {
+	lightClientModule LightClientModule
+}
+
+// NewMsgServerImpl returns an implementation of the MsgServer interface for the provided light client module.
+func NewMsgServerImpl(lightClientModule LightClientModule) MsgServer {
+	return msgServer{lightClientModule: lightClientModule}
+}
+
+// SubmitAttestation stores the submitted attestation and updates the client if enough attestations have been submitted.
+func (ms msgServer) SubmitAttestation(goCtx context.Context, msg *MsgSubmitAttestation) (*MsgSubmitAttestationResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	signer, err := sdk.AccAddressFromBech32(msg.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	clientUpdated, err := ms.lightClientModule.submitAttestation(ctx, signer, msg.Attestation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MsgSubmitAttestationResponse{ClientUpdated: clientUpdated}, nil
+}
@@ -0,0 +1,315 @@
+package lightclient_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	clienttypes "github.com/cosmos/ibc-go/v9/modules/core/02-client/types"
+
+	"github.com/cosmos/interchain-attestation/core/lightclient"
+	"github.com/cosmos/interchain-attestation/core/types"
+)
+
+func (s *AttestationLightClientTestSuite) TestMsgServer_SubmitAttestation() {
+	const requiredAttestations = 3
+
+	clientID := createClientID(0)
+	attestedHeight := clienttypes.NewHeight(1, defaultHeight.RevisionHeight+1)
+	attestedTimestamp := time.Now()
+	setClientToUpdate := func(attestedData *types.IBCData) {
+		attestedData.ClientToUpdate = clientID
+		attestedData.Height = attestedHeight
+		attestedData.Timestamp = attestedTimestamp
+	}
+
+	var (
+		msgServer    lightclient.MsgServer
+		attestations []types.Attestation
+		// signer of the last submitted attestation, defaults to the operator of the attestator
+		signer sdk.AccAddress
+	)
+
+	submit := func(attestation types.Attestation) (*lightclient.MsgSubmitAttestationResponse, error) {
+		msg := lightclient.NewMsgSubmitAttestation(sdk.AccAddress(attestation.AttestatorId).String(), attestation)
+		return msgServer.SubmitAttestation(s.ctx, msg)
+	}
+
+	tests := []struct {
+		name           string
+		malleate       func()
+		expUpdated     bool
+		expError       error
+		expStateUpdate bool
+	}{
+		{
+			"success: client updated when the last required attestation is submitted",
+			func() {
+				for i := 0; i < requiredAttestations-1; i++ {
+					resp, err := submit(attestations[i])
+					s.Require().NoError(err)
+					s.Require().False(resp.ClientUpdated)
+				}
+			},
+			true,
+			nil,
+			true,
+		},
+		{
+			"success: not enough attestations yet",
+			func() {
+				resp, err := submit(attestations[0])
+				s.Require().NoError(err)
+				s.Require().False(resp.ClientUpdated)
+			},
+			false,
+			nil,
+			false,
+		},
+		{
+			"success: conflicting attestations do not count towards the update",
+			func() {
+				for _, attestation := range generateAttestations(s.mockAttestators[:requiredAttestations-1], 1, setClientToUpdate) {
+					_, err := submit(attestation)
+					s.Require().NoError(err)
+				}
+			},
+			false,
+			nil,
+			false,
+		},
+		{
+			"failure: duplicate attestation from the same attestator",
+			func() {
+				_, err := submit(attestations[requiredAttestations-1])
+				s.Require().NoError(err)
+			},
+			false,
+			lightclient.ErrDuplicateAttestation,
+			false,
+		},
+		{
+			"failure: signer is not the operator of the attestator",
+			func() {
+				signer = sdk.AccAddress(s.mockAttestators[0].id)
+			},
+			false,
+			lightclient.ErrInvalidSigner,
+			false,
+		},
+		{
+			"failure: unregistered attestator",
+			func() {
+				unregistered := generateAttestators(1)
+				attestations[requiredAttestations-1] = generateAttestations(unregistered, 1, setClientToUpdate)[0]
+				signer = sdk.AccAddress(unregistered[0].id)
+			},
+			false,
+			lightclient.ErrInvalidSigner,
+			false,
+		},
+		{
+			"failure: invalid signature",
+			func() {
+				attestations[requiredAttestations-1].Signature = attestations[0].Signature
+			},
+			false,
+			lightclient.ErrInvalidAttestation,
+			false,
+		},
+		{
+			"failure: height is not greater than the latest client height",
+			func() {
+				attestations[requiredAttestations-1].Payload.GetIbcDataV1().Height = defaultHeight
+			},
+			false,
+			lightclient.ErrInvalidHeaderHeight,
+			false,
+		},
+		{
+			"failure: client not found",
+			func() {
+				attestations[requiredAttestations-1].Payload.GetIbcDataV1().ClientToUpdate = createClientID(1)
+			},
+			false,
+			clienttypes.ErrClientNotFound,
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+
+			attestatorsHandler := NewMockAttestatorsHandler(s.mockAttestators)
+			attestatorsHandler.sufficientAttestations = func(attestatorIDs [][]byte) (bool, error) {
+				return len(attestatorIDs) >= requiredAttestations, nil
+			}
+			lightClientModule, _ := lightclient.NewLightClientModule(s.encCfg.Codec, s.storeProvider, attestatorsHandler)
+			msgServer = lightclient.NewMsgServerImpl(lightClientModule)
+
+			err := lightClientModule.Initialize(s.ctx, clientID, s.encCfg.Codec.MustMarshal(initialClientState), s.encCfg.Codec.MustMarshal(initialConsensusState))
+			s.Require().NoError(err)
+
+			attestations = generateAttestations(s.mockAttestators[:requiredAttestations], 5, setClientToUpdate)
+
+			signer = sdk.AccAddress(attestations[requiredAttestations-1].AttestatorId)
+
+			tt.malleate()
+
+			msg := lightclient.NewMsgSubmitAttestation(signer.String(), attestations[requiredAttestations-1])
+			resp, err := msgServer.SubmitAttestation(s.ctx, msg)
+			if tt.expError != nil {
+				s.Require().ErrorIs(err, tt.expError)
+				return
+			}
+			s.Require().NoError(err)
+			s.Require().Equal(tt.expUpdated, resp.ClientUpdated)
+
+			clientStore := s.storeProvider.ClientStore(s.ctx, clientID)
+			if tt.expStateUpdate {
+				s.assertClientState(clientID, attestedHeight, attestedTimestamp)
+				s.assertPacketCommitmentStored(clientID, attestations[0].Payload.GetIbcDataV1().PacketCommitments)
+			} else {
+				s.Require().Equal(defaultHeight, getClientState(clientStore, s.encCfg.Codec).LatestHeight)
+			}
+		})
+	}
+}
+
+func (s *AttestationLightClientTestSuite) TestMsgServer_SubmitAttestationPendingHeightsLimit() {
+	clientID := createClientID(0)
+	attestator := s.mockAttestators[0]
+
+	attestatorsHandler := NewMockAttestatorsHandler(s.mockAttestators)
+	attestatorsHandler.sufficientAttestations = func(_ [][]byte) (bool, error) {
+		return false, nil
+	}
+	lightClientModule, _ := lightclient.NewLightClientModule(s.encCfg.Codec, s.storeProvider, attestatorsHandler)
+	msgServer := lightclient.NewMsgServerImpl(lightClientModule)
+
+	err := lightClientModule.Initialize(s.ctx, clientID, s.encCfg.Codec.MustMarshal(initialClientState), s.encCfg.Codec.MustMarshal(initialConsensusState))
+	s.Require().NoError(err)
+
+	submit := func(revisionHeight uint64) error {
+		attestation := generateAttestations([]mockAttestator{attestator}, 1, func(attestedData *types.IBCData) {
+			attestedData.ClientToUpdate = clientID
+			attestedData.Height = clienttypes.NewHeight(1, revisionHeight)
+		})[0]
+		_, err := msgServer.SubmitAttestation(s.ctx, lightclient.NewMsgSubmitAttestation(sdk.AccAddress(attestator.id).String(), attestation))
+		return err
+	}
+
+	firstHeight := defaultHeight.RevisionHeight + 1
+	for i := uint64(0); i <= lightclient.MaxPendingAttestationHeights; i++ {
+		s.Require().NoError(submit(firstHeight + i))
+	}
+
+	// the lowest pending height was pruned to make room for the last one, the others are still pending
+	s.Require().ErrorIs(submit(firstHeight+lightclient.MaxPendingAttestationHeights), lightclient.ErrDuplicateAttestation)
+	s.Require().ErrorIs(submit(firstHeight+1), lightclient.ErrDuplicateAttestation)
+	s.Require().NoError(submit(firstHeight))
+}
+
+func (s *AttestationLightClientTestSuite) TestMsgSubmitAttestation_ValidateBasic() {
+	attestator := s.mockAttestators[0]
+	validAttestation := generateAttestations([]mockAttestator{attestator}, 1, func(attestedData *types.IBCData) {
+		attestedData.ClientToUpdate = createClientID(0)
+	})[0]
+
+	tests := []struct {
+		name     string
+		malleate func(msg *lightclient.MsgSubmitAttestation)
+		expError error
+	}{
+		{
+			"valid message",
+			func(_ *lightclient.MsgSubmitAttestation) {},
+			nil,
+		},
+		{
+			"invalid signer address",
+			func(msg *lightclient.MsgSubmitAttestation) {
+				msg.Signer = "invalid"
+			},
+			lightclient.ErrInvalidSigner,
+		},
+		{
+			"empty attestator id",
+			func(msg *lightclient.MsgSubmitAttestation) {
+				msg.Attestation.AttestatorId = nil
+			},
+			lightclient.ErrInvalidAttestation,
+		},
+		{
+			"empty chain id",
+			func(msg *lightclient.MsgSubmitAttestation) {
+				msg.Attestation.Payload.GetIbcDataV1().ChainId = ""
+			},
+			lightclient.ErrInvalidChainID,
+		},
+		{
+			"invalid client to update",
+			func(msg *lightclient.MsgSubmitAttestation) {
+				msg.Attestation.Payload.GetIbcDataV1().ClientToUpdate = ""
+			},
+			lightclient.ErrInvalidAttestation,
+		},
+		{
+			"zero height",
+			func(msg *lightclient.MsgSubmitAttestation) {
+				msg.Attestation.Payload.GetIbcDataV1().Height = clienttypes.ZeroHeight()
+			},
+			lightclient.ErrInvalidHeaderHeight,
+		},
+		{
+			"empty payload",
+			func(msg *lightclient.MsgSubmitAttestation) {
+				msg.Attestation.Payload = types.AttestationPayload{}
+			},
+			lightclient.ErrInvalidAttestation,
+		},
+		{
+			"valid block data payload",
+			func(msg *lightclient.MsgSubmitAttestation) {
+				msg.Attestation.Payload = types.NewBlockDataPayload(types.BlockData{
+					ChainId:        mockChainID,
+					ClientToUpdate: createClientID(0),
+					BlockNumber:    42,
+					BlockHash:      []byte("block hash"),
+					Timestamp:      time.Now(),
+				})
+			},
+			nil,
+		},
+		{
+			"block data with zero block number",
+			func(msg *lightclient.MsgSubmitAttestation) {
+				msg.Attestation.Payload = types.NewBlockDataPayload(types.BlockData{
+					ChainId:        mockChainID,
+					ClientToUpdate: createClientID(0),
+					BlockHash:      []byte("block hash"),
+					Timestamp:      time.Now(),
+				})
+			},
+			lightclient.ErrInvalidHeaderHeight,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// the payload is copied so that the test cases don't modify the valid attestation
+			attestation := validAttestation
+			attestation.Payload = types.NewIBCDataPayload(*validAttestation.Payload.GetIbcDataV1())
+			msg := lightclient.NewMsgSubmitAttestation(sdk.AccAddress(attestator.id).String(), attestation)
+			tt.malleate(msg)
+
+			err := msg.ValidateBasic()
+			if tt.expError != nil {
+				s.Require().ErrorIs(err, tt.expError)
+			} else {
+				s.Require().NoError(err)
+			}
+		})
+	}
+}
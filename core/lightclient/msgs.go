@@ -0,0 +1,52 @@
+package lightclient
+
+import (
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	host "github.com/cosmos/ibc-go/v9/modules/core/24-host"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+)
+
+var _ sdk.Msg = (*MsgSubmitAttestation)(nil)
+
+// NewMsgSubmitAttestation creates a new MsgSubmitAttestation instance
+func NewMsgSubmitAttestation(signer string, attestation types.Attestation) *MsgSubmitAttestation {
+	return &MsgSubmitAttestation{
+		Signer:      signer,
+		Attestation: attestation,
+	}
+}
+
+// ValidateBasic performs stateless validation of the submitted attestation
+func (m *MsgSubmitAttestation) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(m.Signer); err != nil {
+		return errorsmod.Wrapf(ErrInvalidSigner, "invalid signer address: %s", err)
+	}
+
+	// that the signer is the operator of the attestator is checked against the registered attestators when the message is executed
+	if len(m.Attestation.AttestatorId) == 0 {
+		return errorsmod.Wrap(ErrInvalidAttestation, "attestator id cannot be empty")
+	}
+
+	payload := m.Attestation.Payload.Unpack()
+	if payload == nil {
+		return errorsmod.Wrap(ErrInvalidAttestation, "payload cannot be empty")
+	}
+	if payload.GetChainId() == "" {
+		return errorsmod.Wrap(ErrInvalidChainID, "chain id cannot be empty")
+	}
+	if err := host.ClientIdentifierValidator(payload.GetClientToUpdate()); err != nil {
+		return errorsmod.Wrapf(ErrInvalidAttestation, "invalid client to update: %s", err)
+	}
+	if payload.AttestedHeight().IsZero() {
+		return errorsmod.Wrap(ErrInvalidHeaderHeight, "height cannot be zero")
+	}
+	if payload.GetTimestamp().IsZero() {
+		return errorsmod.Wrap(ErrInvalidAttestation, "timestamp cannot be zero")
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package lightclient
 
 import (
+	"encoding/binary"
 	"fmt"
 
 	"cosmossdk.io/store/prefix"
@@ -11,6 +12,8 @@ import (
 	clienttypes "github.com/cosmos/ibc-go/v9/modules/core/02-client/types"
 	host "github.com/cosmos/ibc-go/v9/modules/core/24-host"
 	"github.com/cosmos/ibc-go/v9/modules/core/exported"
+
+	"github.com/cosmos/interchain-attestation/core/types"
 )
 
 // getClientState retrieves the client state from the store using the provided KVStore and codec.
@@ -76,6 +79,168 @@ func setPacketCommitmentState(clientStore storetypes.KVStore, packetCommitments
 	}
 }
 
+// getPacketCommitments returns all the packet commitments currently stored for the client (sorted)
+func getPacketCommitments(clientStore storetypes.KVStore) [][]byte {
+	packetCommitmentStore := getPacketCommitmentStore(clientStore)
+
+	var packetCommitments [][]byte
+	iterator := packetCommitmentStore.Iterator(nil, nil)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		packetCommitments = append(packetCommitments, iterator.Key())
+	}
+
+	return packetCommitments
+}
+
 func getPacketCommitmentStore(clientStore storetypes.KVStore) storetypes.KVStore {
 	return prefix.NewStore(clientStore, []byte(PacketCommitmentStoreKey))
 }
+
+// hasPendingAttestation returns true if the attestator already has a pending attestation stored for the given height
+func hasPendingAttestation(clientStore storetypes.KVStore, height exported.Height, attestatorID []byte) bool {
+	return getPendingAttestationStore(clientStore).Has(pendingAttestationKey(height, attestatorID))
+}
+
+// setPendingAttestation stores an attestation submitted through a transaction until enough attestations
+// for the same height have been collected
+func setPendingAttestation(clientStore storetypes.KVStore, cdc codec.BinaryCodec, attestation types.Attestation) {
+	key := pendingAttestationKey(attestation.Payload.AttestedHeight(), attestation.AttestatorId)
+	getPendingAttestationStore(clientStore).Set(key, cdc.MustMarshal(&attestation))
+}
+
+// getPendingAttestations returns all the pending attestations for the given height
+func getPendingAttestations(clientStore storetypes.KVStore, cdc codec.BinaryCodec, height exported.Height) []types.Attestation {
+	heightStore := prefix.NewStore(getPendingAttestationStore(clientStore), heightPrefix(height))
+
+	var attestations []types.Attestation
+	iterator := heightStore.Iterator(nil, nil)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var attestation types.Attestation
+		cdc.MustUnmarshal(iterator.Value(), &attestation)
+		attestations = append(attestations, attestation)
+	}
+
+	return attestations
+}
+
+// deletePendingAttestations removes all pending attestations for heights up to and including the given height
+func deletePendingAttestations(clientStore storetypes.KVStore, height exported.Height) {
+	pendingAttestationStore := getPendingAttestationStore(clientStore)
+
+	iterator := pendingAttestationStore.Iterator(nil, storetypes.PrefixEndBytes(heightPrefix(height)))
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		pendingAttestationStore.Delete(key)
+	}
+}
+
+// getPendingAttestationHeights returns the heights that have pending attestations stored, in ascending order
+func getPendingAttestationHeights(clientStore storetypes.KVStore) []clienttypes.Height {
+	iterator := getPendingAttestationStore(clientStore).Iterator(nil, nil)
+	defer iterator.Close()
+
+	var heights []clienttypes.Height
+	for ; iterator.Valid(); iterator.Next() {
+		height := heightFromPrefix(iterator.Key())
+		if len(heights) == 0 || !heights[len(heights)-1].EQ(height) {
+			heights = append(heights, height)
+		}
+	}
+
+	return heights
+}
+
+// setHistoricalCommitments stores the packet commitments of the client at the given height, so that a commitments delta
+// based on that height can still be applied after the client has been updated to a later height
+func setHistoricalCommitments(clientStore storetypes.KVStore, height exported.Height, packetCommitments [][]byte) {
+	getHistoricalCommitmentHeightsStore(clientStore).Set(heightPrefix(height), []byte{1})
+
+	commitmentsStore := prefix.NewStore(getHistoricalCommitmentsStore(clientStore), heightPrefix(height))
+	for _, packetCommitment := range packetCommitments {
+		commitmentsStore.Set(packetCommitment, []byte{1})
+	}
+}
+
+// getHistoricalCommitments returns the packet commitments (sorted) of the client at the given height,
+// and false if they are not stored (anymore)
+func getHistoricalCommitments(clientStore storetypes.KVStore, height exported.Height) ([][]byte, bool) {
+	if !getHistoricalCommitmentHeightsStore(clientStore).Has(heightPrefix(height)) {
+		return nil, false
+	}
+
+	commitmentsStore := prefix.NewStore(getHistoricalCommitmentsStore(clientStore), heightPrefix(height))
+	iterator := commitmentsStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	var packetCommitments [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		packetCommitments = append(packetCommitments, iterator.Key())
+	}
+
+	return packetCommitments, true
+}
+
+// pruneHistoricalCommitments removes the packet commitments of the lowest heights, so that they are stored for at most maxHeights heights
+func pruneHistoricalCommitments(clientStore storetypes.KVStore, maxHeights int) {
+	heightsStore := getHistoricalCommitmentHeightsStore(clientStore)
+
+	var heightKeys [][]byte
+	iterator := heightsStore.Iterator(nil, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		heightKeys = append(heightKeys, iterator.Key())
+	}
+	iterator.Close()
+
+	for len(heightKeys) > maxHeights {
+		heightsStore.Delete(heightKeys[0])
+
+		commitmentsStore := prefix.NewStore(getHistoricalCommitmentsStore(clientStore), heightKeys[0])
+		var keys [][]byte
+		commitmentsIterator := commitmentsStore.Iterator(nil, nil)
+		for ; commitmentsIterator.Valid(); commitmentsIterator.Next() {
+			keys = append(keys, commitmentsIterator.Key())
+		}
+		commitmentsIterator.Close()
+		for _, key := range keys {
+			commitmentsStore.Delete(key)
+		}
+
+		heightKeys = heightKeys[1:]
+	}
+}
+
+func getHistoricalCommitmentsStore(clientStore storetypes.KVStore) storetypes.KVStore {
+	return prefix.NewStore(clientStore, []byte(HistoricalCommitmentsStoreKey))
+}
+
+func getHistoricalCommitmentHeightsStore(clientStore storetypes.KVStore) storetypes.KVStore {
+	return prefix.NewStore(clientStore, []byte(HistoricalCommitmentHeightsStoreKey))
+}
+
+func getPendingAttestationStore(clientStore storetypes.KVStore) storetypes.KVStore {
+	return prefix.NewStore(clientStore, []byte(PendingAttestationStoreKey))
+}
+
+// heightPrefix returns the big endian encoded height so that keys (e.g. pending attestations) are ordered by height
+func heightPrefix(height exported.Height) []byte {
+	bz := make([]byte, 16)
+	binary.BigEndian.PutUint64(bz[:8], height.GetRevisionNumber())
+	binary.BigEndian.PutUint64(bz[8:], height.GetRevisionHeight())
+	return bz
+}
+
+// heightFromPrefix decodes the height from a key that starts with a heightPrefix
+func heightFromPrefix(key []byte) clienttypes.Height {
+	return clienttypes.NewHeight(binary.BigEndian.Uint64(key[:8]), binary.BigEndian.Uint64(key[8:16]))
+}
+
+func pendingAttestationKey(height exported.Height, attestatorID []byte) []byte {
+	return append(heightPrefix(height), attestatorID...)
+}
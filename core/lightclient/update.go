@@ -2,6 +2,7 @@ package lightclient
 
 import (
 	"bytes"
+	"fmt"
 
 	errorsmod "cosmossdk.io/errors"
 	storetypes "cosmossdk.io/store/types"
@@ -9,15 +10,27 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	host "github.com/cosmos/ibc-go/v9/modules/core/24-host"
 	"github.com/cosmos/ibc-go/v9/modules/core/exported"
 
 	"github.com/cosmos/interchain-attestation/core/types"
 )
 
+// MaxHistoricalCommitmentHeights is the number of most recent heights that the packet commitments of a client are kept for.
+// Vote extensions are created before the client updates of the block they vote on are applied, so their commitments delta
+// can be based on a height the client has already moved past by the time the delta is applied.
+//
+// Every update stores a full copy of its packet commitments (one store write per commitment) for its height, on top of
+// the current packet commitments, so an update costs about twice the gas of the commitments it writes, and a client
+// stores up to MaxHistoricalCommitmentHeights+1 copies of its packet commitments. Storing the historical commitments as
+// deltas would be cheaper to write, but every delta applied to them would then have to replay the deltas in between.
+const MaxHistoricalCommitmentHeights = 8
+
 // VerifyClientMessage checks if the clientMessage is the correct type and verifies the message
 func (cs *ClientState) VerifyClientMessage(
 	ctx sdk.Context,
 	cdc codec.BinaryCodec,
+	clientStore storetypes.KVStore,
 	attestatorsHandler AttestatorsController,
 	clientMsg exported.ClientMessage,
 ) error {
@@ -26,36 +39,38 @@ func (cs *ClientState) VerifyClientMessage(
 		return errorsmod.Wrapf(ErrInvalidClientMsg, "invalid client message type %T", clientMsg)
 	}
 
-	return cs.verifyAttestationClaim(ctx, cdc, attestatorsHandler, attestationClaim)
+	return cs.verifyAttestationClaim(ctx, cdc, clientStore, attestatorsHandler, attestationClaim)
 }
 
-// verifyAttestationClaim verifies that the provided attestation claims are valid, all the same and valid signatures from enough validators
+// verifyAttestationClaim verifies that the provided attestation claim is well-formed, signed by its attestators and that enough attestators attested to it
 func (cs *ClientState) verifyAttestationClaim(
 	ctx sdk.Context,
 	cdc codec.BinaryCodec,
+	clientStore storetypes.KVStore,
 	attestatorsHandler AttestatorsController,
 	attestationClaim *AttestationClaim,
 ) error {
-	if len(attestationClaim.Attestations) == 0 {
-		return errorsmod.Wrapf(ErrInvalidClientMsg, "empty attestations")
+	// checks that there are attestators, that they are unique and that the number of signatures matches
+	if err := attestationClaim.ValidateBasic(); err != nil {
+		return err
 	}
 
-	seenAttestators := make(map[string]bool)
-	var attestatorsSignedOff [][]byte
-	for _, attestation := range attestationClaim.Attestations {
-		attestator := string(attestation.AttestatorId)
-
-		// check that all attestators are unqiue
-		_, ok := seenAttestators[attestator]
-		if ok {
-			return errorsmod.Wrapf(ErrInvalidClientMsg, "duplicate attestation from %s", attestator)
+	attestatorIDs := attestationClaim.AttestatorIds
+	if len(attestationClaim.ParticipationBitmap) != 0 {
+		attestatorSet, err := attestatorsHandler.AttestatorSet(ctx)
+		if err != nil {
+			return errorsmod.Wrapf(ErrInvalidClientMsg, "failed to get attestator set: %s", err)
+		}
+		if attestatorIDs, err = ParticipatingAttestators(attestatorSet, attestationClaim.ParticipationBitmap); err != nil {
+			return err
+		}
+		if len(attestatorIDs) == 0 {
+			return errorsmod.Wrapf(ErrInvalidClientMsg, "empty attestations")
 		}
-		seenAttestators[attestator] = true
-		attestatorsSignedOff = append(attestatorsSignedOff, attestation.AttestatorId)
 	}
 
 	// check that enough attestators have signed off
-	sufficient, err := attestatorsHandler.SufficientAttestations(ctx, attestatorsSignedOff)
+	sufficient, err := attestatorsHandler.SufficientAttestations(ctx, attestatorIDs)
 	if err != nil {
 		return errorsmod.Wrapf(ErrInvalidClientMsg, "failed to check sufficient attestations: %s", err)
 	}
@@ -63,30 +78,15 @@ func (cs *ClientState) verifyAttestationClaim(
 		return errorsmod.Wrapf(ErrInvalidClientMsg, "not enough attestations")
 	}
 
-	// check that all attestations have packet commitments that are unique
-	seenPacketCommitments := make(map[string]bool)
-	for _, packetCommitements := range attestationClaim.Attestations[0].AttestedData.PacketCommitments {
-		_, ok := seenPacketCommitments[string(packetCommitements)]
-		if ok {
-			return errorsmod.Wrapf(ErrInvalidClientMsg, "duplicate packet commitment %s", string(packetCommitements))
-		}
-		seenPacketCommitments[string(packetCommitements)] = true
+	// check that the attestators actually signed the attested data
+	signBytes := types.GetAttestationSignBytes(ctx.ChainID(), attestationClaim.Payload)
+	if err := attestatorsHandler.VerifySignatures(ctx, signBytes, attestatorIDs, attestationClaim.Signatures, attestationClaim.AggregateSignature); err != nil {
+		return errorsmod.Wrapf(ErrInvalidClientMsg, "failed to verify signatures: %s", err)
 	}
 
-	// Used to check against all the other attestations to make sure they match
-	firstAttestationBytes := types.GetDeterministicAttestationBytes(cdc, attestationClaim.Attestations[0].AttestedData)
-
-	// check that the attestations are all the same
-	for i, attestation := range attestationClaim.Attestations {
-		// we are going to equals check against the first one, so we skip it here
-		if i == 0 {
-			continue
-		}
-
-		attestationBytes := types.GetDeterministicAttestationBytes(cdc, attestation.AttestedData)
-		if !bytes.Equal(firstAttestationBytes, attestationBytes) {
-			return errorsmod.Wrapf(ErrInvalidClientMsg, "attestations must all be the same")
-		}
+	// check that the payload is of a supported type, and that its packet commitments can be resolved into a valid set
+	if _, err := cs.resolvePacketCommitments(clientStore, attestationClaim.Payload); err != nil {
+		return errorsmod.Wrap(ErrInvalidClientMsg, err.Error())
 	}
 
 	return nil
@@ -98,14 +98,17 @@ func (cs *ClientState) UpdateState(ctx sdk.Context, cdc codec.BinaryCodec, clien
 		panic(errorsmod.Wrapf(ErrInvalidClientMsg, "invalid client message type %T", clientMsg))
 	}
 
-	if len(attestationClaim.Attestations) == 0 {
+	if len(attestationClaim.AttestatorIds) == 0 && len(attestationClaim.ParticipationBitmap) == 0 {
 		// perform no-op
 		return []exported.Height{}
 	}
 
-	height := attestationClaim.Attestations[0].AttestedData.Height
-	timestamp := attestationClaim.Attestations[0].AttestedData.Timestamp
-	packetCommitements := attestationClaim.Attestations[0].AttestedData.PacketCommitments
+	payload := attestationClaim.Payload.Unpack()
+	if payload == nil {
+		panic(errorsmod.Wrap(ErrInvalidClientMsg, "empty payload"))
+	}
+	height := payload.AttestedHeight()
+	timestamp := payload.GetTimestamp()
 
 	// TODO: Pruning
 
@@ -115,7 +118,16 @@ func (cs *ClientState) UpdateState(ctx sdk.Context, cdc codec.BinaryCodec, clien
 		return []exported.Height{height}
 	}
 
-	if height.GT(cs.LatestHeight) {
+	// the packet commitments need to be resolved before updating the state, since a delta is relative to the stored commitments
+	packetCommitements, err := cs.resolvePacketCommitments(clientStore, attestationClaim.Payload)
+	if err != nil {
+		panic(errorsmod.Wrap(ErrInvalidClientMsg, err.Error()))
+	}
+
+	// the current packet commitments are those of the latest height, so an update for an older height only stores the
+	// packet commitments of that height as historical commitments
+	isLatest := height.GTE(cs.LatestHeight)
+	if isLatest {
 		cs.LatestHeight = height
 	}
 
@@ -123,7 +135,97 @@ func (cs *ClientState) UpdateState(ctx sdk.Context, cdc codec.BinaryCodec, clien
 
 	setClientState(clientStore, cdc, cs)
 	setConsensusState(clientStore, cdc, consensusState, height)
-	setPacketCommitmentState(clientStore, packetCommitements)
+	if isLatest {
+		setPacketCommitmentState(clientStore, packetCommitements)
+	}
+	setHistoricalCommitments(clientStore, height, packetCommitements)
+	pruneHistoricalCommitments(clientStore, MaxHistoricalCommitmentHeights)
 
 	return []exported.Height{height}
 }
+
+// resolvePacketCommitments returns the full set of packet commitments for the payload, dispatching on the payload type.
+// Payload types that the client doesn't support are rejected.
+func (cs *ClientState) resolvePacketCommitments(clientStore storetypes.KVStore, payload types.AttestationPayload) ([][]byte, error) {
+	switch data := payload.Unpack().(type) {
+	case *types.IBCData:
+		return cs.resolveIBCDataPacketCommitments(clientStore, *data)
+	case *types.BlockData:
+		return resolveBlockDataPacketCommitments(*data)
+	case nil:
+		return nil, fmt.Errorf("empty payload")
+	default:
+		return nil, fmt.Errorf("unsupported payload type %T", data)
+	}
+}
+
+// resolveBlockDataPacketCommitments returns the packet commitments of block data, which are always given in full
+func resolveBlockDataPacketCommitments(blockData types.BlockData) ([][]byte, error) {
+	if len(blockData.BlockHash) == 0 {
+		return nil, fmt.Errorf("block hash cannot be empty")
+	}
+	if err := validateUniquePacketCommitments(blockData.PacketCommitments); err != nil {
+		return nil, err
+	}
+
+	return blockData.PacketCommitments, nil
+}
+
+// resolveIBCDataPacketCommitments returns the full set of packet commitments for the attested data. If the packet commitments
+// are given as a delta, it is applied to the packet commitments the client had at the base height of the delta, which must
+// be one of the recent heights the client still has a consensus state and packet commitments stored for
+// (see MaxHistoricalCommitmentHeights). The resulting set is checked against the commitment set hash if one is given
+// (which is required for deltas).
+func (cs *ClientState) resolveIBCDataPacketCommitments(clientStore storetypes.KVStore, attestedData types.IBCData) ([][]byte, error) {
+	if attestedData.CommitmentsDelta == nil {
+		if err := validateUniquePacketCommitments(attestedData.PacketCommitments); err != nil {
+			return nil, err
+		}
+
+		if len(attestedData.CommitmentSetHash) != 0 && !bytes.Equal(attestedData.CommitmentSetHash, types.CommitmentSetHash(attestedData.PacketCommitments)) {
+			return nil, fmt.Errorf("packet commitments do not match the commitment set hash")
+		}
+
+		return attestedData.PacketCommitments, nil
+	}
+
+	if len(attestedData.PacketCommitments) != 0 {
+		return nil, fmt.Errorf("packet commitments and commitments delta cannot both be set")
+	}
+	if len(attestedData.CommitmentSetHash) == 0 {
+		return nil, fmt.Errorf("commitment set hash is required with a commitments delta")
+	}
+
+	baseHeight := attestedData.CommitmentsDelta.BaseHeight
+	if !clientStore.Has(host.ConsensusStateKey(baseHeight)) {
+		return nil, fmt.Errorf("no consensus state stored for commitments delta base height %s", baseHeight)
+	}
+	basePacketCommitments, found := getHistoricalCommitments(clientStore, baseHeight)
+	if !found {
+		return nil, fmt.Errorf("no packet commitments stored for commitments delta base height %s (latest client height %s)", baseHeight, cs.LatestHeight)
+	}
+
+	packetCommitments, err := attestedData.CommitmentsDelta.Apply(basePacketCommitments)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(attestedData.CommitmentSetHash, types.CommitmentSetHash(packetCommitments)) {
+		return nil, fmt.Errorf("commitments delta from base height %s does not result in the commitment set hash", attestedData.CommitmentsDelta.BaseHeight)
+	}
+
+	return packetCommitments, nil
+}
+
+// validateUniquePacketCommitments checks that the packet commitments are unique
+func validateUniquePacketCommitments(packetCommitments [][]byte) error {
+	seenPacketCommitments := make(map[string]bool)
+	for _, packetCommitement := range packetCommitments {
+		if seenPacketCommitments[string(packetCommitement)] {
+			return fmt.Errorf("duplicate packet commitment %s", string(packetCommitement))
+		}
+		seenPacketCommitments[string(packetCommitement)] = true
+	}
+
+	return nil
+}
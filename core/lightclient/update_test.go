@@ -7,6 +7,7 @@ import (
 	"github.com/cosmos/ibc-go/v9/modules/core/exported"
 	tmclienttypes "github.com/cosmos/ibc-go/v9/modules/light-clients/07-tendermint"
 
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
 	"github.com/cosmos/interchain-attestation/core/lightclient"
 	"github.com/cosmos/interchain-attestation/core/types"
 )
@@ -20,63 +21,63 @@ func (s *AttestationLightClientTestSuite) TestVerifyClientMessage() {
 		name                      string
 		numberOfAttestator        int
 		numberOfPacketCommitments int
-		malleate                  func(*types.Attestation)
+		malleate                  func()
 		expError                  string
 	}{
 		{
 			"valid attestations",
 			10,
 			5,
-			func(_ *types.Attestation) {},
+			func() {},
 			"",
 		},
 		{
 			"valid attestations: single attestator",
 			1,
 			5,
-			func(_ *types.Attestation) {},
+			func() {},
 			"",
 		},
 		{
 			"valid attestations: single packet commitment",
 			10,
 			1,
-			func(_ *types.Attestation) {},
+			func() {},
 			"",
 		},
 		{
 			"valid attestations: zero commitments",
 			10,
 			0,
-			func(_ *types.Attestation) {},
+			func() {},
 			"",
 		},
 		{
 			"valid attestations: many attestators",
 			100,
 			5,
-			func(_ *types.Attestation) {},
+			func() {},
 			"",
 		},
 		{
 			"valid attestations: many packet commitments",
 			10,
 			100,
-			func(_ *types.Attestation) {},
+			func() {},
 			"",
 		},
 		{
 			"valid attestations: many attestators and packet commitments",
 			100,
 			100,
-			func(_ *types.Attestation) {},
+			func() {},
 			"",
 		},
 		{
 			"invalid client message: type",
 			10,
 			5,
-			func(_ *types.Attestation) {
+			func() {
 				clientMsg = &tmclienttypes.Header{}
 			},
 			"invalid client message type",
@@ -85,76 +86,134 @@ func (s *AttestationLightClientTestSuite) TestVerifyClientMessage() {
 			"invalid client message: zero attestations",
 			10,
 			5,
-			func(_ *types.Attestation) {
-				clientMsg = &lightclient.AttestationClaim{}
+			func() {
+				clientMsg = &lightclient.AttestationClaim{Payload: clientMsg.(*lightclient.AttestationClaim).Payload}
 			},
 			"empty attestations",
 		},
 		{
-			"invalid client message: different heights",
+			"invalid client message: empty payload",
 			10,
 			5,
-			func(attestation *types.Attestation) {
-				attestation.AttestedData.Height = clienttypes.Height{
-					RevisionNumber: 1,
-					RevisionHeight: 100000,
-				}
+			func() {
+				clientMsg.(*lightclient.AttestationClaim).Payload = types.AttestationPayload{}
+			},
+			"empty payload",
+		},
+		{
+			"valid attestations: block data payload",
+			10,
+			5,
+			func() {
+				clientMsg = generateBlockDataClientMsg(attestators, 5)
+			},
+			"",
+		},
+		{
+			"invalid client message: block data without block hash",
+			10,
+			5,
+			func() {
+				clientMsg = generateBlockDataClientMsg(attestators, 5, func(blockData *types.BlockData) {
+					blockData.BlockHash = nil
+				})
+			},
+			"block hash cannot be empty",
+		},
+		{
+			"invalid client message: block data with duplicate packet commitment",
+			10,
+			5,
+			func() {
+				clientMsg = generateBlockDataClientMsg(attestators, 5, func(blockData *types.BlockData) {
+					blockData.PacketCommitments[1] = blockData.PacketCommitments[0]
+				})
+			},
+			"duplicate packet commitment",
+		},
+		{
+			"invalid client message: block data changed after signing",
+			10,
+			5,
+			func() {
+				clientMsg = generateBlockDataClientMsg(attestators, 5)
+				clientMsg.(*lightclient.AttestationClaim).Payload.GetBlockDataV1().BlockHash = []byte("other block hash")
 			},
-			"attestations must all be the same",
+			"failed to verify signatures",
 		},
 		{
-			"invalid client message: different timestamps",
+			"invalid client message: attested data changed after signing",
 			10,
 			5,
-			func(attestation *types.Attestation) {
-				attestation.AttestedData.Timestamp = attestation.AttestedData.Timestamp.Add(10)
+			func() {
+				clientMsg.(*lightclient.AttestationClaim).Payload.GetIbcDataV1().Height = clienttypes.NewHeight(1, 100000)
 			},
-			"attestations must all be the same",
+			"failed to verify signatures",
 		},
 		{
-			"invalid client message: different packet commitments",
+			"invalid client message: packet commitments changed after signing",
 			10,
 			5,
-			func(attestation *types.Attestation) {
-				attestation.AttestedData.PacketCommitments[0] = []byte{0x01}
+			func() {
+				clientMsg.(*lightclient.AttestationClaim).Payload.GetIbcDataV1().PacketCommitments[0] = []byte{0x01}
 			},
-			"attestations must all be the same",
+			"failed to verify signatures",
 		},
 		{
-			"invalid client message: different amount of packet commitments",
+			"invalid client message: signature from another attestator",
 			10,
 			5,
-			func(attestation *types.Attestation) {
-				attestation.AttestedData.PacketCommitments = append(attestation.AttestedData.PacketCommitments, []byte{0x01})
+			func() {
+				signatures := clientMsg.(*lightclient.AttestationClaim).Signatures
+				signatures[0], signatures[1] = signatures[1], signatures[0]
 			},
-			"attestations must all be the same",
+			"failed to verify signatures",
 		},
 		{
-			"invalid client message: different chain id",
+			"invalid client message: missing signatures",
 			10,
 			5,
-			func(attestation *types.Attestation) {
-				attestation.AttestedData.ChainId = "different chain id"
+			func() {
+				clientMsg.(*lightclient.AttestationClaim).Signatures = nil
 			},
-			"attestations must all be the same",
+			"failed to verify signatures",
 		},
 		{
-			"invalid client message: different client id",
+			"invalid client message: number of signatures does not match attestators",
 			10,
 			5,
-			func(attestation *types.Attestation) {
-				attestation.AttestedData.ClientId = "different client id"
+			func() {
+				clientMsg.(*lightclient.AttestationClaim).Signatures = clientMsg.(*lightclient.AttestationClaim).Signatures[:1]
 			},
-			"attestations must all be the same",
+			"expected 10 signatures, got 1",
+		},
+		{
+			"invalid client message: signatures and aggregate signature",
+			10,
+			5,
+			func() {
+				clientMsg.(*lightclient.AttestationClaim).AggregateSignature = []byte("aggregate")
+			},
+			"signatures and aggregate signature cannot both be set",
+		},
+		{
+			"invalid client message: aggregate signature not supported for secp256k1 keys",
+			10,
+			5,
+			func() {
+				clientMsg.(*lightclient.AttestationClaim).Signatures = nil
+				clientMsg.(*lightclient.AttestationClaim).AggregateSignature = []byte("aggregate")
+			},
+			"aggregate signatures not supported for",
 		},
 		{
 			"invalid client message: duplicate packet commitment",
 			10,
 			5,
-			func(_ *types.Attestation) {
-				for _, attestation := range clientMsg.(*lightclient.AttestationClaim).Attestations {
-					attestation.AttestedData.PacketCommitments[1] = attestation.AttestedData.PacketCommitments[0]
-				}
+			func() {
+				clientMsg = generateClientMsg(attestators, 5, func(attestedData *types.IBCData) {
+					attestedData.PacketCommitments[1] = attestedData.PacketCommitments[0]
+				})
 			},
 			"duplicate packet commitment",
 		},
@@ -162,8 +221,10 @@ func (s *AttestationLightClientTestSuite) TestVerifyClientMessage() {
 			"invalid client message: duplicate attestator",
 			10,
 			5,
-			func(attestation *types.Attestation) {
-				clientMsg.(*lightclient.AttestationClaim).Attestations = append(clientMsg.(*lightclient.AttestationClaim).Attestations, *attestation)
+			func() {
+				attestationClaim := clientMsg.(*lightclient.AttestationClaim)
+				attestationClaim.AttestatorIds = append(attestationClaim.AttestatorIds, attestationClaim.AttestatorIds[0])
+				attestationClaim.Signatures = append(attestationClaim.Signatures, attestationClaim.Signatures[0])
 			},
 			"duplicate attestation from",
 		},
@@ -171,8 +232,8 @@ func (s *AttestationLightClientTestSuite) TestVerifyClientMessage() {
 			"insufficient number of attestators in claim",
 			10,
 			5,
-			func(attestation *types.Attestation) {
-				attestatorsHandler.sufficientAttestations = func() (bool, error) {
+			func() {
+				attestatorsHandler.sufficientAttestations = func(_ [][]byte) (bool, error) {
 					return false, nil
 				}
 			},
@@ -182,8 +243,8 @@ func (s *AttestationLightClientTestSuite) TestVerifyClientMessage() {
 			"sufficient attestators handler error",
 			10,
 			5,
-			func(attestation *types.Attestation) {
-				attestatorsHandler.sufficientAttestations = func() (bool, error) {
+			func() {
+				attestatorsHandler.sufficientAttestations = func(_ [][]byte) (bool, error) {
 					return false, fmt.Errorf("handler error")
 				}
 			},
@@ -195,19 +256,149 @@ func (s *AttestationLightClientTestSuite) TestVerifyClientMessage() {
 		s.Run(tt.name, func() {
 			attestators = generateAttestators(tt.numberOfAttestator)
 			attestatorsHandler = NewMockAttestatorsHandler(attestators)
+			clientMsg = generateClientMsg(attestators, tt.numberOfPacketCommitments)
 
-			for i := 0; i < tt.numberOfAttestator; i++ {
-				clientMsg = generateClientMsg(s.encCfg.Codec, attestators, tt.numberOfPacketCommitments)
-				tt.malleate(&clientMsg.(*lightclient.AttestationClaim).Attestations[i])
+			tt.malleate()
 
-				err := initialClientState.VerifyClientMessage(s.ctx, s.encCfg.Codec, attestatorsHandler, clientMsg)
-				if tt.expError != "" {
-					s.Require().Error(err)
-					s.Require().Contains(err.Error(), tt.expError)
-				} else {
-					s.Require().NoError(err)
-				}
+			err := initialClientState.VerifyClientMessage(s.ctx, s.encCfg.Codec, s.storeProvider.ClientStore(s.ctx, mockClientID), attestatorsHandler, clientMsg)
+			if tt.expError != "" {
+				s.Require().Error(err)
+				s.Require().Contains(err.Error(), tt.expError)
+			} else {
+				s.Require().NoError(err)
 			}
 		})
 	}
 }
+
+func (s *AttestationLightClientTestSuite) TestVerifyClientMessageWithAggregateSignature() {
+	attestators := generateBLSAttestators(10)
+	attestatorsHandler := NewMockAttestatorsHandler(attestators)
+	clientStore := s.storeProvider.ClientStore(s.ctx, mockClientID)
+
+	// only some of the attestators attest
+	participating := []mockAttestator{attestators[0], attestators[3], attestators[4], attestators[9]}
+	aggregatedClaim := func() *lightclient.AttestationClaim {
+		clientMsg := generateClientMsg(participating, 5)
+		s.Require().NoError(clientMsg.AggregateSignatures(attestatorsHandler.attestatorSet))
+		return clientMsg
+	}
+
+	clientMsg := aggregatedClaim()
+	s.Require().Empty(clientMsg.AttestatorIds)
+	s.Require().Empty(clientMsg.Signatures)
+	s.Require().Len(clientMsg.AggregateSignature, bls12381.SignatureSize)
+	s.Require().Equal([]byte{0b00011001, 0b00000010}, clientMsg.ParticipationBitmap)
+
+	participants, err := lightclient.ParticipatingAttestators(attestatorsHandler.attestatorSet, clientMsg.ParticipationBitmap)
+	s.Require().NoError(err)
+	s.Require().Equal([][]byte{attestators[0].id, attestators[3].id, attestators[4].id, attestators[9].id}, participants)
+
+	var sufficientAttestatorIDs [][]byte
+	attestatorsHandler.sufficientAttestations = func(attestatorIDs [][]byte) (bool, error) {
+		sufficientAttestatorIDs = attestatorIDs
+		return true, nil
+	}
+	s.Require().NoError(initialClientState.VerifyClientMessage(s.ctx, s.encCfg.Codec, clientStore, attestatorsHandler, clientMsg))
+	s.Require().Equal(participants, sufficientAttestatorIDs)
+
+	tests := []struct {
+		name     string
+		malleate func(clientMsg *lightclient.AttestationClaim)
+		expError string
+	}{
+		{
+			"bitmap does not match the signers",
+			func(clientMsg *lightclient.AttestationClaim) {
+				clientMsg.ParticipationBitmap[0] |= 0b00000010
+			},
+			"invalid aggregate signature",
+		},
+		{
+			"bitmap with a bit outside the attestator set",
+			func(clientMsg *lightclient.AttestationClaim) {
+				clientMsg.ParticipationBitmap[1] |= 0b10000000
+			},
+			"only 10 attestators",
+		},
+		{
+			"bitmap with the wrong length",
+			func(clientMsg *lightclient.AttestationClaim) {
+				clientMsg.ParticipationBitmap = append(clientMsg.ParticipationBitmap, 0)
+			},
+			"participation bitmap must be 2 bytes",
+		},
+		{
+			"empty bitmap",
+			func(clientMsg *lightclient.AttestationClaim) {
+				clientMsg.ParticipationBitmap = []byte{0, 0}
+			},
+			"empty attestations",
+		},
+		{
+			"bitmap without aggregate signature",
+			func(clientMsg *lightclient.AttestationClaim) {
+				clientMsg.AggregateSignature = nil
+			},
+			"participation bitmap requires an aggregate signature",
+		},
+		{
+			"bitmap and attestator ids",
+			func(clientMsg *lightclient.AttestationClaim) {
+				clientMsg.AttestatorIds = [][]byte{attestators[0].id}
+			},
+			"attestator ids and participation bitmap cannot both be set",
+		},
+		{
+			"attested data changed after signing",
+			func(clientMsg *lightclient.AttestationClaim) {
+				clientMsg.Payload.GetIbcDataV1().Height = clienttypes.NewHeight(1, 100000)
+			},
+			"invalid aggregate signature",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			clientMsg := aggregatedClaim()
+			tt.malleate(clientMsg)
+
+			err := initialClientState.VerifyClientMessage(s.ctx, s.encCfg.Codec, clientStore, attestatorsHandler, clientMsg)
+			s.Require().Error(err)
+			s.Require().Contains(err.Error(), tt.expError)
+		})
+	}
+
+	// signatures that are not BLS12-381 can't be aggregated
+	secp256k1ClientMsg := generateClientMsg(s.mockAttestators, 5)
+	s.Require().Error(secp256k1ClientMsg.AggregateSignatures(NewMockAttestatorsHandler(s.mockAttestators).attestatorSet))
+	s.Require().NotEmpty(secp256k1ClientMsg.Signatures)
+
+	// attestators outside the attestator set can't be in the bitmap
+	clientMsg = generateClientMsg(participating, 5)
+	s.Require().ErrorContains(clientMsg.AggregateSignatures(attestatorsHandler.attestatorSet[1:]), "is not in the attestator set")
+}
+
+func (s *AttestationLightClientTestSuite) TestNewAttestationClaim() {
+	attestations := generateAttestations(s.mockAttestators, 5)
+
+	clientMsg, err := lightclient.NewAttestationClaim(mockHostChainID, attestations)
+	s.Require().NoError(err)
+	s.Require().Equal(attestations[0].Payload, clientMsg.Payload)
+	s.Require().Len(clientMsg.AttestatorIds, len(attestations))
+	s.Require().Len(clientMsg.Signatures, len(attestations))
+	for i, attestation := range attestations {
+		s.Require().Equal(attestation.AttestatorId, clientMsg.AttestatorIds[i])
+		s.Require().Equal(attestation.Signature, clientMsg.Signatures[i])
+	}
+
+	// the compact claim only includes the attested data once
+	s.Require().Less(clientMsg.Size(), len(attestations)*attestations[0].Size())
+
+	_, err = lightclient.NewAttestationClaim(mockHostChainID, nil)
+	s.Require().ErrorContains(err, "empty attestations")
+
+	attestations[1].Payload.GetIbcDataV1().Height = clienttypes.NewHeight(1, 100000)
+	_, err = lightclient.NewAttestationClaim(mockHostChainID, attestations)
+	s.Require().ErrorContains(err, "attestations must all be the same")
+}
@@ -2,12 +2,77 @@ package types
 
 import (
 	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"time"
+)
 
-	"github.com/cosmos/cosmos-sdk/codec"
+const (
+	// AttestationSignBytesDomain separates attestation signatures from any other use of the attestation keys
+	AttestationSignBytesDomain = "interchain-attestation/10-attestation"
+	// AttestationSignBytesVersion is the version of the sign bytes format, and is bumped on any change to it
+	AttestationSignBytesVersion uint64 = 2
 )
 
-func GetDeterministicAttestationBytes(cdc codec.BinaryCodec, dataToAttestTo IBCData) []byte {
-	packetBytes := cdc.MustMarshal(&dataToAttestTo)
-	hash := sha256.Sum256(packetBytes)
-	return hash[:]
+// GetAttestationSignBytes returns the canonical bytes that attestations are signed (and compared) by.
+// They bind the payload to the host chain that the attestation is submitted to, so that a signature
+// can't be replayed on another host chain, for another payload type or in another format. The sign bytes
+// are the sha256 hash of:
+//
+//	lp(AttestationSignBytesDomain)
+//	|| uint64(AttestationSignBytesVersion)
+//	|| lp(host chain id)
+//	|| lp(payload type)
+//	|| payload type specific fields
+//
+// The payload type specific fields for ibc_data_v1 are:
+//
+//	lp(client to update)
+//	|| lp(chain id)
+//	|| lp(client id)
+//	|| uint64(height revision number) || uint64(height revision height)
+//	|| int64(timestamp seconds) || int32(timestamp nanos)
+//	|| lp(commitment set hash)
+//
+// Since the packet commitments can either be given in full or as a delta, only the commitment set hash
+// (see CommitmentSetHash) is included. The payload type specific fields for block_data_v1 are:
+//
+//	lp(client to update)
+//	|| lp(chain id)
+//	|| uint64(block number)
+//	|| lp(block hash)
+//	|| int64(timestamp seconds) || int32(timestamp nanos)
+//	|| lp(storage root)
+//	|| lp(commitment set hash)
+//
+// All integers are big-endian, and lp(x) is uint64(len(x)) || x. An empty payload only has the (empty) payload type.
+func GetAttestationSignBytes(hostChainID string, payload AttestationPayload) []byte {
+	hasher := sha256.New()
+	writeLengthPrefixed(hasher, []byte(AttestationSignBytesDomain))
+	writeUint64(hasher, AttestationSignBytesVersion)
+	writeLengthPrefixed(hasher, []byte(hostChainID))
+
+	data := payload.Unpack()
+	if data == nil {
+		writeLengthPrefixed(hasher, nil)
+		return hasher.Sum(nil)
+	}
+	writeLengthPrefixed(hasher, []byte(data.payloadType()))
+	data.writeSignBytes(hasher)
+
+	return hasher.Sum(nil)
+}
+
+func writeLengthPrefixed(hasher hash.Hash, bz []byte) {
+	writeUint64(hasher, uint64(len(bz)))
+	hasher.Write(bz)
+}
+
+func writeUint64(hasher hash.Hash, n uint64) {
+	hasher.Write(binary.BigEndian.AppendUint64(nil, n))
+}
+
+func writeTimestamp(hasher hash.Hash, timestamp time.Time) {
+	writeUint64(hasher, uint64(timestamp.Unix()))
+	hasher.Write(binary.BigEndian.AppendUint32(nil, uint32(timestamp.Nanosecond())))
 }
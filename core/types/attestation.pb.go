@@ -29,8 +29,12 @@ var _ = time.Kitchen
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type Attestation struct {
-	AttestatorId []byte  `protobuf:"bytes,1,opt,name=attestator_id,json=attestatorId,proto3" json:"attestator_id,omitempty"`
-	AttestedData IBCData `protobuf:"bytes,2,opt,name=attested_data,json=attestedData,proto3" json:"attested_data"`
+	AttestatorId []byte `protobuf:"bytes,1,opt,name=attestator_id,json=attestatorId,proto3" json:"attestator_id,omitempty"`
+	// payload is the data the attestator attested to
+	Payload AttestationPayload `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload"`
+	// signature is the signature of the attestator over the sign bytes of
+	// payload (see GetAttestationSignBytes)
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
 func (m *Attestation) Reset()         { *m = Attestation{} }
@@ -73,27 +77,130 @@ func (m *Attestation) GetAttestatorId() []byte {
 	return nil
 }
 
-func (m *Attestation) GetAttestedData() IBCData {
+func (m *Attestation) GetPayload() AttestationPayload {
 	if m != nil {
-		return m.AttestedData
+		return m.Payload
+	}
+	return AttestationPayload{}
+}
+
+func (m *Attestation) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// AttestationPayload is the versioned, counterparty specific data that is
+// attested to. New counterparty shapes, and new versions of existing shapes,
+// are added as new payload types.
+type AttestationPayload struct {
+	// Types that are valid to be assigned to Data:
+	//	*AttestationPayload_IbcDataV1
+	//	*AttestationPayload_BlockDataV1
+	Data isAttestationPayload_Data `protobuf_oneof:"data"`
+}
+
+func (m *AttestationPayload) Reset()         { *m = AttestationPayload{} }
+func (m *AttestationPayload) String() string { return proto.CompactTextString(m) }
+func (*AttestationPayload) ProtoMessage()    {}
+func (*AttestationPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25eb7c0454d2e150, []int{1}
+}
+func (m *AttestationPayload) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AttestationPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AttestationPayload.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AttestationPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttestationPayload.Merge(m, src)
+}
+func (m *AttestationPayload) XXX_Size() int {
+	return m.Size()
+}
+func (m *AttestationPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttestationPayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttestationPayload proto.InternalMessageInfo
+
+type isAttestationPayload_Data interface {
+	isAttestationPayload_Data()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type AttestationPayload_IbcDataV1 struct {
+	IbcDataV1 *IBCData `protobuf:"bytes,1,opt,name=ibc_data_v1,json=ibcDataV1,proto3,oneof" json:"ibc_data_v1,omitempty"`
+}
+type AttestationPayload_BlockDataV1 struct {
+	BlockDataV1 *BlockData `protobuf:"bytes,2,opt,name=block_data_v1,json=blockDataV1,proto3,oneof" json:"block_data_v1,omitempty"`
+}
+
+func (*AttestationPayload_IbcDataV1) isAttestationPayload_Data()   {}
+func (*AttestationPayload_BlockDataV1) isAttestationPayload_Data() {}
+
+func (m *AttestationPayload) GetData() isAttestationPayload_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *AttestationPayload) GetIbcDataV1() *IBCData {
+	if x, ok := m.GetData().(*AttestationPayload_IbcDataV1); ok {
+		return x.IbcDataV1
+	}
+	return nil
+}
+
+func (m *AttestationPayload) GetBlockDataV1() *BlockData {
+	if x, ok := m.GetData().(*AttestationPayload_BlockDataV1); ok {
+		return x.BlockDataV1
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*AttestationPayload) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*AttestationPayload_IbcDataV1)(nil),
+		(*AttestationPayload_BlockDataV1)(nil),
 	}
-	return IBCData{}
 }
 
 type IBCData struct {
-	ChainId           string       `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	ClientId          string       `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	ClientToUpdate    string       `protobuf:"bytes,3,opt,name=client_to_update,json=clientToUpdate,proto3" json:"client_to_update,omitempty"`
-	Height            types.Height `protobuf:"bytes,4,opt,name=height,proto3" json:"height"`
-	Timestamp         time.Time    `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
-	PacketCommitments [][]byte     `protobuf:"bytes,6,rep,name=packet_commitments,json=packetCommitments,proto3" json:"packet_commitments,omitempty"`
+	ChainId        string       `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ClientId       string       `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientToUpdate string       `protobuf:"bytes,3,opt,name=client_to_update,json=clientToUpdate,proto3" json:"client_to_update,omitempty"`
+	Height         types.Height `protobuf:"bytes,4,opt,name=height,proto3" json:"height"`
+	Timestamp      time.Time    `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	// packet_commitments is the full set of packet commitments. It is left
+	// empty when the packet commitments are expressed as a commitments_delta.
+	PacketCommitments [][]byte `protobuf:"bytes,6,rep,name=packet_commitments,json=packetCommitments,proto3" json:"packet_commitments,omitempty"`
+	// commitments_delta expresses the packet commitments as changes relative to
+	// the packet commitments of the client at base_height.
+	CommitmentsDelta *CommitmentsDelta `protobuf:"bytes,7,opt,name=commitments_delta,json=commitmentsDelta,proto3" json:"commitments_delta,omitempty"`
+	// commitment_set_hash is the hash of the full set of packet commitments (see
+	// CommitmentSetHash). It is required when commitments_delta is used.
+	CommitmentSetHash []byte `protobuf:"bytes,8,opt,name=commitment_set_hash,json=commitmentSetHash,proto3" json:"commitment_set_hash,omitempty"`
 }
 
 func (m *IBCData) Reset()         { *m = IBCData{} }
 func (m *IBCData) String() string { return proto.CompactTextString(m) }
 func (*IBCData) ProtoMessage()    {}
 func (*IBCData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25eb7c0454d2e150, []int{1}
+	return fileDescriptor_25eb7c0454d2e150, []int{2}
 }
 func (m *IBCData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -164,41 +271,238 @@ func (m *IBCData) GetPacketCommitments() [][]byte {
 	return nil
 }
 
+func (m *IBCData) GetCommitmentsDelta() *CommitmentsDelta {
+	if m != nil {
+		return m.CommitmentsDelta
+	}
+	return nil
+}
+
+func (m *IBCData) GetCommitmentSetHash() []byte {
+	if m != nil {
+		return m.CommitmentSetHash
+	}
+	return nil
+}
+
+// CommitmentsDelta is the set of packet commitments that have been added and
+// removed since base_height
+type CommitmentsDelta struct {
+	BaseHeight types.Height `protobuf:"bytes,1,opt,name=base_height,json=baseHeight,proto3" json:"base_height"`
+	Added      [][]byte     `protobuf:"bytes,2,rep,name=added,proto3" json:"added,omitempty"`
+	Removed    [][]byte     `protobuf:"bytes,3,rep,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (m *CommitmentsDelta) Reset()         { *m = CommitmentsDelta{} }
+func (m *CommitmentsDelta) String() string { return proto.CompactTextString(m) }
+func (*CommitmentsDelta) ProtoMessage()    {}
+func (*CommitmentsDelta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25eb7c0454d2e150, []int{3}
+}
+func (m *CommitmentsDelta) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CommitmentsDelta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommitmentsDelta.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CommitmentsDelta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitmentsDelta.Merge(m, src)
+}
+func (m *CommitmentsDelta) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommitmentsDelta) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitmentsDelta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitmentsDelta proto.InternalMessageInfo
+
+func (m *CommitmentsDelta) GetBaseHeight() types.Height {
+	if m != nil {
+		return m.BaseHeight
+	}
+	return types.Height{}
+}
+
+func (m *CommitmentsDelta) GetAdded() [][]byte {
+	if m != nil {
+		return m.Added
+	}
+	return nil
+}
+
+func (m *CommitmentsDelta) GetRemoved() [][]byte {
+	if m != nil {
+		return m.Removed
+	}
+	return nil
+}
+
+// BlockData is the attested state of a counterparty at a block, for
+// counterparties that are not identified by ibc-go heights
+type BlockData struct {
+	ChainId        string `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ClientToUpdate string `protobuf:"bytes,2,opt,name=client_to_update,json=clientToUpdate,proto3" json:"client_to_update,omitempty"`
+	BlockNumber    uint64 `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// block_hash is the hash of the block, so that a reorg of the block can be
+	// detected
+	BlockHash []byte    `protobuf:"bytes,4,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Timestamp time.Time `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	// storage_root is the root of the storage that the IBC state of the
+	// counterparty is committed to at the block (e.g. the storage root of the
+	// IBC contract)
+	StorageRoot []byte `protobuf:"bytes,6,opt,name=storage_root,json=storageRoot,proto3" json:"storage_root,omitempty"`
+	// packet_commitments is the full set of packet commitments read from the
+	// IBC state at the block
+	PacketCommitments [][]byte `protobuf:"bytes,7,rep,name=packet_commitments,json=packetCommitments,proto3" json:"packet_commitments,omitempty"`
+}
+
+func (m *BlockData) Reset()         { *m = BlockData{} }
+func (m *BlockData) String() string { return proto.CompactTextString(m) }
+func (*BlockData) ProtoMessage()    {}
+func (*BlockData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25eb7c0454d2e150, []int{4}
+}
+func (m *BlockData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockData.Merge(m, src)
+}
+func (m *BlockData) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockData) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockData proto.InternalMessageInfo
+
+func (m *BlockData) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+func (m *BlockData) GetClientToUpdate() string {
+	if m != nil {
+		return m.ClientToUpdate
+	}
+	return ""
+}
+
+func (m *BlockData) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *BlockData) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *BlockData) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
+func (m *BlockData) GetStorageRoot() []byte {
+	if m != nil {
+		return m.StorageRoot
+	}
+	return nil
+}
+
+func (m *BlockData) GetPacketCommitments() [][]byte {
+	if m != nil {
+		return m.PacketCommitments
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Attestation)(nil), "core.types.v1.Attestation")
+	proto.RegisterType((*AttestationPayload)(nil), "core.types.v1.AttestationPayload")
 	proto.RegisterType((*IBCData)(nil), "core.types.v1.IBCData")
+	proto.RegisterType((*CommitmentsDelta)(nil), "core.types.v1.CommitmentsDelta")
+	proto.RegisterType((*BlockData)(nil), "core.types.v1.BlockData")
 }
 
 func init() { proto.RegisterFile("core/types/v1/attestation.proto", fileDescriptor_25eb7c0454d2e150) }
 
 var fileDescriptor_25eb7c0454d2e150 = []byte{
-	// 406 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x92, 0x31, 0x6f, 0xd4, 0x30,
-	0x14, 0xc7, 0x93, 0x6b, 0xb9, 0xde, 0xb9, 0x57, 0x04, 0x16, 0x42, 0xe1, 0x90, 0x92, 0x53, 0x59,
-	0xb2, 0xd4, 0xd6, 0xd1, 0x85, 0xb5, 0x29, 0x03, 0x19, 0x58, 0xa2, 0xb2, 0xb0, 0x44, 0x8e, 0x6d,
-	0x12, 0x8b, 0x26, 0x8e, 0x92, 0x97, 0x93, 0xf8, 0x16, 0x1d, 0xf9, 0x48, 0x1d, 0x6f, 0x64, 0x02,
-	0x74, 0xf7, 0x45, 0x50, 0xec, 0x84, 0xc0, 0x66, 0xbf, 0xff, 0xef, 0xbd, 0xf7, 0xf7, 0x5f, 0x46,
-	0x01, 0xd7, 0x8d, 0xa4, 0xf0, 0xad, 0x96, 0x2d, 0xdd, 0x6d, 0x29, 0x03, 0x90, 0x2d, 0x30, 0x50,
-	0xba, 0x22, 0x75, 0xa3, 0x41, 0xe3, 0x8b, 0x1e, 0x20, 0x06, 0x20, 0xbb, 0xed, 0xfa, 0x45, 0xae,
-	0x73, 0x6d, 0x14, 0xda, 0x9f, 0x2c, 0xb4, 0x0e, 0x54, 0xc6, 0xa9, 0x99, 0xc4, 0xef, 0x95, 0xac,
-	0xa0, 0x1f, 0x65, 0x4f, 0x23, 0x90, 0x6b, 0x9d, 0xdf, 0x4b, 0x6a, 0x6e, 0x59, 0xf7, 0x85, 0x82,
-	0x2a, 0xfb, 0x45, 0x65, 0x6d, 0x81, 0xcb, 0x0e, 0x9d, 0xdf, 0x4c, 0xbb, 0xf1, 0x1b, 0x74, 0x31,
-	0x5a, 0xd1, 0x4d, 0xaa, 0x84, 0xe7, 0x6e, 0xdc, 0x70, 0x95, 0xac, 0xa6, 0x62, 0x2c, 0xf0, 0xcd,
-	0x08, 0x49, 0x91, 0x0a, 0x06, 0xcc, 0x9b, 0x6d, 0xdc, 0xf0, 0xfc, 0xed, 0x4b, 0xf2, 0x9f, 0x65,
-	0x12, 0x47, 0xb7, 0xef, 0x19, 0xb0, 0xe8, 0xf4, 0xf1, 0x67, 0xe0, 0x8c, 0x23, 0xa4, 0xe8, 0x6b,
-	0x97, 0xdf, 0x67, 0xe8, 0x6c, 0xd0, 0xf1, 0x2b, 0xb4, 0xe0, 0x05, 0x53, 0xd5, 0xb8, 0x6e, 0x99,
-	0x9c, 0x99, 0x7b, 0x2c, 0xf0, 0x6b, 0xb4, 0xb4, 0xcf, 0xe9, 0xb5, 0x99, 0xd1, 0x16, 0xb6, 0x10,
-	0x0b, 0x1c, 0xa2, 0x67, 0x83, 0x08, 0x3a, 0xed, 0x6a, 0xc1, 0x40, 0x7a, 0x27, 0x86, 0x79, 0x6a,
-	0xeb, 0x77, 0xfa, 0x93, 0xa9, 0xe2, 0x77, 0x68, 0x5e, 0x48, 0x95, 0x17, 0xe0, 0x9d, 0x1a, 0xa7,
-	0x6b, 0xa2, 0x32, 0x6e, 0xdd, 0x0e, 0x69, 0xed, 0xb6, 0xe4, 0x83, 0x21, 0x06, 0xb7, 0x03, 0x8f,
-	0x23, 0xb4, 0xfc, 0x9b, 0x98, 0xf7, 0x64, 0x68, 0xb6, 0x99, 0x92, 0x31, 0x53, 0x72, 0x37, 0x12,
-	0xd1, 0xa2, 0x6f, 0x7e, 0xf8, 0x15, 0xb8, 0xc9, 0xd4, 0x86, 0xaf, 0x10, 0xae, 0x19, 0xff, 0x2a,
-	0x21, 0xe5, 0xba, 0x2c, 0x15, 0x94, 0xb2, 0x82, 0xd6, 0x9b, 0x6f, 0x4e, 0xc2, 0x55, 0xf2, 0xdc,
-	0x2a, 0xb7, 0x93, 0x10, 0x7d, 0x7c, 0x3c, 0xf8, 0xee, 0xfe, 0xe0, 0xbb, 0xbf, 0x0f, 0xbe, 0xfb,
-	0x70, 0xf4, 0x9d, 0xfd, 0xd1, 0x77, 0x7e, 0x1c, 0x7d, 0xe7, 0xf3, 0x75, 0xae, 0xa0, 0xe8, 0x32,
-	0xc2, 0x75, 0x49, 0xb9, 0x6e, 0x4b, 0xdd, 0x52, 0x55, 0x81, 0x6c, 0x4c, 0x5a, 0x57, 0xff, 0x7c,
-	0x21, 0x3a, 0x7d, 0xae, 0x6c, 0x6e, 0x6c, 0x5e, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0xa6, 0x15,
-	0xa5, 0x24, 0x71, 0x02, 0x00, 0x00,
+	// 673 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xc1, 0x4e, 0xdb, 0x4a,
+	0x14, 0x8d, 0x93, 0x90, 0xc4, 0xd7, 0xe1, 0x29, 0xcc, 0x43, 0x4f, 0x7e, 0xbc, 0xd7, 0x04, 0xd2,
+	0x4d, 0x36, 0xd8, 0x0a, 0x6c, 0x58, 0x55, 0xc2, 0xb0, 0x20, 0x55, 0x5b, 0x55, 0x2e, 0x65, 0xd1,
+	0x8d, 0x35, 0xb6, 0xa7, 0xf6, 0x88, 0xd8, 0x13, 0xd9, 0x93, 0x48, 0x7c, 0x40, 0xf7, 0xac, 0x90,
+	0xfa, 0x07, 0xfd, 0x14, 0x96, 0x2c, 0xbb, 0x6a, 0x2b, 0xf8, 0x91, 0x6a, 0x66, 0x6c, 0x9c, 0xa6,
+	0x50, 0x75, 0xd1, 0xdd, 0xcc, 0xb9, 0xe7, 0xdc, 0x7b, 0x73, 0xe6, 0xc4, 0x30, 0x08, 0x58, 0x46,
+	0x6c, 0x7e, 0x31, 0x23, 0xb9, 0xbd, 0x18, 0xdb, 0x98, 0x73, 0x92, 0x73, 0xcc, 0x29, 0x4b, 0xad,
+	0x59, 0xc6, 0x38, 0x43, 0xeb, 0x82, 0x60, 0x49, 0x82, 0xb5, 0x18, 0x6f, 0x6d, 0x46, 0x2c, 0x62,
+	0xb2, 0x62, 0x8b, 0x93, 0x22, 0x6d, 0x0d, 0xa8, 0x1f, 0xd8, 0xb2, 0x53, 0x30, 0xa5, 0x24, 0xe5,
+	0xa2, 0x95, 0x3a, 0x95, 0x84, 0x88, 0xb1, 0x68, 0x4a, 0x6c, 0x79, 0xf3, 0xe7, 0xef, 0x6d, 0x4e,
+	0x13, 0x31, 0x28, 0x99, 0x29, 0xc2, 0xf0, 0xa3, 0x06, 0xc6, 0x61, 0x35, 0x1c, 0x3d, 0x85, 0xf5,
+	0x72, 0x17, 0x96, 0x79, 0x34, 0x34, 0xb5, 0x6d, 0x6d, 0xd4, 0x75, 0xbb, 0x15, 0x38, 0x09, 0xd1,
+	0x21, 0xb4, 0x67, 0xf8, 0x62, 0xca, 0x70, 0x68, 0x36, 0xb7, 0xb5, 0x91, 0xb1, 0xb7, 0x63, 0xfd,
+	0xb0, 0xad, 0xb5, 0xd4, 0xf1, 0xb5, 0x22, 0x3a, 0xcd, 0xeb, 0x2f, 0x83, 0x9a, 0x5b, 0xea, 0xd0,
+	0xff, 0xa0, 0xe7, 0x34, 0x4a, 0x31, 0x9f, 0x67, 0xc4, 0x6c, 0xc8, 0x19, 0x15, 0xf0, 0xbc, 0xd9,
+	0xa9, 0xf7, 0x1a, 0xc3, 0x2b, 0x0d, 0xd0, 0xcf, 0x9d, 0xd0, 0x01, 0x18, 0xd4, 0x0f, 0xbc, 0x10,
+	0x73, 0xec, 0x2d, 0xc6, 0x72, 0x41, 0x63, 0xef, 0x9f, 0x95, 0x0d, 0x26, 0xce, 0xd1, 0x31, 0xe6,
+	0xf8, 0xa4, 0xe6, 0xea, 0xd4, 0x0f, 0xc4, 0xf1, 0x6c, 0x8c, 0x9e, 0xc1, 0xba, 0x3f, 0x65, 0xc1,
+	0xf9, 0xbd, 0xb6, 0x2e, 0xb5, 0xe6, 0x8a, 0xd6, 0x11, 0x9c, 0x42, 0x6d, 0xf8, 0xe5, 0xe5, 0x6c,
+	0xec, 0xb4, 0xa0, 0x29, 0x94, 0xc3, 0xab, 0x06, 0xb4, 0x8b, 0x01, 0xe8, 0x5f, 0xe8, 0x04, 0x31,
+	0xa6, 0x69, 0xe9, 0x95, 0xee, 0xb6, 0xe5, 0x7d, 0x12, 0xa2, 0xff, 0x40, 0x57, 0x8f, 0x21, 0x6a,
+	0x75, 0x59, 0xeb, 0x28, 0x60, 0x12, 0xa2, 0x11, 0xf4, 0x8a, 0x22, 0x67, 0xde, 0x7c, 0x16, 0x62,
+	0xae, 0x7c, 0xd0, 0xdd, 0xbf, 0x14, 0x7e, 0xca, 0xde, 0x4a, 0x14, 0x1d, 0x40, 0x2b, 0x26, 0x34,
+	0x8a, 0x79, 0x61, 0xf6, 0x96, 0x45, 0xfd, 0x40, 0xad, 0x5c, 0xbc, 0xf5, 0x62, 0x6c, 0x9d, 0x48,
+	0x46, 0xe1, 0x72, 0xc1, 0x47, 0x0e, 0xe8, 0xf7, 0xef, 0x6d, 0xae, 0x15, 0x62, 0x95, 0x08, 0xab,
+	0x4c, 0x84, 0x75, 0x5a, 0x32, 0x9c, 0x8e, 0x10, 0x5f, 0x7e, 0x1d, 0x68, 0x6e, 0x25, 0x43, 0xbb,
+	0x80, 0x66, 0x38, 0x38, 0x27, 0xdc, 0x0b, 0x58, 0x92, 0x50, 0x9e, 0x90, 0x94, 0xe7, 0x66, 0x6b,
+	0xbb, 0x31, 0xea, 0xba, 0x1b, 0xaa, 0x72, 0x54, 0x15, 0xd0, 0x0b, 0xd8, 0x58, 0xe2, 0x79, 0x21,
+	0x99, 0x72, 0x6c, 0xb6, 0xe5, 0xe8, 0xc1, 0x8a, 0xcd, 0x4b, 0xb2, 0x63, 0x41, 0x73, 0x7b, 0xc1,
+	0x0a, 0x82, 0x2c, 0xf8, 0xbb, 0xc2, 0xbc, 0x9c, 0x70, 0x2f, 0xc6, 0x79, 0x6c, 0x76, 0x64, 0x5e,
+	0x96, 0x06, 0xbd, 0x21, 0xfc, 0x04, 0xe7, 0xf1, 0xf0, 0x83, 0x06, 0xbd, 0xd5, 0xb6, 0xe8, 0x10,
+	0x0c, 0x1f, 0xe7, 0xc4, 0x2b, 0x4c, 0xd4, 0x7e, 0xd3, 0x44, 0x10, 0x22, 0x85, 0xa0, 0x4d, 0x58,
+	0xc3, 0x61, 0x48, 0xc4, 0x2b, 0x8a, 0xdf, 0xad, 0x2e, 0xc8, 0x84, 0x76, 0x46, 0x12, 0xb6, 0x20,
+	0xa1, 0xd9, 0x90, 0x78, 0x79, 0x1d, 0x7e, 0xaa, 0x83, 0x7e, 0x9f, 0xa2, 0x5f, 0x45, 0xe4, 0xa1,
+	0x14, 0xd4, 0x1f, 0x4c, 0xc1, 0x0e, 0x74, 0x55, 0x76, 0xd3, 0x79, 0xe2, 0x93, 0x4c, 0x66, 0xa5,
+	0x59, 0xc4, 0xf3, 0x95, 0x84, 0xd0, 0x13, 0x00, 0x45, 0x91, 0x26, 0x35, 0xd5, 0x9f, 0x4a, 0x22,
+	0xc2, 0x9c, 0x3f, 0x92, 0x86, 0x1d, 0xe8, 0xe6, 0x9c, 0x65, 0x38, 0x22, 0x5e, 0xc6, 0x18, 0x37,
+	0x5b, 0x72, 0x88, 0x51, 0x60, 0x2e, 0x63, 0xfc, 0x91, 0xc0, 0xb4, 0x1f, 0x09, 0x8c, 0xf3, 0xf2,
+	0xfa, 0xb6, 0xaf, 0xdd, 0xdc, 0xf6, 0xb5, 0x6f, 0xb7, 0x7d, 0xed, 0xf2, 0xae, 0x5f, 0xbb, 0xb9,
+	0xeb, 0xd7, 0x3e, 0xdf, 0xf5, 0x6b, 0xef, 0xf6, 0x23, 0xca, 0xe3, 0xb9, 0x6f, 0x05, 0x2c, 0xb1,
+	0x03, 0x96, 0x27, 0x2c, 0xb7, 0x69, 0xca, 0x49, 0x26, 0xbd, 0xdb, 0x5d, 0xfa, 0x62, 0xda, 0xd5,
+	0xb7, 0xd4, 0x6f, 0xc9, 0x5f, 0xb2, 0xff, 0x7d, 0x00, 0xe0, 0xb6, 0x52, 0x4d, 0x60, 0x05, 0x00,
+	0x00,
 }
 
 func (m *Attestation) Marshal() (dAtA []byte, err error) {
@@ -222,7 +526,7 @@ func (m *Attestation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	{
-		size, err := m.AttestedData.MarshalToSizedBuffer(dAtA[:i])
+		size, err := m.Payload.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
 			return 0, err
 		}
@@ -230,7 +534,14 @@ func (m *Attestation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i = encodeVarintAttestation(dAtA, i, uint64(size))
 	}
 	i--
-	dAtA[i] = 0x12
+	dAtA[i] = 0x22
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintAttestation(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.AttestatorId) > 0 {
 		i -= len(m.AttestatorId)
 		copy(dAtA[i:], m.AttestatorId)
@@ -241,6 +552,80 @@ func (m *Attestation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *AttestationPayload) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AttestationPayload) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AttestationPayload) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Data != nil {
+		{
+			size := m.Data.Size()
+			i -= size
+			if _, err := m.Data.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AttestationPayload_IbcDataV1) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AttestationPayload_IbcDataV1) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.IbcDataV1 != nil {
+		{
+			size, err := m.IbcDataV1.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAttestation(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AttestationPayload_BlockDataV1) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AttestationPayload_BlockDataV1) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BlockDataV1 != nil {
+		{
+			size, err := m.BlockDataV1.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAttestation(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
 func (m *IBCData) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -261,6 +646,25 @@ func (m *IBCData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.CommitmentSetHash) > 0 {
+		i -= len(m.CommitmentSetHash)
+		copy(dAtA[i:], m.CommitmentSetHash)
+		i = encodeVarintAttestation(dAtA, i, uint64(len(m.CommitmentSetHash)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.CommitmentsDelta != nil {
+		{
+			size, err := m.CommitmentsDelta.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAttestation(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
 	if len(m.PacketCommitments) > 0 {
 		for iNdEx := len(m.PacketCommitments) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.PacketCommitments[iNdEx])
@@ -270,12 +674,12 @@ func (m *IBCData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			dAtA[i] = 0x32
 		}
 	}
-	n2, err2 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
-	if err2 != nil {
-		return 0, err2
+	n5, err5 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err5 != nil {
+		return 0, err5
 	}
-	i -= n2
-	i = encodeVarintAttestation(dAtA, i, uint64(n2))
+	i -= n5
+	i = encodeVarintAttestation(dAtA, i, uint64(n5))
 	i--
 	dAtA[i] = 0x2a
 	{
@@ -283,99 +687,915 @@ func (m *IBCData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		if err != nil {
 			return 0, err
 		}
-		i -= size
-		i = encodeVarintAttestation(dAtA, i, uint64(size))
-	}
-	i--
-	dAtA[i] = 0x22
-	if len(m.ClientToUpdate) > 0 {
-		i -= len(m.ClientToUpdate)
-		copy(dAtA[i:], m.ClientToUpdate)
-		i = encodeVarintAttestation(dAtA, i, uint64(len(m.ClientToUpdate)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.ClientId) > 0 {
-		i -= len(m.ClientId)
-		copy(dAtA[i:], m.ClientId)
-		i = encodeVarintAttestation(dAtA, i, uint64(len(m.ClientId)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ChainId) > 0 {
-		i -= len(m.ChainId)
-		copy(dAtA[i:], m.ChainId)
-		i = encodeVarintAttestation(dAtA, i, uint64(len(m.ChainId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
-}
-
-func encodeVarintAttestation(dAtA []byte, offset int, v uint64) int {
-	offset -= sovAttestation(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
-}
-func (m *Attestation) Size() (n int) {
-	if m == nil {
-		return 0
+		i -= size
+		i = encodeVarintAttestation(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	if len(m.ClientToUpdate) > 0 {
+		i -= len(m.ClientToUpdate)
+		copy(dAtA[i:], m.ClientToUpdate)
+		i = encodeVarintAttestation(dAtA, i, uint64(len(m.ClientToUpdate)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.ClientId) > 0 {
+		i -= len(m.ClientId)
+		copy(dAtA[i:], m.ClientId)
+		i = encodeVarintAttestation(dAtA, i, uint64(len(m.ClientId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintAttestation(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CommitmentsDelta) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitmentsDelta) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CommitmentsDelta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Removed) > 0 {
+		for iNdEx := len(m.Removed) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Removed[iNdEx])
+			copy(dAtA[i:], m.Removed[iNdEx])
+			i = encodeVarintAttestation(dAtA, i, uint64(len(m.Removed[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Added) > 0 {
+		for iNdEx := len(m.Added) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Added[iNdEx])
+			copy(dAtA[i:], m.Added[iNdEx])
+			i = encodeVarintAttestation(dAtA, i, uint64(len(m.Added[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	{
+		size, err := m.BaseHeight.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintAttestation(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PacketCommitments) > 0 {
+		for iNdEx := len(m.PacketCommitments) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PacketCommitments[iNdEx])
+			copy(dAtA[i:], m.PacketCommitments[iNdEx])
+			i = encodeVarintAttestation(dAtA, i, uint64(len(m.PacketCommitments[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.StorageRoot) > 0 {
+		i -= len(m.StorageRoot)
+		copy(dAtA[i:], m.StorageRoot)
+		i = encodeVarintAttestation(dAtA, i, uint64(len(m.StorageRoot)))
+		i--
+		dAtA[i] = 0x32
+	}
+	n8, err8 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err8 != nil {
+		return 0, err8
+	}
+	i -= n8
+	i = encodeVarintAttestation(dAtA, i, uint64(n8))
+	i--
+	dAtA[i] = 0x2a
+	if len(m.BlockHash) > 0 {
+		i -= len(m.BlockHash)
+		copy(dAtA[i:], m.BlockHash)
+		i = encodeVarintAttestation(dAtA, i, uint64(len(m.BlockHash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.BlockNumber != 0 {
+		i = encodeVarintAttestation(dAtA, i, uint64(m.BlockNumber))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ClientToUpdate) > 0 {
+		i -= len(m.ClientToUpdate)
+		copy(dAtA[i:], m.ClientToUpdate)
+		i = encodeVarintAttestation(dAtA, i, uint64(len(m.ClientToUpdate)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintAttestation(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintAttestation(dAtA []byte, offset int, v uint64) int {
+	offset -= sovAttestation(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Attestation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AttestatorId)
+	if l > 0 {
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	l = m.Payload.Size()
+	n += 1 + l + sovAttestation(uint64(l))
+	return n
+}
+
+func (m *AttestationPayload) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Data != nil {
+		n += m.Data.Size()
+	}
+	return n
+}
+
+func (m *AttestationPayload_IbcDataV1) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.IbcDataV1 != nil {
+		l = m.IbcDataV1.Size()
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	return n
+}
+func (m *AttestationPayload_BlockDataV1) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockDataV1 != nil {
+		l = m.BlockDataV1.Size()
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	return n
+}
+func (m *IBCData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	l = len(m.ClientId)
+	if l > 0 {
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	l = len(m.ClientToUpdate)
+	if l > 0 {
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	l = m.Height.Size()
+	n += 1 + l + sovAttestation(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovAttestation(uint64(l))
+	if len(m.PacketCommitments) > 0 {
+		for _, b := range m.PacketCommitments {
+			l = len(b)
+			n += 1 + l + sovAttestation(uint64(l))
+		}
+	}
+	if m.CommitmentsDelta != nil {
+		l = m.CommitmentsDelta.Size()
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	l = len(m.CommitmentSetHash)
+	if l > 0 {
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	return n
+}
+
+func (m *CommitmentsDelta) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.BaseHeight.Size()
+	n += 1 + l + sovAttestation(uint64(l))
+	if len(m.Added) > 0 {
+		for _, b := range m.Added {
+			l = len(b)
+			n += 1 + l + sovAttestation(uint64(l))
+		}
+	}
+	if len(m.Removed) > 0 {
+		for _, b := range m.Removed {
+			l = len(b)
+			n += 1 + l + sovAttestation(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *BlockData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	l = len(m.ClientToUpdate)
+	if l > 0 {
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	if m.BlockNumber != 0 {
+		n += 1 + sovAttestation(uint64(m.BlockNumber))
+	}
+	l = len(m.BlockHash)
+	if l > 0 {
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovAttestation(uint64(l))
+	l = len(m.StorageRoot)
+	if l > 0 {
+		n += 1 + l + sovAttestation(uint64(l))
+	}
+	if len(m.PacketCommitments) > 0 {
+		for _, b := range m.PacketCommitments {
+			l = len(b)
+			n += 1 + l + sovAttestation(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovAttestation(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozAttestation(x uint64) (n int) {
+	return sovAttestation(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Attestation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAttestation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Attestation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Attestation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AttestatorId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AttestatorId = append(m.AttestatorId[:0], dAtA[iNdEx:postIndex]...)
+			if m.AttestatorId == nil {
+				m.AttestatorId = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Payload.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAttestation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AttestationPayload) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAttestation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AttestationPayload: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AttestationPayload: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IbcDataV1", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &IBCData{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &AttestationPayload_IbcDataV1{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockDataV1", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &BlockData{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &AttestationPayload_BlockDataV1{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAttestation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IBCData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAttestation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IBCData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IBCData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientToUpdate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientToUpdate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Height.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PacketCommitments", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PacketCommitments = append(m.PacketCommitments, make([]byte, postIndex-iNdEx))
+			copy(m.PacketCommitments[len(m.PacketCommitments)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitmentsDelta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CommitmentsDelta == nil {
+				m.CommitmentsDelta = &CommitmentsDelta{}
+			}
+			if err := m.CommitmentsDelta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitmentSetHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CommitmentSetHash = append(m.CommitmentSetHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.CommitmentSetHash == nil {
+				m.CommitmentSetHash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAttestation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	var l int
-	_ = l
-	l = len(m.AttestatorId)
-	if l > 0 {
-		n += 1 + l + sovAttestation(uint64(l))
-	}
-	l = m.AttestedData.Size()
-	n += 1 + l + sovAttestation(uint64(l))
-	return n
-}
 
-func (m *IBCData) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ChainId)
-	if l > 0 {
-		n += 1 + l + sovAttestation(uint64(l))
-	}
-	l = len(m.ClientId)
-	if l > 0 {
-		n += 1 + l + sovAttestation(uint64(l))
-	}
-	l = len(m.ClientToUpdate)
-	if l > 0 {
-		n += 1 + l + sovAttestation(uint64(l))
-	}
-	l = m.Height.Size()
-	n += 1 + l + sovAttestation(uint64(l))
-	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
-	n += 1 + l + sovAttestation(uint64(l))
-	if len(m.PacketCommitments) > 0 {
-		for _, b := range m.PacketCommitments {
-			l = len(b)
-			n += 1 + l + sovAttestation(uint64(l))
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovAttestation(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozAttestation(x uint64) (n int) {
-	return sovAttestation(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *Attestation) Unmarshal(dAtA []byte) error {
+func (m *CommitmentsDelta) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -398,15 +1618,48 @@ func (m *Attestation) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Attestation: wiretype end group for non-group")
+			return fmt.Errorf("proto: CommitmentsDelta: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Attestation: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CommitmentsDelta: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AttestatorId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseHeight", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAttestation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BaseHeight.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Added", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -433,16 +1686,14 @@ func (m *Attestation) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AttestatorId = append(m.AttestatorId[:0], dAtA[iNdEx:postIndex]...)
-			if m.AttestatorId == nil {
-				m.AttestatorId = []byte{}
-			}
+			m.Added = append(m.Added, make([]byte, postIndex-iNdEx))
+			copy(m.Added[len(m.Added)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AttestedData", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAttestation
@@ -452,24 +1703,23 @@ func (m *Attestation) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAttestation
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAttestation
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.AttestedData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Removed = append(m.Removed, make([]byte, postIndex-iNdEx))
+			copy(m.Removed[len(m.Removed)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -492,7 +1742,7 @@ func (m *Attestation) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *IBCData) Unmarshal(dAtA []byte) error {
+func (m *BlockData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -515,10 +1765,10 @@ func (m *IBCData) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IBCData: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IBCData: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -555,7 +1805,7 @@ func (m *IBCData) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientToUpdate", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -583,13 +1833,32 @@ func (m *IBCData) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClientId = string(dAtA[iNdEx:postIndex])
+			m.ClientToUpdate = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockNumber", wireType)
+			}
+			m.BlockNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAttestation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockNumber |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientToUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHash", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAttestation
@@ -599,27 +1868,29 @@ func (m *IBCData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAttestation
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAttestation
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClientToUpdate = string(dAtA[iNdEx:postIndex])
+			m.BlockHash = append(m.BlockHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BlockHash == nil {
+				m.BlockHash = []byte{}
+			}
 			iNdEx = postIndex
-		case 4:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -646,15 +1917,15 @@ func (m *IBCData) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Height.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageRoot", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAttestation
@@ -664,26 +1935,27 @@ func (m *IBCData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAttestation
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAttestation
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.StorageRoot = append(m.StorageRoot[:0], dAtA[iNdEx:postIndex]...)
+			if m.StorageRoot == nil {
+				m.StorageRoot = []byte{}
 			}
 			iNdEx = postIndex
-		case 6:
+		case 7:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field PacketCommitments", wireType)
 			}
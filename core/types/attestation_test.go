@@ -2,13 +2,15 @@ package types_test
 
 import (
 	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
-	"github.com/cosmos/cosmos-sdk/types/module/testutil"
 
 	clienttypes "github.com/cosmos/ibc-go/v9/modules/core/02-client/types"
 
@@ -16,13 +18,12 @@ import (
 )
 
 const (
-	mockChainID  = "testchain-1"
-	mockClientID = "testclient-1"
+	mockChainID     = "testchain-1"
+	mockClientID    = "testclient-1"
+	mockHostChainID = "hostchain-1"
 )
 
-func TestGetDeterministicBytes(t *testing.T) {
-	cdc := testutil.MakeTestEncodingConfig().Codec
-
+func TestGetAttestationSignBytes(t *testing.T) {
 	for i := 0; i < 10; i++ {
 		var packetCommitments [][]byte
 		for j := 0; j < i; j++ {
@@ -36,7 +37,8 @@ func TestGetDeterministicBytes(t *testing.T) {
 			Timestamp:         time.Now(),
 			PacketCommitments: packetCommitments,
 		}
-		expectedAttestationBytes := types.GetDeterministicAttestationBytes(cdc, attestationData)
+		payload := types.NewIBCDataPayload(attestationData)
+		expectedAttestationBytes := types.GetAttestationSignBytes(mockHostChainID, payload)
 
 		var signers []*secp256k1.PrivKey
 		for j := 0; j < i; j++ {
@@ -45,7 +47,7 @@ func TestGetDeterministicBytes(t *testing.T) {
 
 		for j := 0; j < 10; j++ {
 			for _, signer := range signers {
-				bz := types.GetDeterministicAttestationBytes(cdc, attestationData)
+				bz := types.GetAttestationSignBytes(mockHostChainID, payload)
 				require.NotNil(t, bz)
 
 				// verify bytes are the same every time
@@ -59,6 +61,124 @@ func TestGetDeterministicBytes(t *testing.T) {
 				require.True(t, verified)
 			}
 		}
+
+		// the sign bytes are bound to the host chain
+		require.NotEqual(t, expectedAttestationBytes, types.GetAttestationSignBytes("other-host-chain", payload))
+	}
+}
+
+func TestGetAttestationSignBytesPayloadTypes(t *testing.T) {
+	timestamp := time.Unix(1700000000, 0)
+	ibcPayload := types.NewIBCDataPayload(types.IBCData{
+		ChainId:        mockChainID,
+		ClientId:       mockClientID,
+		ClientToUpdate: "10-attestation-0",
+		Height:         clienttypes.NewHeight(0, 42),
+		Timestamp:      timestamp,
+	})
+	blockPayload := types.NewBlockDataPayload(types.BlockData{
+		ChainId:        mockChainID,
+		ClientToUpdate: "10-attestation-0",
+		BlockNumber:    42,
+		BlockHash:      getRandomBytes(16),
+		Timestamp:      timestamp,
+	})
+
+	require.IsType(t, &types.IBCData{}, ibcPayload.Unpack())
+	require.IsType(t, &types.BlockData{}, blockPayload.Unpack())
+	require.Equal(t, ibcPayload.AttestedHeight(), blockPayload.AttestedHeight())
+	require.Equal(t, "10-attestation-0", blockPayload.ClientToUpdate())
+
+	// the same chain, height and timestamp in different payload types never have the same sign bytes
+	require.NotEqual(t, types.GetAttestationSignBytes(mockHostChainID, ibcPayload), types.GetAttestationSignBytes(mockHostChainID, blockPayload))
+
+	var emptyPayload types.AttestationPayload
+	require.Nil(t, emptyPayload.Unpack())
+	require.Empty(t, emptyPayload.ClientToUpdate())
+	require.True(t, emptyPayload.AttestedHeight().IsZero())
+	require.NotEqual(t, types.GetAttestationSignBytes(mockHostChainID, emptyPayload), types.GetAttestationSignBytes(mockHostChainID, ibcPayload))
+}
+
+type signBytesVectors struct {
+	Domain  string `json:"domain"`
+	Version uint64 `json:"version"`
+	Vectors []struct {
+		Name              string   `json:"name"`
+		PayloadType       string   `json:"payload_type"`
+		HostChainID       string   `json:"host_chain_id"`
+		ChainID           string   `json:"chain_id"`
+		ClientID          string   `json:"client_id"`
+		ClientToUpdate    string   `json:"client_to_update"`
+		RevisionNumber    uint64   `json:"revision_number"`
+		RevisionHeight    uint64   `json:"revision_height"`
+		BlockNumber       uint64   `json:"block_number"`
+		BlockHash         string   `json:"block_hash"`
+		StorageRoot       string   `json:"storage_root"`
+		TimestampSeconds  int64    `json:"timestamp_seconds"`
+		TimestampNanos    int64    `json:"timestamp_nanos"`
+		PacketCommitments []string `json:"packet_commitments"`
+		CommitmentSetHash string   `json:"commitment_set_hash"`
+		SignBytes         string   `json:"sign_bytes"`
+	} `json:"vectors"`
+}
+
+// TestGetAttestationSignBytesVectors checks the sign bytes against the golden vectors in testdata, which implementations
+// in other languages can use to check that they produce compatible sign bytes
+func TestGetAttestationSignBytesVectors(t *testing.T) {
+	bz, err := os.ReadFile("testdata/attestation_sign_bytes.json")
+	require.NoError(t, err)
+	var vectors signBytesVectors
+	require.NoError(t, json.Unmarshal(bz, &vectors))
+
+	require.Equal(t, types.AttestationSignBytesDomain, vectors.Domain)
+	require.Equal(t, types.AttestationSignBytesVersion, vectors.Version)
+	require.NotEmpty(t, vectors.Vectors)
+
+	for _, vector := range vectors.Vectors {
+		t.Run(vector.Name, func(t *testing.T) {
+			var packetCommitments [][]byte
+			for _, packetCommitment := range vector.PacketCommitments {
+				packetCommitmentBz, err := hex.DecodeString(packetCommitment)
+				require.NoError(t, err)
+				packetCommitments = append(packetCommitments, packetCommitmentBz)
+			}
+			timestamp := time.Unix(vector.TimestampSeconds, vector.TimestampNanos)
+
+			var payload types.AttestationPayload
+			switch vector.PayloadType {
+			case types.PayloadTypeIBCDataV1:
+				commitmentSetHash, err := hex.DecodeString(vector.CommitmentSetHash)
+				require.NoError(t, err)
+				payload = types.NewIBCDataPayload(types.IBCData{
+					ChainId:           vector.ChainID,
+					ClientId:          vector.ClientID,
+					ClientToUpdate:    vector.ClientToUpdate,
+					Height:            clienttypes.NewHeight(vector.RevisionNumber, vector.RevisionHeight),
+					Timestamp:         timestamp,
+					PacketCommitments: packetCommitments,
+					CommitmentSetHash: commitmentSetHash,
+				})
+			case types.PayloadTypeBlockDataV1:
+				blockHash, err := hex.DecodeString(vector.BlockHash)
+				require.NoError(t, err)
+				storageRoot, err := hex.DecodeString(vector.StorageRoot)
+				require.NoError(t, err)
+				payload = types.NewBlockDataPayload(types.BlockData{
+					ChainId:           vector.ChainID,
+					ClientToUpdate:    vector.ClientToUpdate,
+					BlockNumber:       vector.BlockNumber,
+					BlockHash:         blockHash,
+					Timestamp:         timestamp,
+					StorageRoot:       storageRoot,
+					PacketCommitments: packetCommitments,
+				})
+			case "":
+			default:
+				t.Fatalf("unknown payload type %s", vector.PayloadType)
+			}
+
+			require.Equal(t, vector.SignBytes, hex.EncodeToString(types.GetAttestationSignBytes(vector.HostChainID, payload)))
+		})
 	}
 }
 
@@ -12,16 +12,21 @@ import (
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
 )
 
 type AttestatorRegistration struct {
 	AttestatorID         []byte
 	AttestationPublicKey *codectypes.Any
+	// ProofOfPossession is required for BLS12-381 keys, since their signatures can be aggregated
+	ProofOfPossession []byte
 }
 
 type AttestatorRegistrationJson struct {
 	AttestatorID         string          `json:"attestator-id"`
 	AttestationPublicKey json.RawMessage `json:"attestation-public-key"`
+	ProofOfPossession    string          `json:"proof-of-possession,omitempty"`
 }
 
 func ParseAndValidateAttestationRegistrationJSONFromFile(cdc codec.Codec, path string) (AttestatorRegistration, error) {
@@ -62,12 +67,40 @@ func UnmarshalAttestationRegistrationJSON(cdc codec.Codec, bz []byte) (Attestato
 		return AttestatorRegistration{}, err
 	}
 
+	proofOfPossession, err := base64.StdEncoding.DecodeString(attestatorRegistrationJSON.ProofOfPossession)
+	if err != nil {
+		return AttestatorRegistration{}, err
+	}
+
 	return AttestatorRegistration{
 		AttestatorID:         attestatorID,
 		AttestationPublicKey: publicKeyAny,
+		ProofOfPossession:    proofOfPossession,
 	}, nil
 }
 
+func MarshalAttestationRegistrationJSON(cdc codec.Codec, attestatorRegistration AttestatorRegistration) ([]byte, error) {
+	pubKey, ok := attestatorRegistration.AttestationPublicKey.GetCachedValue().(cryptotypes.PubKey)
+	if !ok {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidType, "expecting cryptotypes.PubKey, got %T", attestatorRegistration.AttestationPublicKey.GetCachedValue())
+	}
+	publicKeyJSON, err := cdc.MarshalInterfaceJSON(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var proofOfPossession string
+	if len(attestatorRegistration.ProofOfPossession) != 0 {
+		proofOfPossession = base64.StdEncoding.EncodeToString(attestatorRegistration.ProofOfPossession)
+	}
+
+	return json.MarshalIndent(AttestatorRegistrationJson{
+		AttestatorID:         base64.StdEncoding.EncodeToString(attestatorRegistration.AttestatorID),
+		AttestationPublicKey: publicKeyJSON,
+		ProofOfPossession:    proofOfPossession,
+	}, "", "  ")
+}
+
 func (a AttestatorRegistration) Validate() error {
 	pubKey, ok := a.AttestationPublicKey.GetCachedValue().(cryptotypes.PubKey)
 	if !ok {
@@ -78,5 +111,9 @@ func (a AttestatorRegistration) Validate() error {
 		return fmt.Errorf("attestator id cannot be empty")
 	}
 
+	if blsPubKey, ok := pubKey.(*bls12381.PubKey); ok && !blsPubKey.VerifyProofOfPossession(a.ProofOfPossession) {
+		return fmt.Errorf("invalid proof of possession for BLS12-381 attestation public key")
+	}
+
 	return nil
 }
@@ -1,14 +1,18 @@
 package types_test
 
 import (
+	"encoding/base64"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	"github.com/cosmos/cosmos-sdk/std"
 
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
 	"github.com/cosmos/interchain-attestation/core/types"
 )
 
@@ -35,4 +39,72 @@ func TestUnmarshalAttestationJSON(t *testing.T) {
 	// TODO: Test some error scenarios
 }
 
+func TestUnmarshalBLSAttestationJSON(t *testing.T) {
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(interfaceRegistry)
+	bls12381.RegisterInterfaces(interfaceRegistry)
+	cdc := codec.NewProtoCodec(interfaceRegistry)
+
+	privKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	proofOfPossession, err := privKey.ProofOfPossession()
+	require.NoError(t, err)
+
+	registrationJSON := func(proofOfPossession []byte) []byte {
+		return []byte(fmt.Sprintf(`{
+    "attestator-id":"aGVsbG8=",
+    "attestation-public-key": {
+        "@type": "/core.crypto.bls12381.v1.PubKey",
+        "key":"%s"
+    },
+    "proof-of-possession":"%s"
+}`, base64.StdEncoding.EncodeToString(privKey.PubKey().Bytes()), base64.StdEncoding.EncodeToString(proofOfPossession)))
+	}
+
+	attestationRegistration, err := types.UnmarshalAttestationRegistrationJSON(cdc, registrationJSON(proofOfPossession))
+	require.NoError(t, err)
+	require.Equal(t, proofOfPossession, attestationRegistration.ProofOfPossession)
+	require.NoError(t, attestationRegistration.Validate())
+
+	// BLS12-381 keys require a valid proof of possession
+	otherPrivKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	otherProofOfPossession, err := otherPrivKey.ProofOfPossession()
+	require.NoError(t, err)
+	for _, invalidProofOfPossession := range [][]byte{nil, otherProofOfPossession} {
+		attestationRegistration, err = types.UnmarshalAttestationRegistrationJSON(cdc, registrationJSON(invalidProofOfPossession))
+		require.NoError(t, err)
+		require.ErrorContains(t, attestationRegistration.Validate(), "invalid proof of possession")
+	}
+}
+
+func TestMarshalAttestationRegistrationJSON(t *testing.T) {
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(interfaceRegistry)
+	bls12381.RegisterInterfaces(interfaceRegistry)
+	cdc := codec.NewProtoCodec(interfaceRegistry)
+
+	privKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	proofOfPossession, err := privKey.ProofOfPossession()
+	require.NoError(t, err)
+	pubKeyAny, err := codectypes.NewAnyWithValue(privKey.PubKey())
+	require.NoError(t, err)
+
+	registration := types.AttestatorRegistration{
+		AttestatorID:         []byte("hello"),
+		AttestationPublicKey: pubKeyAny,
+		ProofOfPossession:    proofOfPossession,
+	}
+	bz, err := types.MarshalAttestationRegistrationJSON(cdc, registration)
+	require.NoError(t, err)
+
+	unmarshalledRegistration, err := types.UnmarshalAttestationRegistrationJSON(cdc, bz)
+	require.NoError(t, err)
+	require.NoError(t, unmarshalledRegistration.Validate())
+	require.Equal(t, registration.AttestatorID, unmarshalledRegistration.AttestatorID)
+	require.Equal(t, registration.ProofOfPossession, unmarshalledRegistration.ProofOfPossession)
+	require.True(t, privKey.PubKey().Equals(unmarshalledRegistration.AttestationPublicKey.GetCachedValue().(cryptotypes.PubKey)))
+}
+
 // TODO: Test validate attestator separately with both valid and invalid inputs
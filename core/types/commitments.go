@@ -0,0 +1,106 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	clienttypes "github.com/cosmos/ibc-go/v9/modules/core/02-client/types"
+)
+
+// CommitmentSetHash returns the hash of a set of packet commitments. The commitments are sorted and length-prefixed
+// before hashing, so the hash does not depend on the order of the commitments.
+func CommitmentSetHash(packetCommitments [][]byte) []byte {
+	sorted := sortedCommitments(packetCommitments)
+
+	hasher := sha256.New()
+	lengthBz := make([]byte, 8)
+	for _, packetCommitment := range sorted {
+		binary.BigEndian.PutUint64(lengthBz, uint64(len(packetCommitment)))
+		hasher.Write(lengthBz)
+		hasher.Write(packetCommitment)
+	}
+
+	return hasher.Sum(nil)
+}
+
+// NewCommitmentsDelta creates the delta that turns the base packet commitments (at base height) into the target packet commitments
+func NewCommitmentsDelta(baseHeight clienttypes.Height, base [][]byte, target [][]byte) CommitmentsDelta {
+	baseSet := make(map[string]bool, len(base))
+	for _, packetCommitment := range base {
+		baseSet[string(packetCommitment)] = true
+	}
+	targetSet := make(map[string]bool, len(target))
+	for _, packetCommitment := range target {
+		targetSet[string(packetCommitment)] = true
+	}
+
+	var added, removed [][]byte
+	for _, packetCommitment := range target {
+		if !baseSet[string(packetCommitment)] {
+			added = append(added, packetCommitment)
+		}
+	}
+	for _, packetCommitment := range base {
+		if !targetSet[string(packetCommitment)] {
+			removed = append(removed, packetCommitment)
+		}
+	}
+
+	return CommitmentsDelta{
+		BaseHeight: baseHeight,
+		Added:      sortedCommitments(added),
+		Removed:    sortedCommitments(removed),
+	}
+}
+
+// Validate checks that the delta has no duplicate commitments, and that no commitment is both added and removed
+func (d CommitmentsDelta) Validate() error {
+	seen := make(map[string]bool, len(d.Added)+len(d.Removed))
+	for _, packetCommitment := range append(append([][]byte{}, d.Added...), d.Removed...) {
+		if seen[string(packetCommitment)] {
+			return fmt.Errorf("duplicate packet commitment %s in commitments delta", string(packetCommitment))
+		}
+		seen[string(packetCommitment)] = true
+	}
+
+	return nil
+}
+
+// Apply applies the delta to the given packet commitments and returns the resulting (sorted) set of packet commitments.
+// Added commitments that already exist and removed commitments that do not exist are ignored, the result is expected
+// to be checked against a commitment set hash.
+func (d CommitmentsDelta) Apply(packetCommitments [][]byte) ([][]byte, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	set := make(map[string][]byte, len(packetCommitments)+len(d.Added))
+	for _, packetCommitment := range packetCommitments {
+		set[string(packetCommitment)] = packetCommitment
+	}
+	for _, packetCommitment := range d.Added {
+		set[string(packetCommitment)] = packetCommitment
+	}
+	for _, packetCommitment := range d.Removed {
+		delete(set, string(packetCommitment))
+	}
+
+	result := make([][]byte, 0, len(set))
+	for _, packetCommitment := range set {
+		result = append(result, packetCommitment)
+	}
+
+	return sortedCommitments(result), nil
+}
+
+func sortedCommitments(packetCommitments [][]byte) [][]byte {
+	sorted := make([][]byte, len(packetCommitments))
+	copy(sorted, packetCommitments)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+	return sorted
+}
@@ -0,0 +1,48 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	clienttypes "github.com/cosmos/ibc-go/v9/modules/core/02-client/types"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+)
+
+func TestCommitmentSetHash(t *testing.T) {
+	commitments := [][]byte{[]byte("pckt1"), []byte("pckt2"), []byte("pckt3")}
+	reordered := [][]byte{[]byte("pckt3"), []byte("pckt1"), []byte("pckt2")}
+
+	require.Equal(t, types.CommitmentSetHash(commitments), types.CommitmentSetHash(reordered))
+	require.NotEqual(t, types.CommitmentSetHash(commitments), types.CommitmentSetHash(commitments[:2]))
+	// length prefixes make sure that concatenations of commitments do not collide
+	require.NotEqual(t, types.CommitmentSetHash([][]byte{[]byte("ab"), []byte("c")}), types.CommitmentSetHash([][]byte{[]byte("a"), []byte("bc")}))
+}
+
+func TestCommitmentsDelta(t *testing.T) {
+	base := [][]byte{[]byte("pckt1"), []byte("pckt2"), []byte("pckt3")}
+	target := [][]byte{[]byte("pckt4"), []byte("pckt2"), []byte("pckt3")}
+
+	delta := types.NewCommitmentsDelta(clienttypes.NewHeight(1, 42), base, target)
+	require.Equal(t, clienttypes.NewHeight(1, 42), delta.BaseHeight)
+	require.Equal(t, [][]byte{[]byte("pckt4")}, delta.Added)
+	require.Equal(t, [][]byte{[]byte("pckt1")}, delta.Removed)
+
+	result, err := delta.Apply(base)
+	require.NoError(t, err)
+	require.Equal(t, types.CommitmentSetHash(target), types.CommitmentSetHash(result))
+
+	emptyDelta := types.NewCommitmentsDelta(clienttypes.NewHeight(1, 42), base, base)
+	require.Empty(t, emptyDelta.Added)
+	require.Empty(t, emptyDelta.Removed)
+
+	invalidDelta := types.CommitmentsDelta{
+		BaseHeight: clienttypes.NewHeight(1, 42),
+		Added:      [][]byte{[]byte("pckt1")},
+		Removed:    [][]byte{[]byte("pckt1")},
+	}
+	require.Error(t, invalidDelta.Validate())
+	_, err = invalidDelta.Apply(base)
+	require.Error(t, err)
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"hash"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	clienttypes "github.com/cosmos/ibc-go/v9/modules/core/02-client/types"
+)
+
+const (
+	PayloadTypeIBCDataV1   = "ibc_data_v1"
+	PayloadTypeBlockDataV1 = "block_data_v1"
+)
+
+// Payload is implemented by each of the payload types that can be attested to (see AttestationPayload)
+type Payload interface {
+	proto.Message
+
+	GetChainId() string
+	GetClientToUpdate() string
+	GetTimestamp() time.Time
+	// AttestedHeight is the height of the counterparty that the payload attests to
+	AttestedHeight() clienttypes.Height
+
+	// payloadType is the name of the payload type, which is part of the sign bytes
+	payloadType() string
+	// writeSignBytes writes the payload type specific part of the sign bytes
+	writeSignBytes(hasher hash.Hash)
+}
+
+var (
+	_ Payload = (*IBCData)(nil)
+	_ Payload = (*BlockData)(nil)
+)
+
+// NewIBCDataPayload wraps the IBC data in an attestation payload
+func NewIBCDataPayload(data IBCData) AttestationPayload {
+	return AttestationPayload{Data: &AttestationPayload_IbcDataV1{IbcDataV1: &data}}
+}
+
+// NewBlockDataPayload wraps the block data in an attestation payload
+func NewBlockDataPayload(data BlockData) AttestationPayload {
+	return AttestationPayload{Data: &AttestationPayload_BlockDataV1{BlockDataV1: &data}}
+}
+
+// Unpack returns the payload type that is set, or nil if none is set
+func (m AttestationPayload) Unpack() Payload {
+	switch data := m.Data.(type) {
+	case *AttestationPayload_IbcDataV1:
+		if data.IbcDataV1 != nil {
+			return data.IbcDataV1
+		}
+	case *AttestationPayload_BlockDataV1:
+		if data.BlockDataV1 != nil {
+			return data.BlockDataV1
+		}
+	}
+
+	return nil
+}
+
+// ClientToUpdate returns the client on the host chain that the payload is for, or an empty string if no payload is set
+func (m AttestationPayload) ClientToUpdate() string {
+	payload := m.Unpack()
+	if payload == nil {
+		return ""
+	}
+
+	return payload.GetClientToUpdate()
+}
+
+// AttestedHeight returns the counterparty height the payload attests to, or a zero height if no payload is set
+func (m AttestationPayload) AttestedHeight() clienttypes.Height {
+	payload := m.Unpack()
+	if payload == nil {
+		return clienttypes.ZeroHeight()
+	}
+
+	return payload.AttestedHeight()
+}
+
+func (m *IBCData) AttestedHeight() clienttypes.Height {
+	return m.Height
+}
+
+func (m *IBCData) payloadType() string {
+	return PayloadTypeIBCDataV1
+}
+
+func (m *IBCData) writeSignBytes(hasher hash.Hash) {
+	commitmentSetHash := m.CommitmentSetHash
+	if len(commitmentSetHash) == 0 {
+		commitmentSetHash = CommitmentSetHash(m.PacketCommitments)
+	}
+
+	writeLengthPrefixed(hasher, []byte(m.ClientToUpdate))
+	writeLengthPrefixed(hasher, []byte(m.ChainId))
+	writeLengthPrefixed(hasher, []byte(m.ClientId))
+	writeUint64(hasher, m.Height.RevisionNumber)
+	writeUint64(hasher, m.Height.RevisionHeight)
+	writeTimestamp(hasher, m.Timestamp)
+	writeLengthPrefixed(hasher, commitmentSetHash)
+}
+
+// AttestedHeight returns the block number as a height. Block numbers have no revision, so the revision number is zero.
+func (m *BlockData) AttestedHeight() clienttypes.Height {
+	return clienttypes.NewHeight(0, m.BlockNumber)
+}
+
+func (m *BlockData) payloadType() string {
+	return PayloadTypeBlockDataV1
+}
+
+func (m *BlockData) writeSignBytes(hasher hash.Hash) {
+	writeLengthPrefixed(hasher, []byte(m.ClientToUpdate))
+	writeLengthPrefixed(hasher, []byte(m.ChainId))
+	writeUint64(hasher, m.BlockNumber)
+	writeLengthPrefixed(hasher, m.BlockHash)
+	writeTimestamp(hasher, m.Timestamp)
+	writeLengthPrefixed(hasher, m.StorageRoot)
+	writeLengthPrefixed(hasher, CommitmentSetHash(m.PacketCommitments))
+}
@@ -9,18 +9,22 @@ import (
 	_ "github.com/cosmos/gogoproto/gogoproto"
 	grpc1 "github.com/cosmos/gogoproto/grpc"
 	proto "github.com/cosmos/gogoproto/proto"
+	github_com_cosmos_gogoproto_types "github.com/cosmos/gogoproto/types"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
 	io "io"
 	math "math"
 	math_bits "math/bits"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -109,216 +113,3500 @@ func (m *GetAttestationsResponse) GetAttestations() []Attestation {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*GetAttestationsRequest)(nil), "core.sidecar.v1.GetAttestationsRequest")
-	proto.RegisterType((*GetAttestationsResponse)(nil), "core.sidecar.v1.GetAttestationsResponse")
+type GetChannelTopologyRequest struct {
+	ChainId string `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
 }
 
-func init() { proto.RegisterFile("core/sidecar/v1/sidecar.proto", fileDescriptor_8ce634b51eec8241) }
+func (m *GetChannelTopologyRequest) Reset()         { *m = GetChannelTopologyRequest{} }
+func (m *GetChannelTopologyRequest) String() string { return proto.CompactTextString(m) }
+func (*GetChannelTopologyRequest) ProtoMessage()    {}
+func (*GetChannelTopologyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8ce634b51eec8241, []int{2}
+}
+func (m *GetChannelTopologyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetChannelTopologyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetChannelTopologyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetChannelTopologyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetChannelTopologyRequest.Merge(m, src)
+}
+func (m *GetChannelTopologyRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetChannelTopologyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetChannelTopologyRequest.DiscardUnknown(m)
+}
 
-var fileDescriptor_8ce634b51eec8241 = []byte{
-	// 261 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x4d, 0xce, 0x2f, 0x4a,
-	0xd5, 0x2f, 0xce, 0x4c, 0x49, 0x4d, 0x4e, 0x2c, 0xd2, 0x2f, 0x33, 0x84, 0x31, 0xf5, 0x0a, 0x8a,
-	0xf2, 0x4b, 0xf2, 0x85, 0xf8, 0x41, 0xd2, 0x7a, 0x30, 0xb1, 0x32, 0x43, 0x29, 0x91, 0xf4, 0xfc,
-	0xf4, 0x7c, 0xb0, 0x9c, 0x3e, 0x88, 0x05, 0x51, 0x26, 0x25, 0x0f, 0x36, 0xa5, 0xa4, 0xb2, 0x20,
-	0xb5, 0x18, 0x64, 0x46, 0x62, 0x49, 0x49, 0x6a, 0x71, 0x49, 0x62, 0x49, 0x66, 0x7e, 0x1e, 0x44,
-	0x81, 0x92, 0x04, 0x97, 0x98, 0x7b, 0x6a, 0x89, 0x23, 0x42, 0xbc, 0x38, 0x28, 0xb5, 0xb0, 0x34,
-	0xb5, 0xb8, 0x44, 0x29, 0x9e, 0x4b, 0x1c, 0x43, 0xa6, 0xb8, 0x20, 0x3f, 0xaf, 0x38, 0x55, 0xc8,
-	0x85, 0x8b, 0x07, 0xc9, 0xa4, 0x62, 0x09, 0x46, 0x05, 0x66, 0x0d, 0x6e, 0x23, 0x29, 0x3d, 0xb0,
-	0x9b, 0xc0, 0x96, 0xe9, 0x95, 0x19, 0xea, 0x21, 0x69, 0x75, 0x62, 0x39, 0x71, 0x4f, 0x9e, 0x21,
-	0x08, 0x45, 0x97, 0x51, 0x21, 0x17, 0x7b, 0x30, 0xc4, 0xfd, 0x42, 0x69, 0x5c, 0xfc, 0x68, 0x76,
-	0x09, 0xa9, 0xeb, 0xa1, 0xf9, 0x50, 0x0f, 0xbb, 0x3b, 0xa5, 0x34, 0x08, 0x2b, 0x84, 0x38, 0x5b,
-	0x89, 0xc1, 0xc9, 0xf7, 0xc4, 0x23, 0x39, 0xc6, 0x0b, 0x8f, 0xe4, 0x18, 0x1f, 0x3c, 0x92, 0x63,
-	0x9c, 0xf0, 0x58, 0x8e, 0xe1, 0xc2, 0x63, 0x39, 0x86, 0x1b, 0x8f, 0xe5, 0x18, 0xa2, 0x8c, 0xd3,
-	0x33, 0x4b, 0x32, 0x4a, 0x93, 0xf4, 0x92, 0xf3, 0x73, 0xf5, 0x93, 0xf3, 0x8b, 0x73, 0xf3, 0x8b,
-	0xf5, 0x33, 0xf3, 0x4a, 0x52, 0x8b, 0x92, 0x33, 0x12, 0x33, 0xf3, 0x74, 0x91, 0xdc, 0xad, 0x8f,
-	0x08, 0xd1, 0x24, 0x36, 0x70, 0x18, 0x1a, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0xc6, 0x45, 0x24,
-	0x24, 0xac, 0x01, 0x00, 0x00,
+var xxx_messageInfo_GetChannelTopologyRequest proto.InternalMessageInfo
+
+func (m *GetChannelTopologyRequest) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+type GetChannelTopologyResponse struct {
+	ChainId  string `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ClientId string `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// the topology cached by every rpc endpoint of the chain, which can differ
+	// while the endpoints are at different heights
+	Topologies []ChannelTopology `protobuf:"bytes,3,rep,name=topologies,proto3" json:"topologies"`
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *GetChannelTopologyResponse) Reset()         { *m = GetChannelTopologyResponse{} }
+func (m *GetChannelTopologyResponse) String() string { return proto.CompactTextString(m) }
+func (*GetChannelTopologyResponse) ProtoMessage()    {}
+func (*GetChannelTopologyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8ce634b51eec8241, []int{3}
+}
+func (m *GetChannelTopologyResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetChannelTopologyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetChannelTopologyResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetChannelTopologyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetChannelTopologyResponse.Merge(m, src)
+}
+func (m *GetChannelTopologyResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetChannelTopologyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetChannelTopologyResponse.DiscardUnknown(m)
+}
 
-// SidecarClient is the client API for Sidecar service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type SidecarClient interface {
-	GetAttestations(ctx context.Context, in *GetAttestationsRequest, opts ...grpc.CallOption) (*GetAttestationsResponse, error)
+var xxx_messageInfo_GetChannelTopologyResponse proto.InternalMessageInfo
+
+func (m *GetChannelTopologyResponse) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
 }
 
-type sidecarClient struct {
-	cc grpc1.ClientConn
+func (m *GetChannelTopologyResponse) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
 }
 
-func NewSidecarClient(cc grpc1.ClientConn) SidecarClient {
-	return &sidecarClient{cc}
+func (m *GetChannelTopologyResponse) GetTopologies() []ChannelTopology {
+	if m != nil {
+		return m.Topologies
+	}
+	return nil
 }
 
-func (c *sidecarClient) GetAttestations(ctx context.Context, in *GetAttestationsRequest, opts ...grpc.CallOption) (*GetAttestationsResponse, error) {
-	out := new(GetAttestationsResponse)
-	err := c.cc.Invoke(ctx, "/core.sidecar.v1.Sidecar/GetAttestations", in, out, opts...)
-	if err != nil {
-		return nil, err
+// ChannelTopology is the connections and open channels of a client at a height
+type ChannelTopology struct {
+	// the rpc endpoint the topology was read from, without credentials or path
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// the height the topology was read at
+	Height        uint64            `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	ConnectionIds []string          `protobuf:"bytes,3,rep,name=connection_ids,json=connectionIds,proto3" json:"connection_ids,omitempty"`
+	Channels      []TopologyChannel `protobuf:"bytes,4,rep,name=channels,proto3" json:"channels"`
+}
+
+func (m *ChannelTopology) Reset()         { *m = ChannelTopology{} }
+func (m *ChannelTopology) String() string { return proto.CompactTextString(m) }
+func (*ChannelTopology) ProtoMessage()    {}
+func (*ChannelTopology) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8ce634b51eec8241, []int{4}
+}
+func (m *ChannelTopology) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChannelTopology) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChannelTopology.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *ChannelTopology) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelTopology.Merge(m, src)
+}
+func (m *ChannelTopology) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChannelTopology) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelTopology.DiscardUnknown(m)
 }
 
-// SidecarServer is the server API for Sidecar service.
-type SidecarServer interface {
-	GetAttestations(context.Context, *GetAttestationsRequest) (*GetAttestationsResponse, error)
+var xxx_messageInfo_ChannelTopology proto.InternalMessageInfo
+
+func (m *ChannelTopology) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
 }
 
-// UnimplementedSidecarServer can be embedded to have forward compatible implementations.
-type UnimplementedSidecarServer struct {
+func (m *ChannelTopology) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
 }
 
-func (*UnimplementedSidecarServer) GetAttestations(ctx context.Context, req *GetAttestationsRequest) (*GetAttestationsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAttestations not implemented")
+func (m *ChannelTopology) GetConnectionIds() []string {
+	if m != nil {
+		return m.ConnectionIds
+	}
+	return nil
 }
 
-func RegisterSidecarServer(s grpc1.Server, srv SidecarServer) {
-	s.RegisterService(&_Sidecar_serviceDesc, srv)
+func (m *ChannelTopology) GetChannels() []TopologyChannel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
 }
 
-func _Sidecar_GetAttestations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAttestationsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+// TopologyChannel is an open channel on one of the connections of a client
+type TopologyChannel struct {
+	PortId       string `protobuf:"bytes,1,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`
+	ChannelId    string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	ConnectionId string `protobuf:"bytes,3,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+}
+
+func (m *TopologyChannel) Reset()         { *m = TopologyChannel{} }
+func (m *TopologyChannel) String() string { return proto.CompactTextString(m) }
+func (*TopologyChannel) ProtoMessage()    {}
+func (*TopologyChannel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8ce634b51eec8241, []int{5}
+}
+func (m *TopologyChannel) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TopologyChannel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TopologyChannel.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if interceptor == nil {
-		return srv.(SidecarServer).GetAttestations(ctx, in)
+}
+func (m *TopologyChannel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopologyChannel.Merge(m, src)
+}
+func (m *TopologyChannel) XXX_Size() int {
+	return m.Size()
+}
+func (m *TopologyChannel) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopologyChannel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopologyChannel proto.InternalMessageInfo
+
+func (m *TopologyChannel) GetPortId() string {
+	if m != nil {
+		return m.PortId
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/core.sidecar.v1.Sidecar/GetAttestations",
+	return ""
+}
+
+func (m *TopologyChannel) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SidecarServer).GetAttestations(ctx, req.(*GetAttestationsRequest))
+	return ""
+}
+
+func (m *TopologyChannel) GetConnectionId() string {
+	if m != nil {
+		return m.ConnectionId
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-var _Sidecar_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "core.sidecar.v1.Sidecar",
-	HandlerType: (*SidecarServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetAttestations",
-			Handler:    _Sidecar_GetAttestations_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "core/sidecar/v1/sidecar.proto",
+type GetAttestationRequest struct {
+	ChainId string `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Height  uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
 }
 
-func (m *GetAttestationsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *GetAttestationRequest) Reset()         { *m = GetAttestationRequest{} }
+func (m *GetAttestationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAttestationRequest) ProtoMessage()    {}
+func (*GetAttestationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8ce634b51eec8241, []int{6}
+}
+func (m *GetAttestationRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetAttestationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetAttestationRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *GetAttestationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAttestationRequest.Merge(m, src)
+}
+func (m *GetAttestationRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetAttestationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAttestationRequest.DiscardUnknown(m)
 }
 
-func (m *GetAttestationsRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_GetAttestationRequest proto.InternalMessageInfo
+
+func (m *GetAttestationRequest) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
 }
 
-func (m *GetAttestationsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+func (m *GetAttestationRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
 }
 
-func (m *GetAttestationsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+type GetAttestationResponse struct {
+	Attestation Attestation `protobuf:"bytes,1,opt,name=attestation,proto3" json:"attestation"`
+}
+
+func (m *GetAttestationResponse) Reset()         { *m = GetAttestationResponse{} }
+func (m *GetAttestationResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAttestationResponse) ProtoMessage()    {}
+func (*GetAttestationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8ce634b51eec8241, []int{7}
+}
+func (m *GetAttestationResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetAttestationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetAttestationResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *GetAttestationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAttestationResponse.Merge(m, src)
+}
+func (m *GetAttestationResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetAttestationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAttestationResponse.DiscardUnknown(m)
 }
 
-func (m *GetAttestationsResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_GetAttestationResponse proto.InternalMessageInfo
+
+func (m *GetAttestationResponse) GetAttestation() Attestation {
+	if m != nil {
+		return m.Attestation
+	}
+	return Attestation{}
 }
 
-func (m *GetAttestationsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Attestations) > 0 {
-		for iNdEx := len(m.Attestations) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Attestations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
+type ListChainsRequest struct {
+}
+
+func (m *ListChainsRequest) Reset()         { *m = ListChainsRequest{} }
+func (m *ListChainsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListChainsRequest) ProtoMessage()    {}
+func (*ListChainsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8ce634b51eec8241, []int{8}
+}
+func (m *ListChainsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListChainsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListChainsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListChainsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListChainsRequest.Merge(m, src)
+}
+func (m *ListChainsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListChainsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListChainsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListChainsRequest proto.InternalMessageInfo
+
+type ListChainsResponse struct {
+	// sorted by chain id
+	Chains []ChainStatus `protobuf:"bytes,1,rep,name=chains,proto3" json:"chains"`
+}
+
+func (m *ListChainsResponse) Reset()         { *m = ListChainsResponse{} }
+func (m *ListChainsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListChainsResponse) ProtoMessage()    {}
+func (*ListChainsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8ce634b51eec8241, []int{9}
+}
+func (m *ListChainsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListChainsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListChainsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListChainsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListChainsResponse.Merge(m, src)
+}
+func (m *ListChainsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListChainsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListChainsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListChainsResponse proto.InternalMessageInfo
+
+func (m *ListChainsResponse) GetChains() []ChainStatus {
+	if m != nil {
+		return m.Chains
+	}
+	return nil
+}
+
+// ChainStatus is the health of the collection loop of a chain and the heights
+// it has attested to
+type ChainStatus struct {
+	ChainId string `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// one of healthy, degraded or failing
+	Health              string `protobuf:"bytes,2,opt,name=health,proto3" json:"health,omitempty"`
+	ConsecutiveFailures uint32 `protobuf:"varint,3,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	// the time of the last successful collection, unset if none succeeded yet
+	LastSuccess *time.Time `protobuf:"bytes,4,opt,name=last_success,json=lastSuccess,proto3,stdtime" json:"last_success,omitempty"`
+	LastError   string     `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// the height of the latest signed attestation, 0 if there is none
+	LatestHeight uint64 `protobuf:"varint,6,opt,name=latest_height,json=latestHeight,proto3" json:"latest_height,omitempty"`
+	// the height of the latest collected attestation, 0 if there is none
+	CollectedHeight uint64 `protobuf:"varint,7,opt,name=collected_height,json=collectedHeight,proto3" json:"collected_height,omitempty"`
+	// the number of heights the latest signed attestation lags behind the
+	// latest collected one, e.g. while the sidecar is on standby
+	LaggingHeights uint64 `protobuf:"varint,8,opt,name=lagging_heights,json=laggingHeights,proto3" json:"lagging_heights,omitempty"`
+}
+
+func (m *ChainStatus) Reset()         { *m = ChainStatus{} }
+func (m *ChainStatus) String() string { return proto.CompactTextString(m) }
+func (*ChainStatus) ProtoMessage()    {}
+func (*ChainStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8ce634b51eec8241, []int{10}
+}
+func (m *ChainStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChainStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChainStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChainStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainStatus.Merge(m, src)
+}
+func (m *ChainStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChainStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainStatus proto.InternalMessageInfo
+
+func (m *ChainStatus) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+func (m *ChainStatus) GetHealth() string {
+	if m != nil {
+		return m.Health
+	}
+	return ""
+}
+
+func (m *ChainStatus) GetConsecutiveFailures() uint32 {
+	if m != nil {
+		return m.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (m *ChainStatus) GetLastSuccess() *time.Time {
+	if m != nil {
+		return m.LastSuccess
+	}
+	return nil
+}
+
+func (m *ChainStatus) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *ChainStatus) GetLatestHeight() uint64 {
+	if m != nil {
+		return m.LatestHeight
+	}
+	return 0
+}
+
+func (m *ChainStatus) GetCollectedHeight() uint64 {
+	if m != nil {
+		return m.CollectedHeight
+	}
+	return 0
+}
+
+func (m *ChainStatus) GetLaggingHeights() uint64 {
+	if m != nil {
+		return m.LaggingHeights
+	}
+	return 0
+}
+
+type ListAttestationsRequest struct {
+	ChainId string `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// the lowest height to return attestations from
+	StartHeight uint64 `protobuf:"varint,2,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	// the highest height to return attestations up to, 0 for no limit
+	EndHeight uint64 `protobuf:"varint,3,opt,name=end_height,json=endHeight,proto3" json:"end_height,omitempty"`
+	// the maximum number of attestations to return, 0 for the default of 100
+	Limit uint32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *ListAttestationsRequest) Reset()         { *m = ListAttestationsRequest{} }
+func (m *ListAttestationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAttestationsRequest) ProtoMessage()    {}
+func (*ListAttestationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8ce634b51eec8241, []int{11}
+}
+func (m *ListAttestationsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListAttestationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListAttestationsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListAttestationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAttestationsRequest.Merge(m, src)
+}
+func (m *ListAttestationsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListAttestationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAttestationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAttestationsRequest proto.InternalMessageInfo
+
+func (m *ListAttestationsRequest) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+func (m *ListAttestationsRequest) GetStartHeight() uint64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *ListAttestationsRequest) GetEndHeight() uint64 {
+	if m != nil {
+		return m.EndHeight
+	}
+	return 0
+}
+
+func (m *ListAttestationsRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListAttestationsResponse struct {
+	Attestations []Attestation `protobuf:"bytes,1,rep,name=attestations,proto3" json:"attestations"`
+	// the start_height of the next page, 0 if this is the last page
+	NextHeight uint64 `protobuf:"varint,2,opt,name=next_height,json=nextHeight,proto3" json:"next_height,omitempty"`
+}
+
+func (m *ListAttestationsResponse) Reset()         { *m = ListAttestationsResponse{} }
+func (m *ListAttestationsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAttestationsResponse) ProtoMessage()    {}
+func (*ListAttestationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8ce634b51eec8241, []int{12}
+}
+func (m *ListAttestationsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListAttestationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListAttestationsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListAttestationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAttestationsResponse.Merge(m, src)
+}
+func (m *ListAttestationsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListAttestationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAttestationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAttestationsResponse proto.InternalMessageInfo
+
+func (m *ListAttestationsResponse) GetAttestations() []Attestation {
+	if m != nil {
+		return m.Attestations
+	}
+	return nil
+}
+
+func (m *ListAttestationsResponse) GetNextHeight() uint64 {
+	if m != nil {
+		return m.NextHeight
+	}
+	return 0
+}
+
+type SubscribeAttestationsRequest struct {
+	// the chains to stream the attestations of, all chains if empty
+	ChainIds []string `protobuf:"bytes,1,rep,name=chain_ids,json=chainIds,proto3" json:"chain_ids,omitempty"`
+}
+
+func (m *SubscribeAttestationsRequest) Reset()         { *m = SubscribeAttestationsRequest{} }
+func (m *SubscribeAttestationsRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeAttestationsRequest) ProtoMessage()    {}
+func (*SubscribeAttestationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8ce634b51eec8241, []int{13}
+}
+func (m *SubscribeAttestationsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeAttestationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeAttestationsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribeAttestationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeAttestationsRequest.Merge(m, src)
+}
+func (m *SubscribeAttestationsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeAttestationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeAttestationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeAttestationsRequest proto.InternalMessageInfo
+
+func (m *SubscribeAttestationsRequest) GetChainIds() []string {
+	if m != nil {
+		return m.ChainIds
+	}
+	return nil
+}
+
+type SubscribeAttestationsResponse struct {
+	Attestation Attestation `protobuf:"bytes,1,opt,name=attestation,proto3" json:"attestation"`
+}
+
+func (m *SubscribeAttestationsResponse) Reset()         { *m = SubscribeAttestationsResponse{} }
+func (m *SubscribeAttestationsResponse) String() string { return proto.CompactTextString(m) }
+func (*SubscribeAttestationsResponse) ProtoMessage()    {}
+func (*SubscribeAttestationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8ce634b51eec8241, []int{14}
+}
+func (m *SubscribeAttestationsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeAttestationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeAttestationsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribeAttestationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeAttestationsResponse.Merge(m, src)
+}
+func (m *SubscribeAttestationsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeAttestationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeAttestationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeAttestationsResponse proto.InternalMessageInfo
+
+func (m *SubscribeAttestationsResponse) GetAttestation() Attestation {
+	if m != nil {
+		return m.Attestation
+	}
+	return Attestation{}
+}
+
+func init() {
+	proto.RegisterType((*GetAttestationsRequest)(nil), "core.sidecar.v1.GetAttestationsRequest")
+	proto.RegisterType((*GetAttestationsResponse)(nil), "core.sidecar.v1.GetAttestationsResponse")
+	proto.RegisterType((*GetChannelTopologyRequest)(nil), "core.sidecar.v1.GetChannelTopologyRequest")
+	proto.RegisterType((*GetChannelTopologyResponse)(nil), "core.sidecar.v1.GetChannelTopologyResponse")
+	proto.RegisterType((*ChannelTopology)(nil), "core.sidecar.v1.ChannelTopology")
+	proto.RegisterType((*TopologyChannel)(nil), "core.sidecar.v1.TopologyChannel")
+	proto.RegisterType((*GetAttestationRequest)(nil), "core.sidecar.v1.GetAttestationRequest")
+	proto.RegisterType((*GetAttestationResponse)(nil), "core.sidecar.v1.GetAttestationResponse")
+	proto.RegisterType((*ListChainsRequest)(nil), "core.sidecar.v1.ListChainsRequest")
+	proto.RegisterType((*ListChainsResponse)(nil), "core.sidecar.v1.ListChainsResponse")
+	proto.RegisterType((*ChainStatus)(nil), "core.sidecar.v1.ChainStatus")
+	proto.RegisterType((*ListAttestationsRequest)(nil), "core.sidecar.v1.ListAttestationsRequest")
+	proto.RegisterType((*ListAttestationsResponse)(nil), "core.sidecar.v1.ListAttestationsResponse")
+	proto.RegisterType((*SubscribeAttestationsRequest)(nil), "core.sidecar.v1.SubscribeAttestationsRequest")
+	proto.RegisterType((*SubscribeAttestationsResponse)(nil), "core.sidecar.v1.SubscribeAttestationsResponse")
+}
+
+func init() { proto.RegisterFile("core/sidecar/v1/sidecar.proto", fileDescriptor_8ce634b51eec8241) }
+
+var fileDescriptor_8ce634b51eec8241 = []byte{
+	// 910 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xf7, 0x36, 0x89, 0x63, 0x3f, 0x3b, 0x71, 0x99, 0xa6, 0x8d, 0xbb, 0x34, 0x4e, 0xd8, 0x0a,
+	0xe2, 0x82, 0xba, 0x26, 0xad, 0xc4, 0x01, 0x4e, 0xb8, 0xd0, 0x62, 0x04, 0x12, 0xda, 0x54, 0x42,
+	0x42, 0x48, 0xd6, 0x7a, 0x76, 0xb2, 0x1e, 0x69, 0x3d, 0x63, 0x76, 0xc6, 0x11, 0xe5, 0xc6, 0x89,
+	0x0b, 0x87, 0x7e, 0x00, 0x3e, 0x04, 0x1f, 0xa3, 0xc7, 0x1c, 0x39, 0x01, 0x4a, 0xbe, 0x48, 0x35,
+	0x7f, 0x76, 0xbd, 0xb1, 0x37, 0x71, 0x0e, 0xbd, 0x79, 0xde, 0xfb, 0xfd, 0xe6, 0xfd, 0xf6, 0xcd,
+	0x6f, 0xde, 0x18, 0xf6, 0x30, 0x4f, 0x49, 0x4f, 0xd0, 0x88, 0xe0, 0x30, 0xed, 0x9d, 0x1e, 0x65,
+	0x3f, 0xfd, 0x69, 0xca, 0x25, 0x47, 0x2d, 0x95, 0xf6, 0xb3, 0xd8, 0xe9, 0x91, 0xbb, 0x13, 0xf3,
+	0x98, 0xeb, 0x5c, 0x4f, 0xfd, 0x32, 0x30, 0x77, 0x3f, 0xe6, 0x3c, 0x4e, 0x48, 0x4f, 0xaf, 0x46,
+	0xb3, 0x93, 0x9e, 0xa4, 0x13, 0x22, 0x64, 0x38, 0x99, 0x66, 0x00, 0x5d, 0x46, 0xbe, 0x9a, 0x12,
+	0xa1, 0x8a, 0x84, 0x52, 0xaa, 0xb4, 0xa4, 0x9c, 0x19, 0x80, 0xd7, 0x86, 0x7b, 0x2f, 0x88, 0xfc,
+	0x72, 0x1e, 0x17, 0x01, 0xf9, 0x65, 0x46, 0x84, 0xf4, 0x86, 0xb0, 0xbb, 0x94, 0x11, 0x53, 0xce,
+	0x04, 0x41, 0x5f, 0x41, 0xb3, 0xb0, 0x93, 0x68, 0x3b, 0x07, 0x6b, 0xdd, 0xc6, 0x13, 0xd7, 0xd7,
+	0xa2, 0x75, 0x31, 0xff, 0xf4, 0xc8, 0x2f, 0x50, 0xfb, 0xeb, 0x6f, 0xfe, 0xdd, 0xaf, 0x04, 0x97,
+	0x58, 0xde, 0x67, 0x70, 0xff, 0x05, 0x91, 0xcf, 0xc6, 0x21, 0x63, 0x24, 0x79, 0xc9, 0xa7, 0x3c,
+	0xe1, 0xf1, 0x2b, 0x5b, 0x1d, 0xdd, 0x87, 0x1a, 0x1e, 0x87, 0x94, 0x0d, 0x69, 0xd4, 0x76, 0x0e,
+	0x9c, 0x6e, 0x3d, 0xd8, 0xd4, 0xeb, 0x41, 0xe4, 0xfd, 0xe5, 0x80, 0x5b, 0x46, 0xb4, 0xe2, 0xae,
+	0x66, 0xa2, 0xf7, 0xa1, 0x8e, 0x13, 0x4a, 0x98, 0x54, 0xb9, 0x5b, 0x3a, 0x57, 0x33, 0x81, 0x41,
+	0x84, 0x9e, 0x03, 0x48, 0xb3, 0x17, 0x25, 0xa2, 0xbd, 0xa6, 0x3f, 0xe9, 0xc0, 0x5f, 0x38, 0x07,
+	0x7f, 0xa1, 0xaa, 0xfd, 0xb0, 0x02, 0xd3, 0xfb, 0xdb, 0x81, 0xd6, 0x02, 0x0a, 0xb9, 0x50, 0x23,
+	0x2c, 0x9a, 0x72, 0xca, 0xa4, 0xd5, 0x94, 0xaf, 0xd1, 0x3d, 0xa8, 0x8e, 0x09, 0x8d, 0xc7, 0x52,
+	0x2b, 0x5a, 0x0f, 0xec, 0x0a, 0x7d, 0x08, 0xdb, 0x98, 0x33, 0x46, 0xb0, 0xea, 0xd6, 0x90, 0x46,
+	0x46, 0x53, 0x3d, 0xd8, 0x9a, 0x47, 0x07, 0x91, 0x40, 0x7d, 0xfd, 0xb9, 0xaa, 0x9a, 0x68, 0xaf,
+	0x5f, 0x21, 0x3a, 0xd3, 0x61, 0x65, 0x59, 0xd1, 0x39, 0xcf, 0x63, 0xd0, 0x5a, 0x80, 0xa0, 0x5d,
+	0xd8, 0x9c, 0xf2, 0x54, 0xce, 0x9b, 0x58, 0x55, 0xcb, 0x41, 0x84, 0xf6, 0x00, 0x2c, 0x6f, 0xde,
+	0xc4, 0xba, 0x8d, 0x0c, 0x22, 0xf4, 0x10, 0xb6, 0x2e, 0xa9, 0x6e, 0xaf, 0x69, 0x44, 0xb3, 0x28,
+	0xda, 0xfb, 0x16, 0xee, 0x5e, 0xb6, 0xd6, 0xea, 0x53, 0xbf, 0xaa, 0x4d, 0xde, 0xcf, 0x8b, 0x06,
+	0xce, 0x8d, 0xd0, 0x87, 0x46, 0xc1, 0x6f, 0x7a, 0xbf, 0x9b, 0x98, 0xb4, 0x48, 0xf2, 0xee, 0xc0,
+	0x7b, 0xdf, 0x51, 0xa1, 0xbc, 0x46, 0xe7, 0x37, 0xe3, 0x07, 0x40, 0xc5, 0xa0, 0x2d, 0xf7, 0x39,
+	0x54, 0xb5, 0xd6, 0xec, 0x3a, 0x3c, 0x28, 0xf3, 0x0e, 0x65, 0xc7, 0x32, 0x94, 0x33, 0x61, 0x6b,
+	0x59, 0x86, 0x77, 0x76, 0x0b, 0x1a, 0x85, 0xec, 0xca, 0x3e, 0x84, 0x89, 0x1c, 0xdb, 0xde, 0xdb,
+	0x15, 0x3a, 0x82, 0x1d, 0xac, 0x74, 0xe0, 0x99, 0xa4, 0xa7, 0x64, 0x78, 0x12, 0xd2, 0x64, 0x96,
+	0x6a, 0x23, 0x3b, 0xdd, 0xad, 0xe0, 0x4e, 0x21, 0xf7, 0xdc, 0xa6, 0xd0, 0x33, 0x68, 0x26, 0xa1,
+	0x90, 0x43, 0x31, 0xc3, 0x98, 0x08, 0x65, 0x1f, 0xd3, 0x21, 0x33, 0x54, 0xfc, 0x6c, 0xa8, 0xf8,
+	0x2f, 0xb3, 0xa1, 0xd2, 0x5f, 0x7f, 0xfd, 0xdf, 0xbe, 0x13, 0x34, 0x14, 0xeb, 0xd8, 0x90, 0x94,
+	0x1f, 0xf4, 0x26, 0x24, 0x4d, 0x79, 0xda, 0xde, 0x30, 0x7e, 0x50, 0x91, 0xaf, 0x55, 0x40, 0xf9,
+	0x21, 0x09, 0x25, 0x11, 0x72, 0x68, 0x4f, 0xaf, 0xaa, 0x4f, 0xaf, 0x69, 0x82, 0xdf, 0x18, 0xab,
+	0x3f, 0x82, 0xdb, 0x98, 0x27, 0x09, 0xc1, 0x92, 0x44, 0x19, 0x6e, 0x53, 0xe3, 0x5a, 0x79, 0xdc,
+	0x42, 0x0f, 0xa1, 0x95, 0x84, 0x71, 0x4c, 0x59, 0x6c, 0x81, 0xa2, 0x5d, 0xd3, 0xc8, 0x6d, 0x1b,
+	0x36, 0x38, 0xe1, 0xfd, 0xe9, 0xc0, 0xae, 0x3a, 0xa5, 0x92, 0xd1, 0x76, 0x5d, 0x7b, 0x3f, 0x80,
+	0xa6, 0x90, 0x61, 0x9a, 0xcb, 0x35, 0x66, 0x6b, 0xe8, 0x98, 0x95, 0xb0, 0x07, 0x40, 0x58, 0xae,
+	0x73, 0x4d, 0x03, 0xea, 0x84, 0x65, 0x0a, 0x77, 0x60, 0x23, 0xa1, 0x13, 0x2a, 0x75, 0x3b, 0xb7,
+	0x02, 0xb3, 0xf0, 0x7e, 0x77, 0xa0, 0xbd, 0x2c, 0xe7, 0x5d, 0xce, 0x53, 0xb4, 0x0f, 0x0d, 0x46,
+	0x7e, 0x5d, 0x50, 0x0e, 0x2a, 0x64, 0x94, 0x79, 0x5f, 0xc0, 0x83, 0xe3, 0xd9, 0x48, 0xe0, 0x94,
+	0x8e, 0x48, 0x59, 0x5b, 0xd4, 0x78, 0xb4, 0x6d, 0x31, 0x1a, 0xea, 0x41, 0xcd, 0xf6, 0x45, 0x78,
+	0x18, 0xf6, 0xae, 0x20, 0xbf, 0xbb, 0xeb, 0xf6, 0xe4, 0x8f, 0x0d, 0xd8, 0x3c, 0x36, 0x17, 0x06,
+	0x9d, 0x40, 0x6b, 0xe1, 0xfd, 0x41, 0x87, 0x4b, 0x57, 0xaa, 0xfc, 0xed, 0x72, 0xbb, 0xab, 0x81,
+	0x46, 0xb5, 0x57, 0x41, 0x1c, 0xd0, 0xf2, 0x6b, 0x82, 0x3e, 0x2e, 0xdb, 0xa1, 0xfc, 0xad, 0x72,
+	0x3f, 0xb9, 0x11, 0x36, 0x2f, 0x88, 0x61, 0xfb, 0xb2, 0x1a, 0xf4, 0xd1, 0x0a, 0xb9, 0x59, 0xa1,
+	0xc3, 0x95, 0xb8, 0xbc, 0xc8, 0x8f, 0x00, 0xf3, 0x19, 0x85, 0xbc, 0x25, 0xe2, 0xd2, 0x54, 0x73,
+	0x1f, 0x5e, 0x8b, 0xc9, 0x37, 0xa6, 0x70, 0x7b, 0xd1, 0xc7, 0xa8, 0x5b, 0x4a, 0x2d, 0x3b, 0x98,
+	0x47, 0x37, 0x40, 0xe6, 0xa5, 0x7e, 0x83, 0xbb, 0xa5, 0x96, 0x43, 0x8f, 0x97, 0x76, 0xb9, 0xce,
+	0xd7, 0xae, 0x7f, 0x53, 0x78, 0x56, 0xf9, 0x53, 0xa7, 0xff, 0xfd, 0x9b, 0xf3, 0x8e, 0x73, 0x76,
+	0xde, 0x71, 0xfe, 0x3f, 0xef, 0x38, 0xaf, 0x2f, 0x3a, 0x95, 0xb3, 0x8b, 0x4e, 0xe5, 0x9f, 0x8b,
+	0x4e, 0xe5, 0xa7, 0xa7, 0x31, 0x95, 0xe3, 0xd9, 0xc8, 0xc7, 0x7c, 0xd2, 0xc3, 0x5c, 0x4c, 0xb8,
+	0xe8, 0x51, 0x26, 0x49, 0xaa, 0x6f, 0xca, 0xe3, 0x82, 0x9b, 0x7b, 0xf3, 0xff, 0x5e, 0xa3, 0xaa,
+	0x1e, 0xa6, 0x4f, 0xdf, 0x0e, 0x00, 0x1e, 0x98, 0x32, 0x4e, 0xf7, 0x09, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SidecarClient is the client API for Sidecar service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SidecarClient interface {
+	GetAttestations(ctx context.Context, in *GetAttestationsRequest, opts ...grpc.CallOption) (*GetAttestationsResponse, error)
+	// GetChannelTopology returns the connections and open channels of the
+	// attested client of a chain, as cached by each of its rpc endpoints
+	GetChannelTopology(ctx context.Context, in *GetChannelTopologyRequest, opts ...grpc.CallOption) (*GetChannelTopologyResponse, error)
+	// GetAttestation returns the attestation of a chain at a height
+	GetAttestation(ctx context.Context, in *GetAttestationRequest, opts ...grpc.CallOption) (*GetAttestationResponse, error)
+	// ListChains returns the status of every chain the sidecar attests to
+	ListChains(ctx context.Context, in *ListChainsRequest, opts ...grpc.CallOption) (*ListChainsResponse, error)
+	// ListAttestations returns the attestations of a chain in order of height,
+	// a page at a time
+	ListAttestations(ctx context.Context, in *ListAttestationsRequest, opts ...grpc.CallOption) (*ListAttestationsResponse, error)
+	// SubscribeAttestations streams every attestation signed after the
+	// subscription started
+	SubscribeAttestations(ctx context.Context, in *SubscribeAttestationsRequest, opts ...grpc.CallOption) (Sidecar_SubscribeAttestationsClient, error)
+}
+
+type sidecarClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewSidecarClient(cc grpc1.ClientConn) SidecarClient {
+	return &sidecarClient{cc}
+}
+
+func (c *sidecarClient) GetAttestations(ctx context.Context, in *GetAttestationsRequest, opts ...grpc.CallOption) (*GetAttestationsResponse, error) {
+	out := new(GetAttestationsResponse)
+	err := c.cc.Invoke(ctx, "/core.sidecar.v1.Sidecar/GetAttestations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sidecarClient) GetChannelTopology(ctx context.Context, in *GetChannelTopologyRequest, opts ...grpc.CallOption) (*GetChannelTopologyResponse, error) {
+	out := new(GetChannelTopologyResponse)
+	err := c.cc.Invoke(ctx, "/core.sidecar.v1.Sidecar/GetChannelTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sidecarClient) GetAttestation(ctx context.Context, in *GetAttestationRequest, opts ...grpc.CallOption) (*GetAttestationResponse, error) {
+	out := new(GetAttestationResponse)
+	err := c.cc.Invoke(ctx, "/core.sidecar.v1.Sidecar/GetAttestation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sidecarClient) ListChains(ctx context.Context, in *ListChainsRequest, opts ...grpc.CallOption) (*ListChainsResponse, error) {
+	out := new(ListChainsResponse)
+	err := c.cc.Invoke(ctx, "/core.sidecar.v1.Sidecar/ListChains", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sidecarClient) ListAttestations(ctx context.Context, in *ListAttestationsRequest, opts ...grpc.CallOption) (*ListAttestationsResponse, error) {
+	out := new(ListAttestationsResponse)
+	err := c.cc.Invoke(ctx, "/core.sidecar.v1.Sidecar/ListAttestations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sidecarClient) SubscribeAttestations(ctx context.Context, in *SubscribeAttestationsRequest, opts ...grpc.CallOption) (Sidecar_SubscribeAttestationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Sidecar_serviceDesc.Streams[0], "/core.sidecar.v1.Sidecar/SubscribeAttestations", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sidecarSubscribeAttestationsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Sidecar_SubscribeAttestationsClient interface {
+	Recv() (*SubscribeAttestationsResponse, error)
+	grpc.ClientStream
+}
+
+type sidecarSubscribeAttestationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sidecarSubscribeAttestationsClient) Recv() (*SubscribeAttestationsResponse, error) {
+	m := new(SubscribeAttestationsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SidecarServer is the server API for Sidecar service.
+type SidecarServer interface {
+	GetAttestations(context.Context, *GetAttestationsRequest) (*GetAttestationsResponse, error)
+	// GetChannelTopology returns the connections and open channels of the
+	// attested client of a chain, as cached by each of its rpc endpoints
+	GetChannelTopology(context.Context, *GetChannelTopologyRequest) (*GetChannelTopologyResponse, error)
+	// GetAttestation returns the attestation of a chain at a height
+	GetAttestation(context.Context, *GetAttestationRequest) (*GetAttestationResponse, error)
+	// ListChains returns the status of every chain the sidecar attests to
+	ListChains(context.Context, *ListChainsRequest) (*ListChainsResponse, error)
+	// ListAttestations returns the attestations of a chain in order of height,
+	// a page at a time
+	ListAttestations(context.Context, *ListAttestationsRequest) (*ListAttestationsResponse, error)
+	// SubscribeAttestations streams every attestation signed after the
+	// subscription started
+	SubscribeAttestations(*SubscribeAttestationsRequest, Sidecar_SubscribeAttestationsServer) error
+}
+
+// UnimplementedSidecarServer can be embedded to have forward compatible implementations.
+type UnimplementedSidecarServer struct {
+}
+
+func (*UnimplementedSidecarServer) GetAttestations(ctx context.Context, req *GetAttestationsRequest) (*GetAttestationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttestations not implemented")
+}
+func (*UnimplementedSidecarServer) GetChannelTopology(ctx context.Context, req *GetChannelTopologyRequest) (*GetChannelTopologyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChannelTopology not implemented")
+}
+func (*UnimplementedSidecarServer) GetAttestation(ctx context.Context, req *GetAttestationRequest) (*GetAttestationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttestation not implemented")
+}
+func (*UnimplementedSidecarServer) ListChains(ctx context.Context, req *ListChainsRequest) (*ListChainsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChains not implemented")
+}
+func (*UnimplementedSidecarServer) ListAttestations(ctx context.Context, req *ListAttestationsRequest) (*ListAttestationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttestations not implemented")
+}
+func (*UnimplementedSidecarServer) SubscribeAttestations(req *SubscribeAttestationsRequest, srv Sidecar_SubscribeAttestationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeAttestations not implemented")
+}
+
+func RegisterSidecarServer(s grpc1.Server, srv SidecarServer) {
+	s.RegisterService(&_Sidecar_serviceDesc, srv)
+}
+
+func _Sidecar_GetAttestations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttestationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SidecarServer).GetAttestations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/core.sidecar.v1.Sidecar/GetAttestations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SidecarServer).GetAttestations(ctx, req.(*GetAttestationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sidecar_GetChannelTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChannelTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SidecarServer).GetChannelTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/core.sidecar.v1.Sidecar/GetChannelTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SidecarServer).GetChannelTopology(ctx, req.(*GetChannelTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sidecar_GetAttestation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttestationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SidecarServer).GetAttestation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/core.sidecar.v1.Sidecar/GetAttestation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SidecarServer).GetAttestation(ctx, req.(*GetAttestationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sidecar_ListChains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SidecarServer).ListChains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/core.sidecar.v1.Sidecar/ListChains",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SidecarServer).ListChains(ctx, req.(*ListChainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sidecar_ListAttestations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttestationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SidecarServer).ListAttestations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/core.sidecar.v1.Sidecar/ListAttestations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SidecarServer).ListAttestations(ctx, req.(*ListAttestationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sidecar_SubscribeAttestations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeAttestationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SidecarServer).SubscribeAttestations(m, &sidecarSubscribeAttestationsServer{stream})
+}
+
+type Sidecar_SubscribeAttestationsServer interface {
+	Send(*SubscribeAttestationsResponse) error
+	grpc.ServerStream
+}
+
+type sidecarSubscribeAttestationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sidecarSubscribeAttestationsServer) Send(m *SubscribeAttestationsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Sidecar_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "core.sidecar.v1.Sidecar",
+	HandlerType: (*SidecarServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAttestations",
+			Handler:    _Sidecar_GetAttestations_Handler,
+		},
+		{
+			MethodName: "GetChannelTopology",
+			Handler:    _Sidecar_GetChannelTopology_Handler,
+		},
+		{
+			MethodName: "GetAttestation",
+			Handler:    _Sidecar_GetAttestation_Handler,
+		},
+		{
+			MethodName: "ListChains",
+			Handler:    _Sidecar_ListChains_Handler,
+		},
+		{
+			MethodName: "ListAttestations",
+			Handler:    _Sidecar_ListAttestations_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeAttestations",
+			Handler:       _Sidecar_SubscribeAttestations_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "core/sidecar/v1/sidecar.proto",
+}
+
+func (m *GetAttestationsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAttestationsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetAttestationsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetAttestationsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAttestationsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetAttestationsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Attestations) > 0 {
+		for iNdEx := len(m.Attestations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Attestations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSidecar(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetChannelTopologyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetChannelTopologyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetChannelTopologyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintSidecar(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetChannelTopologyResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetChannelTopologyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetChannelTopologyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Topologies) > 0 {
+		for iNdEx := len(m.Topologies) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Topologies[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSidecar(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.ClientId) > 0 {
+		i -= len(m.ClientId)
+		copy(dAtA[i:], m.ClientId)
+		i = encodeVarintSidecar(dAtA, i, uint64(len(m.ClientId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintSidecar(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ChannelTopology) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChannelTopology) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChannelTopology) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Channels) > 0 {
+		for iNdEx := len(m.Channels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Channels[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSidecar(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.ConnectionIds) > 0 {
+		for iNdEx := len(m.ConnectionIds) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ConnectionIds[iNdEx])
+			copy(dAtA[i:], m.ConnectionIds[iNdEx])
+			i = encodeVarintSidecar(dAtA, i, uint64(len(m.ConnectionIds[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Height != 0 {
+		i = encodeVarintSidecar(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Endpoint) > 0 {
+		i -= len(m.Endpoint)
+		copy(dAtA[i:], m.Endpoint)
+		i = encodeVarintSidecar(dAtA, i, uint64(len(m.Endpoint)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TopologyChannel) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TopologyChannel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TopologyChannel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ConnectionId) > 0 {
+		i -= len(m.ConnectionId)
+		copy(dAtA[i:], m.ConnectionId)
+		i = encodeVarintSidecar(dAtA, i, uint64(len(m.ConnectionId)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.ChannelId) > 0 {
+		i -= len(m.ChannelId)
+		copy(dAtA[i:], m.ChannelId)
+		i = encodeVarintSidecar(dAtA, i, uint64(len(m.ChannelId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.PortId) > 0 {
+		i -= len(m.PortId)
+		copy(dAtA[i:], m.PortId)
+		i = encodeVarintSidecar(dAtA, i, uint64(len(m.PortId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetAttestationRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAttestationRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetAttestationRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintSidecar(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintSidecar(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetAttestationResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAttestationResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetAttestationResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Attestation.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintSidecar(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *ListChainsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListChainsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListChainsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *ListChainsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListChainsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListChainsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Chains) > 0 {
+		for iNdEx := len(m.Chains) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Chains[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSidecar(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ChainStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChainStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChainStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LaggingHeights != 0 {
+		i = encodeVarintSidecar(dAtA, i, uint64(m.LaggingHeights))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.CollectedHeight != 0 {
+		i = encodeVarintSidecar(dAtA, i, uint64(m.CollectedHeight))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.LatestHeight != 0 {
+		i = encodeVarintSidecar(dAtA, i, uint64(m.LatestHeight))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.LastError) > 0 {
+		i -= len(m.LastError)
+		copy(dAtA[i:], m.LastError)
+		i = encodeVarintSidecar(dAtA, i, uint64(len(m.LastError)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.LastSuccess != nil {
+		n2, err2 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(*m.LastSuccess, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(*m.LastSuccess):])
+		if err2 != nil {
+			return 0, err2
+		}
+		i -= n2
+		i = encodeVarintSidecar(dAtA, i, uint64(n2))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.ConsecutiveFailures != 0 {
+		i = encodeVarintSidecar(dAtA, i, uint64(m.ConsecutiveFailures))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Health) > 0 {
+		i -= len(m.Health)
+		copy(dAtA[i:], m.Health)
+		i = encodeVarintSidecar(dAtA, i, uint64(len(m.Health)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintSidecar(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListAttestationsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListAttestationsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListAttestationsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Limit != 0 {
+		i = encodeVarintSidecar(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.EndHeight != 0 {
+		i = encodeVarintSidecar(dAtA, i, uint64(m.EndHeight))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.StartHeight != 0 {
+		i = encodeVarintSidecar(dAtA, i, uint64(m.StartHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintSidecar(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListAttestationsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListAttestationsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListAttestationsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.NextHeight != 0 {
+		i = encodeVarintSidecar(dAtA, i, uint64(m.NextHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Attestations) > 0 {
+		for iNdEx := len(m.Attestations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Attestations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSidecar(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribeAttestationsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeAttestationsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeAttestationsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChainIds) > 0 {
+		for iNdEx := len(m.ChainIds) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ChainIds[iNdEx])
+			copy(dAtA[i:], m.ChainIds[iNdEx])
+			i = encodeVarintSidecar(dAtA, i, uint64(len(m.ChainIds[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribeAttestationsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeAttestationsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeAttestationsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Attestation.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintSidecar(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintSidecar(dAtA []byte, offset int, v uint64) int {
+	offset -= sovSidecar(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *GetAttestationsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetAttestationsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Attestations) > 0 {
+		for _, e := range m.Attestations {
+			l = e.Size()
+			n += 1 + l + sovSidecar(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetChannelTopologyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovSidecar(uint64(l))
+	}
+	return n
+}
+
+func (m *GetChannelTopologyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovSidecar(uint64(l))
+	}
+	l = len(m.ClientId)
+	if l > 0 {
+		n += 1 + l + sovSidecar(uint64(l))
+	}
+	if len(m.Topologies) > 0 {
+		for _, e := range m.Topologies {
+			l = e.Size()
+			n += 1 + l + sovSidecar(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ChannelTopology) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Endpoint)
+	if l > 0 {
+		n += 1 + l + sovSidecar(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovSidecar(uint64(m.Height))
+	}
+	if len(m.ConnectionIds) > 0 {
+		for _, s := range m.ConnectionIds {
+			l = len(s)
+			n += 1 + l + sovSidecar(uint64(l))
+		}
+	}
+	if len(m.Channels) > 0 {
+		for _, e := range m.Channels {
+			l = e.Size()
+			n += 1 + l + sovSidecar(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TopologyChannel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PortId)
+	if l > 0 {
+		n += 1 + l + sovSidecar(uint64(l))
+	}
+	l = len(m.ChannelId)
+	if l > 0 {
+		n += 1 + l + sovSidecar(uint64(l))
+	}
+	l = len(m.ConnectionId)
+	if l > 0 {
+		n += 1 + l + sovSidecar(uint64(l))
+	}
+	return n
+}
+
+func (m *GetAttestationRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovSidecar(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovSidecar(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *GetAttestationResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Attestation.Size()
+	n += 1 + l + sovSidecar(uint64(l))
+	return n
+}
+
+func (m *ListChainsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ListChainsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Chains) > 0 {
+		for _, e := range m.Chains {
+			l = e.Size()
+			n += 1 + l + sovSidecar(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ChainStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovSidecar(uint64(l))
+	}
+	l = len(m.Health)
+	if l > 0 {
+		n += 1 + l + sovSidecar(uint64(l))
+	}
+	if m.ConsecutiveFailures != 0 {
+		n += 1 + sovSidecar(uint64(m.ConsecutiveFailures))
+	}
+	if m.LastSuccess != nil {
+		l = github_com_cosmos_gogoproto_types.SizeOfStdTime(*m.LastSuccess)
+		n += 1 + l + sovSidecar(uint64(l))
+	}
+	l = len(m.LastError)
+	if l > 0 {
+		n += 1 + l + sovSidecar(uint64(l))
+	}
+	if m.LatestHeight != 0 {
+		n += 1 + sovSidecar(uint64(m.LatestHeight))
+	}
+	if m.CollectedHeight != 0 {
+		n += 1 + sovSidecar(uint64(m.CollectedHeight))
+	}
+	if m.LaggingHeights != 0 {
+		n += 1 + sovSidecar(uint64(m.LaggingHeights))
+	}
+	return n
+}
+
+func (m *ListAttestationsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovSidecar(uint64(l))
+	}
+	if m.StartHeight != 0 {
+		n += 1 + sovSidecar(uint64(m.StartHeight))
+	}
+	if m.EndHeight != 0 {
+		n += 1 + sovSidecar(uint64(m.EndHeight))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovSidecar(uint64(m.Limit))
+	}
+	return n
+}
+
+func (m *ListAttestationsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Attestations) > 0 {
+		for _, e := range m.Attestations {
+			l = e.Size()
+			n += 1 + l + sovSidecar(uint64(l))
+		}
+	}
+	if m.NextHeight != 0 {
+		n += 1 + sovSidecar(uint64(m.NextHeight))
+	}
+	return n
+}
+
+func (m *SubscribeAttestationsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.ChainIds) > 0 {
+		for _, s := range m.ChainIds {
+			l = len(s)
+			n += 1 + l + sovSidecar(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SubscribeAttestationsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Attestation.Size()
+	n += 1 + l + sovSidecar(uint64(l))
+	return n
+}
+
+func sovSidecar(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozSidecar(x uint64) (n int) {
+	return sovSidecar(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *GetAttestationsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSidecar
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetAttestationsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetAttestationsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSidecar(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetAttestationsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSidecar
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetAttestationsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetAttestationsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attestations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attestations = append(m.Attestations, Attestation{})
+			if err := m.Attestations[len(m.Attestations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSidecar(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetChannelTopologyRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSidecar
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetChannelTopologyRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetChannelTopologyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSidecar(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetChannelTopologyResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSidecar
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetChannelTopologyResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetChannelTopologyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topologies", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topologies = append(m.Topologies, ChannelTopology{})
+			if err := m.Topologies[len(m.Topologies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSidecar(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChannelTopology) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSidecar
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChannelTopology: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChannelTopology: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Endpoint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionIds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConnectionIds = append(m.ConnectionIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Channels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Channels = append(m.Channels, TopologyChannel{})
+			if err := m.Channels[len(m.Channels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSidecar(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TopologyChannel) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSidecar
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TopologyChannel: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TopologyChannel: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PortId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PortId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConnectionId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSidecar(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetAttestationRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSidecar
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetAttestationRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetAttestationRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSidecar(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetAttestationResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSidecar
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetAttestationResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetAttestationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attestation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Attestation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSidecar(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListChainsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSidecar
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListChainsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListChainsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSidecar(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListChainsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSidecar
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListChainsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListChainsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chains", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Chains = append(m.Chains, ChainStatus{})
+			if err := m.Chains[len(m.Chains)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSidecar(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChainStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSidecar
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChainStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChainStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Health", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Health = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsecutiveFailures", wireType)
+			}
+			m.ConsecutiveFailures = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConsecutiveFailures |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSuccess", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LastSuccess == nil {
+				m.LastSuccess = new(time.Time)
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(m.LastSuccess, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastError", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastError = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LatestHeight", wireType)
+			}
+			m.LatestHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LatestHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CollectedHeight", wireType)
+			}
+			m.CollectedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CollectedHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LaggingHeights", wireType)
+			}
+			m.LaggingHeights = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LaggingHeights |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSidecar(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListAttestationsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSidecar
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListAttestationsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListAttestationsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				i -= size
-				i = encodeVarintSidecar(dAtA, i, uint64(size))
 			}
-			i--
-			dAtA[i] = 0xa
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartHeight", wireType)
+			}
+			m.StartHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndHeight", wireType)
+			}
+			m.EndHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSidecar(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return len(dAtA) - i, nil
-}
 
-func encodeVarintSidecar(dAtA []byte, offset int, v uint64) int {
-	offset -= sovSidecar(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
-}
-func (m *GetAttestationsRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	var l int
-	_ = l
-	return n
+	return nil
 }
-
-func (m *GetAttestationsResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Attestations) > 0 {
-		for _, e := range m.Attestations {
-			l = e.Size()
-			n += 1 + l + sovSidecar(uint64(l))
+func (m *ListAttestationsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSidecar
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListAttestationsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListAttestationsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attestations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attestations = append(m.Attestations, Attestation{})
+			if err := m.Attestations[len(m.Attestations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextHeight", wireType)
+			}
+			m.NextHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NextHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSidecar(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return n
-}
 
-func sovSidecar(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozSidecar(x uint64) (n int) {
-	return sovSidecar(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *GetAttestationsRequest) Unmarshal(dAtA []byte) error {
+func (m *SubscribeAttestationsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -341,12 +3629,44 @@ func (m *GetAttestationsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetAttestationsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SubscribeAttestationsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetAttestationsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SubscribeAttestationsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainIds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSidecar
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSidecar
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainIds = append(m.ChainIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSidecar(dAtA[iNdEx:])
@@ -368,7 +3688,7 @@ func (m *GetAttestationsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetAttestationsResponse) Unmarshal(dAtA []byte) error {
+func (m *SubscribeAttestationsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -391,15 +3711,15 @@ func (m *GetAttestationsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetAttestationsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SubscribeAttestationsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetAttestationsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SubscribeAttestationsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Attestations", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Attestation", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -426,8 +3746,7 @@ func (m *GetAttestationsResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Attestations = append(m.Attestations, Attestation{})
-			if err := m.Attestations[len(m.Attestations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Attestation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -0,0 +1,28 @@
+package voteextension
+
+import (
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var _ sdk.AnteDecorator = RejectClientUpdatesTxDecorator{}
+
+// RejectClientUpdatesTxDecorator rejects the client updates tx if it shows up in regular transaction processing
+// (e.g. submitted to the mempool). The client updates tx is only ever valid when injected by the proposer and
+// handled in PreBlocker.
+type RejectClientUpdatesTxDecorator struct{}
+
+// NewRejectClientUpdatesTxDecorator creates a new RejectClientUpdatesTxDecorator
+func NewRejectClientUpdatesTxDecorator() RejectClientUpdatesTxDecorator {
+	return RejectClientUpdatesTxDecorator{}
+}
+
+// AnteHandle implements the sdk.AnteDecorator interface
+func (d RejectClientUpdatesTxDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	if IsClientUpdatesTx(ctx.TxBytes()) {
+		return ctx, errorsmod.Wrap(ErrClientUpdatesTxNotAllowed, "client updates tx submitted as a regular transaction")
+	}
+
+	return next(ctx, tx, simulate)
+}
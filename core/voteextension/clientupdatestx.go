@@ -0,0 +1,55 @@
+package voteextension
+
+import (
+	"bytes"
+
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+const (
+	// ClientUpdatesTxVersion is the current version of the client updates tx format
+	ClientUpdatesTxVersion byte = 1
+)
+
+// ClientUpdatesTxMarker is prepended (together with the version) to the client updates tx injected by the proposer.
+// It starts with a zero byte, which is never a valid protobuf field tag, so a marked tx can never be decoded as a regular transaction.
+var ClientUpdatesTxMarker = []byte("\x00attestation/client-updates")
+
+// EncodeClientUpdatesTx creates the marked and versioned client updates tx that is injected into the proposal
+func EncodeClientUpdatesTx(cdc codec.BinaryCodec, clientUpdates *ClientUpdates) ([]byte, error) {
+	clientUpdatesBz, err := cdc.Marshal(clientUpdates)
+	if err != nil {
+		return nil, err
+	}
+
+	txBz := make([]byte, 0, len(ClientUpdatesTxMarker)+1+len(clientUpdatesBz))
+	txBz = append(txBz, ClientUpdatesTxMarker...)
+	txBz = append(txBz, ClientUpdatesTxVersion)
+	return append(txBz, clientUpdatesBz...), nil
+}
+
+// DecodeClientUpdatesTx decodes a client updates tx created with EncodeClientUpdatesTx
+func DecodeClientUpdatesTx(cdc codec.BinaryCodec, txBz []byte) (*ClientUpdates, error) {
+	if !IsClientUpdatesTx(txBz) {
+		return nil, ErrNotClientUpdatesTx
+	}
+
+	version := txBz[len(ClientUpdatesTxMarker)]
+	if version != ClientUpdatesTxVersion {
+		return nil, errorsmod.Wrapf(ErrUnsupportedClientUpdatesTx, "got version %d, expected %d", version, ClientUpdatesTxVersion)
+	}
+
+	var clientUpdates ClientUpdates
+	if err := cdc.Unmarshal(txBz[len(ClientUpdatesTxMarker)+1:], &clientUpdates); err != nil {
+		return nil, errorsmod.Wrap(ErrInvalidClientUpdatesTx, err.Error())
+	}
+
+	return &clientUpdates, nil
+}
+
+// IsClientUpdatesTx returns true if the tx bytes carry the client updates tx marker (regardless of version)
+func IsClientUpdatesTx(txBz []byte) bool {
+	return len(txBz) > len(ClientUpdatesTxMarker) && bytes.HasPrefix(txBz, ClientUpdatesTxMarker)
+}
@@ -0,0 +1,61 @@
+package voteextension_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+
+	"github.com/cosmos/interchain-attestation/core/voteextension"
+)
+
+func TestClientUpdatesTx(t *testing.T) {
+	cdc := moduletestutil.MakeTestEncodingConfig().Codec
+	clientUpdates := &voteextension.ClientUpdates{
+		ClientUpdates: []voteextension.ClientUpdate{
+			{ClientToUpdate: "10-attestation-0"},
+		},
+	}
+
+	txBz, err := voteextension.EncodeClientUpdatesTx(cdc, clientUpdates)
+	require.NoError(t, err)
+	require.True(t, voteextension.IsClientUpdatesTx(txBz))
+
+	decoded, err := voteextension.DecodeClientUpdatesTx(cdc, txBz)
+	require.NoError(t, err)
+	require.Equal(t, clientUpdates, decoded)
+
+	// unmarked client updates are not a client updates tx
+	unmarkedBz := cdc.MustMarshal(clientUpdates)
+	require.False(t, voteextension.IsClientUpdatesTx(unmarkedBz))
+	_, err = voteextension.DecodeClientUpdatesTx(cdc, unmarkedBz)
+	require.ErrorIs(t, err, voteextension.ErrNotClientUpdatesTx)
+
+	// the marker alone (without version) is not a client updates tx
+	require.False(t, voteextension.IsClientUpdatesTx(voteextension.ClientUpdatesTxMarker))
+
+	unsupportedVersionBz := append([]byte{}, txBz...)
+	unsupportedVersionBz[len(voteextension.ClientUpdatesTxMarker)]++
+	_, err = voteextension.DecodeClientUpdatesTx(cdc, unsupportedVersionBz)
+	require.ErrorIs(t, err, voteextension.ErrUnsupportedClientUpdatesTx)
+
+	invalidBz := append(append([]byte{}, txBz[:len(voteextension.ClientUpdatesTxMarker)+1]...), 0xff)
+	_, err = voteextension.DecodeClientUpdatesTx(cdc, invalidBz)
+	require.ErrorIs(t, err, voteextension.ErrInvalidClientUpdatesTx)
+}
+
+// The client updates tx can never be processed as a regular transaction (in CheckTx or when the block is executed),
+// because the tx decoder rejects it before it reaches the ante handler
+func TestClientUpdatesTxIsNotARegularTx(t *testing.T) {
+	encodingCfg := moduletestutil.MakeTestEncodingConfig()
+	txBz, err := voteextension.EncodeClientUpdatesTx(encodingCfg.Codec, &voteextension.ClientUpdates{
+		ClientUpdates: []voteextension.ClientUpdate{
+			{ClientToUpdate: "10-attestation-0"},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = encodingCfg.TxConfig.TxDecoder()(txBz)
+	require.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package voteextension
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cast"
+
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+)
+
+// app.toml keys for the sidecar config
+const (
+	FlagSidecarEnabled             = "attestation-sidecar.enabled"
+	FlagSidecarAddress             = "attestation-sidecar.address"
+	FlagSidecarFailoverAddresses   = "attestation-sidecar.failover-addresses"
+	FlagSidecarRequestTimeout      = "attestation-sidecar.request-timeout"
+	FlagSidecarMaxResponseAge      = "attestation-sidecar.max-response-age"
+	FlagSidecarMaxReconnectBackoff = "attestation-sidecar.max-reconnect-backoff"
+	FlagSidecarTLSEnabled          = "attestation-sidecar.tls-enabled"
+	FlagSidecarTLSCAFile           = "attestation-sidecar.tls-ca-file"
+	FlagSidecarTLSCertFile         = "attestation-sidecar.tls-cert-file"
+	FlagSidecarTLSKeyFile          = "attestation-sidecar.tls-key-file"
+	FlagSidecarTLSServerName       = "attestation-sidecar.tls-server-name"
+	FlagSidecarAuthToken           = "attestation-sidecar.auth-token"
+)
+
+// DefaultConfigTemplate is the app.toml template for the sidecar config. It expects the app config to have the
+// Config under a field called AttestationSidecar (with `mapstructure:"attestation-sidecar"`).
+const DefaultConfigTemplate = `
+###############################################################################
+###                   Attestation Sidecar Configuration                     ###
+###############################################################################
+
+[attestation-sidecar]
+
+# Enabled turns on fetching attestations from the sidecar in ExtendVote.
+# Only validators need to enable this.
+enabled = {{ .AttestationSidecar.Enabled }}
+
+# Address of the sidecar gRPC server, either host:port or a unix socket (unix:///path/to/sidecar.sock).
+address = "{{ .AttestationSidecar.Address }}"
+
+# Addresses of the other sidecars of a high availability group, tried in order when the sidecar at address is
+# unavailable or on standby, e.g. ["sidecar-2:6969", "sidecar-3:6969"].
+failover-addresses = [{{ range $i, $address := .AttestationSidecar.FailoverAddresses }}{{ if $i }}, {{ end }}"{{ $address }}"{{ end }}]
+
+# Deadline for a single request to the sidecar. This needs to be well below the consensus timeouts.
+request-timeout = "{{ .AttestationSidecar.RequestTimeout }}"
+
+# How old the last successful sidecar response can be and still be used if the sidecar is unavailable.
+max-response-age = "{{ .AttestationSidecar.MaxResponseAge }}"
+
+# Maximum delay between attempts to reconnect to the sidecar.
+max-reconnect-backoff = "{{ .AttestationSidecar.MaxReconnectBackoff }}"
+
+# Use TLS for the connection to the sidecar.
+tls-enabled = {{ .AttestationSidecar.TLS.Enabled }}
+
+# CA used to verify the sidecar certificate. The system roots are used if empty.
+tls-ca-file = "{{ .AttestationSidecar.TLS.CAFile }}"
+
+# Client certificate and key, for mTLS.
+tls-cert-file = "{{ .AttestationSidecar.TLS.CertFile }}"
+tls-key-file = "{{ .AttestationSidecar.TLS.KeyFile }}"
+
+# Server name used to verify the sidecar certificate (defaults to the host in the address).
+tls-server-name = "{{ .AttestationSidecar.TLS.ServerName }}"
+
+# Token sent to the sidecar with every request, if the sidecar requires one (auth_token in the sidecar config).
+auth-token = "{{ .AttestationSidecar.AuthToken }}"
+`
+
+// Config is the sidecar configuration in app.toml
+type Config struct {
+	Enabled             bool `mapstructure:"enabled"`
+	SidecarClientConfig `mapstructure:",squash"`
+}
+
+// DefaultConfig returns the default (disabled) sidecar configuration
+func DefaultConfig() Config {
+	return Config{
+		Enabled:             false,
+		SidecarClientConfig: DefaultSidecarClientConfig(),
+	}
+}
+
+// Validate checks that the config is valid. The sidecar client config is only validated if the sidecar is enabled.
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	return c.SidecarClientConfig.Validate()
+}
+
+// ReadConfig reads the sidecar configuration from app.toml, using the defaults for missing values
+func ReadConfig(appOpts servertypes.AppOptions) (Config, error) {
+	config := DefaultConfig()
+
+	var err error
+	if v := appOpts.Get(FlagSidecarEnabled); v != nil {
+		if config.Enabled, err = cast.ToBoolE(v); err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", FlagSidecarEnabled, err)
+		}
+	}
+	if v := appOpts.Get(FlagSidecarTLSEnabled); v != nil {
+		if config.TLS.Enabled, err = cast.ToBoolE(v); err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", FlagSidecarTLSEnabled, err)
+		}
+	}
+
+	for _, s := range []struct {
+		flag   string
+		target *string
+	}{
+		{FlagSidecarAddress, &config.Address},
+		{FlagSidecarTLSCAFile, &config.TLS.CAFile},
+		{FlagSidecarTLSCertFile, &config.TLS.CertFile},
+		{FlagSidecarTLSKeyFile, &config.TLS.KeyFile},
+		{FlagSidecarTLSServerName, &config.TLS.ServerName},
+		{FlagSidecarAuthToken, &config.AuthToken},
+	} {
+		if v := appOpts.Get(s.flag); v != nil {
+			if *s.target, err = cast.ToStringE(v); err != nil {
+				return Config{}, fmt.Errorf("invalid %s: %w", s.flag, err)
+			}
+		}
+	}
+
+	if v := appOpts.Get(FlagSidecarFailoverAddresses); v != nil {
+		if config.FailoverAddresses, err = cast.ToStringSliceE(v); err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", FlagSidecarFailoverAddresses, err)
+		}
+	}
+
+	for _, d := range []struct {
+		flag   string
+		target *time.Duration
+	}{
+		{FlagSidecarRequestTimeout, &config.RequestTimeout},
+		{FlagSidecarMaxResponseAge, &config.MaxResponseAge},
+		{FlagSidecarMaxReconnectBackoff, &config.MaxReconnectBackoff},
+	} {
+		if v := appOpts.Get(d.flag); v != nil && v != "" {
+			if *d.target, err = cast.ToDurationE(v); err != nil {
+				return Config{}, fmt.Errorf("invalid %s: %w", d.flag, err)
+			}
+		}
+	}
+
+	return config, config.Validate()
+}
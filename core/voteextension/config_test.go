@@ -0,0 +1,124 @@
+package voteextension_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+
+	"github.com/cosmos/interchain-attestation/core/voteextension"
+)
+
+func TestReadConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		appOpts   simtestutil.AppOptionsMap
+		expConfig func(config *voteextension.Config)
+		expError  string
+	}{
+		{
+			"defaults",
+			simtestutil.AppOptionsMap{},
+			func(_ *voteextension.Config) {},
+			"",
+		},
+		{
+			"enabled with tls",
+			simtestutil.AppOptionsMap{
+				voteextension.FlagSidecarEnabled:        true,
+				voteextension.FlagSidecarAddress:        "unix:///var/run/sidecar.sock",
+				voteextension.FlagSidecarRequestTimeout: "250ms",
+				voteextension.FlagSidecarMaxResponseAge: "1m",
+				voteextension.FlagSidecarTLSEnabled:     "true",
+				voteextension.FlagSidecarTLSCertFile:    "cert.pem",
+				voteextension.FlagSidecarTLSKeyFile:     "key.pem",
+				voteextension.FlagSidecarAuthToken:      "secret",
+			},
+			func(config *voteextension.Config) {
+				config.Enabled = true
+				config.Address = "unix:///var/run/sidecar.sock"
+				config.RequestTimeout = 250 * time.Millisecond
+				config.MaxResponseAge = time.Minute
+				config.TLS.Enabled = true
+				config.TLS.CertFile = "cert.pem"
+				config.TLS.KeyFile = "key.pem"
+				config.AuthToken = "secret"
+			},
+			"",
+		},
+		{
+			"failover addresses",
+			simtestutil.AppOptionsMap{
+				voteextension.FlagSidecarEnabled:           true,
+				voteextension.FlagSidecarAddress:           "sidecar-1:6969",
+				voteextension.FlagSidecarFailoverAddresses: []interface{}{"sidecar-2:6969", "sidecar-3:6969"},
+			},
+			func(config *voteextension.Config) {
+				config.Enabled = true
+				config.Address = "sidecar-1:6969"
+				config.FailoverAddresses = []string{"sidecar-2:6969", "sidecar-3:6969"}
+			},
+			"",
+		},
+		{
+			"disabled config is not validated",
+			simtestutil.AppOptionsMap{
+				voteextension.FlagSidecarAddress: "",
+			},
+			func(_ *voteextension.Config) {},
+			"",
+		},
+		{
+			"enabled without address",
+			simtestutil.AppOptionsMap{
+				voteextension.FlagSidecarEnabled: true,
+			},
+			nil,
+			"sidecar address cannot be empty",
+		},
+		{
+			"invalid duration",
+			simtestutil.AppOptionsMap{
+				voteextension.FlagSidecarRequestTimeout: "soon",
+			},
+			nil,
+			"invalid attestation-sidecar.request-timeout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := voteextension.ReadConfig(tt.appOpts)
+			if tt.expError != "" {
+				require.ErrorContains(t, err, tt.expError)
+				return
+			}
+			require.NoError(t, err)
+
+			expConfig := voteextension.DefaultConfig()
+			tt.expConfig(&expConfig)
+			require.Equal(t, expConfig, config)
+		})
+	}
+}
+
+func TestKeeper_UpdateConfig(t *testing.T) {
+	keeper, err := voteextension.NewKeeper(voteextension.DefaultConfig())
+	require.NoError(t, err)
+	require.False(t, keeper.SidecarEnabled())
+
+	config := voteextension.DefaultConfig()
+	config.Enabled = true
+	require.Error(t, keeper.UpdateConfig(config))
+	require.False(t, keeper.SidecarEnabled())
+
+	config.Address = "localhost:6969"
+	require.NoError(t, keeper.UpdateConfig(config))
+	require.True(t, keeper.SidecarEnabled())
+	require.Equal(t, config, keeper.Config())
+
+	require.NoError(t, keeper.Close())
+	require.False(t, keeper.SidecarEnabled())
+}
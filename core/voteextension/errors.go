@@ -0,0 +1,13 @@
+package voteextension
+
+import (
+	errorsmod "cosmossdk.io/errors"
+)
+
+// Attestation vote extension sentinel errors
+var (
+	ErrNotClientUpdatesTx         = errorsmod.Register(ModuleName, 2, "not a client updates tx")
+	ErrUnsupportedClientUpdatesTx = errorsmod.Register(ModuleName, 3, "unsupported client updates tx version")
+	ErrInvalidClientUpdatesTx     = errorsmod.Register(ModuleName, 4, "invalid client updates tx")
+	ErrClientUpdatesTxNotAllowed  = errorsmod.Register(ModuleName, 5, "client updates tx can only be injected by the block proposer")
+)
@@ -0,0 +1,9 @@
+package voteextension
+
+// Vote extension events
+const (
+	EventTypeClientUpdateFailed = "attestation_client_update_failed"
+
+	AttributeKeyClientID = "client_id"
+	AttributeKeyError    = "error"
+)
@@ -0,0 +1,100 @@
+package voteextension
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+)
+
+// Keeper holds the sidecar configuration and the connection to the sidecar.
+// It is shared by pointer, so the connection is kept between calls to the (value receiver) AppModule.
+type Keeper struct {
+	mu sync.RWMutex
+
+	config        Config
+	sidecarClient *SidecarClient // nil if the sidecar is not enabled
+}
+
+// NewKeeper creates a new Keeper. If the sidecar is enabled, the connection to the sidecar is set up right away
+// (in the background, so the sidecar does not need to be available yet).
+func NewKeeper(config Config) (*Keeper, error) {
+	k := &Keeper{}
+	if err := k.UpdateConfig(config); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// Config returns the current sidecar configuration
+func (k *Keeper) Config() Config {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.config
+}
+
+// SidecarEnabled returns true if attestations should be fetched from the sidecar
+func (k *Keeper) SidecarEnabled() bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.sidecarClient != nil
+}
+
+// UpdateConfig validates and applies a new sidecar configuration, replacing the existing connection to the sidecar
+func (k *Keeper) UpdateConfig(config Config) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	var sidecarClient *SidecarClient
+	if config.Enabled {
+		var err error
+		sidecarClient, err = NewSidecarClient(config.SidecarClientConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	k.mu.Lock()
+	oldSidecarClient := k.sidecarClient
+	k.config = config
+	k.sidecarClient = sidecarClient
+	k.mu.Unlock()
+
+	if oldSidecarClient != nil {
+		return oldSidecarClient.Close()
+	}
+
+	return nil
+}
+
+// GetAttestations gets the latest attestations from the sidecar (see SidecarClient.GetAttestations)
+func (k *Keeper) GetAttestations(ctx context.Context) (resp *types.GetAttestationsResponse, cached bool, err error) {
+	k.mu.RLock()
+	sidecarClient := k.sidecarClient
+	k.mu.RUnlock()
+
+	if sidecarClient == nil {
+		return nil, false, fmt.Errorf("sidecar not enabled")
+	}
+
+	return sidecarClient.GetAttestations(ctx)
+}
+
+// Close closes the connection to the sidecar
+func (k *Keeper) Close() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.sidecarClient == nil {
+		return nil
+	}
+
+	err := k.sidecarClient.Close()
+	k.sidecarClient = nil
+	return err
+}
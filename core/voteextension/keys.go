@@ -1,6 +0,0 @@
-package voteextension
-
-const (
-	SidecarAddressEnv    = "ATTESTATION_SIDECAR_ADDRESS"
-	SidecarConfigPathEnv = "ATTESTATION_SIDECAR_CONFIG_PATH"
-)
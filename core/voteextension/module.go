@@ -1,10 +1,10 @@
 package voteextension
 
 import (
-	"os"
+	"fmt"
+	"slices"
 
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
-	"google.golang.org/grpc"
 
 	"cosmossdk.io/core/appmodule"
 
@@ -14,17 +14,13 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
 
-	"github.com/cometbft/cometbft/libs/json"
+	"github.com/cosmos/ibc-go/v9/modules/core/exported"
 
 	lightclient "github.com/cosmos/interchain-attestation/core/lightclient"
 )
 
 const ModuleName = "attestationvoteextension"
 
-type SidecarConfig struct {
-	SidecarAddress string `json:"sidecar_address"`
-}
-
 var (
 	_ module.AppModuleBasic = (*AppModuleBasic)(nil)
 	_ appmodule.AppModule   = (*AppModule)(nil)
@@ -50,49 +46,43 @@ func (a AppModuleBasic) RegisterGRPCGatewayRoutes(context client.Context, mux *r
 type AppModule struct {
 	AppModuleBasic
 
-	// TODO: Should we just move this stuff into a keeper, or is it fine here?
-	sidecarAddress          string
+	keeper                  *Keeper
+	clientReader            AttestationClientReader
 	trustedUpdateClientFunc lightclient.TrustedClientUpdateFunc
 	cdc                     codec.Codec
+	// moduleIndex is the index of the module in the order of the vote extension modules, see WithVoteExtensionOrder
+	moduleIndex int
+}
 
-	// Create lazily
-	sidecarGrpcClient *grpc.ClientConn
+// AttestationClientReader gives read access to the attestation light clients. It is implemented by the light client module.
+// The packet commitments are used to express the packet commitments in the vote extensions as a delta,
+// and the attestator set is used to aggregate the signatures in the client updates.
+type AttestationClientReader interface {
+	PacketCommitments(ctx sdk.Context, clientID string) (exported.Height, [][]byte, error)
+	AttestatorSet(ctx sdk.Context) ([][]byte, error)
 }
 
 // NewAppModule creates a new attestation vote extension AppModule
-func NewAppModule(trustedUpdateClientFunc lightclient.TrustedClientUpdateFunc, cdc codec.Codec) AppModule {
-	sidecarAddress := os.Getenv(SidecarAddressEnv)
-
+func NewAppModule(keeper *Keeper, clientReader AttestationClientReader, trustedUpdateClientFunc lightclient.TrustedClientUpdateFunc, cdc codec.Codec) AppModule {
 	return AppModule{
-		sidecarAddress:          sidecarAddress,
+		keeper:                  keeper,
+		clientReader:            clientReader,
 		trustedUpdateClientFunc: trustedUpdateClientFunc,
 		cdc:                     cdc,
 	}
 }
 
-func (a AppModule) GetSidecarAddress(ctx sdk.Context) string {
-	if a.sidecarAddress == "" {
-		ctx.Logger().Info("GetSidecarAddress: no sidecar address set")
-		sidecarConfigPath := os.Getenv(SidecarConfigPathEnv)
-		if sidecarConfigPath == "" {
-			ctx.Logger().Info("GetSidecarAddress: no sidecar config path set")
-			return ""
-		}
-
-		sidecarConfigBz, err := os.ReadFile(sidecarConfigPath)
-		if err != nil {
-			ctx.Logger().Error("GetSidecarAddress: failed to read sidecar config", "path", sidecarConfigPath, "error", err)
-			return ""
-		}
-		var sidecarConfig SidecarConfig
-		if err = json.Unmarshal(sidecarConfigBz, &sidecarConfig); err != nil {
-			ctx.Logger().Error("GetSidecarAddress: failed to unmarshal sidecar config", "error", err)
-			return ""
-		}
-		a.sidecarAddress = sidecarConfig.SidecarAddress
+// WithVoteExtensionOrder sets the order of the vote extension modules, as passed to the handlers of the vote extensions
+// framework. The framework passes the index of the module to ProcessProposal and PreBlocker, but not to PrepareProposal,
+// so the proposer looks it up in the order to inject the client updates tx at the same index.
+// Without it, the module is assumed to be the first vote extension module.
+func (a AppModule) WithVoteExtensionOrder(order []string) AppModule {
+	a.moduleIndex = slices.Index(order, ModuleName)
+	if a.moduleIndex < 0 {
+		panic(fmt.Sprintf("%s is missing from the vote extension order %v", ModuleName, order))
 	}
 
-	return a.sidecarAddress
+	return a
 }
 
 // IsAppModule implements the appmodule.AppModule interface.
@@ -0,0 +1,265 @@
+package voteextension
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+)
+
+const (
+	DefaultRequestTimeout      = 500 * time.Millisecond
+	DefaultMaxResponseAge      = 10 * time.Second
+	DefaultMaxReconnectBackoff = 5 * time.Second
+)
+
+// SidecarClientConfig configures the connection to the sidecar.
+// The address can either be a host:port or a unix socket (unix:///path/to/socket).
+type SidecarClientConfig struct {
+	Address string `mapstructure:"address"`
+	// FailoverAddresses are the other sidecars of a high availability group, which are tried in order when the sidecar
+	// at Address is unavailable or on standby
+	FailoverAddresses []string         `mapstructure:"failover-addresses"`
+	TLS               SidecarTLSConfig `mapstructure:",squash"`
+	// AuthToken is sent to the sidecar with every request, if the sidecar requires one
+	AuthToken string `mapstructure:"auth-token"`
+
+	// RequestTimeout is the deadline for a single GetAttestations call. Since the call happens during ExtendVote,
+	// this needs to be well below the consensus timeouts.
+	RequestTimeout time.Duration `mapstructure:"request-timeout"`
+	// MaxResponseAge is how old the last successful response can be and still be used if the sidecar is unavailable
+	MaxResponseAge time.Duration `mapstructure:"max-response-age"`
+	// MaxReconnectBackoff is the maximum delay between reconnection attempts
+	MaxReconnectBackoff time.Duration `mapstructure:"max-reconnect-backoff"`
+}
+
+// SidecarTLSConfig configures TLS (and optionally mTLS) for the connection to the sidecar
+type SidecarTLSConfig struct {
+	Enabled bool `mapstructure:"tls-enabled"`
+	// CAFile is the CA used to verify the sidecar certificate. The system roots are used if empty.
+	CAFile string `mapstructure:"tls-ca-file"`
+	// CertFile and KeyFile are the client certificate and key, used for mTLS
+	CertFile   string `mapstructure:"tls-cert-file"`
+	KeyFile    string `mapstructure:"tls-key-file"`
+	ServerName string `mapstructure:"tls-server-name"`
+}
+
+// DefaultSidecarClientConfig returns a config without an address, using the default timeouts
+func DefaultSidecarClientConfig() SidecarClientConfig {
+	return SidecarClientConfig{
+		RequestTimeout:      DefaultRequestTimeout,
+		MaxResponseAge:      DefaultMaxResponseAge,
+		MaxReconnectBackoff: DefaultMaxReconnectBackoff,
+	}
+}
+
+// Validate checks that the config is valid
+func (c SidecarClientConfig) Validate() error {
+	if c.Address == "" {
+		return fmt.Errorf("sidecar address cannot be empty")
+	}
+	seenAddresses := map[string]bool{c.Address: true}
+	for _, address := range c.FailoverAddresses {
+		if address == "" {
+			return fmt.Errorf("sidecar failover address cannot be empty")
+		}
+		if seenAddresses[address] {
+			return fmt.Errorf("duplicate sidecar address %s", address)
+		}
+		seenAddresses[address] = true
+	}
+	if c.RequestTimeout <= 0 {
+		return fmt.Errorf("request timeout must be positive")
+	}
+	if c.MaxResponseAge < 0 {
+		return fmt.Errorf("max response age cannot be negative")
+	}
+	if c.MaxReconnectBackoff <= 0 {
+		return fmt.Errorf("max reconnect backoff must be positive")
+	}
+
+	return c.TLS.Validate()
+}
+
+// Validate checks that the TLS config is valid
+func (c SidecarTLSConfig) Validate() error {
+	if !c.Enabled {
+		if c.CAFile != "" || c.CertFile != "" || c.KeyFile != "" {
+			return fmt.Errorf("tls files are set, but tls is not enabled")
+		}
+		return nil
+	}
+
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return fmt.Errorf("both tls cert file and key file must be set for mTLS")
+	}
+
+	return nil
+}
+
+func (c SidecarTLSConfig) transportCredentials() (credentials.TransportCredentials, error) {
+	if !c.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: c.ServerName,
+	}
+
+	if c.CAFile != "" {
+		caBz, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls ca file: %w", err)
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caBz) {
+			return nil, fmt.Errorf("failed to parse tls ca file %s", c.CAFile)
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// Addresses returns the address of the sidecar followed by the failover addresses
+func (c SidecarClientConfig) Addresses() []string {
+	return append([]string{c.Address}, c.FailoverAddresses...)
+}
+
+// authToken sends the auth token of the sidecar with every request. It is also sent without TLS, since the sidecar is
+// often reached over a unix socket.
+type authToken string
+
+func (t authToken) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t authToken) RequireTransportSecurity() bool {
+	return false
+}
+
+// SidecarClient is a long-lived client for the sidecar. The underlying connections are created once and
+// reconnect with backoff on their own, so they are never re-created during ExtendVote.
+// With failover addresses, the client sticks to the last sidecar that responded, and only tries the others when it fails.
+// The last successful response is kept as a fallback for when no sidecar is available.
+type SidecarClient struct {
+	mu sync.Mutex
+
+	config SidecarClientConfig
+	// conns has a connection for every address of the config, in order
+	conns []*grpc.ClientConn
+	// active is the index of the connection to try first
+	active int
+
+	lastResponse   *types.GetAttestationsResponse
+	lastResponseAt time.Time
+}
+
+// NewSidecarClient validates the config and sets up the connections to the sidecars.
+// The connections are established in the background, so the sidecars do not need to be available yet.
+func NewSidecarClient(config SidecarClientConfig) (*SidecarClient, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	creds, err := config.TLS.transportCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	backoffConfig := backoff.DefaultConfig
+	backoffConfig.MaxDelay = config.MaxReconnectBackoff
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoffConfig,
+			MinConnectTimeout: config.RequestTimeout,
+		}),
+	}
+	if config.AuthToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(authToken(config.AuthToken)))
+	}
+
+	client := &SidecarClient{config: config}
+	for _, address := range config.Addresses() {
+		conn, err := grpc.NewClient(address, opts...)
+		if err != nil {
+			_ = client.Close()
+			return nil, fmt.Errorf("failed to create sidecar client for %s: %w", address, err)
+		}
+		// Start connecting right away, so the connection is (hopefully) ready by the time we need it
+		conn.Connect()
+		client.conns = append(client.conns, conn)
+	}
+
+	return client, nil
+}
+
+// GetAttestations gets the latest attestations from the sidecar, with the configured request timeout as deadline.
+// If the request fails, the failover sidecars are tried in order, each with the request timeout as deadline. A sidecar
+// on standby fails right away, so failing over to the signing sidecar only takes long if sidecars are unreachable.
+// If every request fails, the last successful response is returned instead (with cached set to true) as long as it is
+// not older than the configured max response age.
+func (c *SidecarClient) GetAttestations(ctx context.Context) (resp *types.GetAttestationsResponse, cached bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.conns {
+		index := (c.active + i) % len(c.conns)
+		resp, err = c.getAttestations(ctx, c.conns[index])
+		if err == nil {
+			c.active = index
+			break
+		}
+	}
+	if err != nil {
+		if c.lastResponse != nil && time.Since(c.lastResponseAt) <= c.config.MaxResponseAge {
+			return c.lastResponse, true, nil
+		}
+
+		return nil, false, err
+	}
+
+	c.lastResponse = resp
+	c.lastResponseAt = time.Now()
+
+	return resp, false, nil
+}
+
+func (c *SidecarClient) getAttestations(ctx context.Context, conn *grpc.ClientConn) (*types.GetAttestationsResponse, error) {
+	callCtx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+	defer cancel()
+
+	return types.NewSidecarClient(conn).GetAttestations(callCtx, &types.GetAttestationsRequest{})
+}
+
+// Close closes the connections to the sidecars
+func (c *SidecarClient) Close() error {
+	var err error
+	for _, conn := range c.conns {
+		if closeErr := conn.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,254 @@
+package voteextension_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/core/voteextension"
+	"github.com/cosmos/interchain-attestation/core/voteextension/testutil"
+)
+
+func startUnixMockServer(t *testing.T) (*testutil.Server, string) {
+	t.Helper()
+
+	socketPath := filepath.Join(t.TempDir(), "sidecar.sock")
+	lis, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	server := testutil.NewServer()
+	server.Response = &types.GetAttestationsResponse{
+		Attestations: []types.Attestation{{AttestatorId: []byte("mock-attestor-id")}},
+	}
+	go func() {
+		_ = server.ServeListener(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	return server, "unix://" + socketPath
+}
+
+func TestSidecarClient_GetAttestations(t *testing.T) {
+	server, addr := startUnixMockServer(t)
+
+	config := voteextension.DefaultSidecarClientConfig()
+	config.Address = addr
+	config.MaxResponseAge = 200 * time.Millisecond
+
+	client, err := voteextension.NewSidecarClient(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	resp, cached, err := client.GetAttestations(context.Background())
+	require.NoError(t, err)
+	require.False(t, cached)
+	require.Equal(t, server.Response, resp)
+
+	// the last response is used while the sidecar is unavailable
+	server.Err = fmt.Errorf("sidecar unavailable")
+	resp, cached, err = client.GetAttestations(context.Background())
+	require.NoError(t, err)
+	require.True(t, cached)
+	require.Equal(t, server.Response, resp)
+
+	// until it is too old
+	time.Sleep(config.MaxResponseAge)
+	_, _, err = client.GetAttestations(context.Background())
+	require.ErrorContains(t, err, "sidecar unavailable")
+
+	// and a successful response is used again once the sidecar is back
+	server.Err = nil
+	_, cached, err = client.GetAttestations(context.Background())
+	require.NoError(t, err)
+	require.False(t, cached)
+}
+
+func TestSidecarClient_Failover(t *testing.T) {
+	first, firstAddr := startUnixMockServer(t)
+	second, secondAddr := startUnixMockServer(t)
+	second.Response = &types.GetAttestationsResponse{
+		Attestations: []types.Attestation{{AttestatorId: []byte("failover-attestor-id")}},
+	}
+
+	config := voteextension.DefaultSidecarClientConfig()
+	config.Address = firstAddr
+	config.FailoverAddresses = []string{secondAddr}
+	config.MaxResponseAge = 0
+
+	client, err := voteextension.NewSidecarClient(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	resp, _, err := client.GetAttestations(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, first.Response, resp)
+
+	// the first sidecar goes on standby, so the client fails over to the second one
+	first.Err = status.Error(codes.Unavailable, "sidecar is on standby")
+	resp, cached, err := client.GetAttestations(context.Background())
+	require.NoError(t, err)
+	require.False(t, cached)
+	require.Equal(t, second.Response, resp)
+
+	// and sticks to it, even once the first sidecar is back
+	first.Err = nil
+	resp, _, err = client.GetAttestations(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, second.Response, resp)
+
+	// until the second sidecar fails
+	second.Err = status.Error(codes.Unavailable, "sidecar is on standby")
+	resp, _, err = client.GetAttestations(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, first.Response, resp)
+
+	first.Err = status.Error(codes.Unavailable, "sidecar is on standby")
+	_, _, err = client.GetAttestations(context.Background())
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestSidecarClient_AuthToken(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "sidecar.sock")
+	lis, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	server := testutil.NewServer()
+	server.Response = &types.GetAttestationsResponse{}
+	requireToken := grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if authorization := md.Get("authorization"); len(authorization) != 1 || authorization[0] != "Bearer secret" {
+			return nil, status.Error(codes.Unauthenticated, "missing or invalid auth token")
+		}
+		return handler(ctx, req)
+	})
+	go func() {
+		_ = server.ServeListener(lis, requireToken)
+	}()
+	t.Cleanup(server.Stop)
+
+	config := voteextension.DefaultSidecarClientConfig()
+	config.Address = "unix://" + socketPath
+	config.MaxResponseAge = 0
+
+	client, err := voteextension.NewSidecarClient(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+	_, _, err = client.GetAttestations(context.Background())
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	config.AuthToken = "secret"
+	authenticatedClient, err := voteextension.NewSidecarClient(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, authenticatedClient.Close()) })
+	_, _, err = authenticatedClient.GetAttestations(context.Background())
+	require.NoError(t, err)
+}
+
+func TestSidecarClient_RequestTimeout(t *testing.T) {
+	server, addr := startUnixMockServer(t)
+	server.Delay = 200 * time.Millisecond
+
+	config := voteextension.DefaultSidecarClientConfig()
+	config.Address = addr
+	config.RequestTimeout = 50 * time.Millisecond
+
+	client, err := voteextension.NewSidecarClient(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	start := time.Now()
+	_, _, err = client.GetAttestations(context.Background())
+	require.Error(t, err)
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	require.Less(t, time.Since(start), server.Delay)
+}
+
+func TestSidecarClientConfig_Validate(t *testing.T) {
+	validConfig := voteextension.DefaultSidecarClientConfig()
+	validConfig.Address = "localhost:6969"
+
+	tests := []struct {
+		name     string
+		malleate func(config *voteextension.SidecarClientConfig)
+		expError string
+	}{
+		{
+			"valid config",
+			func(_ *voteextension.SidecarClientConfig) {},
+			"",
+		},
+		{
+			"valid tls config with client certificate",
+			func(config *voteextension.SidecarClientConfig) {
+				config.TLS = voteextension.SidecarTLSConfig{Enabled: true, CertFile: "cert.pem", KeyFile: "key.pem"}
+			},
+			"",
+		},
+		{
+			"empty address",
+			func(config *voteextension.SidecarClientConfig) {
+				config.Address = ""
+			},
+			"sidecar address cannot be empty",
+		},
+		{
+			"valid failover addresses",
+			func(config *voteextension.SidecarClientConfig) {
+				config.FailoverAddresses = []string{"localhost:6970", "unix:///var/run/sidecar.sock"}
+			},
+			"",
+		},
+		{
+			"duplicate failover address",
+			func(config *voteextension.SidecarClientConfig) {
+				config.FailoverAddresses = []string{"localhost:6970", "localhost:6969"}
+			},
+			"duplicate sidecar address localhost:6969",
+		},
+		{
+			"zero request timeout",
+			func(config *voteextension.SidecarClientConfig) {
+				config.RequestTimeout = 0
+			},
+			"request timeout must be positive",
+		},
+		{
+			"tls files without tls enabled",
+			func(config *voteextension.SidecarClientConfig) {
+				config.TLS.CAFile = "ca.pem"
+			},
+			"tls is not enabled",
+		},
+		{
+			"client certificate without key",
+			func(config *voteextension.SidecarClientConfig) {
+				config.TLS = voteextension.SidecarTLSConfig{Enabled: true, CertFile: "cert.pem"}
+			},
+			"both tls cert file and key file must be set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validConfig
+			tt.malleate(&config)
+
+			err := config.Validate()
+			if tt.expError != "" {
+				require.ErrorContains(t, err, tt.expError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -15,6 +16,10 @@ type Server struct {
 	grpcServer *grpc.Server
 
 	Response *types.GetAttestationsResponse
+	// Err is returned instead of the response if set
+	Err error
+	// Delay is applied before responding
+	Delay time.Duration
 }
 
 var _ types.SidecarServer = &Server{}
@@ -29,7 +34,12 @@ func (s *Server) Serve(listenAddr string) error {
 		return fmt.Errorf("failed to start server: %w", err)
 	}
 
-	s.grpcServer = grpc.NewServer()
+	return s.ServeListener(lis)
+}
+
+// ServeListener serves on the given listener (e.g. a unix socket)
+func (s *Server) ServeListener(lis net.Listener, opts ...grpc.ServerOption) error {
+	s.grpcServer = grpc.NewServer(opts...)
 	types.RegisterSidecarServer(s.grpcServer, s)
 	if err := s.grpcServer.Serve(lis); err != nil {
 		return err
@@ -43,5 +53,11 @@ func (s *Server) Stop() {
 }
 
 func (s *Server) GetAttestations(_ context.Context, _ *types.GetAttestationsRequest) (*types.GetAttestationsResponse, error) {
+	time.Sleep(s.Delay)
+
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
 	return s.Response, nil
 }
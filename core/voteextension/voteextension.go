@@ -1,9 +1,10 @@
 package voteextension
 
 import (
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/connectivity"
-	"google.golang.org/grpc/credentials/insecure"
+	"fmt"
+	"maps"
+	"slices"
+
 	ve "vote-extensions.dev"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -11,55 +12,72 @@ import (
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/libs/json"
 
+	clienttypes "github.com/cosmos/ibc-go/v9/modules/core/02-client/types"
+
 	attestationlightclient "github.com/cosmos/interchain-attestation/core/lightclient"
 	"github.com/cosmos/interchain-attestation/core/types"
 )
 
 var _ ve.HasVoteExtension = AppModule{}
 
-// TODO: Add checks for ctx.ConsensusParams().Abci.VoteExtensionsEnableHeight
+// voteExtensionsEnabled returns true if vote extensions are enabled at the given height
+func voteExtensionsEnabled(ctx sdk.Context, height int64) bool {
+	abciParams := ctx.ConsensusParams().Abci
+	if abciParams == nil || abciParams.VoteExtensionsEnableHeight == 0 {
+		return false
+	}
+
+	return height >= abciParams.VoteExtensionsEnableHeight
+}
+
+// clientUpdatesTxExpected returns true if a client updates tx can be injected into the proposal at the given height.
+// Vote extensions from the previous height are needed, so this is not the case at the enable height itself.
+func clientUpdatesTxExpected(ctx sdk.Context, height int64) bool {
+	return voteExtensionsEnabled(ctx, height-1)
+}
 
 // ExtendVote asks sidecar for attestations and return vote extension
 func (a AppModule) ExtendVote(ctx sdk.Context, vote *abci.RequestExtendVote) (*abci.ResponseExtendVote, error) {
 	ctx.Logger().Info("AttestationVoteExtension: ExtendVote")
 
-	sidecarAddress := a.GetSidecarAddress(ctx)
-	if sidecarAddress == "" {
-		ctx.Logger().Info("AttestationVoteExtension: ExtendVote (no sidecar address set)")
+	if !voteExtensionsEnabled(ctx, vote.Height) {
+		ctx.Logger().Info("AttestationVoteExtension: ExtendVote (vote extensions not enabled)", "height", vote.Height)
 		return &abci.ResponseExtendVote{}, nil
 	}
 
-	if a.sidecarGrpcClient == nil || a.sidecarGrpcClient.GetState() != connectivity.Ready {
-		var err error
-		a.sidecarGrpcClient, err = grpc.NewClient(sidecarAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
-		if err != nil {
-			ctx.Logger().Error("AttestationVoteExtension: ExtendVote (failed to create client)", "sidecarAddress", sidecarAddress, "error", err)
-			return &abci.ResponseExtendVote{}, nil // TODO: Should this return the error or not? We need to check what the correct handling is
-		}
+	if !a.keeper.SidecarEnabled() {
+		ctx.Logger().Info("AttestationVoteExtension: ExtendVote (sidecar not enabled)")
+		return &abci.ResponseExtendVote{}, nil
 	}
 
-	sidecarClient := types.NewSidecarClient(a.sidecarGrpcClient)
-	resp, err := sidecarClient.GetAttestations(ctx, &types.GetAttestationsRequest{})
+	resp, cached, err := a.keeper.GetAttestations(ctx)
 	if err != nil {
-		ctx.Logger().Error("AttestationVoteExtension: ExtendVote (failed to get attestations from sidecar)", "sidecarAddress", sidecarAddress, "error", err)
+		ctx.Logger().Error("AttestationVoteExtension: ExtendVote (failed to get attestations from sidecar)", "error", err)
 		return &abci.ResponseExtendVote{}, nil // TODO: Should this return the error or not? We need to check what the correct handling is
 	}
+	if cached {
+		ctx.Logger().Info("AttestationVoteExtension: ExtendVote (sidecar unavailable, using last response)")
+	}
 
 	ctx.Logger().Info("AttestationVoteExtension: ExtendVote (got attestations)", "num_attestations", len(resp.Attestations))
 	for i, attestation := range resp.Attestations {
 		ctx.Logger().Info("AttestationVoteExtension: ExtendVote (attestation)",
 			"attestation #", i+1,
 			"attestator_id", attestation.AttestatorId,
-			"height", attestation.AttestedData.Height.RevisionHeight,
-			"client_id", attestation.AttestedData.ClientId,
-			"timestamp", attestation.AttestedData.Timestamp,
-			"client_to_update", attestation.AttestedData.ClientToUpdate,
-			"num_packet_commitments", len(attestation.AttestedData.PacketCommitments),
+			"payload_type", fmt.Sprintf("%T", attestation.Payload.Data),
+			"height", attestation.Payload.AttestedHeight(),
+			"client_to_update", attestation.Payload.ClientToUpdate(),
 		)
 	}
 
+	// express the packet commitments as deltas to keep the vote extension small, regardless of the number of packets in flight
+	attestations := make([]types.Attestation, len(resp.Attestations))
+	for i, attestation := range resp.Attestations {
+		attestations[i] = a.withCommitmentsDelta(ctx, attestation)
+	}
+
 	voteExtension := &VoteExtension{
-		Attestations: resp.Attestations,
+		Attestations: attestations,
 	}
 
 	voteExtensionBz, err := a.cdc.Marshal(voteExtension)
@@ -73,6 +91,36 @@ func (a AppModule) ExtendVote(ctx sdk.Context, vote *abci.RequestExtendVote) (*a
 	}, nil
 }
 
+// withCommitmentsDelta replaces the full set of packet commitments in the attestation with a delta relative to the packet
+// commitments currently stored in the light client (and the hash of the full set). The attestation is returned unchanged
+// if the light client can't be read, or if the payload is not IBC data (other payload types always carry the full set).
+func (a AppModule) withCommitmentsDelta(ctx sdk.Context, attestation types.Attestation) types.Attestation {
+	ibcData := attestation.Payload.GetIbcDataV1()
+	if a.clientReader == nil || ibcData == nil || ibcData.CommitmentsDelta != nil {
+		return attestation
+	}
+	attestedData := *ibcData
+
+	latestHeight, packetCommitments, err := a.clientReader.PacketCommitments(ctx, attestedData.ClientToUpdate)
+	if err != nil {
+		ctx.Logger().Error("AttestationVoteExtension: ExtendVote (failed to read light client, using full packet commitments)", "client_to_update", attestedData.ClientToUpdate, "error", err)
+		return attestation
+	}
+	baseHeight, ok := latestHeight.(clienttypes.Height)
+	if !ok {
+		ctx.Logger().Error("AttestationVoteExtension: ExtendVote (unexpected height type, using full packet commitments)", "height_type", fmt.Sprintf("%T", latestHeight))
+		return attestation
+	}
+
+	commitmentsDelta := types.NewCommitmentsDelta(baseHeight, packetCommitments, attestedData.PacketCommitments)
+	attestedData.CommitmentSetHash = types.CommitmentSetHash(attestedData.PacketCommitments)
+	attestedData.CommitmentsDelta = &commitmentsDelta
+	attestedData.PacketCommitments = nil
+	attestation.Payload = types.NewIBCDataPayload(attestedData)
+
+	return attestation
+}
+
 // TODO: Document
 func (a AppModule) VerifyVote(ctx sdk.Context, req *abci.RequestVerifyVoteExtension) (*abci.ResponseVerifyVoteExtension, error) {
 	// Verify vote extension
@@ -86,14 +134,22 @@ func (a AppModule) VerifyVote(ctx sdk.Context, req *abci.RequestVerifyVoteExtens
 	}, nil
 }
 
-// TODO: Document
-// Contract: We do not need to check if vote extensions are enabled as that is dealt with by the top level handler
+// PrepareProposal collects the attestations from the vote extensions of the previous height and injects them into the
+// proposal as a client updates tx (see EncodeClientUpdatesTx). Nothing is injected before vote extensions are available.
 func (a AppModule) PrepareProposal(ctx sdk.Context, proposal *abci.RequestPrepareProposal, bytes []byte) (*abci.ResponsePrepareProposal, error) {
 	// Extract vote extensions and add "fake tx" to the proposal
 
 	ctx.Logger().Info("AttestationVoteExtension: PrepareProposal", "num_votes", len(proposal.LocalLastCommit.Votes))
 
-	clientClaims := make(map[string]*attestationlightclient.AttestationClaim)
+	if !clientUpdatesTxExpected(ctx, proposal.Height) {
+		ctx.Logger().Info("AttestationVoteExtension: PrepareProposal (no vote extensions available yet)", "height", proposal.Height)
+		return &abci.ResponsePrepareProposal{
+			Txs: proposal.Txs,
+		}, nil
+	}
+
+	// attestations are grouped by the client they update, and then by the payload they attest to
+	clientAttestations := make(map[string]map[string][]types.Attestation)
 	for _, vote := range proposal.LocalLastCommit.Votes {
 		if vote.VoteExtension == nil {
 			continue
@@ -117,66 +173,127 @@ func (a AppModule) PrepareProposal(ctx sdk.Context, proposal *abci.RequestPrepar
 		}
 
 		for _, attestation := range voteExtension.Attestations {
-			claim, ok := clientClaims[attestation.AttestedData.ClientToUpdate]
-			if !ok {
-				claim = &attestationlightclient.AttestationClaim{}
-				clientClaims[attestation.AttestedData.ClientToUpdate] = claim
+			clientID := attestation.Payload.ClientToUpdate()
+			if _, ok := clientAttestations[clientID]; !ok {
+				clientAttestations[clientID] = make(map[string][]types.Attestation)
 			}
 
-			claim.Attestations = append(claim.Attestations, attestation)
+			attestationBytes := string(types.GetAttestationSignBytes(ctx.ChainID(), attestation.Payload))
+			clientAttestations[clientID][attestationBytes] = append(clientAttestations[clientID][attestationBytes], attestation)
 		}
 	}
 
-	if len(clientClaims) == 0 {
+	if len(clientAttestations) == 0 {
 		ctx.Logger().Info("AttestationVoteExtension: PrepareProposal (no client claims)")
 		return &abci.ResponsePrepareProposal{
 			Txs: proposal.Txs,
 		}, nil
 	}
 
+	// sorted, so that the client updates tx is deterministic
+	clientIDs := slices.Sorted(maps.Keys(clientAttestations))
+
+	// the attestator set is needed to replace the signatures with an aggregate signature and a participation bitmap
+	var attestatorSet [][]byte
+	if a.clientReader != nil {
+		var err error
+		if attestatorSet, err = a.clientReader.AttestatorSet(ctx); err != nil {
+			ctx.Logger().Error("AttestationVoteExtension: PrepareProposal (failed to get attestator set, not aggregating signatures)", "error", err)
+		}
+	}
+
 	clientUpdates := ClientUpdates{
-		ClientUpdates: make([]ClientUpdate, len(clientClaims)),
+		ClientUpdates: make([]ClientUpdate, 0, len(clientIDs)),
 	}
-	i := 0
-	for clientID, claim := range clientClaims {
+	for _, clientID := range clientIDs {
+		attestations := largestAttestationGroup(clientAttestations[clientID])
+		claim, err := attestationlightclient.NewAttestationClaim(ctx.ChainID(), attestations)
+		if err != nil {
+			ctx.Logger().Error("AttestationVoteExtension: PrepareProposal (failed to create attestation claim)", "client_id", clientID, "error", err)
+			continue
+		}
+
+		if len(attestatorSet) != 0 && len(claim.Signatures) != 0 {
+			// only possible if all the attestators use BLS12-381 keys, otherwise the individual signatures are kept
+			if err := claim.AggregateSignatures(attestatorSet); err != nil {
+				ctx.Logger().Debug("AttestationVoteExtension: PrepareProposal (not aggregating signatures)", "client_id", clientID, "reason", err)
+			}
+		}
+
 		ctx.Logger().Info("AttestationVoteExtension: PrepareProposal (adding client update)",
 			"client_id", clientID,
-			"num_attestations", len(claim.Attestations),
+			"num_attestations", len(attestations),
 		)
-		clientUpdates.ClientUpdates[i] = ClientUpdate{
+		clientUpdates.ClientUpdates = append(clientUpdates.ClientUpdates, ClientUpdate{
 			ClientToUpdate:   clientID,
 			AttestationClaim: *claim,
-		}
-		i++
+		})
 	}
 
-	specialTxBz, err := a.cdc.Marshal(&clientUpdates)
+	specialTxBz, err := EncodeClientUpdatesTx(a.cdc, &clientUpdates)
 	if err != nil {
 		ctx.Logger().Error("failed to marshal client updates", "error", err)
 		return nil, err
 	}
 
-	ctx.Logger().Info("AttestationVoteExtension: PrepareProposal (adding special tx) with client updates", "num_client_updates", len(clientUpdates.ClientUpdates))
+	// the vote extension modules before this one have injected their txs at their own index
+	if len(proposal.Txs) < a.moduleIndex {
+		ctx.Logger().Error("AttestationVoteExtension: PrepareProposal (not enough txs to inject the client updates tx at the module index)", "module_index", a.moduleIndex, "num_txs", len(proposal.Txs))
+		return &abci.ResponsePrepareProposal{
+			Txs: proposal.Txs,
+		}, nil
+	}
+
+	ctx.Logger().Info("AttestationVoteExtension: PrepareProposal (adding special tx) with client updates", "num_client_updates", len(clientUpdates.ClientUpdates), "module_index", a.moduleIndex)
 
 	return &abci.ResponsePrepareProposal{
-		Txs: append([][]byte{specialTxBz}, proposal.Txs...),
+		Txs: slices.Insert(slices.Clone(proposal.Txs), a.moduleIndex, specialTxBz),
 	}, nil
 }
 
-// TODO: Document
-// Contract: We do not need to check if vote extensions are enabled as that is dealt with by the top level handler
+// largestAttestationGroup returns the largest group of attestations that attest to the same payload.
+// Ties are broken by the sign bytes of the payload, so that all proposers pick the same group.
+func largestAttestationGroup(attestationGroups map[string][]types.Attestation) []types.Attestation {
+	var largestKey string
+	var largest []types.Attestation
+	for key, attestations := range attestationGroups {
+		if len(attestations) > len(largest) || (len(attestations) == len(largest) && key < largestKey) {
+			largestKey = key
+			largest = attestations
+		}
+	}
+
+	return largest
+}
+
+// ProcessProposal rejects proposals where a client updates tx shows up anywhere but at the index (i) of this module,
+// where it is not expected (before vote extensions are available), or where it cannot be decoded.
+// TODO: Verify the attestations in the client updates tx against the vote extensions
 func (a AppModule) ProcessProposal(ctx sdk.Context, req *abci.RequestProcessProposal, i int) (*abci.ResponseProcessProposal, error) {
-	ctx.Logger().Info("AttestationVoteExtension: ProcessProposal (doing nothing atm)")
+	ctx.Logger().Info("AttestationVoteExtension: ProcessProposal")
+
+	reject := &abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}
+	for txIndex, txBz := range req.Txs {
+		if !IsClientUpdatesTx(txBz) {
+			continue
+		}
 
-	// ?
+		if txIndex != i || !clientUpdatesTxExpected(ctx, req.Height) {
+			ctx.Logger().Error("AttestationVoteExtension: ProcessProposal (unexpected client updates tx)", "tx_index", txIndex, "module_index", i, "height", req.Height)
+			return reject, nil
+		}
 
-	// TODO implement me
+		if _, err := DecodeClientUpdatesTx(a.cdc, txBz); err != nil {
+			ctx.Logger().Error("AttestationVoteExtension: ProcessProposal (invalid client updates tx)", "error", err)
+			return reject, nil
+		}
+	}
 
 	return &abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}, nil
 }
 
-// TODO: Document
-// Contract: We do not need to check if vote extensions are enabled as that is dealt with by the top level handler
+// PreBlocker applies the client updates from the client updates tx at the index (i) of this module.
+// If the proposer did not inject a client updates tx (or vote extensions are not available yet), nothing is done.
 func (a AppModule) PreBlocker(ctx sdk.Context, req *abci.RequestFinalizeBlock, i int) error {
 	// If something panics here, don't panic the app
 	defer func() {
@@ -188,22 +305,37 @@ func (a AppModule) PreBlocker(ctx sdk.Context, req *abci.RequestFinalizeBlock, i
 	// Extract "fake tx" and send an update client msg to the light client
 	ctx.Logger().Info("AttestationVoteExtension: PreBlocker")
 
-	if len(req.Txs) == 0 {
-		ctx.Logger().Info("AttestationVoteExtension: PreBlocker doing nothing (no txs)")
+	if !clientUpdatesTxExpected(ctx, req.Height) {
+		ctx.Logger().Info("AttestationVoteExtension: PreBlocker doing nothing (no vote extensions available yet)", "height", req.Height)
+		return nil
+	}
+
+	if len(req.Txs) <= i || !IsClientUpdatesTx(req.Txs[i]) {
+		ctx.Logger().Info("AttestationVoteExtension: PreBlocker doing nothing (no client updates tx)", "module_index", i)
 		return nil
 	}
 
-	specialTxBz := req.Txs[0] // TODO: use i to get our correct one
-	var clientUpdates ClientUpdates
-	if err := a.cdc.Unmarshal(specialTxBz, &clientUpdates); err != nil {
-		ctx.Logger().Error("failed to unmarshal client updates", "error", err)
+	clientUpdates, err := DecodeClientUpdatesTx(a.cdc, req.Txs[i])
+	if err != nil {
+		ctx.Logger().Error("failed to decode client updates tx", "error", err)
 		return nil
 	}
 
+	// a client update that fails is skipped (without any of its state changes), the other clients are still updated
 	for _, clientUpdate := range clientUpdates.ClientUpdates {
-		if err := a.trustedUpdateClientFunc(ctx, clientUpdate.ClientToUpdate, &clientUpdate.AttestationClaim); err != nil {
-			ctx.Logger().Error("failed to update client", "error", err, "client_id", clientUpdate.ClientToUpdate)
+		cacheCtx, writeCache := ctx.CacheContext()
+		if _, err := a.trustedUpdateClientFunc(cacheCtx, clientUpdate.ClientToUpdate, &clientUpdate.AttestationClaim); err != nil {
+			ctx.Logger().Error("AttestationVoteExtension: PreBlocker (failed to update client)", "error", err, "client_id", clientUpdate.ClientToUpdate)
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					EventTypeClientUpdateFailed,
+					sdk.NewAttribute(AttributeKeyClientID, clientUpdate.ClientToUpdate),
+					sdk.NewAttribute(AttributeKeyError, err.Error()),
+				),
+			)
+			continue
 		}
+		writeCache()
 
 		ctx.Logger().Info("AttestationVoteExtension: PreBlocker (updated client)", "client_id", clientUpdate.ClientToUpdate)
 	}
@@ -1,6 +1,7 @@
 package voteextension_test
 
 import (
+	"context"
 	fmt "fmt"
 	"os"
 	"sync"
@@ -12,17 +13,23 @@ import (
 	"golang.org/x/exp/rand"
 
 	"cosmossdk.io/log"
+	sdkmath "cosmossdk.io/math"
 	storetypes "cosmossdk.io/store/types"
 
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdktestutil "github.com/cosmos/cosmos-sdk/testutil"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/json"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 
 	clienttypes "github.com/cosmos/ibc-go/v9/modules/core/02-client/types"
 	"github.com/cosmos/ibc-go/v9/modules/core/exported"
 
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
 	lightclient "github.com/cosmos/interchain-attestation/core/lightclient"
 	"github.com/cosmos/interchain-attestation/core/types"
 	"github.com/cosmos/interchain-attestation/core/voteextension"
@@ -35,8 +42,23 @@ type VoteExtensionTestSuite struct {
 	ctx            sdk.Context
 	encodingCfg    moduletestutil.TestEncodingConfig
 	mockServer     *testutil.Server
+	keeper         *voteextension.Keeper
 	appModule      voteextension.AppModule
 	mockUpdateFunc lightclient.TrustedClientUpdateFunc
+	clientReader   *mockClientReader
+}
+
+// mockClientReader has no clients (so attestations are never expressed as a delta), and returns the attestator set set in the test
+type mockClientReader struct {
+	attestatorSet [][]byte
+}
+
+func (m *mockClientReader) PacketCommitments(_ sdk.Context, clientID string) (exported.Height, [][]byte, error) {
+	return nil, nil, fmt.Errorf("client %s not found", clientID)
+}
+
+func (m *mockClientReader) AttestatorSet(_ sdk.Context) ([][]byte, error) {
+	return m.attestatorSet, nil
 }
 
 func (s *VoteExtensionTestSuite) SetupSuite() {
@@ -55,14 +77,18 @@ func (s *VoteExtensionTestSuite) SetupSuite() {
 	}()
 	time.Sleep(1 * time.Second)
 
-	err := os.Setenv(voteextension.SidecarAddressEnv, addr)
+	config := voteextension.DefaultConfig()
+	config.Enabled = true
+	config.Address = addr
+	keeper, err := voteextension.NewKeeper(config)
 	require.NoError(s.T(), err)
+	s.keeper = keeper
 
 	s.mockServer.Response = &types.GetAttestationsResponse{
 		Attestations: []types.Attestation{
 			{
 				AttestatorId: []byte("mock-attestor-id"),
-				AttestedData: types.IBCData{
+				Payload: types.NewIBCDataPayload(types.IBCData{
 					ChainId:        "mock-chain-id",
 					ClientId:       "mock-client-id",
 					ClientToUpdate: "mock-client-to-update",
@@ -72,35 +98,47 @@ func (s *VoteExtensionTestSuite) SetupSuite() {
 						[]byte("pckt1"),
 						[]byte("pckt2"),
 					},
-				},
+				}),
 			},
 		},
 	}
 
 	s.mockUpdateFunc = nilUpdateFunc // Default to no updates, change in test if you need another
-	s.appModule = voteextension.NewAppModule(func(ctx sdk.Context, clientID string, clientMsg exported.ClientMessage) []exported.Height {
+	s.clientReader = &mockClientReader{}
+	s.appModule = voteextension.NewAppModule(keeper, s.clientReader, func(ctx sdk.Context, clientID string, clientMsg exported.ClientMessage) ([]exported.Height, error) {
 		return s.mockUpdateFunc(ctx, clientID, clientMsg)
 	}, s.encodingCfg.Codec)
 
 	testKey := storetypes.NewKVStoreKey("upgrade")
-	s.ctx = sdktestutil.DefaultContext(testKey, storetypes.NewTransientStoreKey("transient_test")).WithLogger(log.NewLogger(os.Stdout))
+	s.ctx = sdktestutil.DefaultContext(testKey, storetypes.NewTransientStoreKey("transient_test")).
+		WithChainID("hostchain-1").
+		WithLogger(log.NewLogger(os.Stdout)).
+		WithConsensusParams(cmtproto.ConsensusParams{
+			Abci: &cmtproto.ABCIParams{VoteExtensionsEnableHeight: voteExtensionsEnableHeight},
+		})
 }
 
 func TestVoteExtensionTestSuite(t *testing.T) {
 	suite.Run(t, new(VoteExtensionTestSuite))
 }
 
-var nilUpdateFunc = func(ctx sdk.Context, clientID string, clientMsg exported.ClientMessage) []exported.Height {
-	return nil
+const voteExtensionsEnableHeight = 10
+
+var nilUpdateFunc = func(ctx sdk.Context, clientID string, clientMsg exported.ClientMessage) ([]exported.Height, error) {
+	return nil, nil
 }
 
 // panicUpdateFunc can be used for tests that want to ensure the update function is never called
-var panicUpdateFunc = func(ctx sdk.Context, clientID string, clientMsg exported.ClientMessage) []exported.Height {
+var panicUpdateFunc = func(ctx sdk.Context, clientID string, clientMsg exported.ClientMessage) ([]exported.Height, error) {
 	panic("should-not-happen")
 }
 
 func (s *VoteExtensionTestSuite) TestExtendVote() {
-	responseExtendVote, err := s.appModule.ExtendVote(s.ctx, &abci.RequestExtendVote{})
+	responseExtendVote, err := s.appModule.ExtendVote(s.ctx, &abci.RequestExtendVote{Height: voteExtensionsEnableHeight - 1})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), responseExtendVote.VoteExtension)
+
+	responseExtendVote, err = s.appModule.ExtendVote(s.ctx, &abci.RequestExtendVote{Height: voteExtensionsEnableHeight})
 	require.NoError(s.T(), err)
 	require.NotEmpty(s.T(), responseExtendVote.VoteExtension)
 
@@ -109,75 +147,549 @@ func (s *VoteExtensionTestSuite) TestExtendVote() {
 	require.NoError(s.T(), err)
 	require.Len(s.T(), voteExt.Attestations, 1)
 	require.Equal(s.T(), s.mockServer.Response.Attestations[0].AttestatorId, voteExt.Attestations[0].AttestatorId)
-	require.Equal(s.T(), s.mockServer.Response.Attestations[0].AttestedData.ChainId, voteExt.Attestations[0].AttestedData.ChainId)
-	require.Equal(s.T(), s.mockServer.Response.Attestations[0].AttestedData.ClientId, voteExt.Attestations[0].AttestedData.ClientId)
-	require.Equal(s.T(), s.mockServer.Response.Attestations[0].AttestedData.ClientToUpdate, voteExt.Attestations[0].AttestedData.ClientToUpdate)
-	require.Equal(s.T(), s.mockServer.Response.Attestations[0].AttestedData.Height, voteExt.Attestations[0].AttestedData.Height)
-	require.Equal(s.T(), s.mockServer.Response.Attestations[0].AttestedData.Timestamp.UnixNano(), voteExt.Attestations[0].AttestedData.Timestamp.UnixNano())
+	require.Equal(s.T(), s.mockServer.Response.Attestations[0].Payload.GetIbcDataV1().ChainId, voteExt.Attestations[0].Payload.GetIbcDataV1().ChainId)
+	require.Equal(s.T(), s.mockServer.Response.Attestations[0].Payload.GetIbcDataV1().ClientId, voteExt.Attestations[0].Payload.GetIbcDataV1().ClientId)
+	require.Equal(s.T(), s.mockServer.Response.Attestations[0].Payload.GetIbcDataV1().ClientToUpdate, voteExt.Attestations[0].Payload.GetIbcDataV1().ClientToUpdate)
+	require.Equal(s.T(), s.mockServer.Response.Attestations[0].Payload.GetIbcDataV1().Height, voteExt.Attestations[0].Payload.GetIbcDataV1().Height)
+	require.Equal(s.T(), s.mockServer.Response.Attestations[0].Payload.GetIbcDataV1().Timestamp.UnixNano(), voteExt.Attestations[0].Payload.GetIbcDataV1().Timestamp.UnixNano())
+
+	require.Len(s.T(), voteExt.Attestations[0].Payload.GetIbcDataV1().PacketCommitments, 2)
+	require.Equal(s.T(), s.mockServer.Response.Attestations[0].Payload.GetIbcDataV1().PacketCommitments[0], voteExt.Attestations[0].Payload.GetIbcDataV1().PacketCommitments[0])
+	require.Equal(s.T(), s.mockServer.Response.Attestations[0].Payload.GetIbcDataV1().PacketCommitments[1], voteExt.Attestations[0].Payload.GetIbcDataV1().PacketCommitments[1])
+	require.Nil(s.T(), voteExt.Attestations[0].Payload.GetIbcDataV1().CommitmentsDelta)
+}
+
+// testAttestatorsHandler verifies the signatures of the attestators against their public keys, and always has enough attestations
+type testAttestatorsHandler struct {
+	pubKeys map[string]cryptotypes.PubKey
+}
+
+var _ lightclient.AttestatorsController = testAttestatorsHandler{}
+
+func (h testAttestatorsHandler) SufficientAttestations(_ context.Context, _ [][]byte) (bool, error) {
+	return true, nil
+}
+
+func (h testAttestatorsHandler) VerifySignatures(_ context.Context, signBytes []byte, attestatorIDs [][]byte, signatures [][]byte, _ []byte) error {
+	if len(signatures) != len(attestatorIDs) {
+		return fmt.Errorf("expected %d signatures, got %d", len(attestatorIDs), len(signatures))
+	}
+	for i, attestatorID := range attestatorIDs {
+		pubKey, ok := h.pubKeys[string(attestatorID)]
+		if !ok || !pubKey.VerifySignature(signBytes, signatures[i]) {
+			return fmt.Errorf("invalid signature from %s", string(attestatorID))
+		}
+	}
+
+	return nil
+}
+
+func (h testAttestatorsHandler) AttestatorSet(_ context.Context) ([][]byte, error) {
+	return nil, nil
+}
+
+func (h testAttestatorsHandler) AttestatorOperator(_ context.Context, attestatorID []byte) ([]byte, error) {
+	return attestatorID, nil
+}
+
+// TestExtendVoteAndPreBlockerWithCommitmentsDelta runs the vote extension flow against a real light client over consecutive blocks.
+// The vote extensions for a block are created before the client updates in that block are applied, so the commitments delta
+// in them is based on a height the client has already moved past when it is applied in the next block.
+func (s *VoteExtensionTestSuite) TestExtendVoteAndPreBlockerWithCommitmentsDelta() {
+	encodingCfg := moduletestutil.MakeTestEncodingConfig(lightclient.AppModuleBasic{})
+	storeKey := storetypes.NewKVStoreKey(exported.StoreKey)
+	ctx := sdktestutil.DefaultContext(storeKey, storetypes.NewTransientStoreKey("transient_lightclient")).
+		WithChainID(s.ctx.ChainID()).
+		WithConsensusParams(s.ctx.ConsensusParams())
+
+	attestatorID := []byte("attestator")
+	privKey := secp256k1.GenPrivKey()
+	attestatorsHandler := testAttestatorsHandler{pubKeys: map[string]cryptotypes.PubKey{string(attestatorID): privKey.PubKey()}}
+	lightClientModule, trustedUpdateFunc := lightclient.NewLightClientModule(encodingCfg.Codec, clienttypes.NewStoreProvider(storeKey), attestatorsHandler)
+	appModule := voteextension.NewAppModule(s.keeper, &lightClientModule, trustedUpdateFunc, encodingCfg.Codec)
+
+	clientID := fmt.Sprintf("%s-0", lightclient.ModuleName)
+	initialHeight := clienttypes.NewHeight(1, 1)
+	clientState := lightclient.NewClientState("counterparty-1", sdkmath.NewInt(100), clienttypes.Height{}, initialHeight)
+	err := lightClientModule.Initialize(ctx, clientID, encodingCfg.Codec.MustMarshal(clientState), encodingCfg.Codec.MustMarshal(lightclient.NewConsensusState(time.Now())))
+	s.Require().NoError(err)
+
+	originalResponse := s.mockServer.Response
+	defer func() { s.mockServer.Response = originalResponse }()
+
+	// extendVote makes the sidecar return a signed attestation to the given packet commitments, and returns the vote extension for it
+	extendVote := func(height int64, attestedHeight clienttypes.Height, packetCommitments [][]byte) abci.ExtendedVoteInfo {
+		payload := types.NewIBCDataPayload(types.IBCData{
+			ChainId:           clientState.ChainId,
+			ClientId:          "counterparty-client",
+			ClientToUpdate:    clientID,
+			Height:            attestedHeight,
+			Timestamp:         time.Now(),
+			PacketCommitments: packetCommitments,
+		})
+		signature, err := privKey.Sign(types.GetAttestationSignBytes(ctx.ChainID(), payload))
+		s.Require().NoError(err)
+		s.mockServer.Response = &types.GetAttestationsResponse{
+			Attestations: []types.Attestation{{AttestatorId: attestatorID, Payload: payload, Signature: signature}},
+		}
+
+		resp, err := appModule.ExtendVote(ctx, &abci.RequestExtendVote{Height: height})
+		s.Require().NoError(err)
+		ext, err := json.Marshal(map[string][]byte{voteextension.ModuleName: resp.VoteExtension})
+		s.Require().NoError(err)
+		return abci.ExtendedVoteInfo{VoteExtension: ext}
+	}
+
+	// finalizeBlock injects the client updates from the vote extensions of the previous block and applies them
+	finalizeBlock := func(height int64, vote abci.ExtendedVoteInfo) {
+		prepareResp, err := appModule.PrepareProposal(ctx, &abci.RequestPrepareProposal{
+			Height:          height,
+			LocalLastCommit: abci.ExtendedCommitInfo{Votes: []abci.ExtendedVoteInfo{vote}},
+		}, nil)
+		s.Require().NoError(err)
+		s.Require().NoError(appModule.PreBlocker(ctx, &abci.RequestFinalizeBlock{Height: height, Txs: prepareResp.Txs}, 0))
+	}
+
+	firstPacketCommitments := [][]byte{[]byte("pckt1"), []byte("pckt2")}
+	secondPacketCommitments := [][]byte{[]byte("pckt2"), []byte("pckt3")}
+	firstHeight := clienttypes.NewHeight(1, 2)
+	secondHeight := clienttypes.NewHeight(1, 3)
+
+	// block H: the vote extension is a delta from the initial client height
+	firstVote := extendVote(voteExtensionsEnableHeight+1, firstHeight, firstPacketCommitments)
+
+	// block H+1: the vote extension is created before the client is updated with the first attestation in the same block
+	secondVote := extendVote(voteExtensionsEnableHeight+2, secondHeight, secondPacketCommitments)
+	finalizeBlock(voteExtensionsEnableHeight+2, firstVote)
+
+	latestHeight, packetCommitments, err := lightClientModule.PacketCommitments(ctx, clientID)
+	s.Require().NoError(err)
+	s.Require().Equal(firstHeight, latestHeight)
+	s.Require().ElementsMatch(firstPacketCommitments, packetCommitments)
+
+	// block H+2: the delta based on the initial height is applied to the client that is now at the first height
+	finalizeBlock(voteExtensionsEnableHeight+3, secondVote)
+
+	latestHeight, packetCommitments, err = lightClientModule.PacketCommitments(ctx, clientID)
+	s.Require().NoError(err)
+	s.Require().Equal(secondHeight, latestHeight)
+	s.Require().ElementsMatch(secondPacketCommitments, packetCommitments)
+	s.Require().Empty(ctx.EventManager().Events())
+}
+
+func (s *VoteExtensionTestSuite) TestPrepareProposal() {
+	timestamp := time.Now()
+	newAttestation := func(attestatorID string, clientToUpdate string, height uint64) types.Attestation {
+		return types.Attestation{
+			AttestatorId: []byte(attestatorID),
+			Payload: types.NewIBCDataPayload(types.IBCData{
+				ChainId:           "mock-chain-id",
+				ClientId:          "mock-client-id",
+				ClientToUpdate:    clientToUpdate,
+				Height:            clienttypes.NewHeight(1, height),
+				Timestamp:         timestamp,
+				PacketCommitments: [][]byte{[]byte("pckt1")},
+			}),
+			Signature: []byte("signature-" + attestatorID),
+		}
+	}
+	newVote := func(attestations ...types.Attestation) abci.ExtendedVoteInfo {
+		voteExtensionBz := s.encodingCfg.Codec.MustMarshal(&voteextension.VoteExtension{Attestations: attestations})
+		ext, err := json.Marshal(map[string][]byte{voteextension.ModuleName: voteExtensionBz})
+		s.Require().NoError(err)
+		return abci.ExtendedVoteInfo{VoteExtension: ext}
+	}
+
+	req := &abci.RequestPrepareProposal{
+		Height: voteExtensionsEnableHeight + 1,
+		Txs:    [][]byte{[]byte("regular tx")},
+		LocalLastCommit: abci.ExtendedCommitInfo{
+			Votes: []abci.ExtendedVoteInfo{
+				newVote(newAttestation("val1", "client-b", 5), newAttestation("val1", "client-a", 10)),
+				newVote(newAttestation("val2", "client-b", 5), newAttestation("val2", "client-a", 10)),
+				newVote(newAttestation("val3", "client-a", 11)),
+				newVote(newAttestation("val4", "client-a", 10)),
+				{},
+			},
+		},
+	}
+
+	resp, err := s.appModule.PrepareProposal(s.ctx, req, nil)
+	s.Require().NoError(err)
+	s.Require().Len(resp.Txs, 2)
+	s.Require().Equal([]byte("regular tx"), resp.Txs[1])
+
+	clientUpdates, err := voteextension.DecodeClientUpdatesTx(s.encodingCfg.Codec, resp.Txs[0])
+	s.Require().NoError(err)
+	s.Require().Len(clientUpdates.ClientUpdates, 2)
+
+	// the clients are sorted, and the attested data is only included once per claim
+	clientA := clientUpdates.ClientUpdates[0]
+	s.Require().Equal("client-a", clientA.ClientToUpdate)
+	s.Require().Equal(clienttypes.NewHeight(1, 10), clientA.AttestationClaim.Payload.GetIbcDataV1().Height)
+	s.Require().Equal([][]byte{[]byte("val1"), []byte("val2"), []byte("val4")}, clientA.AttestationClaim.AttestatorIds)
+	s.Require().Equal([][]byte{[]byte("signature-val1"), []byte("signature-val2"), []byte("signature-val4")}, clientA.AttestationClaim.Signatures)
+
+	clientB := clientUpdates.ClientUpdates[1]
+	s.Require().Equal("client-b", clientB.ClientToUpdate)
+	s.Require().Equal([][]byte{[]byte("val1"), []byte("val2")}, clientB.AttestationClaim.AttestatorIds)
+
+	// nothing is injected at the enable height
+	req.Height = voteExtensionsEnableHeight
+	resp, err = s.appModule.PrepareProposal(s.ctx, req, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(req.Txs, resp.Txs)
+}
+
+// Another vote extension module that comes first in the order injects its own tx at index 0, so the client updates tx
+// is injected, checked and applied at index 1
+func (s *VoteExtensionTestSuite) TestClientUpdatesTxAfterAnotherVoteExtensionModule() {
+	order := []string{"othervoteextension", voteextension.ModuleName}
+	var updatedClients []string
+	appModule := voteextension.NewAppModule(s.keeper, s.clientReader, func(_ sdk.Context, clientID string, _ exported.ClientMessage) ([]exported.Height, error) {
+		updatedClients = append(updatedClients, clientID)
+		return nil, nil
+	}, s.encodingCfg.Codec).WithVoteExtensionOrder(order)
+
+	attestation := types.Attestation{
+		AttestatorId: []byte("val1"),
+		Payload: types.NewIBCDataPayload(types.IBCData{
+			ChainId:        "mock-chain-id",
+			ClientId:       "mock-client-id",
+			ClientToUpdate: "client-a",
+			Height:         clienttypes.NewHeight(1, 10),
+			Timestamp:      time.Now(),
+		}),
+		Signature: []byte("signature-val1"),
+	}
+	voteExtensionBz := s.encodingCfg.Codec.MustMarshal(&voteextension.VoteExtension{Attestations: []types.Attestation{attestation}})
+	ext, err := json.Marshal(map[string][]byte{voteextension.ModuleName: voteExtensionBz})
+	s.Require().NoError(err)
+
+	otherModuleTx := []byte("other vote extension module tx")
+	height := int64(voteExtensionsEnableHeight + 1)
+	prepareResp, err := appModule.PrepareProposal(s.ctx, &abci.RequestPrepareProposal{
+		Height:          height,
+		Txs:             [][]byte{otherModuleTx, []byte("regular tx")},
+		LocalLastCommit: abci.ExtendedCommitInfo{Votes: []abci.ExtendedVoteInfo{{VoteExtension: ext}}},
+	}, nil)
+	s.Require().NoError(err)
+	s.Require().Len(prepareResp.Txs, 3)
+	s.Require().Equal(otherModuleTx, prepareResp.Txs[0])
+	s.Require().True(voteextension.IsClientUpdatesTx(prepareResp.Txs[1]))
+	s.Require().Equal([]byte("regular tx"), prepareResp.Txs[2])
+
+	processResp, err := appModule.ProcessProposal(s.ctx, &abci.RequestProcessProposal{Height: height, Txs: prepareResp.Txs}, 1)
+	s.Require().NoError(err)
+	s.Require().Equal(abci.ResponseProcessProposal_ACCEPT, processResp.Status)
+
+	// a client updates tx at the index of the other module is rejected, and not applied
+	misplacedTxs := [][]byte{prepareResp.Txs[1], otherModuleTx, []byte("regular tx")}
+	processResp, err = appModule.ProcessProposal(s.ctx, &abci.RequestProcessProposal{Height: height, Txs: misplacedTxs}, 1)
+	s.Require().NoError(err)
+	s.Require().Equal(abci.ResponseProcessProposal_REJECT, processResp.Status)
+	s.Require().NoError(appModule.PreBlocker(s.ctx, &abci.RequestFinalizeBlock{Height: height, Txs: misplacedTxs}, 1))
+	s.Require().Empty(updatedClients)
+
+	s.Require().NoError(appModule.PreBlocker(s.ctx, &abci.RequestFinalizeBlock{Height: height, Txs: prepareResp.Txs}, 1))
+	s.Require().Equal([]string{"client-a"}, updatedClients)
+
+	s.Require().Panics(func() {
+		voteextension.NewAppModule(s.keeper, s.clientReader, nilUpdateFunc, s.encodingCfg.Codec).WithVoteExtensionOrder([]string{"othervoteextension"})
+	})
+}
+
+func (s *VoteExtensionTestSuite) TestPrepareProposalAggregatesSignatures() {
+	attestedData := types.IBCData{
+		ChainId:           "mock-chain-id",
+		ClientId:          "mock-client-id",
+		ClientToUpdate:    "mock-client-to-update",
+		Height:            clienttypes.NewHeight(1, 10),
+		Timestamp:         time.Now(),
+		PacketCommitments: [][]byte{[]byte("pckt1")},
+	}
+	signBytes := types.GetAttestationSignBytes(s.ctx.ChainID(), types.NewIBCDataPayload(attestedData))
 
-	require.Len(s.T(), voteExt.Attestations[0].AttestedData.PacketCommitments, 2)
-	require.Equal(s.T(), s.mockServer.Response.Attestations[0].AttestedData.PacketCommitments[0], voteExt.Attestations[0].AttestedData.PacketCommitments[0])
-	require.Equal(s.T(), s.mockServer.Response.Attestations[0].AttestedData.PacketCommitments[1], voteExt.Attestations[0].AttestedData.PacketCommitments[1])
+	var votes []abci.ExtendedVoteInfo
+	var pubKeys []*bls12381.PubKey
+	s.clientReader.attestatorSet = nil
+	defer func() { s.clientReader.attestatorSet = nil }()
+	for i := 0; i < 4; i++ {
+		privKey, err := bls12381.GenPrivKey()
+		s.Require().NoError(err)
+		signature, err := privKey.Sign(signBytes)
+		s.Require().NoError(err)
+
+		attestatorID := []byte(fmt.Sprintf("val%d", i))
+		s.clientReader.attestatorSet = append(s.clientReader.attestatorSet, attestatorID)
+		if i == 2 {
+			// one of the attestators did not attest
+			continue
+		}
+		pubKeys = append(pubKeys, privKey.PubKey().(*bls12381.PubKey))
+
+		voteExtensionBz := s.encodingCfg.Codec.MustMarshal(&voteextension.VoteExtension{Attestations: []types.Attestation{
+			{AttestatorId: attestatorID, Payload: types.NewIBCDataPayload(attestedData), Signature: signature},
+		}})
+		ext, err := json.Marshal(map[string][]byte{voteextension.ModuleName: voteExtensionBz})
+		s.Require().NoError(err)
+		votes = append(votes, abci.ExtendedVoteInfo{VoteExtension: ext})
+	}
+
+	resp, err := s.appModule.PrepareProposal(s.ctx, &abci.RequestPrepareProposal{
+		Height:          voteExtensionsEnableHeight + 1,
+		LocalLastCommit: abci.ExtendedCommitInfo{Votes: votes},
+	}, nil)
+	s.Require().NoError(err)
+	s.Require().Len(resp.Txs, 1)
+
+	clientUpdates, err := voteextension.DecodeClientUpdatesTx(s.encodingCfg.Codec, resp.Txs[0])
+	s.Require().NoError(err)
+	s.Require().Len(clientUpdates.ClientUpdates, 1)
+
+	claim := clientUpdates.ClientUpdates[0].AttestationClaim
+	s.Require().Empty(claim.AttestatorIds)
+	s.Require().Empty(claim.Signatures)
+	s.Require().Equal([]byte{0b00001011}, claim.ParticipationBitmap)
+	s.Require().True(bls12381.VerifyAggregateSignature(pubKeys, signBytes, claim.AggregateSignature))
 }
 
 func (s *VoteExtensionTestSuite) TestPreBlocker() {
 	// TODO: Add a mocked light client to test with
+	validClientUpdates := &voteextension.ClientUpdates{
+		ClientUpdates: []voteextension.ClientUpdate{
+			{
+				ClientToUpdate: "mock-client-to-update",
+				AttestationClaim: lightclient.AttestationClaim{
+					Payload:       s.mockServer.Response.Attestations[0].Payload,
+					AttestatorIds: [][]byte{s.mockServer.Response.Attestations[0].AttestatorId},
+				},
+			},
+		},
+	}
+
 	tests := []struct {
 		name           string
+		height         int64
+		txs            [][]byte
 		clientUpdates  *voteextension.ClientUpdates
 		mockUpdateFunc lightclient.TrustedClientUpdateFunc
+		expUpdate      bool
 	}{
 		{
 			"success: no attestation tx",
+			voteExtensionsEnableHeight + 1,
+			nil,
 			nil,
 			panicUpdateFunc,
+			false,
 		},
 		{
 			"success: empty attestation list in tx",
+			voteExtensionsEnableHeight + 1,
+			nil,
 			&voteextension.ClientUpdates{},
 			panicUpdateFunc,
+			false,
+		},
+		{
+			"success: client updated",
+			voteExtensionsEnableHeight + 1,
+			nil,
+			validClientUpdates,
+			nilUpdateFunc,
+			true,
+		},
+		{
+			"success: regular tx at the client updates tx index is ignored",
+			voteExtensionsEnableHeight + 1,
+			[][]byte{[]byte("regular tx")},
+			nil,
+			panicUpdateFunc,
+			false,
+		},
+		{
+			"success: unmarked client updates are ignored",
+			voteExtensionsEnableHeight + 1,
+			[][]byte{s.encodingCfg.Codec.MustMarshal(validClientUpdates)},
+			nil,
+			panicUpdateFunc,
+			false,
+		},
+		{
+			"success: client updates tx is ignored at the enable height",
+			voteExtensionsEnableHeight,
+			nil,
+			validClientUpdates,
+			panicUpdateFunc,
+			false,
 		},
 		{
 			"success: panic on update should not panic the app",
+			voteExtensionsEnableHeight + 1,
+			nil,
 			&voteextension.ClientUpdates{
 				ClientUpdates: []voteextension.ClientUpdate{
 					{
 						ClientToUpdate: "non-existent",
 						AttestationClaim: lightclient.AttestationClaim{
-							Attestations: []types.Attestation{
-								{
-									AttestatorId: []byte("whatever"),
-									AttestedData: types.IBCData{
-										ChainId:           "whateverchain",
-										ClientId:          "whateverclient",
-										ClientToUpdate:    "non-existent",
-										Height:            clienttypes.Height{},
-										Timestamp:         time.Now(),
-										PacketCommitments: [][]byte{},
-									},
-								},
-							},
+							Payload: types.NewIBCDataPayload(types.IBCData{
+								ChainId:           "whateverchain",
+								ClientId:          "whateverclient",
+								ClientToUpdate:    "non-existent",
+								Height:            clienttypes.Height{},
+								Timestamp:         time.Now(),
+								PacketCommitments: [][]byte{},
+							}),
+							AttestatorIds: [][]byte{[]byte("whatever")},
 						},
 					},
 				},
 			},
 			panicUpdateFunc,
+			true,
 		},
 	}
 
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			req := &abci.RequestFinalizeBlock{
-				Txs: [][]byte{},
+				Height: tt.height,
+				Txs:    tt.txs,
 			}
 			if tt.clientUpdates != nil {
-				txBz, err := s.encodingCfg.Codec.Marshal(tt.clientUpdates)
+				txBz, err := voteextension.EncodeClientUpdatesTx(s.encodingCfg.Codec, tt.clientUpdates)
 				s.Require().NoError(err)
 				req.Txs = append(req.Txs, txBz)
 			}
-			s.mockUpdateFunc = tt.mockUpdateFunc
+
+			updated := false
+			s.mockUpdateFunc = func(ctx sdk.Context, clientID string, clientMsg exported.ClientMessage) ([]exported.Height, error) {
+				updated = true
+				return tt.mockUpdateFunc(ctx, clientID, clientMsg)
+			}
 			err := s.appModule.PreBlocker(s.ctx, req, 0)
 			require.NoError(s.T(), err)
+			require.Equal(s.T(), tt.expUpdate, updated)
+		})
+	}
+}
+
+func (s *VoteExtensionTestSuite) TestPreBlocker_FailedClientUpdate() {
+	newClientUpdate := func(clientID string) voteextension.ClientUpdate {
+		return voteextension.ClientUpdate{
+			ClientToUpdate: clientID,
+			AttestationClaim: lightclient.AttestationClaim{
+				Payload:       s.mockServer.Response.Attestations[0].Payload,
+				AttestatorIds: [][]byte{s.mockServer.Response.Attestations[0].AttestatorId},
+			},
+		}
+	}
+	txBz, err := voteextension.EncodeClientUpdatesTx(s.encodingCfg.Codec, &voteextension.ClientUpdates{
+		ClientUpdates: []voteextension.ClientUpdate{newClientUpdate("failing-client"), newClientUpdate("valid-client")},
+	})
+	s.Require().NoError(err)
+
+	storeKey := storetypes.NewKVStoreKey("client")
+	ctx := sdktestutil.DefaultContext(storeKey, storetypes.NewTransientStoreKey("transient_client")).
+		WithChainID(s.ctx.ChainID()).
+		WithConsensusParams(s.ctx.ConsensusParams())
+	var updatedClients []string
+	s.mockUpdateFunc = func(ctx sdk.Context, clientID string, _ exported.ClientMessage) ([]exported.Height, error) {
+		ctx.KVStore(storeKey).Set([]byte(clientID), []byte("updated"))
+		if clientID == "failing-client" {
+			return nil, fmt.Errorf("invalid client message")
+		}
+		updatedClients = append(updatedClients, clientID)
+		return nil, nil
+	}
+
+	err = s.appModule.PreBlocker(ctx, &abci.RequestFinalizeBlock{Height: voteExtensionsEnableHeight + 1, Txs: [][]byte{txBz}}, 0)
+	s.Require().NoError(err)
+
+	// the failing client is skipped without its state changes, and the other client is still updated
+	s.Require().Equal([]string{"valid-client"}, updatedClients)
+	s.Require().False(ctx.KVStore(storeKey).Has([]byte("failing-client")))
+	s.Require().True(ctx.KVStore(storeKey).Has([]byte("valid-client")))
+
+	events := ctx.EventManager().Events()
+	s.Require().Len(events, 1)
+	s.Require().Equal(voteextension.EventTypeClientUpdateFailed, events[0].Type)
+	clientIDAttribute, ok := events[0].GetAttribute(voteextension.AttributeKeyClientID)
+	s.Require().True(ok)
+	s.Require().Equal("failing-client", clientIDAttribute.Value)
+}
+
+func (s *VoteExtensionTestSuite) TestProcessProposal() {
+	clientUpdatesTx, err := voteextension.EncodeClientUpdatesTx(s.encodingCfg.Codec, &voteextension.ClientUpdates{})
+	s.Require().NoError(err)
+
+	unsupportedVersionTx := append([]byte{}, voteextension.ClientUpdatesTxMarker...)
+	unsupportedVersionTx = append(unsupportedVersionTx, voteextension.ClientUpdatesTxVersion+1)
+
+	tests := []struct {
+		name      string
+		height    int64
+		txs       [][]byte
+		expStatus abci.ResponseProcessProposal_ProposalStatus
+	}{
+		{
+			"accept: no txs",
+			voteExtensionsEnableHeight + 1,
+			nil,
+			abci.ResponseProcessProposal_ACCEPT,
+		},
+		{
+			"accept: client updates tx at the client updates tx index",
+			voteExtensionsEnableHeight + 1,
+			[][]byte{clientUpdatesTx, []byte("regular tx")},
+			abci.ResponseProcessProposal_ACCEPT,
+		},
+		{
+			"reject: client updates tx at another index",
+			voteExtensionsEnableHeight + 1,
+			[][]byte{[]byte("regular tx"), clientUpdatesTx},
+			abci.ResponseProcessProposal_REJECT,
+		},
+		{
+			"reject: client updates tx at the enable height",
+			voteExtensionsEnableHeight,
+			[][]byte{clientUpdatesTx},
+			abci.ResponseProcessProposal_REJECT,
+		},
+		{
+			"reject: unsupported client updates tx version",
+			voteExtensionsEnableHeight + 1,
+			[][]byte{unsupportedVersionTx},
+			abci.ResponseProcessProposal_REJECT,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp, err := s.appModule.ProcessProposal(s.ctx, &abci.RequestProcessProposal{Height: tt.height, Txs: tt.txs}, 0)
+			s.Require().NoError(err)
+			s.Require().Equal(tt.expStatus, resp.Status)
 		})
 	}
 }
+
+func (s *VoteExtensionTestSuite) TestRejectClientUpdatesTxDecorator() {
+	clientUpdatesTx, err := voteextension.EncodeClientUpdatesTx(s.encodingCfg.Codec, &voteextension.ClientUpdates{})
+	s.Require().NoError(err)
+
+	nextCalled := false
+	next := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+		nextCalled = true
+		return ctx, nil
+	}
+	decorator := voteextension.NewRejectClientUpdatesTxDecorator()
+
+	_, err = decorator.AnteHandle(s.ctx.WithTxBytes(clientUpdatesTx), nil, false, next)
+	s.Require().ErrorIs(err, voteextension.ErrClientUpdatesTxNotAllowed)
+	s.Require().False(nextCalled)
+
+	_, err = decorator.AnteHandle(s.ctx.WithTxBytes([]byte("regular tx")), nil, false, next)
+	s.Require().NoError(err)
+	s.Require().True(nextCalled)
+}
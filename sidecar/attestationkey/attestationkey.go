@@ -0,0 +1,93 @@
+package attestationkey
+
+import (
+	"os"
+	"path"
+
+	"gitlab.com/tozd/go/errors"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
+)
+
+const (
+	keyFileName = "attestation_key.json"
+
+	KeyTypeBLS12381  = bls12381.KeyType
+	KeyTypeSecp256k1 = "secp256k1"
+)
+
+// Generate creates a new attestation key of the given type and saves it in the home directory
+func Generate(cdc codec.Codec, homedir string, keyType string, force bool) (cryptotypes.PrivKey, error) {
+	keyFilePath := getKeyFilePath(homedir)
+
+	if !force {
+		_, err := os.Stat(keyFilePath)
+		if !os.IsNotExist(err) {
+			return nil, errors.Errorf("attestation key already exists at %s", keyFilePath)
+		}
+	}
+
+	var privKey cryptotypes.PrivKey
+	switch keyType {
+	case KeyTypeBLS12381:
+		blsPrivKey, err := bls12381.GenPrivKey()
+		if err != nil {
+			return nil, err
+		}
+		privKey = blsPrivKey
+	case KeyTypeSecp256k1:
+		privKey = secp256k1.GenPrivKey()
+	default:
+		return nil, errors.Errorf("unsupported attestation key type %s", keyType)
+	}
+
+	bz, err := cdc.MarshalInterfaceJSON(privKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(keyFilePath, bz, 0o600); err != nil {
+		return nil, err
+	}
+
+	return privKey, nil
+}
+
+// Load reads the attestation key from the home directory, and returns false if it has not been generated
+func Load(cdc codec.Codec, homedir string) (cryptotypes.PrivKey, bool, error) {
+	return LoadFile(cdc, getKeyFilePath(homedir))
+}
+
+// LoadFile reads the attestation key from a key file, and returns false if the file does not exist
+func LoadFile(cdc codec.Codec, keyFilePath string) (cryptotypes.PrivKey, bool, error) {
+	bz, err := os.ReadFile(keyFilePath)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var privKey cryptotypes.PrivKey
+	if err := cdc.UnmarshalInterfaceJSON(bz, &privKey); err != nil {
+		return nil, false, errors.Errorf("failed to unmarshal attestation key from %s: %w", keyFilePath, err)
+	}
+
+	return privKey, true, nil
+}
+
+// ProofOfPossession returns the proof of possession needed to register a BLS12-381 key, and nil for other key types
+func ProofOfPossession(privKey cryptotypes.PrivKey) ([]byte, error) {
+	if blsPrivKey, ok := privKey.(*bls12381.PrivKey); ok {
+		return blsPrivKey.ProofOfPossession()
+	}
+
+	return nil, nil
+}
+
+func getKeyFilePath(homedir string) string {
+	return path.Join(homedir, keyFileName)
+}
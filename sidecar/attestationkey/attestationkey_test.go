@@ -0,0 +1,43 @@
+package attestationkey_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/interchain-attestation/sidecar/attestationkey"
+	"github.com/cosmos/interchain-attestation/sidecar/attestators/cosmos"
+)
+
+func TestGenerateAndLoad(t *testing.T) {
+	cdc := cosmos.NewCodecConfig().Marshaler
+
+	for _, keyType := range []string{attestationkey.KeyTypeBLS12381, attestationkey.KeyTypeSecp256k1} {
+		t.Run(keyType, func(t *testing.T) {
+			homedir := t.TempDir()
+
+			_, found, err := attestationkey.Load(cdc, homedir)
+			require.NoError(t, err)
+			require.False(t, found)
+
+			privKey, err := attestationkey.Generate(cdc, homedir, keyType, false)
+			require.NoError(t, err)
+			require.Equal(t, keyType, privKey.Type())
+
+			loadedPrivKey, found, err := attestationkey.Load(cdc, homedir)
+			require.NoError(t, err)
+			require.True(t, found)
+			require.True(t, privKey.Equals(loadedPrivKey))
+
+			_, err = attestationkey.Generate(cdc, homedir, keyType, false)
+			require.ErrorContains(t, err, "attestation key already exists")
+
+			newPrivKey, err := attestationkey.Generate(cdc, homedir, keyType, true)
+			require.NoError(t, err)
+			require.False(t, privKey.Equals(newPrivKey))
+		})
+	}
+
+	_, err := attestationkey.Generate(cdc, t.TempDir(), "ed25519", false)
+	require.ErrorContains(t, err, "unsupported attestation key type")
+}
@@ -10,4 +10,11 @@ import (
 type Attestator interface {
 	ChainID() string
 	CollectAttestation(ctx context.Context) (types.Attestation, error)
+	// BlockHash returns the current hash of the block at the given height, used to detect reorgs of attested blocks
+	BlockHash(ctx context.Context, height uint64) ([]byte, error)
+}
+
+// ChannelTopologyAttestator is implemented by attestators that cache the connections and channels of the client they attest to
+type ChannelTopologyAttestator interface {
+	ChannelTopology() (types.GetChannelTopologyResponse, error)
 }
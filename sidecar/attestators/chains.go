@@ -0,0 +1,256 @@
+package attestators
+
+import (
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/dgraph-io/badger/v4"
+	"gitlab.com/tozd/go/errors"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/sidecar/attestators/attestator"
+	"github.com/cosmos/interchain-attestation/sidecar/attestators/cosmos"
+	"github.com/cosmos/interchain-attestation/sidecar/attestators/evm"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+	"github.com/cosmos/interchain-attestation/sidecar/metrics"
+)
+
+// attestationChain is a chain of the config that is attested to
+type attestationChain struct {
+	// config is the config.CosmosChainConfig or config.EVMChainConfig of the chain without the paused setting, so the
+	// attestator is only recreated if anything else changed
+	config   any
+	schedule config.CollectionSchedule
+	paused   bool
+	// newAttestator creates the attestator of the chain
+	newAttestator func() (attestator.Attestator, error)
+}
+
+// attestationChains returns the chains of the config that attestation is enabled for, by chain id
+func attestationChains(logger *zap.Logger, db *badger.DB, attestatorID string, sidecarConfig config.Config) (map[string]attestationChain, error) {
+	chains := make(map[string]attestationChain)
+	for _, cosmosConfig := range sidecarConfig.CosmosChains {
+		if !cosmosConfig.Attestation {
+			logger.Debug("Skipping chain", zap.String("chain_id", cosmosConfig.ChainID), zap.String("reason", "attestation disabled"))
+			continue
+		}
+
+		schedule, err := cosmosConfig.GetSchedule()
+		if err != nil {
+			return nil, err
+		}
+		paused := cosmosConfig.Paused
+		cosmosConfig.Paused = false
+
+		chains[cosmosConfig.ChainID] = attestationChain{
+			config:   cosmosConfig,
+			schedule: schedule,
+			paused:   paused,
+			newAttestator: func() (attestator.Attestator, error) {
+				return cosmos.NewCosmosAttestator(logger, db, attestatorID, cosmosConfig)
+			},
+		}
+	}
+
+	for _, evmConfig := range sidecarConfig.EVMChains {
+		if !evmConfig.Attestation {
+			logger.Debug("Skipping chain", zap.String("chain_id", evmConfig.ChainID), zap.String("reason", "attestation disabled"))
+			continue
+		}
+
+		schedule, err := evmConfig.GetSchedule()
+		if err != nil {
+			return nil, err
+		}
+		paused := evmConfig.Paused
+		evmConfig.Paused = false
+
+		chains[evmConfig.ChainID] = attestationChain{
+			config:   evmConfig,
+			schedule: schedule,
+			paused:   paused,
+			newAttestator: func() (attestator.Attestator, error) {
+				return evm.NewEVMAttestator(logger, db, attestatorID, evmConfig)
+			},
+		}
+	}
+
+	return chains, nil
+}
+
+func (c *coordinator) ApplyConfig(sidecarConfig config.Config) error {
+	if err := sidecarConfig.Validate(); err != nil {
+		return errors.Errorf("invalid config: %w", err)
+	}
+	if c.signer == nil && sidecarConfig.HasAttestationChains() {
+		return errors.New("the sidecar was started without any chain to attest to, and must be restarted to attest to chains")
+	}
+
+	c.applyLock.Lock()
+	defer c.applyLock.Unlock()
+
+	if requiresRestart(c.sidecarConfig, sidecarConfig) {
+		c.logger.Warn("Only the chains of the config are applied at runtime, the sidecar must be restarted to apply the other settings")
+	}
+
+	// the attestator id can't change at runtime, so every attestator uses the one the sidecar was started with
+	chains, err := attestationChains(c.logger, c.db, c.sidecarConfig.AttestatorID, sidecarConfig)
+	if err != nil {
+		return err
+	}
+
+	c.chainsLock.RLock()
+	current := maps.Clone(c.chains)
+	c.chainsLock.RUnlock()
+
+	// the attestators of the new and changed chains are created before anything is applied, so a chain that can't be set
+	// up leaves all the chains as they are
+	created := make(map[string]attestator.Attestator)
+	defer func() {
+		// the attestators that were not added to a chain, because applying the config failed, are not used
+		for _, chainAttestator := range created {
+			closeAttestator(c.logger, chainAttestator)
+		}
+	}()
+	for _, chainID := range slices.Sorted(maps.Keys(chains)) {
+		if currentChain, ok := current[chainID]; ok && reflect.DeepEqual(currentChain.config, chains[chainID].config) {
+			continue
+		}
+
+		chainAttestator, err := chains[chainID].newAttestator()
+		if err != nil {
+			return errors.Errorf("failed to set up chain id %s: %w", chainID, err)
+		}
+		created[chainID] = chainAttestator
+	}
+
+	// If removing or adding a chain fails from here on, the chains that were already changed are kept as they are. Since
+	// the chains are compared with the running chains rather than the last applied config, applying the config again
+	// finishes the changes that are left.
+
+	for _, chainID := range slices.Sorted(maps.Keys(current)) {
+		_, kept := chains[chainID]
+		_, recreated := created[chainID]
+		if kept && !recreated {
+			continue
+		}
+
+		if err := c.removeChain(chainID); err != nil {
+			return err
+		}
+		c.logger.Info("Removed chain", zap.String("chain_id", chainID), zap.Bool("restarting", recreated))
+	}
+
+	for _, chainID := range slices.Sorted(maps.Keys(chains)) {
+		chain := chains[chainID]
+		if chainAttestator, ok := created[chainID]; ok {
+			if err := c.addChain(chainID, chainAttestator, chain); err != nil {
+				return err
+			}
+			delete(created, chainID)
+			c.logger.Info("Added chain", zap.String("chain_id", chainID), zap.Bool("paused", chain.paused))
+			continue
+		}
+
+		if chain.paused == current[chainID].paused {
+			continue
+		}
+		if err := c.setChainPaused(chainID, chain.paused); err != nil {
+			return err
+		}
+		c.logger.Info("Changed chain", zap.String("chain_id", chainID), zap.Bool("paused", chain.paused))
+	}
+
+	// only the chains are applied, so the other settings keep being compared with the ones the sidecar was started with
+	c.sidecarConfig.CosmosChains, c.sidecarConfig.EVMChains = sidecarConfig.CosmosChains, sidecarConfig.EVMChains
+
+	return nil
+}
+
+// addChain starts attesting to a chain, unless it is paused. If the chain can't be added, the attestator is left to
+// the caller.
+func (c *coordinator) addChain(chainID string, chainAttestator attestator.Attestator, chain attestationChain) error {
+	c.chainsLock.Lock()
+	c.chainAttestators[chainID] = chainAttestator
+	c.chains[chainID] = chain
+	c.chainsLock.Unlock()
+
+	if err := c.supervisor.add(c.supervisedChain(chainID, chainAttestator, chain.schedule), chain.paused); err != nil {
+		c.chainsLock.Lock()
+		delete(c.chainAttestators, chainID)
+		delete(c.chains, chainID)
+		c.chainsLock.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// removeChain stops attesting to a chain, once its current collection is done. Its attestations are kept in the store.
+func (c *coordinator) removeChain(chainID string) error {
+	if err := c.supervisor.remove(chainID); err != nil {
+		return err
+	}
+
+	c.chainsLock.Lock()
+	chainAttestator := c.chainAttestators[chainID]
+	delete(c.chainAttestators, chainID)
+	delete(c.chains, chainID)
+	c.chainsLock.Unlock()
+
+	c.collectedHeightsLock.Lock()
+	delete(c.collectedHeights, chainID)
+	c.collectedHeightsLock.Unlock()
+
+	metrics.LatestAttestedHeight.DeleteLabelValues(chainID)
+	metrics.LatestAttestationTime.DeleteLabelValues(chainID)
+	metrics.PacketCommitments.DeleteLabelValues(chainID)
+
+	closeAttestator(c.logger, chainAttestator)
+
+	return nil
+}
+
+// setChainPaused pauses or resumes the collection loop of a chain
+func (c *coordinator) setChainPaused(chainID string, paused bool) error {
+	var err error
+	if paused {
+		err = c.supervisor.pause(chainID)
+	} else {
+		err = c.supervisor.resume(chainID)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.chainsLock.Lock()
+	defer c.chainsLock.Unlock()
+
+	chain := c.chains[chainID]
+	chain.paused = paused
+	c.chains[chainID] = chain
+
+	return nil
+}
+
+// closeAttestator releases the connections of an attestator that holds any
+func closeAttestator(logger *zap.Logger, chainAttestator attestator.Attestator) {
+	closer, ok := chainAttestator.(io.Closer)
+	if !ok {
+		return
+	}
+
+	if err := closer.Close(); err != nil {
+		logger.Warn("Failed to close attestator", zap.String("chain_id", chainAttestator.ChainID()), zap.Error(err))
+	}
+}
+
+// requiresRestart returns whether any setting besides the chains changed
+func requiresRestart(current, next config.Config) bool {
+	current.CosmosChains, current.EVMChains = nil, nil
+	next.CosmosChains, next.EVMChains = nil, nil
+
+	return !reflect.DeepEqual(current, next)
+}
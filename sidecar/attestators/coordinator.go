@@ -3,95 +3,158 @@ package attestators
 import (
 	"context"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
 	"sync"
-	"time"
 
-	"github.com/cosmos/gogoproto/proto"
 	"github.com/dgraph-io/badger/v4"
+	"gitlab.com/tozd/go/errors"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/cosmos/interchain-attestation/core/types"
 	"github.com/cosmos/interchain-attestation/sidecar/attestators/attestator"
-	"github.com/cosmos/interchain-attestation/sidecar/attestators/cosmos"
 	"github.com/cosmos/interchain-attestation/sidecar/config"
+	"github.com/cosmos/interchain-attestation/sidecar/ha"
+	"github.com/cosmos/interchain-attestation/sidecar/metrics"
+	"github.com/cosmos/interchain-attestation/sidecar/signer"
+	"github.com/cosmos/interchain-attestation/sidecar/store"
 )
 
-const (
-	defaultMinQueryLoopDuration = 1 * time.Second
-)
+// ErrUnknownChain is returned for a chain the sidecar doesn't attest to
+var ErrUnknownChain = errors.Base("no attestator for chain id")
 
 // TODO: Document
 type Coordinator interface {
 	Run(ctx context.Context) error
 	GetLatestAttestations() ([]types.Attestation, error)
 	GetAttestationForHeight(chainID string, height uint64) (types.Attestation, error)
+	// ListAttestations returns at most limit attestations of the chain, from the start height up to and including the
+	// end height (0 for no end), and the height the next page starts at, 0 if there are no more attestations
+	ListAttestations(chainID string, startHeight, endHeight uint64, limit int) ([]types.Attestation, uint64, error)
+	// SubscribeAttestations returns a channel with every attestation of the chains (all chains if empty) signed from now
+	// on. The channel is closed once the context is done, or if the subscriber falls too far behind.
+	SubscribeAttestations(ctx context.Context, chainIDs []string) (<-chan types.Attestation, error)
+	GetChannelTopology(chainID string) (types.GetChannelTopologyResponse, error)
+	GetChainHealth() map[string]ChainHealth
+	// GetChainStatuses returns the health and the attested heights of every chain, sorted by chain id
+	GetChainStatuses() ([]types.ChainStatus, error)
+	// Standby returns whether another sidecar of the high availability group is signing, in which case the attestations
+	// of this sidecar are stale
+	Standby() bool
+	// Close releases the attestators, the signer, the lock and the attestation store once Run has returned
+	Close() error
+	// ApplyConfig adds, removes, restarts, pauses and resumes the attestators of the chains to match the config, without
+	// interrupting the chains that didn't change. Nothing is applied if the config is invalid.
+	ApplyConfig(sidecarConfig config.Config) error
 }
 
 type coordinator struct {
 	logger *zap.Logger
-	db     *badger.DB
-
-	chainAttestators  map[string]attestator.Attestator
-	queryLoopDuration time.Duration
+	store  store.AttestationStore
+	// retention decides which attestations are pruned from the store
+	retention config.RetentionPolicy
+
+	// signer signs the collected attestations for the host chain, at most one for every height of a chain
+	signer      *signer.Guard
+	hostChainID string
+	// lock decides whether this sidecar signs in a high availability group, nil if the sidecar runs on its own
+	lock ha.Lock
+
+	// db is used by the attestators for their own state, and sidecarConfig is the config the chains were last set up from
+	db            *badger.DB
+	sidecarConfig config.Config
+	// applyLock makes sure only one config is applied at a time
+	applyLock sync.Mutex
+
+	// chainsLock guards the attestators and the chains, which change when a new config is applied
+	chainsLock       sync.RWMutex
+	chainAttestators map[string]attestator.Attestator
+	chains           map[string]attestationChain
+	supervisor       *supervisor
+	reorgCheckDepth  int
+
+	// collectedHeights is the height of the latest collected attestation of every chain, signed or not
+	collectedHeights     map[string]uint64
+	collectedHeightsLock sync.RWMutex
+	// subscriptions receive every signed attestation
+	subscriptions subscriptions
 }
 
 var _ Coordinator = &coordinator{}
 
-func NewCoordinator(logger *zap.Logger, db *badger.DB, sidecarConfig config.Config) (Coordinator, error) {
-	chainProvers := make(map[string]attestator.Attestator)
-	for _, cosmosConfig := range sidecarConfig.CosmosChains {
-		if !cosmosConfig.Attestation {
-			logger.Debug("Skipping chain", zap.String("chain_id", cosmosConfig.ChainID), zap.String("reason", "attestation disabled"))
-			continue
-		}
+// NewCoordinator sets up the attestators of the chains to attest to. The attestations are kept in the attestation store,
+// while the database is used by the attestators for their own state. The lock is nil unless the sidecar runs in a high
+// availability group, in which case the sign state of the signer must be kept by the lock.
+func NewCoordinator(logger *zap.Logger, db *badger.DB, attestationStore store.AttestationStore, sidecarConfig config.Config, attestationSigner *signer.Guard, lock ha.Lock) (Coordinator, error) {
+	if attestationSigner == nil && sidecarConfig.HasAttestationChains() {
+		return nil, errors.New("signer is required to attest to chains")
+	}
+
+	retention, err := sidecarConfig.Retention.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
 
-		att, err := cosmos.NewCosmosAttestator(
-			logger,
-			sidecarConfig.AttestatorID,
-			cosmosConfig,
-		)
+	chains, err := attestationChains(logger, db, sidecarConfig.AttestatorID, sidecarConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	chainAttestators := make(map[string]attestator.Attestator, len(chains))
+	schedules := make(map[string]config.CollectionSchedule, len(chains))
+	for chainID, chain := range chains {
+		chainAttestators[chainID], err = chain.newAttestator()
 		if err != nil {
 			return nil, err
 		}
-		chainProvers[cosmosConfig.ChainID] = att
+		schedules[chainID] = chain.schedule
+	}
+
+	c := &coordinator{
+		logger:           logger,
+		store:            attestationStore,
+		retention:        retention,
+		signer:           attestationSigner,
+		hostChainID:      sidecarConfig.HostChainID,
+		lock:             lock,
+		db:               db,
+		sidecarConfig:    sidecarConfig,
+		chainAttestators: chainAttestators,
+		chains:           chains,
+		reorgCheckDepth:  defaultReorgCheckDepth,
+	}
+	c.superviseChains(schedules)
+	for chainID, chain := range chains {
+		if chain.paused {
+			if err := c.supervisor.pause(chainID); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	return &coordinator{
-		logger:            logger,
-		db:                db,
-		chainAttestators:  chainProvers,
-		queryLoopDuration: defaultMinQueryLoopDuration,
-	}, nil
+	return c, nil
 }
 
 func (c *coordinator) GetLatestAttestations() ([]types.Attestation, error) {
+	chainAttestators := c.activeAttestators()
+
 	var wg sync.WaitGroup
-	attestationChan := make(chan types.Attestation, len(c.chainAttestators))
-	errChan := make(chan error, len(c.chainAttestators))
+	attestationChan := make(chan types.Attestation, len(chainAttestators))
+	errChan := make(chan error, len(chainAttestators))
 
-	for _, chainAttestator := range c.chainAttestators {
+	for _, chainAttestator := range chainAttestators {
 		wg.Add(1)
 		go func(chainAttestator attestator.Attestator) {
 			defer wg.Done()
 
-			var bz []byte
-			if err := c.db.View(func(txn *badger.Txn) error {
-				item, err := txn.Get(latestKey(chainAttestator.ChainID()))
-				if err != nil {
-					return err
+			attestation, err := c.store.GetLatestAttestation(chainAttestator.ChainID())
+			if err != nil {
+				// no attestation yet, or the latest one was retracted after a reorg
+				if errors.Is(err, store.ErrNotFound) {
+					return
 				}
-				return item.Value(func(val []byte) error {
-					bz = val
-					return nil
-				})
-			}); err != nil {
-				errChan <- err
-				return
-			}
-
-			var attestation types.Attestation
-			if err := proto.Unmarshal(bz, &attestation); err != nil {
 				errChan <- err
 				return
 			}
@@ -119,97 +182,298 @@ func (c *coordinator) GetLatestAttestations() ([]types.Attestation, error) {
 }
 
 func (c *coordinator) GetAttestationForHeight(chainID string, height uint64) (types.Attestation, error) {
-	var attestation types.Attestation
-	if err := c.db.View(func(txn *badger.Txn) error {
-		item, err := txn.Get(heightKey(chainID, height))
-		if err != nil {
-			return err
+	if _, err := c.getAttestator(chainID); err != nil {
+		return types.Attestation{}, err
+	}
+
+	return c.store.GetAttestation(chainID, height)
+}
+
+func (c *coordinator) ListAttestations(chainID string, startHeight, endHeight uint64, limit int) ([]types.Attestation, uint64, error) {
+	if _, err := c.getAttestator(chainID); err != nil {
+		return nil, 0, err
+	}
+	if endHeight == 0 {
+		endHeight = math.MaxUint64
+	}
+
+	var (
+		attestations []types.Attestation
+		nextHeight   uint64
+	)
+	if err := c.store.IterateAttestations(chainID, startHeight, endHeight, func(height uint64, attestation types.Attestation) bool {
+		if len(attestations) == limit {
+			nextHeight = height
+			return false
 		}
-		return item.Value(attestation.Unmarshal)
+		attestations = append(attestations, attestation)
+		return true
 	}); err != nil {
-		return attestation, err
+		return nil, 0, err
+	}
+
+	return attestations, nextHeight, nil
+}
+
+func (c *coordinator) SubscribeAttestations(ctx context.Context, chainIDs []string) (<-chan types.Attestation, error) {
+	for _, chainID := range chainIDs {
+		if _, err := c.getAttestator(chainID); err != nil {
+			return nil, err
+		}
+	}
+
+	sub := c.subscriptions.subscribe(chainIDs)
+	go func() {
+		<-ctx.Done()
+		c.subscriptions.unsubscribe(sub)
+	}()
+
+	return sub.ch, nil
+}
+
+// GetChannelTopology returns the cached connections and channels of the client attested to on the chain
+func (c *coordinator) GetChannelTopology(chainID string) (types.GetChannelTopologyResponse, error) {
+	chainAttestator, err := c.getAttestator(chainID)
+	if err != nil {
+		return types.GetChannelTopologyResponse{}, err
+	}
+
+	topologyAttestator, ok := chainAttestator.(attestator.ChannelTopologyAttestator)
+	if !ok {
+		return types.GetChannelTopologyResponse{}, errors.Errorf("attestator for chain id %s does not cache a channel topology", chainID)
 	}
 
-	return attestation, nil
+	return topologyAttestator.ChannelTopology()
 }
 
+// GetChainHealth returns the health of the collection loop of every chain
+func (c *coordinator) GetChainHealth() map[string]ChainHealth {
+	return c.supervisor.chainHealth()
+}
+
+func (c *coordinator) GetChainStatuses() ([]types.ChainStatus, error) {
+	health := c.supervisor.chainHealth()
+	chainIDs := c.chainIDs()
+
+	c.collectedHeightsLock.RLock()
+	defer c.collectedHeightsLock.RUnlock()
+
+	var statuses []types.ChainStatus
+	for _, chainID := range chainIDs {
+		chainHealth := health[chainID]
+		status := types.ChainStatus{
+			ChainId:             chainID,
+			Health:              string(chainHealth.State),
+			ConsecutiveFailures: uint32(chainHealth.ConsecutiveFailures),
+			LastError:           chainHealth.LastError,
+			CollectedHeight:     c.collectedHeights[chainID],
+		}
+		if !chainHealth.LastSuccess.IsZero() {
+			lastSuccess := chainHealth.LastSuccess
+			status.LastSuccess = &lastSuccess
+		}
+
+		latest, err := c.store.GetLatestAttestation(chainID)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+		case err != nil:
+			return nil, err
+		default:
+			status.LatestHeight = latest.Payload.AttestedHeight().RevisionHeight
+		}
+		if status.CollectedHeight > status.LatestHeight {
+			status.LaggingHeights = status.CollectedHeight - status.LatestHeight
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// getAttestator returns the attestator of the chain, or ErrUnknownChain
+func (c *coordinator) getAttestator(chainID string) (attestator.Attestator, error) {
+	c.chainsLock.RLock()
+	defer c.chainsLock.RUnlock()
+
+	chainAttestator, ok := c.chainAttestators[chainID]
+	if !ok {
+		return nil, errors.Errorf("%w %s", ErrUnknownChain, chainID)
+	}
+
+	return chainAttestator, nil
+}
+
+// activeAttestators returns the attestators of the chains that are not paused, since the latest attestation of a paused
+// chain is stale
+func (c *coordinator) activeAttestators() []attestator.Attestator {
+	c.chainsLock.RLock()
+	defer c.chainsLock.RUnlock()
+
+	var chainAttestators []attestator.Attestator
+	for chainID, chainAttestator := range c.chainAttestators {
+		if !c.chains[chainID].paused {
+			chainAttestators = append(chainAttestators, chainAttestator)
+		}
+	}
+
+	return chainAttestators
+}
+
+// chainIDs returns the ids of all the chains, sorted
+func (c *coordinator) chainIDs() []string {
+	c.chainsLock.RLock()
+	defer c.chainsLock.RUnlock()
+
+	return slices.Sorted(maps.Keys(c.chainAttestators))
+}
+
+func (c *coordinator) Standby() bool {
+	return c.lock != nil && !c.lock.Held()
+}
+
+// Run runs the collection loops of all the chains, and the garbage collection of their attestations, until the context
+// is done. A failing chain does not stop the others. In a high availability group, it also tries to acquire the lock,
+// and only signs while holding it. If the lock fails, everything is stopped and the error is returned. Collections in
+// flight when the context is done are drained before Run returns.
 func (c *coordinator) Run(ctx context.Context) error {
 	c.logger.Debug("Coordinator.Run")
 
-	var eg errgroup.Group
-	runCtx, runCtxCancel := context.WithCancel(ctx)
-	for _, chainProver := range c.chainAttestators {
-		c.logger.Info("Starting chain prover loop", zap.String("chain_id", chainProver.ChainID()))
-
+	eg, ctx := errgroup.WithContext(ctx)
+	if c.lock != nil {
 		eg.Go(func() error {
-			err := c.collectionLoop(runCtx, chainProver)
-			runCtxCancel() // Signal the other chain processors to exit.
-			return err
+			if err := c.lock.Run(ctx); err != nil {
+				return errors.Errorf("high availability lock stopped: %w", err)
+			}
+
+			return nil
 		})
 	}
+	eg.Go(func() error {
+		store.RunGarbageCollection(ctx, c.logger, c.store, c.chainIDs, c.retention)
+		return nil
+	})
+	eg.Go(func() error {
+		c.supervisor.run(ctx)
+		return nil
+	})
 
-	err := eg.Wait()
-	runCtxCancel()
-	return err
+	return eg.Wait()
 }
 
-func (c *coordinator) collectionLoop(ctx context.Context, chainProver attestator.Attestator) error {
-	ticker := time.NewTicker(c.queryLoopDuration) // TODO: Make this configurable per chain
-	defer ticker.Stop()
+func (c *coordinator) Close() error {
+	c.logger.Debug("Coordinator.Close")
 
-	// TODO: Refactor all database stuff into a separate file (and probably the coordinator stuff into its own package)
-	for {
-		c.collectOnce(ctx, chainProver)
+	c.chainsLock.Lock()
+	for _, chainAttestator := range c.chainAttestators {
+		closeAttestator(c.logger, chainAttestator)
+	}
+	c.chainsLock.Unlock()
 
-		select {
-		case <-ctx.Done():
-			return nil
-		case <-ticker.C:
-			ticker.Reset(c.queryLoopDuration)
+	var err error
+	if c.signer != nil {
+		err = c.signer.Close()
+	}
+	// the lock is released once nothing is signed anymore, so a standby sidecar can take over
+	if c.lock != nil {
+		if closeErr := c.lock.Close(); err == nil {
+			err = closeErr
 		}
 	}
+	if closeErr := c.store.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// superviseChains sets up the supervisor to run the collection loop of every chain on its schedule
+func (c *coordinator) superviseChains(schedules map[string]config.CollectionSchedule) {
+	var chains []supervisedChain
+	for chainID, chainAttestator := range c.chainAttestators {
+		chains = append(chains, c.supervisedChain(chainID, chainAttestator, schedules[chainID]))
+	}
+
+	c.supervisor = newSupervisor(c.logger, chains)
 }
 
-func (c *coordinator) collectOnce(ctx context.Context, chainProver attestator.Attestator) {
+func (c *coordinator) supervisedChain(chainID string, chainAttestator attestator.Attestator, schedule config.CollectionSchedule) supervisedChain {
+	return supervisedChain{
+		chainID:  chainID,
+		schedule: schedule,
+		collect: func(ctx context.Context) error {
+			return c.collectOnce(ctx, chainAttestator)
+		},
+	}
+}
+
+func (c *coordinator) collectOnce(ctx context.Context, chainProver attestator.Attestator) error {
+	if err := c.checkReorgs(ctx, chainProver); err != nil {
+		return errors.Errorf("failed to check for reorgs: %w", err)
+	}
+
 	c.logger.Info("Collecting claims", zap.String("chain_id", chainProver.ChainID()))
 	attestation, err := chainProver.CollectAttestation(ctx)
 	if err != nil {
-		c.logger.Error("Failed to collect claims", zap.String("chain_id", chainProver.ChainID()), zap.Error(err))
-		return
+		return errors.Errorf("failed to collect claims: %w", err)
+	}
+	payload := attestation.Payload.Unpack()
+	if payload == nil {
+		return errors.New("collected attestation without payload")
 	}
 	c.logger.Info("Collected attestation for chain",
 		zap.String("chain_id", chainProver.ChainID()),
-		zap.String("client_id", attestation.AttestedData.ClientId),
-		zap.String("client_to_update", attestation.AttestedData.ClientToUpdate),
-		zap.String("height", fmt.Sprint(attestation.AttestedData.Height.RevisionHeight)),
-		zap.String("timestamp", attestation.AttestedData.Timestamp.String()),
-		zap.Int("num_packet_commitments", len(attestation.AttestedData.PacketCommitments)),
+		zap.String("payload_type", fmt.Sprintf("%T", payload)),
+		zap.String("client_to_update", payload.GetClientToUpdate()),
+		zap.String("height", fmt.Sprint(payload.AttestedHeight().RevisionHeight)),
+		zap.String("timestamp", payload.GetTimestamp().String()),
 	)
 
-	if err := c.db.Update(func(txn *badger.Txn) error {
-		aBz, err := attestation.Marshal()
-		if err != nil {
-			return err
-		}
-		height := attestation.AttestedData.Height.RevisionHeight
-		if err := txn.Set(heightKey(chainProver.ChainID(), height), aBz); err != nil {
-			return err
+	c.setCollectedHeight(chainProver.ChainID(), payload.AttestedHeight().RevisionHeight)
+
+	blockHash, err := attestedBlockHash(ctx, chainProver, payload)
+	if err != nil {
+		return errors.Errorf("failed to get attested block hash: %w", err)
+	}
+
+	signBytes := types.GetAttestationSignBytes(c.hostChainID, attestation.Payload)
+	height := payload.AttestedHeight().RevisionHeight
+	attestation.Signature, err = c.signer.SignAttestation(ctx, chainProver.ChainID(), height, signBytes)
+	if err != nil {
+		// a lagging endpoint or a reorg can lower the attested height, which is not attested to again until the chain
+		// has moved past the last signed height
+		if errors.Is(err, signer.ErrHeightRegression) {
+			c.logger.Warn("Not signing attestation below the last signed height", zap.String("chain_id", chainProver.ChainID()), zap.Error(err))
+			return nil
 		}
-		if err := txn.Set(latestKey(chainProver.ChainID()), aBz); err != nil {
-			return err
+		// a standby sidecar keeps collecting, so it is ready to sign as soon as it acquires the lock
+		if errors.Is(err, signer.ErrStandby) {
+			c.logger.Debug("Not signing attestation on standby", zap.String("chain_id", chainProver.ChainID()))
+			return nil
 		}
+		return errors.Errorf("failed to sign attestation: %w", err)
+	}
 
-		return nil
-	}); err != nil {
-		c.logger.Error("Failed to store attestation", zap.String("chain_id", chainProver.ChainID()), zap.Error(err))
-		return
+	if err := c.store.SetAttestation(chainProver.ChainID(), height, blockHash, attestation); err != nil {
+		return errors.Errorf("failed to store attestation: %w", err)
 	}
-}
+	c.subscriptions.publish(chainProver.ChainID(), attestation)
 
-func heightKey(chainID string, height uint64) []byte {
-	return []byte(fmt.Sprintf("%s/attestations/%d", chainID, height))
+	metrics.LatestAttestedHeight.WithLabelValues(chainProver.ChainID()).Set(float64(height))
+	metrics.LatestAttestationTime.WithLabelValues(chainProver.ChainID()).SetToCurrentTime()
+	if ibcData, ok := payload.(*types.IBCData); ok {
+		metrics.PacketCommitments.WithLabelValues(chainProver.ChainID()).Set(float64(len(ibcData.PacketCommitments)))
+	}
+
+	return nil
 }
 
-func latestKey(chainID string) []byte {
-	return []byte(fmt.Sprintf("%s/attestations/latest", chainID))
+func (c *coordinator) setCollectedHeight(chainID string, height uint64) {
+	c.collectedHeightsLock.Lock()
+	defer c.collectedHeightsLock.Unlock()
+
+	if c.collectedHeights == nil {
+		c.collectedHeights = make(map[string]uint64)
+	}
+	c.collectedHeights[chainID] = height
 }
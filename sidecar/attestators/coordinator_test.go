@@ -2,18 +2,29 @@ package attestators
 
 import (
 	"context"
+	"encoding/binary"
+	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
-	"github.com/dgraph-io/badger/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+
 	clienttypes "github.com/cosmos/ibc-go/v9/modules/core/02-client/types"
 
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
 	"github.com/cosmos/interchain-attestation/core/types"
 	"github.com/cosmos/interchain-attestation/sidecar/attestators/attestator"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+	"github.com/cosmos/interchain-attestation/sidecar/ha"
+	"github.com/cosmos/interchain-attestation/sidecar/metrics"
+	"github.com/cosmos/interchain-attestation/sidecar/signer"
+	"github.com/cosmos/interchain-attestation/sidecar/store"
 )
 
 const (
@@ -21,6 +32,7 @@ const (
 	mockClientID       = "mockClientID"
 	mockClientToUpdate = "mockClientToUpdate"
 	mockAttestatorID   = "mockAttestatorID"
+	mockHostChainID    = "mockHostChainID"
 )
 
 var mockPacketCommits = [][]byte{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
@@ -28,6 +40,9 @@ var mockPacketCommits = [][]byte{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
 type MockChainAttestator struct {
 	CurrentHeight uint64
 	Timestamp     time.Time
+	// ReorgHeight is the height from which the blocks have been replaced by the current fork, if Fork is not zero
+	ReorgHeight uint64
+	Fork        byte
 
 	lock sync.Mutex
 }
@@ -43,17 +58,34 @@ func (m *MockChainAttestator) CollectAttestation(ctx context.Context) (types.Att
 	defer m.lock.Unlock()
 	return types.Attestation{
 		AttestatorId: []byte(mockAttestatorID),
-		AttestedData: types.IBCData{
+		Payload: types.NewIBCDataPayload(types.IBCData{
 			ChainId:           mockChainID,
 			ClientId:          mockClientID,
 			ClientToUpdate:    mockClientToUpdate,
 			Height:            clienttypes.NewHeight(1, m.CurrentHeight),
 			Timestamp:         m.Timestamp,
 			PacketCommitments: mockPacketCommits,
-		},
+		}),
 	}, nil
 }
 
+func (m *MockChainAttestator) BlockHash(ctx context.Context, height uint64) ([]byte, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	fork := byte(0)
+	if height >= m.ReorgHeight {
+		fork = m.Fork
+	}
+	return binary.BigEndian.AppendUint64([]byte{fork}, height), nil
+}
+
+func (m *MockChainAttestator) reorg(fromHeight uint64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.ReorgHeight = fromHeight
+	m.Fork++
+}
+
 func (m *MockChainAttestator) updateHeight(height uint64, timestamp time.Time) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -61,19 +93,29 @@ func (m *MockChainAttestator) updateHeight(height uint64, timestamp time.Time) {
 	m.Timestamp = timestamp
 }
 
+func newTestGuard(t *testing.T, attestationKey cryptotypes.PrivKey) *signer.Guard {
+	return signer.NewGuard(signer.NewKeySigner(attestationKey), signer.NewFileStateStore(filepath.Join(t.TempDir(), signer.DefaultSignStateFileName)))
+}
+
 func TestCoordinator_Run(t *testing.T) {
 	mockChainAttestator := &MockChainAttestator{}
 	mockChainAttestator.CurrentHeight = 1
-	db, err := badger.Open(badger.DefaultOptions("").WithInMemory(true))
+	attestationKey, err := bls12381.GenPrivKey()
 	require.NoError(t, err)
 	testCoordinator := &coordinator{
 		chainAttestators: map[string]attestator.Attestator{
 			mockChainID: mockChainAttestator,
 		},
-		logger:            zap.NewNop(),
-		db:                db,
-		queryLoopDuration: 50 * time.Millisecond,
+		logger:          zap.NewNop(),
+		store:           store.NewMemStore(),
+		signer:          newTestGuard(t, attestationKey),
+		hostChainID:     mockHostChainID,
+		retention:       config.RetentionPolicy{GCInterval: time.Minute},
+		reorgCheckDepth: defaultReorgCheckDepth,
 	}
+	testCoordinator.superviseChains(map[string]config.CollectionSchedule{
+		mockChainID: {Interval: 50 * time.Millisecond, Timeout: time.Second},
+	})
 
 	ctx, ctxCancel := context.WithCancel(context.Background())
 	wg := sync.WaitGroup{}
@@ -95,26 +137,360 @@ func TestCoordinator_Run(t *testing.T) {
 		latestAttestations, err := testCoordinator.GetLatestAttestations()
 		require.NoError(t, err)
 		require.Len(t, latestAttestations, 1)
-		require.Equal(t, height, latestAttestations[0].AttestedData.Height.RevisionHeight)
-		require.Equal(t, mockPacketCommits, latestAttestations[0].AttestedData.PacketCommitments)
+		require.Equal(t, height, latestAttestations[0].Payload.GetIbcDataV1().Height.RevisionHeight)
+		require.Equal(t, mockPacketCommits, latestAttestations[0].Payload.GetIbcDataV1().PacketCommitments)
 		require.Equal(t, mockAttestatorID, string(latestAttestations[0].AttestatorId))
-		require.Equal(t, mockChainID, latestAttestations[0].AttestedData.ChainId)
-		require.Equal(t, mockClientID, latestAttestations[0].AttestedData.ClientId)
-		require.Equal(t, mockClientToUpdate, latestAttestations[0].AttestedData.ClientToUpdate)
-		require.Equal(t, timestampAtHeight[height].UnixNano(), latestAttestations[0].AttestedData.Timestamp.UnixNano())
+		require.Equal(t, mockChainID, latestAttestations[0].Payload.GetIbcDataV1().ChainId)
+		require.Equal(t, mockClientID, latestAttestations[0].Payload.GetIbcDataV1().ClientId)
+		require.Equal(t, mockClientToUpdate, latestAttestations[0].Payload.GetIbcDataV1().ClientToUpdate)
+		require.Equal(t, timestampAtHeight[height].UnixNano(), latestAttestations[0].Payload.GetIbcDataV1().Timestamp.UnixNano())
+		signBytes := types.GetAttestationSignBytes(mockHostChainID, latestAttestations[0].Payload)
+		require.True(t, attestationKey.PubKey().VerifySignature(signBytes, latestAttestations[0].Signature))
 
 		attestationAtHeight, err := testCoordinator.GetAttestationForHeight(mockChainID, height)
 		require.NoError(t, err)
-		require.Equal(t, height, attestationAtHeight.AttestedData.Height.RevisionHeight)
+		require.Equal(t, height, attestationAtHeight.Payload.GetIbcDataV1().Height.RevisionHeight)
 		require.Equal(t, latestAttestations[0], attestationAtHeight)
 
 		for j := 1; j <= i; j++ {
 			attestationAtHeight, err := testCoordinator.GetAttestationForHeight(mockChainID, uint64(j))
 			require.NoError(t, err)
-			require.Equal(t, uint64(j), attestationAtHeight.AttestedData.Height.RevisionHeight)
+			require.Equal(t, uint64(j), attestationAtHeight.Payload.GetIbcDataV1().Height.RevisionHeight)
 		}
 	}
 
 	ctxCancel()
 	wg.Wait()
 }
+
+func TestCoordinator_Reorg(t *testing.T) {
+	mockChainAttestator := &MockChainAttestator{}
+	attestationKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	testCoordinator := &coordinator{
+		chainAttestators: map[string]attestator.Attestator{
+			mockChainID: mockChainAttestator,
+		},
+		logger:          zap.NewNop(),
+		store:           store.NewMemStore(),
+		signer:          newTestGuard(t, attestationKey),
+		hostChainID:     mockHostChainID,
+		reorgCheckDepth: defaultReorgCheckDepth,
+	}
+
+	ctx := context.Background()
+	for height := uint64(1); height <= 5; height++ {
+		mockChainAttestator.updateHeight(height, time.Now())
+		require.NoError(t, testCoordinator.collectOnce(ctx, mockChainAttestator))
+	}
+
+	// nothing changed, so nothing is retracted
+	require.NoError(t, testCoordinator.checkReorgs(ctx, mockChainAttestator))
+	for height := uint64(1); height <= 5; height++ {
+		_, err := testCoordinator.GetAttestationForHeight(mockChainID, height)
+		require.NoError(t, err)
+	}
+
+	mockChainAttestator.reorg(4)
+	require.NoError(t, testCoordinator.checkReorgs(ctx, mockChainAttestator))
+
+	for height := uint64(1); height <= 3; height++ {
+		_, err := testCoordinator.GetAttestationForHeight(mockChainID, height)
+		require.NoError(t, err)
+	}
+	for height := uint64(4); height <= 5; height++ {
+		_, err := testCoordinator.GetAttestationForHeight(mockChainID, height)
+		require.ErrorIs(t, err, store.ErrNotFound)
+
+		_, err = testCoordinator.store.GetRetractedAttestation(mockChainID, height)
+		require.NoError(t, err)
+	}
+
+	latestAttestations, err := testCoordinator.GetLatestAttestations()
+	require.NoError(t, err)
+	require.Len(t, latestAttestations, 1)
+	require.Equal(t, uint64(3), latestAttestations[0].Payload.AttestedHeight().RevisionHeight)
+
+	// the forked block at the last signed height has identical attestation data, so it is signed again
+	require.NoError(t, testCoordinator.collectOnce(ctx, mockChainAttestator))
+	_, err = testCoordinator.GetAttestationForHeight(mockChainID, 5)
+	require.NoError(t, err)
+
+	// retracted heights below the last signed height are never signed again, and different attestation data is never
+	// signed at the last signed height
+	mockChainAttestator.updateHeight(4, time.Now())
+	require.NoError(t, testCoordinator.collectOnce(ctx, mockChainAttestator))
+	_, err = testCoordinator.GetAttestationForHeight(mockChainID, 4)
+	require.ErrorIs(t, err, store.ErrNotFound)
+	mockChainAttestator.updateHeight(5, time.Now())
+	require.ErrorIs(t, testCoordinator.collectOnce(ctx, mockChainAttestator), signer.ErrConflictingAttestation)
+
+	// the forked block is not retracted again once the chain moves on
+	mockChainAttestator.updateHeight(6, time.Now())
+	require.NoError(t, testCoordinator.collectOnce(ctx, mockChainAttestator))
+	_, err = testCoordinator.GetAttestationForHeight(mockChainID, 5)
+	require.NoError(t, err)
+
+	// the latest attestation is the one for the highest height
+	attestation, err := testCoordinator.GetAttestationForHeight(mockChainID, 6)
+	require.NoError(t, err)
+	latestAttestations, err = testCoordinator.GetLatestAttestations()
+	require.NoError(t, err)
+	require.Len(t, latestAttestations, 1)
+	require.Equal(t, attestation, latestAttestations[0])
+
+	// if all checked attestations were reorged out, there is no latest attestation
+	mockChainAttestator.reorg(1)
+	require.NoError(t, testCoordinator.checkReorgs(ctx, mockChainAttestator))
+	latestAttestations, err = testCoordinator.GetLatestAttestations()
+	require.NoError(t, err)
+	require.Empty(t, latestAttestations)
+}
+
+// mockLock is a high availability lock that is held or not as set by the test, with the sign state in memory
+type mockLock struct {
+	held  atomic.Bool
+	state signer.SignState
+}
+
+var _ ha.Lock = &mockLock{}
+
+func (l *mockLock) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (l *mockLock) Held() bool {
+	return l.held.Load()
+}
+
+func (l *mockLock) Load() (signer.SignState, error) {
+	if !l.Held() {
+		return signer.SignState{}, signer.ErrStandby
+	}
+	return l.state, nil
+}
+
+func (l *mockLock) Save(state signer.SignState) error {
+	if !l.Held() {
+		return signer.ErrStandby
+	}
+	l.state = state
+	return nil
+}
+
+func (l *mockLock) Close() error {
+	return nil
+}
+
+func TestCoordinator_Standby(t *testing.T) {
+	mockChainAttestator := &MockChainAttestator{}
+	attestationKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	lock := &mockLock{state: signer.SignState{Chains: make(map[string]signer.LastSigned)}}
+	testCoordinator := &coordinator{
+		chainAttestators: map[string]attestator.Attestator{
+			mockChainID: mockChainAttestator,
+		},
+		logger:          zap.NewNop(),
+		store:           store.NewMemStore(),
+		signer:          signer.NewGuard(signer.NewKeySigner(attestationKey), lock),
+		hostChainID:     mockHostChainID,
+		lock:            lock,
+		reorgCheckDepth: defaultReorgCheckDepth,
+	}
+
+	// a standby sidecar collects, but doesn't sign
+	require.True(t, testCoordinator.Standby())
+	mockChainAttestator.updateHeight(1, time.Now())
+	require.NoError(t, testCoordinator.collectOnce(context.Background(), mockChainAttestator))
+	_, err = testCoordinator.GetAttestationForHeight(mockChainID, 1)
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	lock.held.Store(true)
+	require.False(t, testCoordinator.Standby())
+	require.NoError(t, testCoordinator.collectOnce(context.Background(), mockChainAttestator))
+	_, err = testCoordinator.GetAttestationForHeight(mockChainID, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), lock.state.Chains[mockChainID].Height)
+}
+
+func TestCoordinator_ListAndSubscribe(t *testing.T) {
+	mockChainAttestator := &MockChainAttestator{}
+	attestationKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	testCoordinator := &coordinator{
+		chainAttestators: map[string]attestator.Attestator{
+			mockChainID: mockChainAttestator,
+		},
+		logger:          zap.NewNop(),
+		store:           store.NewMemStore(),
+		signer:          newTestGuard(t, attestationKey),
+		hostChainID:     mockHostChainID,
+		reorgCheckDepth: defaultReorgCheckDepth,
+	}
+	testCoordinator.superviseChains(map[string]config.CollectionSchedule{
+		mockChainID: {Interval: time.Second, Timeout: time.Second},
+	})
+
+	_, err = testCoordinator.SubscribeAttestations(context.Background(), []string{"unknown"})
+	require.ErrorIs(t, err, ErrUnknownChain)
+	ctx, cancel := context.WithCancel(context.Background())
+	subscribed, err := testCoordinator.SubscribeAttestations(ctx, []string{mockChainID})
+	require.NoError(t, err)
+	slow, err := testCoordinator.SubscribeAttestations(context.Background(), nil)
+	require.NoError(t, err)
+
+	for height := uint64(1); height <= subscriberBuffer+1; height++ {
+		mockChainAttestator.updateHeight(height, time.Now())
+		require.NoError(t, testCoordinator.collectOnce(context.Background(), mockChainAttestator))
+
+		attestation := <-subscribed
+		require.Equal(t, height, attestation.Payload.AttestedHeight().RevisionHeight)
+	}
+
+	// the subscriber that didn't receive anything was dropped once its buffer was full
+	require.Len(t, slow, subscriberBuffer)
+	for range slow {
+	}
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, ok := <-subscribed
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+
+	attestations, nextHeight, err := testCoordinator.ListAttestations(mockChainID, 10, 0, 25)
+	require.NoError(t, err)
+	require.Len(t, attestations, 25)
+	require.Equal(t, uint64(10), attestations[0].Payload.AttestedHeight().RevisionHeight)
+	require.Equal(t, uint64(35), nextHeight)
+	attestations, nextHeight, err = testCoordinator.ListAttestations(mockChainID, 90, 95, 25)
+	require.NoError(t, err)
+	require.Len(t, attestations, 6)
+	require.Zero(t, nextHeight)
+	_, _, err = testCoordinator.ListAttestations("unknown", 1, 0, 25)
+	require.ErrorIs(t, err, ErrUnknownChain)
+
+	// on standby, attestations are collected but not signed, so the latest attestation lags behind
+	mockChainAttestator.updateHeight(subscriberBuffer+2, time.Now())
+	require.NoError(t, testCoordinator.collectOnce(context.Background(), mockChainAttestator))
+	lock := &mockLock{state: signer.SignState{Chains: make(map[string]signer.LastSigned)}}
+	testCoordinator.signer = signer.NewGuard(signer.NewKeySigner(attestationKey), lock)
+	mockChainAttestator.updateHeight(subscriberBuffer+4, time.Now())
+	require.NoError(t, testCoordinator.collectOnce(context.Background(), mockChainAttestator))
+
+	statuses, err := testCoordinator.GetChainStatuses()
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	require.Equal(t, mockChainID, statuses[0].ChainId)
+	require.Equal(t, string(HealthStateDegraded), statuses[0].Health)
+	require.Nil(t, statuses[0].LastSuccess)
+	require.Equal(t, uint64(subscriberBuffer+2), statuses[0].LatestHeight)
+	require.Equal(t, uint64(subscriberBuffer+4), statuses[0].CollectedHeight)
+	require.Equal(t, uint64(2), statuses[0].LaggingHeights)
+	require.Equal(t, float64(subscriberBuffer+2), testutil.ToFloat64(metrics.LatestAttestedHeight.WithLabelValues(mockChainID)))
+	require.Equal(t, float64(len(mockPacketCommits)), testutil.ToFloat64(metrics.PacketCommitments.WithLabelValues(mockChainID)))
+}
+
+func TestCoordinator_ApplyConfig(t *testing.T) {
+	evmChain := func(chainID string, paused bool) config.EVMChainConfig {
+		return config.EVMChainConfig{
+			ChainID:            chainID,
+			RPC:                "http://localhost:1",
+			Attestation:        true,
+			ClientToUpdate:     chainID + "-client",
+			IBCContractAddress: "0x0000000000000000000000000000000000000001",
+			CollectionConfig:   config.CollectionConfig{CollectionInterval: "1h", Paused: paused},
+		}
+	}
+	sidecarConfig := config.Config{
+		AttestatorID: mockAttestatorID,
+		HostChainID:  mockHostChainID,
+		EVMChains:    []config.EVMChainConfig{evmChain("evm-1", false), evmChain("evm-2", true)},
+	}
+	attestationKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	coordinatorInterface, err := NewCoordinator(zap.NewNop(), nil, store.NewMemStore(), sidecarConfig, newTestGuard(t, attestationKey), nil)
+	require.NoError(t, err)
+	testCoordinator := coordinatorInterface.(*coordinator)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		require.NoError(t, testCoordinator.Run(ctx))
+		close(done)
+	}()
+
+	// the chains are unreachable, so the chains that are not paused stay degraded
+	requireChains := func(expected map[string]HealthState) {
+		t.Helper()
+		statuses, err := testCoordinator.GetChainStatuses()
+		require.NoError(t, err)
+		actual := make(map[string]HealthState)
+		for _, status := range statuses {
+			actual[status.ChainId] = HealthState(status.Health)
+		}
+		require.Equal(t, expected, actual)
+	}
+	getAttestator := func(chainID string) attestator.Attestator {
+		t.Helper()
+		chainAttestator, err := testCoordinator.getAttestator(chainID)
+		require.NoError(t, err)
+		return chainAttestator
+	}
+	requireChains(map[string]HealthState{"evm-1": HealthStateDegraded, "evm-2": HealthStatePaused})
+	evm1, evm2 := getAttestator("evm-1"), getAttestator("evm-2")
+
+	// a new chain is added and a paused chain resumed, without touching the unchanged chain
+	sidecarConfig.EVMChains = []config.EVMChainConfig{evmChain("evm-1", false), evmChain("evm-2", false), evmChain("evm-3", false)}
+	require.NoError(t, testCoordinator.ApplyConfig(sidecarConfig))
+	requireChains(map[string]HealthState{"evm-1": HealthStateDegraded, "evm-2": HealthStateDegraded, "evm-3": HealthStateDegraded})
+	require.Same(t, evm1, getAttestator("evm-1"))
+	require.Same(t, evm2, getAttestator("evm-2"))
+	evm3 := getAttestator("evm-3")
+
+	// a changed chain is restarted with a new attestator, and a removed chain is forgotten
+	changedChain := evmChain("evm-1", false)
+	changedChain.RPC = "http://localhost:2"
+	sidecarConfig.EVMChains = []config.EVMChainConfig{changedChain, evmChain("evm-3", true)}
+	require.NoError(t, testCoordinator.ApplyConfig(sidecarConfig))
+	requireChains(map[string]HealthState{"evm-1": HealthStateDegraded, "evm-3": HealthStatePaused})
+	require.NotSame(t, evm1, getAttestator("evm-1"))
+	require.Same(t, evm3, getAttestator("evm-3"))
+	_, err = testCoordinator.GetAttestationForHeight("evm-2", 1)
+	require.ErrorIs(t, err, ErrUnknownChain)
+
+	// nothing is applied if the config is invalid, or if any new chain can't be set up
+	evm1 = getAttestator("evm-1")
+	invalidChain := evmChain("evm-4", false)
+	invalidChain.IBCContractAddress = ""
+	require.Error(t, testCoordinator.ApplyConfig(config.Config{
+		AttestatorID: mockAttestatorID,
+		HostChainID:  mockHostChainID,
+		EVMChains:    []config.EVMChainConfig{invalidChain},
+	}))
+	unreachableChain := evmChain("evm-4", false)
+	unreachableChain.RPC = "unknown://localhost"
+	require.Error(t, testCoordinator.ApplyConfig(config.Config{
+		AttestatorID: mockAttestatorID,
+		HostChainID:  mockHostChainID,
+		EVMChains:    []config.EVMChainConfig{unreachableChain},
+	}))
+	requireChains(map[string]HealthState{"evm-1": HealthStateDegraded, "evm-3": HealthStatePaused})
+	require.Same(t, evm1, getAttestator("evm-1"))
+
+	// only the chains of a config are applied, so changing the other settings requires a restart every time
+	otherSettings := sidecarConfig
+	otherSettings.HostChainID = "other-host-chain-id"
+	otherSettings.EVMChains = []config.EVMChainConfig{changedChain}
+	require.NoError(t, testCoordinator.ApplyConfig(otherSettings))
+	requireChains(map[string]HealthState{"evm-1": HealthStateDegraded})
+	require.Equal(t, mockHostChainID, testCoordinator.sidecarConfig.HostChainID)
+	require.Equal(t, otherSettings.EVMChains, testCoordinator.sidecarConfig.EVMChains)
+	require.True(t, requiresRestart(testCoordinator.sidecarConfig, otherSettings))
+
+	cancel()
+	<-done
+	require.NoError(t, testCoordinator.Close())
+}
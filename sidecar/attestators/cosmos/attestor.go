@@ -1,53 +1,75 @@
 package cosmos
 
 import (
-	"time"
+	"bytes"
+	"context"
 
-	clientwrapper "github.com/strangelove-ventures/cometbft-client/client"
+	"github.com/dgraph-io/badger/v4"
 	"go.uber.org/zap"
 
 	"github.com/cosmos/interchain-attestation/sidecar/attestators/attestator"
+	"github.com/cosmos/interchain-attestation/sidecar/attestators/finality"
 	"github.com/cosmos/interchain-attestation/sidecar/config"
 )
 
-var _ attestator.Attestator = &Attestator{}
+var (
+	_ attestator.Attestator                = &Attestator{}
+	_ attestator.ChannelTopologyAttestator = &Attestator{}
+)
 
+// Attestator attests to the packet commitments of a client on a Cosmos chain. The chain is read from all of its rpc
+// endpoints independently, and only data that a quorum of the endpoints agree on is attested to.
 type Attestator struct {
 	logger *zap.Logger
 
-	clientConn  *ClientConn
-	cometClient *clientwrapper.Client
-	codec       CodecConfig
+	endpoints []*endpoint
+	quorum    int
 
 	attestatorID string
 	config       config.CosmosChainConfig
+	finality     finality.Policy
 }
 
-func NewCosmosAttestator(logger *zap.Logger, attestatorID string, config config.CosmosChainConfig) (*Attestator, error) {
-	cometClient, err := clientwrapper.NewClient(config.RPC, time.Second*30) // TODO: Make timeout configurable per chain
+// NewCosmosAttestator creates an attestator for the chain, which tracks the packet commitments of its client in the db
+func NewCosmosAttestator(logger *zap.Logger, db *badger.DB, attestatorID string, chainConfig config.CosmosChainConfig) (*Attestator, error) {
+	// CometBFT has instant finality, so by default the latest committed block is attested to
+	finalityPolicy, err := finality.NewPolicy(chainConfig.ChainID, chainConfig.FinalityConfig, config.FinalityPolicyFinalized)
 	if err != nil {
 		return nil, err
 	}
 
 	codec := NewCodecConfig()
 
-	clientConn := &ClientConn{
-		cometClient: cometClient,
-		codec:       codec,
+	var endpoints []*endpoint
+	for _, rpc := range chainConfig.GetRPCEndpoints() {
+		e, err := newEndpoint(logger, db, chainConfig, rpc, codec)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, e)
 	}
 
 	return &Attestator{
 		logger: logger,
 
-		clientConn:  clientConn,
-		cometClient: cometClient,
-		codec:       codec,
+		endpoints: endpoints,
+		quorum:    chainConfig.GetQuorum(),
 
 		attestatorID: attestatorID,
-		config:       config,
+		config:       chainConfig,
+		finality:     finalityPolicy,
 	}, nil
 }
 
 func (c *Attestator) ChainID() string {
 	return c.config.ChainID
 }
+
+// BlockHash returns the hash of the header at the height that a quorum of the endpoints agree on
+func (c *Attestator) BlockHash(ctx context.Context, height uint64) ([]byte, error) {
+	return quorumRead(ctx, c, "block_hash", func(ctx context.Context, e *endpoint) ([]byte, error) {
+		return e.blockHash(ctx, height)
+	}, func(hash []byte) ([]byte, error) {
+		return bytes.Clone(hash), nil
+	})
+}
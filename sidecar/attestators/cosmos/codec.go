@@ -12,6 +12,7 @@ import (
 	ibcchanneltypes "github.com/cosmos/ibc-go/v9/modules/core/04-channel/types"
 	tmclient "github.com/cosmos/ibc-go/v9/modules/light-clients/07-tendermint"
 
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
 	"github.com/cosmos/interchain-attestation/core/lightclient"
 )
 
@@ -31,6 +32,7 @@ func NewCodecConfig() CodecConfig {
 	tmclient.RegisterInterfaces(interfaceRegistry)
 	lightclient.RegisterInterfaces(interfaceRegistry)
 	transfertypes.RegisterInterfaces(interfaceRegistry)
+	bls12381.RegisterInterfaces(interfaceRegistry)
 	cdc := codec.NewProtoCodec(interfaceRegistry)
 
 	return CodecConfig{
@@ -0,0 +1,134 @@
+package cosmos
+
+import (
+	"context"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	clientwrapper "github.com/strangelove-ventures/cometbft-client/client"
+	"gitlab.com/tozd/go/errors"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/sidecar/attestators/finality"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+var _ finality.Chain = &endpoint{}
+
+// endpoint is one of the rpc endpoints of a chain. Every endpoint is read independently, with its own light client
+// and its own tracked packet commitments.
+type endpoint struct {
+	logger *zap.Logger
+	db     *badger.DB
+
+	rpc    string
+	config config.CosmosChainConfig
+
+	clientConn  *ClientConn
+	cometClient *clientwrapper.Client
+	lightClient *lightClient
+	codec       CodecConfig
+
+	// the channel topology is cached in the tracker state, and refreshed at least every topologyRefreshInterval
+	topologyRefreshInterval time.Duration
+	topologyRefreshedAt     time.Time
+}
+
+func newEndpoint(logger *zap.Logger, db *badger.DB, chainConfig config.CosmosChainConfig, rpc string, codec CodecConfig) (*endpoint, error) {
+	cometClient, err := clientwrapper.NewClient(rpc, time.Second*30) // TODO: Make timeout configurable per chain
+	if err != nil {
+		return nil, err
+	}
+
+	topologyRefreshInterval, err := chainConfig.GetTopologyRefreshInterval()
+	if err != nil {
+		return nil, err
+	}
+
+	// The light client cross-checks its headers with the configured witnesses, or with the other endpoints of the chain.
+	// With neither, the rpc is used as its own witness: the headers are still verified against the validator set,
+	// but are not cross-checked with another node.
+	witnesses := chainConfig.Witnesses
+	if len(witnesses) == 0 {
+		for _, otherRPC := range chainConfig.GetRPCEndpoints() {
+			if otherRPC != rpc {
+				witnesses = append(witnesses, otherRPC)
+			}
+		}
+	}
+	if len(witnesses) == 0 {
+		witnesses = []string{rpc}
+	}
+	lightClient := newLightClient(chainConfig, rpc, witnesses)
+
+	return &endpoint{
+		logger: logger,
+		db:     db,
+
+		rpc:    rpc,
+		config: chainConfig,
+
+		clientConn: &ClientConn{
+			cometClient: cometClient,
+			codec:       codec,
+			lightClient: lightClient,
+		},
+		cometClient: cometClient,
+		lightClient: lightClient,
+		codec:       codec,
+
+		topologyRefreshInterval: topologyRefreshInterval,
+	}, nil
+}
+
+// LatestHeight returns the latest height with state that can be verified, which is the height before the last block
+// committed by the application, since the app hash of the state at a height is in the header of the next block
+func (e *endpoint) LatestHeight(ctx context.Context) (uint64, error) {
+	resp, err := e.cometClient.ABCIInfo(ctx)
+	if err != nil {
+		return 0, errors.Errorf("failed to query status for chain id %s: %w", e.config.ChainID, err)
+	}
+	if resp.Response.LastBlockHeight < 2 {
+		return 0, errors.Errorf("chain id %s has no verifiable state yet at height %d", e.config.ChainID, resp.Response.LastBlockHeight)
+	}
+
+	return uint64(resp.Response.LastBlockHeight - 1), nil
+}
+
+// FinalizedHeight is the same as LatestHeight, since a CometBFT block is final once it has been committed
+func (e *endpoint) FinalizedHeight(ctx context.Context) (uint64, error) {
+	return e.LatestHeight(ctx)
+}
+
+// blockHash returns the hash of the light client verified header at the height
+func (e *endpoint) blockHash(ctx context.Context, height uint64) ([]byte, error) {
+	lightBlock, err := e.lightClient.verifiedLightBlock(ctx, int64(height))
+	if err != nil {
+		return nil, err
+	}
+
+	return lightBlock.Hash(), nil
+}
+
+// collectIBCData reads the attestation data for the client at the height, verified by the light client
+func (e *endpoint) collectIBCData(ctx context.Context, height uint64) (types.IBCData, error) {
+	packetCommitments, err := e.trackedPacketCommitments(ctx, height)
+	if err != nil {
+		return types.IBCData{}, errors.Errorf("failed to query packet commitments for client id %s on chain id %s: %w", e.config.ClientID, e.config.ChainID, err)
+	}
+
+	lightBlock, err := e.lightClient.verifiedLightBlock(ctx, int64(height))
+	if err != nil {
+		return types.IBCData{}, err
+	}
+
+	return types.IBCData{
+		ChainId:           e.config.ChainID,
+		ClientId:          e.config.ClientID,
+		ClientToUpdate:    e.config.ClientToUpdate,
+		Height:            e.config.GetClientHeight(height),
+		Timestamp:         lightBlock.Time,
+		PacketCommitments: packetCommitments,
+	}, nil
+}
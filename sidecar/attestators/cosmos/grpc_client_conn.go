@@ -35,6 +35,7 @@ import (
 type ClientConn struct {
 	cometClient *clientwrapper.Client
 	codec       CodecConfig
+	lightClient *lightClient // verifies the proofs of store queries
 }
 
 var _ gogogrpc.ClientConn = &ClientConn{}
@@ -144,11 +145,15 @@ func (c *ClientConn) QueryABCI(ctx context.Context, req abci.RequestQuery) (abci
 		return abci.ResponseQuery{}, sdkErrorToGRPCError(res.Response)
 	}
 
-	// data from trusted node or subspace query doesn't need verification
+	// subspace queries can't be verified
 	if !opts.Prove || !isQueryStoreWithProof(req.Path) {
 		return res.Response, nil
 	}
 
+	if err := c.lightClient.verifyQuery(ctx, req, res.Response); err != nil {
+		return abci.ResponseQuery{}, err
+	}
+
 	return res.Response, nil
 }
 
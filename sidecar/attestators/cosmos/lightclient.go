@@ -0,0 +1,131 @@
+package cosmos
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab.com/tozd/go/errors"
+
+	"cosmossdk.io/store/rootmulti"
+
+	dbm "github.com/cometbft/cometbft-db"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/light"
+	lightdb "github.com/cometbft/cometbft/light/store/db"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+// lightClient verifies headers of the chain with a CometBFT light client, initialized from the trusted height and hash
+// in the config, so that query results can be verified against the app hash instead of trusting the rpc.
+// The light client is created on first use, so that the sidecar can start while the chain is unreachable.
+type lightClient struct {
+	chainConfig config.CosmosChainConfig
+	primary     string
+	witnesses   []string
+
+	lock   sync.Mutex
+	client *light.Client
+}
+
+func newLightClient(chainConfig config.CosmosChainConfig, primary string, witnesses []string) *lightClient {
+	return &lightClient{
+		chainConfig: chainConfig,
+		primary:     primary,
+		witnesses:   witnesses,
+	}
+}
+
+func (l *lightClient) getClient(ctx context.Context) (*light.Client, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.client != nil {
+		return l.client, nil
+	}
+
+	trustOptions, err := l.chainConfig.GetTrustOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	// TODO: Persist the trusted light blocks, so the trusted hash in the config does not expire across restarts
+	client, err := light.NewHTTPClient(
+		ctx,
+		l.chainConfig.ChainID,
+		trustOptions,
+		l.primary,
+		l.witnesses,
+		lightdb.New(dbm.NewMemDB(), l.chainConfig.ChainID),
+		light.Logger(log.NewNopLogger()),
+	)
+	if err != nil {
+		return nil, errors.Errorf("failed to create light client for chain id %s: %w", l.chainConfig.ChainID, err)
+	}
+	l.client = client
+
+	return client, nil
+}
+
+// verifiedLightBlock returns the light block at the height, verified by the light client
+func (l *lightClient) verifiedLightBlock(ctx context.Context, height int64) (*cmttypes.LightBlock, error) {
+	client, err := l.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lightBlock, err := client.VerifyLightBlockAtHeight(ctx, height, time.Now())
+	if err != nil {
+		return nil, errors.Errorf("failed to verify light block %d for chain id %s: %w", height, l.chainConfig.ChainID, err)
+	}
+
+	return lightBlock, nil
+}
+
+// verifyQuery verifies the proof of a store query result, against the app hash of the header after the queried height
+// (which is the app hash of the state at the queried height)
+func (l *lightClient) verifyQuery(ctx context.Context, req abci.RequestQuery, res abci.ResponseQuery) error {
+	if req.Height != 0 && res.Height != req.Height {
+		return errors.Errorf("query response is for height %d instead of %d", res.Height, req.Height)
+	}
+
+	lightBlock, err := l.verifiedLightBlock(ctx, res.Height+1)
+	if err != nil {
+		return err
+	}
+
+	// the path has already been checked to be /store/<store name>/key
+	storeName := strings.SplitN(req.Path[1:], "/", 3)[1]
+
+	return verifyStoreProof(lightBlock.AppHash, storeName, req.Data, res)
+}
+
+// verifyStoreProof verifies the value (or absence of a value) for the key in the store against the app hash
+func verifyStoreProof(appHash []byte, storeName string, key []byte, res abci.ResponseQuery) error {
+	if res.ProofOps == nil {
+		return errors.New("query response has no proof")
+	}
+
+	keyPath := merkle.KeyPath{}.
+		AppendKey([]byte(storeName), merkle.KeyEncodingURL).
+		AppendKey(key, merkle.KeyEncodingURL)
+
+	prt := rootmulti.DefaultProofRuntime()
+	if len(res.Value) == 0 {
+		if err := prt.VerifyAbsence(res.ProofOps, appHash, keyPath.String()); err != nil {
+			return errors.Errorf("failed to verify absence proof for key %X in store %s: %w", key, storeName, err)
+		}
+		return nil
+	}
+
+	if err := prt.VerifyValue(res.ProofOps, appHash, keyPath.String(), res.Value); err != nil {
+		return errors.Errorf("failed to verify proof for key %X in store %s: %w", key, storeName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package cosmos
+
+import (
+	"testing"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/metrics"
+	"cosmossdk.io/store/rootmulti"
+	storetypes "cosmossdk.io/store/types"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	host "github.com/cosmos/ibc-go/v9/modules/core/24-host"
+	ibcexported "github.com/cosmos/ibc-go/v9/modules/core/exported"
+)
+
+func TestVerifyStoreProof(t *testing.T) {
+	ibcKey := storetypes.NewKVStoreKey(ibcexported.StoreKey)
+	otherKey := storetypes.NewKVStoreKey("other")
+
+	store := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger(), metrics.NewNoOpMetrics())
+	store.MountStoreWithDB(ibcKey, storetypes.StoreTypeIAVL, nil)
+	store.MountStoreWithDB(otherKey, storetypes.StoreTypeIAVL, nil)
+	require.NoError(t, store.LoadLatestVersion())
+
+	commitmentKey := host.PacketCommitmentKey("transfer", "channel-0", 1)
+	commitment := []byte{1, 2, 3}
+	store.GetCommitKVStore(ibcKey).Set(commitmentKey, commitment)
+	store.GetCommitKVStore(otherKey).Set(commitmentKey, []byte{4, 5, 6})
+	commitID := store.Commit()
+	appHash := commitID.Hash
+
+	query := func(storeName string, key []byte) abci.ResponseQuery {
+		res, err := store.Query(&storetypes.RequestQuery{
+			Path:   "/" + storeName + "/key",
+			Data:   key,
+			Height: commitID.Version,
+			Prove:  true,
+		})
+		require.NoError(t, err)
+		return abci.ResponseQuery{
+			Key:      res.Key,
+			Value:    res.Value,
+			ProofOps: res.ProofOps,
+			Height:   res.Height,
+		}
+	}
+
+	res := query(ibcexported.StoreKey, commitmentKey)
+	require.Equal(t, commitment, res.Value)
+	require.NoError(t, verifyStoreProof(appHash, ibcexported.StoreKey, commitmentKey, res))
+
+	// a different value for the key does not verify
+	tamperedRes := res
+	tamperedRes.Value = []byte{7, 8, 9}
+	require.ErrorContains(t, verifyStoreProof(appHash, ibcexported.StoreKey, commitmentKey, tamperedRes), "failed to verify proof")
+
+	// neither does the value of another key, or the same key in another store
+	otherCommitmentKey := host.PacketCommitmentKey("transfer", "channel-0", 2)
+	require.Error(t, verifyStoreProof(appHash, ibcexported.StoreKey, otherCommitmentKey, res))
+	require.Error(t, verifyStoreProof(appHash, ibcexported.StoreKey, commitmentKey, query("other", commitmentKey)))
+
+	// or against another app hash
+	require.Error(t, verifyStoreProof(make([]byte, 32), ibcexported.StoreKey, commitmentKey, res))
+
+	// the absence of a key is proven
+	absentRes := query(ibcexported.StoreKey, otherCommitmentKey)
+	require.Empty(t, absentRes.Value)
+	require.NoError(t, verifyStoreProof(appHash, ibcexported.StoreKey, otherCommitmentKey, absentRes))
+
+	// and a value can't be hidden with the absence proof of another key
+	hiddenRes := absentRes
+	require.ErrorContains(t, verifyStoreProof(appHash, ibcexported.StoreKey, commitmentKey, hiddenRes), "failed to verify absence proof")
+
+	// responses without proofs are rejected
+	res.ProofOps = nil
+	require.EqualError(t, verifyStoreProof(appHash, ibcexported.StoreKey, commitmentKey, res), "query response has no proof")
+}
@@ -2,49 +2,64 @@ package cosmos
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"time"
 
 	"gitlab.com/tozd/go/errors"
+	"google.golang.org/grpc/metadata"
 
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
 	querytypes "github.com/cosmos/cosmos-sdk/types/query"
 
+	abci "github.com/cometbft/cometbft/abci/types"
+
 	connectiontypes "github.com/cosmos/ibc-go/v9/modules/core/03-connection/types"
 	chantypes "github.com/cosmos/ibc-go/v9/modules/core/04-channel/types"
+	host "github.com/cosmos/ibc-go/v9/modules/core/24-host"
+	ibcexported "github.com/cosmos/ibc-go/v9/modules/core/exported"
 )
 
 const PaginationDelay = 10 * time.Millisecond
 
-func (c *Attestator) queryConnectionsForClient(ctx context.Context, clientID string) ([]string, error) {
-	qc := connectiontypes.NewQueryClient(c.clientConn)
-	connections, err := qc.ClientConnections(ctx, &connectiontypes.QueryClientConnectionsRequest{
-		ClientId: clientID,
+// queryIBCStore reads the value at the key in the ibc store at the height, with a proof that is verified by the light client.
+// An empty value means the key is proven to not exist.
+func (e *endpoint) queryIBCStore(ctx context.Context, height uint64, key []byte) ([]byte, error) {
+	res, err := e.clientConn.QueryABCI(ctx, abci.RequestQuery{
+		Path:   fmt.Sprintf("/store/%s/key", ibcexported.StoreKey),
+		Data:   key,
+		Height: int64(height),
+		Prove:  true,
 	})
 	if err != nil {
-		return nil, err
+		return nil, errors.Errorf("failed to query key %s at height %d on chain id %s: %w", key, height, e.config.ChainID, err)
 	}
 
-	return connections.ConnectionPaths, nil
+	return res.Value, nil
 }
 
-func (c *Attestator) queryPacketCommitments(ctx context.Context, clientID string) (*chantypes.QueryPacketCommitmentsResponse, error) {
-	// TODO: Check if the client is in the correct state
-	// TODO: Cache some of this crap
-	// TODO: Add support for ibc lite (i.e. skip a bunch of this)
-
-	connections, err := c.queryConnectionsForClient(ctx, clientID)
+func (e *endpoint) queryConnectionsForClient(ctx context.Context, height uint64, clientID string) ([]string, error) {
+	bz, err := e.queryIBCStore(ctx, height, host.ClientConnectionsKey(clientID))
 	if err != nil {
 		return nil, err
 	}
 
-	if len(connections) == 0 {
-		return nil, errors.Errorf("no connections found for client id %s", clientID)
+	var clientPaths connectiontypes.ClientPaths
+	if err := e.codec.Marshaler.Unmarshal(bz, &clientPaths); err != nil {
+		return nil, err
 	}
 
-	qc := chantypes.NewQueryClient(c.clientConn)
+	return clientPaths.Paths, nil
+}
+
+// queryOpenChannelsForConnection lists the channels of the connection (which can't be proven), and verifies that each
+// channel is built on the connection
+func (e *endpoint) queryOpenChannelsForConnection(ctx context.Context, height uint64, connectionID string) ([]*chantypes.IdentifiedChannel, error) {
+	qc := chantypes.NewQueryClient(e.clientConn)
 
 	var channels []*chantypes.IdentifiedChannel
 	p := defaultPageRequest()
-	for _, connectionID := range connections {
+	for {
 		res, err := qc.ConnectionChannels(ctx, &chantypes.QueryConnectionChannelsRequest{
 			Connection: connectionID,
 			Pagination: p,
@@ -53,7 +68,26 @@ func (c *Attestator) queryPacketCommitments(ctx context.Context, clientID string
 			return nil, err
 		}
 
-		channels = append(channels, res.Channels...)
+		for _, listedChannel := range res.Channels {
+			bz, err := e.queryIBCStore(ctx, height, host.ChannelKey(listedChannel.PortId, listedChannel.ChannelId))
+			if err != nil {
+				return nil, err
+			}
+
+			var channel chantypes.Channel
+			if err := e.codec.Marshaler.Unmarshal(bz, &channel); err != nil {
+				return nil, err
+			}
+			if len(channel.ConnectionHops) == 0 || channel.ConnectionHops[0] != connectionID {
+				return nil, errors.Errorf("listed channel %s/%s is not on connection %s", listedChannel.PortId, listedChannel.ChannelId, connectionID)
+			}
+			if channel.State != chantypes.OPEN {
+				continue
+			}
+
+			identifiedChannel := chantypes.NewIdentifiedChannel(listedChannel.PortId, listedChannel.ChannelId, channel)
+			channels = append(channels, &identifiedChannel)
+		}
 
 		next := res.GetPagination().GetNextKey()
 		if len(next) == 0 {
@@ -63,29 +97,76 @@ func (c *Attestator) queryPacketCommitments(ctx context.Context, clientID string
 		p.Key = next
 	}
 
-	if len(channels) == 0 {
-		return nil, errors.Errorf("no channels found for client id %s", clientID)
+	return channels, nil
+}
+
+// queryClientChannels returns the connections of the client and their open channels at the height
+func (e *endpoint) queryClientChannels(ctx context.Context, height uint64, clientID string) (channelTopology, error) {
+	// TODO: Check if the client is in the correct state
+	// TODO: Add support for ibc lite (i.e. skip a bunch of this)
+
+	// the gRPC queries are made against the same height as the proven queries
+	ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatUint(height, 10))
+
+	connections, err := e.queryConnectionsForClient(ctx, height, clientID)
+	if err != nil {
+		return channelTopology{}, err
+	}
+
+	topology := channelTopology{
+		Height:      height,
+		Connections: connections,
+	}
+	for _, connectionID := range connections {
+		connectionChannels, err := e.queryOpenChannelsForConnection(ctx, height, connectionID)
+		if err != nil {
+			return channelTopology{}, err
+		}
+		for _, channel := range connectionChannels {
+			topology.Channels = append(topology.Channels, topologyChannel{
+				PortID:       channel.PortId,
+				ChannelID:    channel.ChannelId,
+				ConnectionID: connectionID,
+			})
+		}
 	}
 
-	commitments := &chantypes.QueryPacketCommitmentsResponse{}
-	p = defaultPageRequest()
+	return topology, nil
+}
+
+// queryPacketCommitments returns all the packet commitments of the channels at the height, by their key in the ibc store.
+// The packet commitments are listed with gRPC queries, which can't be proven, so every listed commitment is read again
+// from the ibc store with a proof that is verified by the light client. A dishonest rpc can hide packet commitments
+// this way, but it can't make them up.
+func (e *endpoint) queryPacketCommitments(ctx context.Context, height uint64, channels []topologyChannel) (map[string][]byte, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatUint(height, 10))
+
+	qc := chantypes.NewQueryClient(e.clientConn)
+	commitments := make(map[string][]byte)
 	for _, channel := range channels {
+		p := defaultPageRequest()
 		for {
-			if channel.State != chantypes.OPEN {
-				break
-			}
-
 			res, err := qc.PacketCommitments(ctx, &chantypes.QueryPacketCommitmentsRequest{
-				PortId:     channel.PortId,
-				ChannelId:  channel.ChannelId,
+				PortId:     channel.PortID,
+				ChannelId:  channel.ChannelID,
 				Pagination: p,
 			})
 			if err != nil {
 				return nil, err
 			}
 
-			commitments.Commitments = append(commitments.Commitments, res.Commitments...)
-			commitments.Height = res.Height
+			for _, listedCommitment := range res.Commitments {
+				key := host.PacketCommitmentKey(channel.PortID, channel.ChannelID, listedCommitment.Sequence)
+				commitment, err := e.queryIBCStore(ctx, height, key)
+				if err != nil {
+					return nil, err
+				}
+				if len(commitment) == 0 {
+					return nil, errors.Errorf("listed packet commitment %s/%s/%d does not exist", channel.PortID, channel.ChannelID, listedCommitment.Sequence)
+				}
+				commitments[string(key)] = commitment
+			}
+
 			next := res.GetPagination().GetNextKey()
 			if len(next) == 0 {
 				break
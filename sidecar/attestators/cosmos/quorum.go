@@ -0,0 +1,178 @@
+package cosmos
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sync"
+	"time"
+
+	"gitlab.com/tozd/go/errors"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/sidecar/metrics"
+)
+
+// endpointResult is the result of reading from one of the endpoints
+type endpointResult[T any] struct {
+	endpoint *endpoint
+	value    T
+	err      error
+	duration time.Duration
+}
+
+// readEndpoints reads from all the endpoints in parallel, and records the latency and errors of each endpoint
+func readEndpoints[T any](ctx context.Context, c *Attestator, readName string, read func(ctx context.Context, e *endpoint) (T, error)) []endpointResult[T] {
+	results := make([]endpointResult[T], len(c.endpoints))
+
+	var wg sync.WaitGroup
+	for i, e := range c.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			value, err := read(ctx, e)
+			results[i] = endpointResult[T]{
+				endpoint: e,
+				value:    value,
+				err:      err,
+				duration: time.Since(start),
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		endpointLabel := metrics.EndpointLabel(result.endpoint.rpc)
+		metrics.EndpointReadDuration.WithLabelValues(c.config.ChainID, endpointLabel, readName).Observe(result.duration.Seconds())
+		if result.err != nil {
+			metrics.EndpointErrors.WithLabelValues(c.config.ChainID, endpointLabel, readName).Inc()
+			c.logger.Warn("Failed to read from endpoint",
+				zap.String("chain_id", c.config.ChainID),
+				zap.String("endpoint", endpointLabel),
+				zap.String("read", readName),
+				zap.Duration("latency", result.duration),
+				zap.Error(result.err),
+			)
+			continue
+		}
+
+		c.logger.Debug("Read from endpoint",
+			zap.String("chain_id", c.config.ChainID),
+			zap.String("endpoint", endpointLabel),
+			zap.String("read", readName),
+			zap.Duration("latency", result.duration),
+		)
+	}
+
+	return results
+}
+
+// quorumRead reads from all the endpoints, and returns the value that at least a quorum of the endpoints returned.
+// Values are compared by their encoding, and the endpoints that returned anything else are reported as disagreeing.
+func quorumRead[T any](ctx context.Context, c *Attestator, readName string, read func(ctx context.Context, e *endpoint) (T, error), encode func(T) ([]byte, error)) (T, error) {
+	results := readEndpoints(ctx, c, readName, read)
+
+	encodings := make([]string, len(results))
+	votes := make(map[string]int)
+	for i, result := range results {
+		if result.err != nil {
+			continue
+		}
+
+		bz, err := encode(result.value)
+		if err != nil {
+			var empty T
+			return empty, errors.Errorf("failed to encode %s from endpoint %s: %w", readName, metrics.EndpointLabel(result.endpoint.rpc), err)
+		}
+		encodings[i] = string(bz)
+		votes[encodings[i]]++
+	}
+
+	// with a quorum of less than a majority, two different results can both reach it, which is treated as no quorum
+	var quorumEncoding string
+	quorumVotes, tied := 0, false
+	for encoding, numVotes := range votes {
+		switch {
+		case numVotes > quorumVotes:
+			quorumEncoding, quorumVotes, tied = encoding, numVotes, false
+		case numVotes == quorumVotes:
+			tied = true
+		}
+	}
+
+	if quorumVotes < c.quorum || tied {
+		metrics.QuorumFailures.WithLabelValues(c.config.ChainID, readName).Inc()
+		for i, result := range results {
+			if result.err != nil {
+				continue
+			}
+			c.logger.Warn("Endpoint result without quorum",
+				zap.String("chain_id", c.config.ChainID),
+				zap.String("endpoint", metrics.EndpointLabel(result.endpoint.rpc)),
+				zap.String("read", readName),
+				zap.String("result_hash", resultHash(encodings[i])),
+			)
+		}
+
+		var empty T
+		if tied {
+			return empty, errors.Errorf("endpoints returned conflicting %s for chain id %s with %d votes each", readName, c.config.ChainID, quorumVotes)
+		}
+		return empty, errors.Errorf("only %d of %d endpoints agree on %s for chain id %s, quorum is %d", quorumVotes, len(results), readName, c.config.ChainID, c.quorum)
+	}
+
+	var quorumValue T
+	for i, result := range results {
+		if result.err != nil {
+			continue
+		}
+		if encodings[i] == quorumEncoding {
+			quorumValue = result.value
+			continue
+		}
+
+		endpointLabel := metrics.EndpointLabel(result.endpoint.rpc)
+		metrics.EndpointDisagreements.WithLabelValues(c.config.ChainID, endpointLabel, readName).Inc()
+		c.logger.Warn("Endpoint disagrees with quorum",
+			zap.String("chain_id", c.config.ChainID),
+			zap.String("endpoint", endpointLabel),
+			zap.String("read", readName),
+			zap.String("result_hash", resultHash(encodings[i])),
+			zap.String("quorum_result_hash", resultHash(quorumEncoding)),
+		)
+	}
+
+	return quorumValue, nil
+}
+
+// quorumFinalHeight returns the highest height that is final on at least a quorum of the endpoints. Endpoints are
+// expected to be at slightly different heights, so they don't have to agree on the exact height.
+func (c *Attestator) quorumFinalHeight(ctx context.Context) (uint64, error) {
+	results := readEndpoints(ctx, c, "final_height", func(ctx context.Context, e *endpoint) (uint64, error) {
+		return c.finality.FinalHeight(ctx, e)
+	})
+
+	var heights []uint64
+	for _, result := range results {
+		if result.err == nil {
+			heights = append(heights, result.value)
+		}
+	}
+	if len(heights) < c.quorum {
+		metrics.QuorumFailures.WithLabelValues(c.config.ChainID, "final_height").Inc()
+		return 0, errors.Errorf("only %d of %d endpoints returned a final height for chain id %s, quorum is %d", len(heights), len(results), c.config.ChainID, c.quorum)
+	}
+
+	slices.Sort(heights)
+	slices.Reverse(heights)
+
+	return heights[c.quorum-1], nil
+}
+
+func resultHash(encoding string) string {
+	hash := sha256.Sum256([]byte(encoding))
+	return hex.EncodeToString(hash[:])
+}
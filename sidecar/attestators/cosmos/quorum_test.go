@@ -0,0 +1,146 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/tozd/go/errors"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/sidecar/attestators/finality"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+	"github.com/cosmos/interchain-attestation/sidecar/metrics"
+)
+
+// mockFinalityPolicy returns a fixed final height per endpoint
+type mockFinalityPolicy map[string]uint64
+
+func (m mockFinalityPolicy) FinalHeight(_ context.Context, chain finality.Chain) (uint64, error) {
+	height, ok := m[chain.(*endpoint).rpc]
+	if !ok {
+		return 0, errors.New("endpoint unavailable")
+	}
+	return height, nil
+}
+
+func newQuorumTestAttestator(chainID string, quorum int, rpcs ...string) *Attestator {
+	attestator := &Attestator{
+		logger: zap.NewNop(),
+		quorum: quorum,
+		config: config.CosmosChainConfig{ChainID: chainID},
+	}
+	for _, rpc := range rpcs {
+		attestator.endpoints = append(attestator.endpoints, &endpoint{rpc: rpc})
+	}
+	return attestator
+}
+
+func TestQuorumRead(t *testing.T) {
+	tests := []struct {
+		name          string
+		quorum        int
+		results       map[string]string // by endpoint, missing endpoints fail
+		expValue      string
+		expErr        string
+		disagreements []string
+	}{
+		{
+			name:     "all agree",
+			quorum:   2,
+			results:  map[string]string{"http://a:26657": "data", "http://b:26657": "data", "http://c:26657": "data"},
+			expValue: "data",
+		},
+		{
+			name:          "one disagrees",
+			quorum:        2,
+			results:       map[string]string{"http://a:26657": "data", "http://b:26657": "fake", "http://c:26657": "data"},
+			expValue:      "data",
+			disagreements: []string{"http://b:26657"},
+		},
+		{
+			name:     "one fails",
+			quorum:   2,
+			results:  map[string]string{"http://a:26657": "data", "http://c:26657": "data"},
+			expValue: "data",
+		},
+		{
+			name:    "not enough agree",
+			quorum:  3,
+			results: map[string]string{"http://a:26657": "data", "http://b:26657": "fake", "http://c:26657": "data"},
+			expErr:  "only 2 of 3 endpoints agree on test_read for chain id %s, quorum is 3",
+		},
+		{
+			name:    "not enough respond",
+			quorum:  2,
+			results: map[string]string{"http://a:26657": "data"},
+			expErr:  "only 1 of 3 endpoints agree on test_read for chain id %s, quorum is 2",
+		},
+		{
+			name:    "conflicting results reach a minority quorum",
+			quorum:  1,
+			results: map[string]string{"http://a:26657": "data", "http://b:26657": "fake"},
+			expErr:  "endpoints returned conflicting test_read for chain id %s with 1 votes each",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the chain id is unique per test case, so the metrics don't carry over between test cases
+			chainID := tt.name
+			attestator := newQuorumTestAttestator(chainID, tt.quorum, "http://a:26657", "http://b:26657", "http://c:26657")
+
+			value, err := quorumRead(context.Background(), attestator, "test_read", func(_ context.Context, e *endpoint) (string, error) {
+				result, ok := tt.results[e.rpc]
+				if !ok {
+					return "", errors.New("endpoint unavailable")
+				}
+				return result, nil
+			}, func(value string) ([]byte, error) {
+				return []byte(value), nil
+			})
+			if tt.expErr != "" {
+				require.EqualError(t, err, fmt.Sprintf(tt.expErr, chainID))
+				require.Equal(t, float64(1), testutil.ToFloat64(metrics.QuorumFailures.WithLabelValues(chainID, "test_read")))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expValue, value)
+
+			for _, e := range attestator.endpoints {
+				expDisagreements := float64(0)
+				if slices.Contains(tt.disagreements, e.rpc) {
+					expDisagreements = 1
+				}
+				require.Equal(t, expDisagreements, testutil.ToFloat64(metrics.EndpointDisagreements.WithLabelValues(chainID, e.rpc, "test_read")), e.rpc)
+
+				expErrors := float64(0)
+				if _, ok := tt.results[e.rpc]; !ok {
+					expErrors = 1
+				}
+				require.Equal(t, expErrors, testutil.ToFloat64(metrics.EndpointErrors.WithLabelValues(chainID, e.rpc, "test_read")), e.rpc)
+			}
+		})
+	}
+}
+
+func TestQuorumFinalHeight(t *testing.T) {
+	attestator := newQuorumTestAttestator("quorum-final-height", 2, "http://a:26657", "http://b:26657", "http://c:26657")
+
+	// the highest height that a quorum of the endpoints have reached
+	attestator.finality = mockFinalityPolicy{"http://a:26657": 100, "http://b:26657": 98, "http://c:26657": 99}
+	height, err := attestator.quorumFinalHeight(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(99), height)
+
+	attestator.finality = mockFinalityPolicy{"http://a:26657": 100, "http://c:26657": 97}
+	height, err = attestator.quorumFinalHeight(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(97), height)
+
+	attestator.finality = mockFinalityPolicy{"http://a:26657": 100}
+	_, err = attestator.quorumFinalHeight(context.Background())
+	require.EqualError(t, err, "only 1 of 3 endpoints returned a final height for chain id quorum-final-height, quorum is 2")
+}
@@ -0,0 +1,122 @@
+package cosmos
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/sidecar/metrics"
+)
+
+// channelTopology is the connections and open channels of the client, which are the channels whose packet commitments
+// are attested to. It is cached with the tracked packet commitments of an endpoint, and refreshed on handshake events of
+// the client's connections and channels, and every topology refresh interval.
+type channelTopology struct {
+	Height      uint64            `json:"height"` // the height the topology was read at
+	Connections []string          `json:"connections"`
+	Channels    []topologyChannel `json:"channels"`
+}
+
+type topologyChannel struct {
+	PortID       string `json:"port_id"`
+	ChannelID    string `json:"channel_id"`
+	ConnectionID string `json:"connection_id"`
+}
+
+func (c topologyChannel) path() string {
+	return channelPath(c.PortID, c.ChannelID)
+}
+
+func channelPath(portID, channelID string) string {
+	return portID + "/" + channelID
+}
+
+// hasChannel returns true if the port and channel is one of the open channels of the client
+func (t channelTopology) hasChannel(portID, channelID string) bool {
+	for _, channel := range t.Channels {
+		if channel.PortID == portID && channel.ChannelID == channelID {
+			return true
+		}
+	}
+	return false
+}
+
+// diff returns the channels that are in the topology but not in the old one, and the channels that are no longer in it
+func (t channelTopology) diff(old channelTopology) (added, removed []topologyChannel) {
+	for _, channel := range t.Channels {
+		if !old.hasChannel(channel.PortID, channel.ChannelID) {
+			added = append(added, channel)
+		}
+	}
+	for _, channel := range old.Channels {
+		if !t.hasChannel(channel.PortID, channel.ChannelID) {
+			removed = append(removed, channel)
+		}
+	}
+	return added, removed
+}
+
+// refreshTopology reads the topology of the client at the height. The packet commitments of the channels that were added
+// since the old topology are read in full, since they were not tracked before.
+func (e *endpoint) refreshTopology(ctx context.Context, height uint64, old channelTopology) (channelTopology, map[string][]byte, []topologyChannel, error) {
+	topology, err := e.queryClientChannels(ctx, height, e.config.ClientID)
+	if err != nil {
+		return channelTopology{}, nil, nil, err
+	}
+	e.topologyRefreshedAt = time.Now()
+
+	added, removed := topology.diff(old)
+	addedCommitments, err := e.queryPacketCommitments(ctx, height, added)
+	if err != nil {
+		return channelTopology{}, nil, nil, err
+	}
+
+	if len(added) > 0 || len(removed) > 0 {
+		e.logger.Info("Channel topology of client changed",
+			zap.String("chain_id", e.config.ChainID),
+			zap.String("client_id", e.config.ClientID),
+			zap.String("endpoint", metrics.EndpointLabel(e.rpc)),
+			zap.Uint64("height", height),
+			zap.Int("channels_added", len(added)),
+			zap.Int("channels_removed", len(removed)),
+		)
+	}
+
+	return topology, addedCommitments, removed, nil
+}
+
+// ChannelTopology returns the cached channel topology of the client of every endpoint
+func (c *Attestator) ChannelTopology() (types.GetChannelTopologyResponse, error) {
+	response := types.GetChannelTopologyResponse{
+		ChainId:  c.config.ChainID,
+		ClientId: c.config.ClientID,
+	}
+	for _, e := range c.endpoints {
+		state, err := e.trackerState()
+		if err != nil {
+			return types.GetChannelTopologyResponse{}, err
+		}
+		// nothing has been tracked from the endpoint yet
+		if state == nil {
+			continue
+		}
+
+		topology := types.ChannelTopology{
+			Endpoint:      metrics.EndpointLabel(e.rpc),
+			Height:        state.Topology.Height,
+			ConnectionIds: state.Topology.Connections,
+		}
+		for _, channel := range state.Topology.Channels {
+			topology.Channels = append(topology.Channels, types.TopologyChannel{
+				PortId:       channel.PortID,
+				ChannelId:    channel.ChannelID,
+				ConnectionId: channel.ConnectionID,
+			})
+		}
+		response.Topologies = append(response.Topologies, topology)
+	}
+
+	return response, nil
+}
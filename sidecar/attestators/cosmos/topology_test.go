@@ -0,0 +1,71 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+func TestChannelTopologyDiff(t *testing.T) {
+	channel0 := topologyChannel{PortID: "transfer", ChannelID: "channel-0", ConnectionID: "connection-0"}
+	channel1 := topologyChannel{PortID: "transfer", ChannelID: "channel-1", ConnectionID: "connection-0"}
+	channel2 := topologyChannel{PortID: "transfer", ChannelID: "channel-2", ConnectionID: "connection-1"}
+
+	old := channelTopology{Connections: []string{"connection-0"}, Channels: []topologyChannel{channel0, channel1}}
+	topology := channelTopology{Connections: []string{"connection-0", "connection-1"}, Channels: []topologyChannel{channel0, channel2}}
+
+	added, removed := topology.diff(old)
+	require.Equal(t, []topologyChannel{channel2}, added)
+	require.Equal(t, []topologyChannel{channel1}, removed)
+
+	added, removed = topology.diff(topology)
+	require.Empty(t, added)
+	require.Empty(t, removed)
+}
+
+func TestAttestatorChannelTopology(t *testing.T) {
+	db, err := badger.Open(badger.DefaultOptions("").WithInMemory(true))
+	require.NoError(t, err)
+	defer db.Close()
+
+	chainConfig := config.CosmosChainConfig{ChainID: "topology-1", ClientID: "07-tendermint-0"}
+	attestator := newQuorumTestAttestator(chainConfig.ChainID, 1, "http://user:pass@a:26657/rpc", "http://b:26657")
+	attestator.config = chainConfig
+	for _, e := range attestator.endpoints {
+		e.logger = zap.NewNop()
+		e.db = db
+		e.config = chainConfig
+	}
+
+	_, err = attestator.endpoints[0].replacePacketCommitments(nil, trackerState{
+		ScannedHeight:    10,
+		ReconciledHeight: 10,
+		Topology: channelTopology{
+			Height:      10,
+			Connections: []string{"connection-0"},
+			Channels:    []topologyChannel{{PortID: "transfer", ChannelID: "channel-0", ConnectionID: "connection-0"}},
+		},
+	})
+	require.NoError(t, err)
+
+	// the second endpoint hasn't tracked anything yet
+	topology, err := attestator.ChannelTopology()
+	require.NoError(t, err)
+	require.Equal(t, types.GetChannelTopologyResponse{
+		ChainId:  "topology-1",
+		ClientId: "07-tendermint-0",
+		Topologies: []types.ChannelTopology{
+			{
+				Endpoint:      "http://a:26657",
+				Height:        10,
+				ConnectionIds: []string{"connection-0"},
+				Channels:      []types.TopologyChannel{{PortId: "transfer", ChannelId: "channel-0", ConnectionId: "connection-0"}},
+			},
+		},
+	}, topology)
+}
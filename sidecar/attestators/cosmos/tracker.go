@@ -0,0 +1,393 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"gitlab.com/tozd/go/errors"
+	"go.uber.org/zap"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	connectiontypes "github.com/cosmos/ibc-go/v9/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v9/modules/core/04-channel/types"
+	host "github.com/cosmos/ibc-go/v9/modules/core/24-host"
+
+	"github.com/cosmos/interchain-attestation/sidecar/metrics"
+)
+
+// The packet commitments of the client are tracked per endpoint in the sidecar db, instead of being listed for every
+// attestation. Each endpoint scans the block results since the last attested height for packet events on the channels
+// of the client, and only reads the packet commitments those events touched (with proofs, at the attested height).
+// Block events can't be proven, so every reconcile interval all packet commitments are listed again to correct any drift.
+
+// trackerState is the state of the packet commitment tracking of an endpoint
+type trackerState struct {
+	ScannedHeight    uint64          `json:"scanned_height"`    // the height the tracked packet commitments are at
+	ReconciledHeight uint64          `json:"reconciled_height"` // the height of the last full reconciliation
+	Topology         channelTopology `json:"topology"`
+}
+
+// trackedPacketCommitments returns the packet commitments of the client at the height, sorted so that all endpoints
+// and attestators produce the same attestation data
+func (e *endpoint) trackedPacketCommitments(ctx context.Context, height uint64) ([][]byte, error) {
+	state, err := e.trackerState()
+	if err != nil {
+		return nil, err
+	}
+
+	// the tracked packet commitments can only be moved forward, and only by a limited number of blocks
+	if state == nil || height < state.ScannedHeight || height-state.ScannedHeight > e.config.GetReconcileInterval() {
+		if err := e.reconcilePacketCommitments(ctx, height, false); err != nil {
+			return nil, err
+		}
+		return e.packetCommitments()
+	}
+
+	if err := e.scanPacketEvents(ctx, *state, height); err != nil {
+		return nil, err
+	}
+	if height-state.ReconciledHeight >= e.config.GetReconcileInterval() {
+		// the tracked packet commitments were brought up to the height by the scan, so any difference is drift
+		if err := e.reconcilePacketCommitments(ctx, height, true); err != nil {
+			return nil, err
+		}
+	}
+
+	return e.packetCommitments()
+}
+
+// scanPacketEvents applies the packet events of the blocks after the scanned height up to and including the height.
+// The channel topology is refreshed first if any of the blocks changed it, or if it is due.
+func (e *endpoint) scanPacketEvents(ctx context.Context, state trackerState, height uint64) error {
+	filter := newPacketEventFilter(e.config.ClientID, state.Topology)
+	touched := make(map[string]topologyChannel)
+	topologyChanged := false
+	for scanHeight := state.ScannedHeight + 1; scanHeight <= height; scanHeight++ {
+		blockHeight := int64(scanHeight)
+		res, err := e.cometClient.BlockResults(ctx, &blockHeight)
+		if err != nil {
+			return errors.Errorf("failed to query block results at height %d on chain id %s: %w", scanHeight, e.config.ChainID, err)
+		}
+
+		events := res.Events
+		for _, txRes := range res.TxResponses {
+			if txRes.IsOK() {
+				events = append(events, txRes.Events...)
+			}
+		}
+
+		changed, err := filter.apply(events, touched)
+		if err != nil {
+			return errors.Errorf("invalid events at height %d on chain id %s: %w", scanHeight, e.config.ChainID, err)
+		}
+		topologyChanged = topologyChanged || changed
+	}
+
+	updates := make(map[string][]byte)
+	var removedChannels []topologyChannel
+	if topologyChanged || time.Since(e.topologyRefreshedAt) >= e.topologyRefreshInterval {
+		topology, addedCommitments, removed, err := e.refreshTopology(ctx, height, state.Topology)
+		if err != nil {
+			return err
+		}
+		for key, commitment := range addedCommitments {
+			updates[key] = commitment
+		}
+		state.Topology = topology
+		removedChannels = removed
+	}
+
+	// the events only tell which packet commitments changed, the values at the height are read with proofs
+	for key, channel := range touched {
+		if _, ok := updates[key]; ok || !state.Topology.hasChannel(channel.PortID, channel.ChannelID) {
+			continue
+		}
+		commitment, err := e.queryIBCStore(ctx, height, []byte(key))
+		if err != nil {
+			return err
+		}
+		updates[key] = commitment
+	}
+
+	e.logger.Debug("Scanned packet events",
+		zap.String("chain_id", e.config.ChainID),
+		zap.String("endpoint", metrics.EndpointLabel(e.rpc)),
+		zap.Uint64("from_height", state.ScannedHeight+1),
+		zap.Uint64("to_height", height),
+		zap.Int("packet_commitments_updated", len(updates)),
+	)
+
+	state.ScannedHeight = height
+	return e.updatePacketCommitments(updates, removedChannels, state)
+}
+
+// reconcilePacketCommitments replaces the channel topology and the tracked packet commitments with all the packet
+// commitments of the client at the height. Differences with the tracked packet commitments can be reported as drift.
+func (e *endpoint) reconcilePacketCommitments(ctx context.Context, height uint64, reportDrift bool) error {
+	topology, err := e.queryClientChannels(ctx, height, e.config.ClientID)
+	if err != nil {
+		return err
+	}
+	e.topologyRefreshedAt = time.Now()
+	commitments, err := e.queryPacketCommitments(ctx, height, topology.Channels)
+	if err != nil {
+		return err
+	}
+
+	drift, err := e.replacePacketCommitments(commitments, trackerState{
+		ScannedHeight:    height,
+		ReconciledHeight: height,
+		Topology:         topology,
+	})
+	if err != nil {
+		return err
+	}
+
+	if reportDrift && drift > 0 {
+		metrics.PacketCommitmentDrift.WithLabelValues(e.config.ChainID, metrics.EndpointLabel(e.rpc)).Add(float64(drift))
+		e.logger.Warn("Tracked packet commitments drifted from the chain",
+			zap.String("chain_id", e.config.ChainID),
+			zap.String("endpoint", metrics.EndpointLabel(e.rpc)),
+			zap.Uint64("height", height),
+			zap.Int("drift", drift),
+		)
+	}
+
+	e.logger.Debug("Reconciled packet commitments",
+		zap.String("chain_id", e.config.ChainID),
+		zap.String("endpoint", metrics.EndpointLabel(e.rpc)),
+		zap.Uint64("height", height),
+		zap.Int("channels", len(topology.Channels)),
+		zap.Int("packet_commitments", len(commitments)),
+	)
+
+	return nil
+}
+
+// trackerState returns the stored tracker state of the endpoint, or nil if nothing has been tracked yet
+func (e *endpoint) trackerState() (*trackerState, error) {
+	var state *trackerState
+	if err := e.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(e.trackerKey("state"))
+		if err != nil {
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		return item.Value(func(val []byte) error {
+			state = &trackerState{}
+			return json.Unmarshal(val, state)
+		})
+	}); err != nil {
+		return nil, errors.Errorf("failed to read packet commitment tracker state for chain id %s: %w", e.config.ChainID, err)
+	}
+
+	return state, nil
+}
+
+// packetCommitments returns the tracked packet commitments, sorted
+func (e *endpoint) packetCommitments() ([][]byte, error) {
+	var commitments [][]byte
+	if err := e.db.View(func(txn *badger.Txn) error {
+		tracked, err := e.readPacketCommitments(txn, commitmentsPrefix)
+		if err != nil {
+			return err
+		}
+		for _, commitment := range tracked {
+			commitments = append(commitments, commitment)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Errorf("failed to read tracked packet commitments for chain id %s: %w", e.config.ChainID, err)
+	}
+
+	slices.SortFunc(commitments, bytes.Compare)
+
+	return commitments, nil
+}
+
+// updatePacketCommitments sets the updated packet commitments, where an empty commitment is deleted, and deletes the
+// packet commitments of the removed channels, together with the state
+func (e *endpoint) updatePacketCommitments(updates map[string][]byte, removedChannels []topologyChannel, state trackerState) error {
+	if err := e.db.Update(func(txn *badger.Txn) error {
+		for _, channel := range removedChannels {
+			channelCommitments, err := e.readPacketCommitments(txn, commitmentsPrefix+string(host.PacketCommitmentPrefixKey(channel.PortID, channel.ChannelID))+"/")
+			if err != nil {
+				return err
+			}
+			for key := range channelCommitments {
+				if err := txn.Delete(e.trackerKey(commitmentsPrefix + key)); err != nil {
+					return err
+				}
+			}
+		}
+
+		for key, commitment := range updates {
+			if err := setPacketCommitment(txn, e.trackerKey(commitmentsPrefix+key), commitment); err != nil {
+				return err
+			}
+		}
+
+		return e.setTrackerState(txn, state)
+	}); err != nil {
+		return errors.Errorf("failed to update tracked packet commitments for chain id %s: %w", e.config.ChainID, err)
+	}
+
+	return nil
+}
+
+// replacePacketCommitments replaces all the tracked packet commitments, and returns how many of them were changed
+func (e *endpoint) replacePacketCommitments(commitments map[string][]byte, state trackerState) (int, error) {
+	var changed int
+	if err := e.db.Update(func(txn *badger.Txn) error {
+		tracked, err := e.readPacketCommitments(txn, commitmentsPrefix)
+		if err != nil {
+			return err
+		}
+
+		for key := range tracked {
+			if _, ok := commitments[key]; !ok {
+				if err := txn.Delete(e.trackerKey(commitmentsPrefix + key)); err != nil {
+					return err
+				}
+				changed++
+			}
+		}
+		for key, commitment := range commitments {
+			if bytes.Equal(tracked[key], commitment) {
+				continue
+			}
+			if err := setPacketCommitment(txn, e.trackerKey(commitmentsPrefix+key), commitment); err != nil {
+				return err
+			}
+			changed++
+		}
+
+		return e.setTrackerState(txn, state)
+	}); err != nil {
+		return 0, errors.Errorf("failed to replace tracked packet commitments for chain id %s: %w", e.config.ChainID, err)
+	}
+
+	return changed, nil
+}
+
+// readPacketCommitments returns the tracked packet commitments under the prefix, by their key in the ibc store
+func (e *endpoint) readPacketCommitments(txn *badger.Txn, prefix string) (map[string][]byte, error) {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = e.trackerKey(prefix)
+
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	keyStart := len(e.trackerKey(commitmentsPrefix))
+	commitments := make(map[string][]byte)
+	for it.Rewind(); it.Valid(); it.Next() {
+		item := it.Item()
+		commitment, err := item.ValueCopy(nil)
+		if err != nil {
+			return nil, err
+		}
+		commitments[string(item.Key()[keyStart:])] = commitment
+	}
+
+	return commitments, nil
+}
+
+func (e *endpoint) setTrackerState(txn *badger.Txn, state trackerState) error {
+	bz, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return txn.Set(e.trackerKey("state"), bz)
+}
+
+func setPacketCommitment(txn *badger.Txn, key []byte, commitment []byte) error {
+	if len(commitment) == 0 {
+		return txn.Delete(key)
+	}
+
+	return txn.Set(key, commitment)
+}
+
+const commitmentsPrefix = "packets/"
+
+// trackerKey is a key under the tracker prefix of the endpoint. Endpoints are identified by a hash of their rpc, so that
+// the url can't interfere with the key layout.
+func (e *endpoint) trackerKey(suffix string) []byte {
+	endpointHash := sha256.Sum256([]byte(e.rpc))
+	return []byte(fmt.Sprintf("%s/commitmenttracker/%s/%s", e.config.ChainID, hex.EncodeToString(endpointHash[:8]), suffix))
+}
+
+// channelHandshakeEvents are the events that open or close a channel, or change its upgrade state
+var channelHandshakeEvents = []string{
+	chantypes.EventTypeChannelOpenAck,
+	chantypes.EventTypeChannelOpenConfirm,
+	chantypes.EventTypeChannelCloseInit,
+	chantypes.EventTypeChannelCloseConfirm,
+	chantypes.EventTypeChannelClosed,
+	chantypes.EventTypeChannelUpgradeOpen,
+}
+
+// packetEventFilter finds the packet commitments that were touched by the events of a block, and detects handshakes
+// that change the channel topology of the client
+type packetEventFilter struct {
+	clientID string
+	topology channelTopology
+}
+
+func newPacketEventFilter(clientID string, topology channelTopology) packetEventFilter {
+	return packetEventFilter{
+		clientID: clientID,
+		topology: topology,
+	}
+}
+
+// apply adds the keys of the packet commitments that were set or deleted by the events to touched, with the channel
+// they are on, and returns true if the channel topology of the client changed. Packet events on all channels are
+// collected, since a channel can be added to the topology by a later event.
+func (f packetEventFilter) apply(events sdk.StringEvents, touched map[string]topologyChannel) (bool, error) {
+	topologyChanged := false
+	for _, event := range events {
+		attributes := make(map[string]string, len(event.Attributes))
+		for _, attribute := range event.Attributes {
+			attributes[attribute.Key] = attribute.Value
+		}
+
+		switch {
+		case event.Type == chantypes.EventTypeSendPacket || event.Type == chantypes.EventTypeAcknowledgePacket || event.Type == chantypes.EventTypeTimeoutPacket:
+			channel := topologyChannel{
+				PortID:       attributes[chantypes.AttributeKeySrcPort],
+				ChannelID:    attributes[chantypes.AttributeKeySrcChannel],
+				ConnectionID: attributes[chantypes.AttributeKeyConnection],
+			}
+			sequence, err := strconv.ParseUint(attributes[chantypes.AttributeKeySequence], 10, 64)
+			if err != nil {
+				return false, errors.Errorf("invalid packet sequence in %s event: %w", event.Type, err)
+			}
+			touched[string(host.PacketCommitmentKey(channel.PortID, channel.ChannelID, sequence))] = channel
+		case slices.Contains(channelHandshakeEvents, event.Type):
+			if slices.Contains(f.topology.Connections, attributes[chantypes.AttributeKeyConnectionID]) ||
+				f.topology.hasChannel(attributes[chantypes.AttributeKeyPortID], attributes[chantypes.AttributeKeyChannelID]) {
+				topologyChanged = true
+			}
+		case event.Type == connectiontypes.EventTypeConnectionOpenAck || event.Type == connectiontypes.EventTypeConnectionOpenConfirm:
+			if attributes[connectiontypes.AttributeKeyClientID] == f.clientID {
+				topologyChanged = true
+			}
+		}
+	}
+
+	return topologyChanged, nil
+}
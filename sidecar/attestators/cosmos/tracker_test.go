@@ -0,0 +1,177 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	host "github.com/cosmos/ibc-go/v9/modules/core/24-host"
+
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+func newStringEvent(eventType string, attributes ...string) sdk.StringEvent {
+	event := sdk.StringEvent{Type: eventType}
+	for i := 0; i < len(attributes); i += 2 {
+		event.Attributes = append(event.Attributes, sdk.Attribute{Key: attributes[i], Value: attributes[i+1]})
+	}
+	return event
+}
+
+func TestPacketEventFilter(t *testing.T) {
+	filter := newPacketEventFilter("07-tendermint-0", channelTopology{
+		Connections: []string{"connection-0"},
+		Channels: []topologyChannel{
+			{PortID: "transfer", ChannelID: "channel-0", ConnectionID: "connection-0"},
+			{PortID: "transfer", ChannelID: "channel-1", ConnectionID: "connection-0"},
+		},
+	})
+
+	tests := []struct {
+		name            string
+		events          sdk.StringEvents
+		expTouched      map[string]string // packet commitment key to channel path
+		topologyChanged bool
+		expErr          string
+	}{
+		{
+			name: "packet events on all channels",
+			events: sdk.StringEvents{
+				newStringEvent("send_packet", "packet_src_port", "transfer", "packet_src_channel", "channel-0", "packet_sequence", "5"),
+				newStringEvent("acknowledge_packet", "packet_src_port", "transfer", "packet_src_channel", "channel-1", "packet_sequence", "1"),
+				newStringEvent("timeout_packet", "packet_src_port", "transfer", "packet_src_channel", "channel-0", "packet_sequence", "2"),
+				newStringEvent("send_packet", "packet_src_port", "transfer", "packet_src_channel", "channel-7", "packet_sequence", "5"),
+			},
+			expTouched: map[string]string{
+				string(host.PacketCommitmentKey("transfer", "channel-0", 5)): "transfer/channel-0",
+				string(host.PacketCommitmentKey("transfer", "channel-1", 1)): "transfer/channel-1",
+				string(host.PacketCommitmentKey("transfer", "channel-0", 2)): "transfer/channel-0",
+				string(host.PacketCommitmentKey("transfer", "channel-7", 5)): "transfer/channel-7",
+			},
+		},
+		{
+			name: "handshakes of other clients and other events are ignored",
+			events: sdk.StringEvents{
+				newStringEvent("recv_packet", "packet_dst_port", "transfer", "packet_dst_channel", "channel-0", "packet_sequence", "3"),
+				newStringEvent("channel_open_ack", "port_id", "transfer", "channel_id", "channel-8", "connection_id", "connection-3"),
+				newStringEvent("connection_open_ack", "client_id", "07-tendermint-9", "connection_id", "connection-3"),
+			},
+		},
+		{
+			name: "channel opened on tracked connection",
+			events: sdk.StringEvents{
+				newStringEvent("channel_open_confirm", "port_id", "transfer", "channel_id", "channel-2", "connection_id", "connection-0"),
+			},
+			topologyChanged: true,
+		},
+		{
+			name: "tracked channel closed",
+			events: sdk.StringEvents{
+				newStringEvent("channel_close", "port_id", "transfer", "channel_id", "channel-1"),
+			},
+			topologyChanged: true,
+		},
+		{
+			name: "connection opened on client",
+			events: sdk.StringEvents{
+				newStringEvent("connection_open_ack", "client_id", "07-tendermint-0", "connection_id", "connection-1"),
+			},
+			topologyChanged: true,
+		},
+		{
+			name: "invalid sequence",
+			events: sdk.StringEvents{
+				newStringEvent("send_packet", "packet_src_port", "transfer", "packet_src_channel", "channel-0", "packet_sequence", "x"),
+			},
+			expErr: "invalid packet sequence in send_packet event",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			touched := make(map[string]topologyChannel)
+			topologyChanged, err := filter.apply(tt.events, touched)
+			if tt.expErr != "" {
+				require.ErrorContains(t, err, tt.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.topologyChanged, topologyChanged)
+
+			touchedChannels := make(map[string]string)
+			for key, channel := range touched {
+				touchedChannels[key] = channel.path()
+			}
+			if len(tt.expTouched) == 0 {
+				require.Empty(t, touchedChannels)
+				return
+			}
+			require.Equal(t, tt.expTouched, touchedChannels)
+		})
+	}
+}
+
+func TestTrackedPacketCommitmentsStore(t *testing.T) {
+	db, err := badger.Open(badger.DefaultOptions("").WithInMemory(true))
+	require.NoError(t, err)
+	defer db.Close()
+
+	chainConfig := config.CosmosChainConfig{ChainID: "tracker-1"}
+	e := &endpoint{logger: zap.NewNop(), db: db, rpc: "http://a:26657", config: chainConfig}
+	other := &endpoint{logger: zap.NewNop(), db: db, rpc: "http://b:26657", config: chainConfig}
+
+	state, err := e.trackerState()
+	require.NoError(t, err)
+	require.Nil(t, state)
+
+	key := func(sequence uint64) string {
+		return string(host.PacketCommitmentKey("transfer", "channel-0", sequence))
+	}
+	channel0 := topologyChannel{PortID: "transfer", ChannelID: "channel-0", ConnectionID: "connection-0"}
+	channel1 := topologyChannel{PortID: "transfer", ChannelID: "channel-1", ConnectionID: "connection-0"}
+	topology := channelTopology{Height: 10, Connections: []string{"connection-0"}, Channels: []topologyChannel{channel0, channel1}}
+
+	changed, err := e.replacePacketCommitments(map[string][]byte{
+		key(1): {3},
+		key(2): {1},
+		string(host.PacketCommitmentKey("transfer", "channel-1", 1)): {6},
+	}, trackerState{ScannedHeight: 10, ReconciledHeight: 10, Topology: topology})
+	require.NoError(t, err)
+	require.Equal(t, 3, changed)
+
+	// sent and acknowledged packets, where an empty commitment is a deleted one, and a closed channel
+	topology = channelTopology{Height: 12, Connections: []string{"connection-0"}, Channels: []topologyChannel{channel0}}
+	require.NoError(t, e.updatePacketCommitments(map[string][]byte{
+		key(1): nil,
+		key(3): {2},
+	}, []topologyChannel{channel1}, trackerState{ScannedHeight: 12, ReconciledHeight: 10, Topology: topology}))
+
+	state, err = e.trackerState()
+	require.NoError(t, err)
+	require.Equal(t, &trackerState{ScannedHeight: 12, ReconciledHeight: 10, Topology: topology}, state)
+
+	commitments, err := e.packetCommitments()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{1}, {2}}, commitments)
+
+	// the other endpoint tracks its own packet commitments
+	commitments, err = other.packetCommitments()
+	require.NoError(t, err)
+	require.Empty(t, commitments)
+
+	// a reconciliation only changes the packet commitments that drifted
+	changed, err = e.replacePacketCommitments(map[string][]byte{
+		key(2): {1},
+		key(3): {4},
+		key(4): {5},
+	}, trackerState{ScannedHeight: 13, ReconciledHeight: 13, Topology: topology})
+	require.NoError(t, err)
+	require.Equal(t, 2, changed)
+
+	commitments, err = e.packetCommitments()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{1}, {4}, {5}}, commitments)
+}
@@ -0,0 +1,92 @@
+package evm
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"slices"
+	"time"
+
+	"gitlab.com/tozd/go/errors"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+)
+
+// CollectAttestation attests to the packet commitments of the IBC contract at the block that is final according to the
+// chain's finality policy
+func (c *Attestator) CollectAttestation(ctx context.Context) (types.Attestation, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.logger.Info("Collecting attestationData for chain", zap.String("chain_id", c.config.ChainID), zap.String("ibc_contract_address", c.contractAddress.Hex()))
+
+	blockNumber, err := c.finality.FinalHeight(ctx, c)
+	if err != nil {
+		return types.Attestation{}, errors.Errorf("failed to get final block for chain id %s: %w", c.config.ChainID, err)
+	}
+	if blockNumber < c.config.StartBlock {
+		return types.Attestation{}, errors.Errorf("final block %d on chain id %s is before start block %d", blockNumber, c.config.ChainID, c.config.StartBlock)
+	}
+
+	if !c.scanStateLoaded {
+		if err := c.loadScanState(); err != nil {
+			return types.Attestation{}, err
+		}
+	}
+	if err := c.resetScanOnReorg(ctx); err != nil {
+		return types.Attestation{}, err
+	}
+	if blockNumber+1 < c.nextBlock {
+		return types.Attestation{}, errors.Errorf("final block %d on chain id %s is before the already scanned block %d", blockNumber, c.config.ChainID, c.nextBlock-1)
+	}
+
+	header, err := c.ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return types.Attestation{}, errors.Errorf("failed to query block %d on chain id %s: %w", blockNumber, c.config.ChainID, err)
+	}
+	if err := c.scanPacketCommitments(ctx, blockNumber, header.Hash()); err != nil {
+		return types.Attestation{}, err
+	}
+	proof, err := c.gethClient.GetProof(ctx, c.contractAddress, nil, header.Number)
+	if err != nil {
+		return types.Attestation{}, errors.Errorf("failed to query storage root of %s at block %d on chain id %s: %w", c.contractAddress.Hex(), blockNumber, c.config.ChainID, err)
+	}
+	// the storage root is only attested to if it is part of the state of the block, which the block hash commits to
+	if err := verifyStorageRoot(header.Root, c.contractAddress, proof); err != nil {
+		return types.Attestation{}, errors.Errorf("invalid storage root of %s at block %d on chain id %s: %w", c.contractAddress.Hex(), blockNumber, c.config.ChainID, err)
+	}
+
+	packetCommitments := make([][]byte, 0, len(c.packetCommitments))
+	for _, commitment := range c.packetCommitments {
+		packetCommitments = append(packetCommitments, commitment.Bytes())
+	}
+	// sorted, so that all attestators produce the same payload
+	slices.SortFunc(packetCommitments, bytes.Compare)
+
+	blockData := types.BlockData{
+		ChainId:           c.config.ChainID,
+		ClientToUpdate:    c.config.ClientToUpdate,
+		BlockNumber:       blockNumber,
+		BlockHash:         header.Hash().Bytes(),
+		Timestamp:         time.Unix(int64(header.Time), 0).UTC(),
+		StorageRoot:       proof.StorageHash.Bytes(),
+		PacketCommitments: packetCommitments,
+	}
+
+	c.logger.Debug("Generated attestation data",
+		zap.String("chain_id", c.config.ChainID),
+		zap.String("client_to_update", c.config.ClientToUpdate),
+		zap.Uint64("block_number", blockNumber),
+		zap.String("block_hash", header.Hash().Hex()),
+		zap.Time("timestamp", blockData.Timestamp),
+		zap.Int("packet_commitments", len(packetCommitments)),
+	)
+
+	attestation := types.Attestation{
+		AttestatorId: []byte(c.attestatorID),
+		Payload:      types.NewBlockDataPayload(blockData),
+	}
+
+	return attestation, nil
+}
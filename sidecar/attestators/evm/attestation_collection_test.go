@@ -0,0 +1,338 @@
+package evm_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	clienttypes "github.com/cosmos/ibc-go/v9/modules/core/02-client/types"
+
+	"github.com/cosmos/interchain-attestation/sidecar/attestators/evm"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+const (
+	mockChainID        = "evm-1337"
+	mockClientToUpdate = "10-attestation-0"
+	mockAttestatorID   = "mockAttestatorID"
+)
+
+// simulatedChain is a simulated EVM chain with a minimal IBC contract deployed, that can be read over JSON-RPC (IPC)
+type simulatedChain struct {
+	t       *testing.T
+	backend *simulated.Backend
+	rpc     string
+
+	key             *ecdsa.PrivateKey
+	nonce           uint64
+	contractAddress common.Address
+}
+
+func newSimulatedChain(t *testing.T) *simulatedChain {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	ipcPath := filepath.Join(t.TempDir(), "geth.ipc")
+	backend := simulated.NewBackend(ethtypes.GenesisAlloc{
+		address: {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))},
+	}, func(nodeConf *node.Config, _ *ethconfig.Config) {
+		nodeConf.IPCPath = ipcPath
+	})
+	t.Cleanup(func() {
+		require.NoError(t, backend.Close())
+	})
+
+	chain := &simulatedChain{
+		t:       t,
+		backend: backend,
+		rpc:     ipcPath,
+		key:     key,
+	}
+	chain.contractAddress = crypto.CreateAddress(address, chain.nonce)
+	chain.sendTx(nil, ibcContractInitCode())
+	chain.backend.Commit()
+
+	code, err := backend.Client().CodeAt(context.Background(), chain.contractAddress, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, code)
+
+	return chain
+}
+
+func newDB(t *testing.T) *badger.DB {
+	db, err := badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	return db
+}
+
+func (c *simulatedChain) sendTx(to *common.Address, data []byte) {
+	tx, err := ethtypes.SignNewTx(c.key, ethtypes.LatestSignerForChainID(big.NewInt(1337)), &ethtypes.DynamicFeeTx{
+		ChainID:   big.NewInt(1337),
+		Nonce:     c.nonce,
+		GasTipCap: big.NewInt(1e9),
+		GasFeeCap: big.NewInt(100e9),
+		Gas:       1_000_000,
+		To:        to,
+		Data:      data,
+	})
+	require.NoError(c.t, err)
+	require.NoError(c.t, c.backend.Client().SendTransaction(context.Background(), tx))
+	c.nonce++
+}
+
+// setPacketCommitment stores the packet commitment for the path, or deletes it if the commitment is zero (in the next block)
+func (c *simulatedChain) setPacketCommitment(path common.Hash, commitment common.Hash) {
+	c.sendTx(&c.contractAddress, append(path.Bytes(), commitment.Bytes()...))
+}
+
+// ibcContractInitCode returns the init code of a minimal IBC contract. It takes a path and a commitment (32 bytes each)
+// as calldata, stores the commitment at the path and emits PacketCommitmentSet, or PacketCommitmentDeleted if the
+// commitment is zero.
+func ibcContractInitCode() []byte {
+	runtime := []byte{
+		byte(vm.PUSH1), 0x20, byte(vm.CALLDATALOAD), // commitment
+		byte(vm.PUSH1), 0x00, byte(vm.CALLDATALOAD), // path
+		byte(vm.DUP2), byte(vm.DUP2), byte(vm.SSTORE), // sstore(path, commitment)
+		byte(vm.DUP2), byte(vm.PUSH1), 0x34, byte(vm.JUMPI), // jump to set if commitment != 0
+		byte(vm.PUSH32),
+	}
+	runtime = append(runtime, evm.PacketCommitmentDeletedTopic.Bytes()...)
+	runtime = append(runtime,
+		byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.LOG2), // log2(0, 0, deleted topic, path)
+		byte(vm.STOP),
+		byte(vm.JUMPDEST),                                    // 0x34
+		byte(vm.DUP2), byte(vm.PUSH1), 0x00, byte(vm.MSTORE), // mstore(0, commitment)
+		byte(vm.PUSH32),
+	)
+	runtime = append(runtime, evm.PacketCommitmentSetTopic.Bytes()...)
+	runtime = append(runtime,
+		byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0x00, byte(vm.LOG2), // log2(0, 32, set topic, path)
+		byte(vm.STOP),
+	)
+
+	// copy the runtime code into memory and return it
+	initCode := []byte{
+		byte(vm.PUSH1), byte(len(runtime)), byte(vm.PUSH1), 0x0c, byte(vm.PUSH1), 0x00, byte(vm.CODECOPY),
+		byte(vm.PUSH1), byte(len(runtime)), byte(vm.PUSH1), 0x00, byte(vm.RETURN),
+	}
+
+	return append(initCode, runtime...)
+}
+
+func TestCollectAttestation(t *testing.T) {
+	ctx := context.Background()
+	chain := newSimulatedChain(t)
+
+	attestator, err := evm.NewEVMAttestator(zap.NewNop(), newDB(t), mockAttestatorID, config.EVMChainConfig{
+		ChainID:            mockChainID,
+		RPC:                chain.rpc,
+		Attestation:        true,
+		ClientToUpdate:     mockClientToUpdate,
+		IBCContractAddress: chain.contractAddress.Hex(),
+		StartBlock:         1,
+		FinalityConfig: config.FinalityConfig{
+			Confirmations: 2,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, mockChainID, attestator.ChainID())
+
+	// the contract was deployed in block 1, which doesn't have enough confirmations yet
+	_, err = attestator.CollectAttestation(ctx)
+	require.ErrorContains(t, err, "does not have 2 confirmations")
+
+	pathA, commitmentA := common.HexToHash("0xa1"), common.HexToHash("0xa2")
+	pathB, commitmentB := common.HexToHash("0xb1"), common.HexToHash("0xb2")
+	chain.setPacketCommitment(pathA, commitmentA)
+	chain.setPacketCommitment(pathB, commitmentB)
+	blockHash := chain.backend.Commit() // block 2
+	chain.backend.Commit()
+	chain.backend.Commit()
+
+	attestation, err := attestator.CollectAttestation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []byte(mockAttestatorID), attestation.AttestatorId)
+	blockData := attestation.Payload.GetBlockDataV1()
+	require.NotNil(t, blockData)
+	require.Equal(t, mockChainID, blockData.ChainId)
+	require.Equal(t, mockClientToUpdate, blockData.ClientToUpdate)
+	require.Equal(t, uint64(2), blockData.BlockNumber)
+	require.Equal(t, blockHash.Bytes(), blockData.BlockHash)
+	require.ElementsMatch(t, [][]byte{commitmentA.Bytes(), commitmentB.Bytes()}, blockData.PacketCommitments)
+	require.NotEmpty(t, blockData.StorageRoot)
+
+	header, err := chain.backend.Client().HeaderByNumber(ctx, big.NewInt(2))
+	require.NoError(t, err)
+	require.Equal(t, int64(header.Time), blockData.Timestamp.Unix())
+
+	// the deletion is only attested to once it has enough confirmations
+	chain.setPacketCommitment(pathA, common.Hash{})
+	chain.backend.Commit() // block 5
+	chain.backend.Commit()
+
+	attestation, err = attestator.CollectAttestation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), attestation.Payload.GetBlockDataV1().BlockNumber)
+	require.ElementsMatch(t, [][]byte{commitmentA.Bytes(), commitmentB.Bytes()}, attestation.Payload.GetBlockDataV1().PacketCommitments)
+	require.Equal(t, blockData.StorageRoot, attestation.Payload.GetBlockDataV1().StorageRoot)
+
+	chain.backend.Commit()
+
+	attestation, err = attestator.CollectAttestation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), attestation.Payload.GetBlockDataV1().BlockNumber)
+	require.Equal(t, [][]byte{commitmentB.Bytes()}, attestation.Payload.GetBlockDataV1().PacketCommitments)
+	require.NotEqual(t, blockData.StorageRoot, attestation.Payload.GetBlockDataV1().StorageRoot)
+	require.Equal(t, clienttypes.NewHeight(0, 5), attestation.Payload.AttestedHeight())
+}
+
+func TestCollectAttestation_Reorg(t *testing.T) {
+	ctx := context.Background()
+	chain := newSimulatedChain(t)
+
+	attestator, err := evm.NewEVMAttestator(zap.NewNop(), newDB(t), mockAttestatorID, config.EVMChainConfig{
+		ChainID:            mockChainID,
+		RPC:                chain.rpc,
+		Attestation:        true,
+		ClientToUpdate:     mockClientToUpdate,
+		IBCContractAddress: chain.contractAddress.Hex(),
+		StartBlock:         1,
+	})
+	require.NoError(t, err)
+
+	deployBlock, err := chain.backend.Client().HeaderByNumber(ctx, big.NewInt(1))
+	require.NoError(t, err)
+
+	path, commitment := common.HexToHash("0xa1"), common.HexToHash("0xa2")
+	chain.setPacketCommitment(path, commitment)
+	chain.backend.Commit() // block 2
+
+	attestation, err := attestator.CollectAttestation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), attestation.Payload.GetBlockDataV1().BlockNumber)
+	require.Equal(t, [][]byte{commitment.Bytes()}, attestation.Payload.GetBlockDataV1().PacketCommitments)
+
+	// block 2 is reorged out, so the packet commitment it set is gone
+	require.NoError(t, chain.backend.Fork(deployBlock.Hash()))
+	chain.backend.Commit() // block 2 on the fork
+	chain.backend.Commit()
+
+	attestation, err = attestator.CollectAttestation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), attestation.Payload.GetBlockDataV1().BlockNumber)
+	require.Empty(t, attestation.Payload.GetBlockDataV1().PacketCommitments)
+
+	blockHash, err := attestator.BlockHash(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, attestation.Payload.GetBlockDataV1().BlockHash, blockHash)
+}
+
+func TestCollectAttestation_Restart(t *testing.T) {
+	ctx := context.Background()
+	chain := newSimulatedChain(t)
+	db := newDB(t)
+
+	chainConfig := config.EVMChainConfig{
+		ChainID:            mockChainID,
+		RPC:                chain.rpc,
+		Attestation:        true,
+		ClientToUpdate:     mockClientToUpdate,
+		IBCContractAddress: chain.contractAddress.Hex(),
+		StartBlock:         1,
+	}
+	attestator, err := evm.NewEVMAttestator(zap.NewNop(), db, mockAttestatorID, chainConfig)
+	require.NoError(t, err)
+
+	pathA, commitmentA := common.HexToHash("0xa1"), common.HexToHash("0xa2")
+	chain.setPacketCommitment(pathA, commitmentA)
+	chain.backend.Commit() // block 2
+
+	attestation, err := attestator.CollectAttestation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{commitmentA.Bytes()}, attestation.Payload.GetBlockDataV1().PacketCommitments)
+	require.NoError(t, attestator.Close())
+
+	pathB, commitmentB := common.HexToHash("0xb1"), common.HexToHash("0xb2")
+	chain.setPacketCommitment(pathB, commitmentB)
+	chain.backend.Commit() // block 3
+
+	// the restarted attestator continues the stored scan after block 2, instead of starting over from the start block
+	// (which is moved past block 2 here, so the packet commitment set in block 2 would be missing otherwise)
+	chainConfig.StartBlock = 3
+	restarted, err := evm.NewEVMAttestator(zap.NewNop(), db, mockAttestatorID, chainConfig)
+	require.NoError(t, err)
+	defer restarted.Close()
+
+	attestation, err = restarted.CollectAttestation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), attestation.Payload.GetBlockDataV1().BlockNumber)
+	require.ElementsMatch(t, [][]byte{commitmentA.Bytes(), commitmentB.Bytes()}, attestation.Payload.GetBlockDataV1().PacketCommitments)
+
+}
+
+func TestCollectAttestation_NoContract(t *testing.T) {
+	ctx := context.Background()
+	chain := newSimulatedChain(t)
+
+	attestator, err := evm.NewEVMAttestator(zap.NewNop(), newDB(t), mockAttestatorID, config.EVMChainConfig{
+		ChainID:            mockChainID,
+		RPC:                chain.rpc,
+		Attestation:        true,
+		ClientToUpdate:     mockClientToUpdate,
+		IBCContractAddress: common.HexToAddress("0x01").Hex(),
+		StartBlock:         1,
+	})
+	require.NoError(t, err)
+	defer attestator.Close()
+
+	// there is no storage root to prove for an address without an account
+	_, err = attestator.CollectAttestation(ctx)
+	require.ErrorContains(t, err, "no account for")
+}
+
+func TestCollectAttestation_FinalizedPolicy(t *testing.T) {
+	ctx := context.Background()
+	chain := newSimulatedChain(t)
+
+	attestator, err := evm.NewEVMAttestator(zap.NewNop(), newDB(t), mockAttestatorID, config.EVMChainConfig{
+		ChainID:            mockChainID,
+		RPC:                chain.rpc,
+		Attestation:        true,
+		ClientToUpdate:     mockClientToUpdate,
+		IBCContractAddress: chain.contractAddress.Hex(),
+		StartBlock:         1,
+		FinalityConfig: config.FinalityConfig{
+			FinalityPolicy: config.FinalityPolicyFinalized,
+		},
+	})
+	require.NoError(t, err)
+
+	// the simulated chain finalizes a block every 32 blocks
+	_, err = attestator.CollectAttestation(ctx)
+	require.ErrorContains(t, err, "final block 0 on chain id evm-1337 is before start block 1")
+
+	for i := 0; i < 32; i++ {
+		chain.backend.Commit()
+	}
+
+	attestation, err := attestator.CollectAttestation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(32), attestation.Payload.GetBlockDataV1().BlockNumber)
+}
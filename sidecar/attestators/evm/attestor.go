@@ -0,0 +1,118 @@
+package evm
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"gitlab.com/tozd/go/errors"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/sidecar/attestators/attestator"
+	"github.com/cosmos/interchain-attestation/sidecar/attestators/finality"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+var (
+	_ attestator.Attestator = &Attestator{}
+	_ finality.Chain        = &Attestator{}
+	_ io.Closer             = &Attestator{}
+)
+
+// Attestator attests to the packet commitments of a solidity IBC contract on an EVM chain, read over JSON-RPC.
+// The packet commitments are tracked from the events of the contract (see PacketCommitmentSetTopic and
+// PacketCommitmentDeletedTopic), and attested to once the block is final according to the chain's finality policy.
+type Attestator struct {
+	logger *zap.Logger
+	db     *badger.DB
+
+	ethClient  *ethclient.Client
+	gethClient *gethclient.Client
+
+	attestatorID    string
+	config          config.EVMChainConfig
+	contractAddress common.Address
+	finality        finality.Policy
+
+	// lock makes sure only one attestation is collected at a time, since the packet commitments are read incrementally.
+	// The scan state below is a copy of the one stored in the db, read when the first attestation is collected.
+	lock sync.Mutex
+	// scanStateLoaded is true once the stored scan state has been read (see loadScanState)
+	scanStateLoaded bool
+	// nextBlock is the first block that hasn't been scanned for packet commitment events yet
+	nextBlock uint64
+	// scannedBlockHash is the hash of the block before nextBlock, to detect if the scanned blocks were reorged out
+	scannedBlockHash common.Hash
+	// packetCommitments are the packet commitments by path, as of the block before nextBlock
+	packetCommitments map[common.Hash]common.Hash
+}
+
+// NewEVMAttestator creates an attestator for the chain, which continues the packet commitment scan stored in the db
+func NewEVMAttestator(logger *zap.Logger, db *badger.DB, attestatorID string, chainConfig config.EVMChainConfig) (*Attestator, error) {
+	finalityPolicy, err := finality.NewPolicy(chainConfig.ChainID, chainConfig.FinalityConfig, config.FinalityPolicyConfirmations)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcClient, err := rpc.DialContext(context.Background(), chainConfig.RPC)
+	if err != nil {
+		return nil, errors.Errorf("failed to dial rpc %s for chain id %s: %w", chainConfig.RPC, chainConfig.ChainID, err)
+	}
+
+	return &Attestator{
+		logger: logger,
+		db:     db,
+
+		ethClient:  ethclient.NewClient(rpcClient),
+		gethClient: gethclient.New(rpcClient),
+
+		attestatorID:    attestatorID,
+		config:          chainConfig,
+		contractAddress: common.HexToAddress(chainConfig.IBCContractAddress),
+		finality:        finalityPolicy,
+	}, nil
+}
+
+// Close closes the connection to the JSON-RPC endpoint
+func (c *Attestator) Close() error {
+	c.ethClient.Close()
+	return nil
+}
+
+func (c *Attestator) ChainID() string {
+	return c.config.ChainID
+}
+
+func (c *Attestator) LatestHeight(ctx context.Context) (uint64, error) {
+	latestBlock, err := c.ethClient.BlockNumber(ctx)
+	if err != nil {
+		return 0, errors.Errorf("failed to query latest block for chain id %s: %w", c.config.ChainID, err)
+	}
+
+	return latestBlock, nil
+}
+
+// FinalizedHeight returns the block with the "finalized" tag
+func (c *Attestator) FinalizedHeight(ctx context.Context) (uint64, error) {
+	header, err := c.ethClient.HeaderByNumber(ctx, big.NewInt(rpc.FinalizedBlockNumber.Int64()))
+	if err != nil {
+		return 0, errors.Errorf("failed to query finalized block for chain id %s: %w", c.config.ChainID, err)
+	}
+
+	return header.Number.Uint64(), nil
+}
+
+func (c *Attestator) BlockHash(ctx context.Context, height uint64) ([]byte, error) {
+	header, err := c.ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(height))
+	if err != nil {
+		return nil, errors.Errorf("failed to query block %d on chain id %s: %w", height, c.config.ChainID, err)
+	}
+
+	return header.Hash().Bytes(), nil
+}
@@ -0,0 +1,112 @@
+package evm
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gitlab.com/tozd/go/errors"
+	"go.uber.org/zap"
+)
+
+// maxBlockRange is the maximum number of blocks requested in one eth_getLogs call, since most JSON-RPC
+// providers limit the range
+const maxBlockRange = 1000
+
+var (
+	// PacketCommitmentSetTopic is the topic of the event the IBC contract emits when a packet commitment is stored:
+	// event PacketCommitmentSet(bytes32 indexed path, bytes32 commitment)
+	PacketCommitmentSetTopic = crypto.Keccak256Hash([]byte("PacketCommitmentSet(bytes32,bytes32)"))
+	// PacketCommitmentDeletedTopic is the topic of the event the IBC contract emits when a packet commitment is deleted:
+	// event PacketCommitmentDeleted(bytes32 indexed path)
+	PacketCommitmentDeletedTopic = crypto.Keccak256Hash([]byte("PacketCommitmentDeleted(bytes32)"))
+)
+
+// scanPacketCommitments applies the packet commitment events of the IBC contract up to and including toBlock, and
+// stores them with toBlockHash as the hash of the scanned block. Nothing is applied if the scan fails, so it can be retried.
+func (c *Attestator) scanPacketCommitments(ctx context.Context, toBlock uint64, toBlockHash common.Hash) error {
+	// the updated packet commitments by path, where a zero commitment is deleted
+	updates := make(map[common.Hash]common.Hash)
+	for nextBlock := c.nextBlock; nextBlock <= toBlock; {
+		endBlock := min(nextBlock+maxBlockRange-1, toBlock)
+		logs, err := c.ethClient.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(nextBlock),
+			ToBlock:   new(big.Int).SetUint64(endBlock),
+			Addresses: []common.Address{c.contractAddress},
+			Topics:    [][]common.Hash{{PacketCommitmentSetTopic, PacketCommitmentDeletedTopic}},
+		})
+		if err != nil {
+			return errors.Errorf("failed to get logs for blocks %d to %d on chain id %s: %w", nextBlock, endBlock, c.config.ChainID, err)
+		}
+
+		for _, log := range logs {
+			if err := applyPacketCommitmentLog(updates, log); err != nil {
+				return err
+			}
+		}
+
+		c.logger.Debug("Scanned packet commitment events",
+			zap.String("chain_id", c.config.ChainID),
+			zap.Uint64("from_block", nextBlock),
+			zap.Uint64("to_block", endBlock),
+			zap.Int("num_events", len(logs)),
+		)
+		nextBlock = endBlock + 1
+	}
+
+	return c.storeScan(updates, scanState{
+		NextBlock:        toBlock + 1,
+		ScannedBlockHash: toBlockHash,
+	})
+}
+
+// resetScanOnReorg starts the scan over from the start block if the last scanned block is no longer part of the chain,
+// since the packet commitments read from the reorged blocks can't be trusted
+func (c *Attestator) resetScanOnReorg(ctx context.Context) error {
+	if c.nextBlock == c.config.StartBlock {
+		return nil
+	}
+
+	scannedBlock := c.nextBlock - 1
+	header, err := c.ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(scannedBlock))
+	if err != nil {
+		return errors.Errorf("failed to query block %d on chain id %s: %w", scannedBlock, c.config.ChainID, err)
+	}
+	if header.Hash() == c.scannedBlockHash {
+		return nil
+	}
+
+	c.logger.Warn("Scanned block was reorged, rescanning packet commitments",
+		zap.String("chain_id", c.config.ChainID),
+		zap.Uint64("block_number", scannedBlock),
+		zap.String("scanned_block_hash", c.scannedBlockHash.Hex()),
+		zap.String("block_hash", header.Hash().Hex()),
+	)
+
+	return c.resetScan()
+}
+
+// applyPacketCommitmentLog adds the packet commitment the log sets or deletes to the updates
+func applyPacketCommitmentLog(updates map[common.Hash]common.Hash, log ethtypes.Log) error {
+	if len(log.Topics) != 2 {
+		return errors.Errorf("unexpected number of topics %d in log %d of block %d", len(log.Topics), log.Index, log.BlockNumber)
+	}
+	path := log.Topics[1]
+
+	switch log.Topics[0] {
+	case PacketCommitmentSetTopic:
+		if len(log.Data) != common.HashLength {
+			return errors.Errorf("unexpected data length %d in log %d of block %d", len(log.Data), log.Index, log.BlockNumber)
+		}
+		updates[path] = common.BytesToHash(log.Data)
+	case PacketCommitmentDeletedTopic:
+		updates[path] = common.Hash{}
+	default:
+		return errors.Errorf("unexpected event %s in log %d of block %d", log.Topics[0], log.Index, log.BlockNumber)
+	}
+
+	return nil
+}
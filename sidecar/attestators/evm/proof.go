@@ -0,0 +1,46 @@
+package evm
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"gitlab.com/tozd/go/errors"
+)
+
+// verifyStorageRoot checks the account proof of the contract against the state root of a block, and that the proven
+// account has the storage root of the proof
+func verifyStorageRoot(stateRoot common.Hash, contractAddress common.Address, proof *gethclient.AccountResult) error {
+	proofDB := memorydb.New()
+	for _, node := range proof.AccountProof {
+		nodeBz, err := hexutil.Decode(node)
+		if err != nil {
+			return errors.Errorf("invalid account proof node %s: %w", node, err)
+		}
+		if err := proofDB.Put(crypto.Keccak256(nodeBz), nodeBz); err != nil {
+			return err
+		}
+	}
+
+	accountBz, err := trie.VerifyProof(stateRoot, crypto.Keccak256(contractAddress.Bytes()), proofDB)
+	if err != nil {
+		return errors.Errorf("invalid account proof for state root %s: %w", stateRoot.Hex(), err)
+	}
+	if len(accountBz) == 0 {
+		return errors.Errorf("no account for %s in state root %s", contractAddress.Hex(), stateRoot.Hex())
+	}
+
+	var account ethtypes.StateAccount
+	if err := rlp.DecodeBytes(accountBz, &account); err != nil {
+		return errors.Errorf("invalid account for %s: %w", contractAddress.Hex(), err)
+	}
+	if account.Root != proof.StorageHash {
+		return errors.Errorf("storage root %s does not match the proven storage root %s", proof.StorageHash.Hex(), account.Root.Hex())
+	}
+
+	return nil
+}
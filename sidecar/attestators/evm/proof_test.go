@@ -0,0 +1,58 @@
+package evm
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyStorageRoot(t *testing.T) {
+	ctx := context.Background()
+	address := common.HexToAddress("0xa1")
+
+	ipcPath := filepath.Join(t.TempDir(), "geth.ipc")
+	backend := simulated.NewBackend(ethtypes.GenesisAlloc{
+		address: {
+			Balance: big.NewInt(1),
+			Storage: map[common.Hash]common.Hash{common.HexToHash("0x01"): common.HexToHash("0x02")},
+		},
+	}, func(nodeConf *node.Config, _ *ethconfig.Config) {
+		nodeConf.IPCPath = ipcPath
+	})
+	defer backend.Close()
+	backend.Commit()
+
+	rpcClient, err := rpc.DialContext(ctx, ipcPath)
+	require.NoError(t, err)
+	defer rpcClient.Close()
+
+	header, err := backend.Client().HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	proof, err := gethclient.New(rpcClient).GetProof(ctx, address, nil, header.Number)
+	require.NoError(t, err)
+	require.NotEqual(t, ethtypes.EmptyRootHash, proof.StorageHash)
+
+	require.NoError(t, verifyStorageRoot(header.Root, address, proof))
+
+	// the proof is for another state root
+	require.ErrorContains(t, verifyStorageRoot(crypto.Keccak256Hash([]byte("other")), address, proof), "invalid account proof")
+
+	// the storage root is not the one of the proven account
+	tampered := *proof
+	tampered.StorageHash = crypto.Keccak256Hash([]byte("other"))
+	require.ErrorContains(t, verifyStorageRoot(header.Root, address, &tampered), "does not match the proven storage root")
+
+	// the proof is for another account
+	require.Error(t, verifyStorageRoot(header.Root, common.HexToAddress("0xb1"), proof))
+}
@@ -0,0 +1,151 @@
+package evm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/ethereum/go-ethereum/common"
+	"gitlab.com/tozd/go/errors"
+)
+
+// The scan state and the tracked packet commitments are stored in the sidecar db, so that a restarted sidecar continues
+// the scan where it stopped (and still detects if the last scanned block was reorged out) instead of reading all the
+// events since the start block again. The attestator keeps a copy in memory, which is only changed after the db.
+
+// scanState is the state of the packet commitment event scan of the IBC contract
+type scanState struct {
+	NextBlock        uint64      `json:"next_block"`         // the first block that hasn't been scanned yet
+	ScannedBlockHash common.Hash `json:"scanned_block_hash"` // the hash of the block before NextBlock
+}
+
+// loadScanState reads the stored scan state and packet commitments into the attestator, or starts at the start block if
+// nothing has been scanned yet
+func (c *Attestator) loadScanState() error {
+	state := scanState{NextBlock: c.config.StartBlock}
+	packetCommitments := make(map[common.Hash]common.Hash)
+	if err := c.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(c.trackerKey("state"))
+		if err != nil {
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				return nil
+			}
+			return err
+		}
+		if err := item.Value(func(val []byte) error {
+			return json.Unmarshal(val, &state)
+		}); err != nil {
+			return err
+		}
+
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = c.trackerKey(commitmentsPrefix)
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			commitment, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			packetCommitments[common.BytesToHash(item.Key()[len(opts.Prefix):])] = common.BytesToHash(commitment)
+		}
+
+		return nil
+	}); err != nil {
+		return errors.Errorf("failed to read packet commitment scan state for chain id %s: %w", c.config.ChainID, err)
+	}
+
+	c.nextBlock = state.NextBlock
+	c.scannedBlockHash = state.ScannedBlockHash
+	c.packetCommitments = packetCommitments
+	c.scanStateLoaded = true
+
+	return nil
+}
+
+// storeScan stores the updated packet commitments, where a zero commitment is deleted, together with the state, and then
+// applies them to the attestator
+func (c *Attestator) storeScan(updates map[common.Hash]common.Hash, state scanState) error {
+	stateBz, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	if err := c.db.Update(func(txn *badger.Txn) error {
+		for path, commitment := range updates {
+			key := c.trackerKey(commitmentsPrefix + string(path.Bytes()))
+			if commitment == (common.Hash{}) {
+				if err := txn.Delete(key); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := txn.Set(key, commitment.Bytes()); err != nil {
+				return err
+			}
+		}
+
+		return txn.Set(c.trackerKey("state"), stateBz)
+	}); err != nil {
+		return errors.Errorf("failed to store packet commitment scan state for chain id %s: %w", c.config.ChainID, err)
+	}
+
+	for path, commitment := range updates {
+		if commitment == (common.Hash{}) {
+			delete(c.packetCommitments, path)
+			continue
+		}
+		c.packetCommitments[path] = commitment
+	}
+	c.nextBlock = state.NextBlock
+	c.scannedBlockHash = state.ScannedBlockHash
+
+	return nil
+}
+
+// resetScan deletes the stored scan state and packet commitments, so that the scan starts over from the start block
+func (c *Attestator) resetScan() error {
+	var keys [][]byte
+	if err := c.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = c.trackerKey("")
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			keys = append(keys, it.Item().KeyCopy(nil))
+		}
+
+		return nil
+	}); err != nil {
+		return errors.Errorf("failed to read packet commitment scan state for chain id %s: %w", c.config.ChainID, err)
+	}
+
+	// the keys are deleted in a write batch, which splits them over as many transactions as needed
+	batch := c.db.NewWriteBatch()
+	defer batch.Cancel()
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	if err := batch.Flush(); err != nil {
+		return errors.Errorf("failed to reset packet commitment scan state for chain id %s: %w", c.config.ChainID, err)
+	}
+
+	c.nextBlock = c.config.StartBlock
+	c.scannedBlockHash = common.Hash{}
+	c.packetCommitments = make(map[common.Hash]common.Hash)
+
+	return nil
+}
+
+const commitmentsPrefix = "packets/"
+
+// trackerKey is a key under the tracker prefix of the IBC contract, so that the scan starts over if the contract changes
+func (c *Attestator) trackerKey(suffix string) []byte {
+	return []byte(fmt.Sprintf("%s/evmtracker/%s/%s", c.config.ChainID, c.contractAddress.Hex(), suffix))
+}
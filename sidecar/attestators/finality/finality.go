@@ -0,0 +1,123 @@
+package finality
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"gitlab.com/tozd/go/errors"
+
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+const oracleTimeout = 10 * time.Second
+
+// Chain exposes the heights of a chain the finality policies are resolved against
+type Chain interface {
+	// LatestHeight is the latest height of the chain, regardless of finality
+	LatestHeight(ctx context.Context) (uint64, error)
+	// FinalizedHeight is the latest height the chain itself considers final
+	FinalizedHeight(ctx context.Context) (uint64, error)
+}
+
+// Policy decides which height of a chain is final enough to be attested to
+type Policy interface {
+	FinalHeight(ctx context.Context, chain Chain) (uint64, error)
+}
+
+// NewPolicy creates the policy for the finality config of a chain, using defaultPolicy if the config does not set one
+func NewPolicy(chainID string, finalityConfig config.FinalityConfig, defaultPolicy string) (Policy, error) {
+	policy := finalityConfig.FinalityPolicy
+	if policy == "" {
+		policy = defaultPolicy
+	}
+
+	switch policy {
+	case config.FinalityPolicyConfirmations:
+		return confirmationsPolicy{confirmations: finalityConfig.Confirmations}, nil
+	case config.FinalityPolicyFinalized:
+		return finalizedPolicy{}, nil
+	case config.FinalityPolicyOracle:
+		oracleURL, err := url.Parse(finalityConfig.FinalityOracle)
+		if err != nil {
+			return nil, errors.Errorf("invalid finality oracle url for chain id %s: %w", chainID, err)
+		}
+		query := oracleURL.Query()
+		query.Set("chain_id", chainID)
+		oracleURL.RawQuery = query.Encode()
+
+		return oraclePolicy{
+			url:        oracleURL.String(),
+			httpClient: &http.Client{Timeout: oracleTimeout},
+		}, nil
+	default:
+		return nil, errors.Errorf("unknown finality policy %q for chain id %s", policy, chainID)
+	}
+}
+
+type confirmationsPolicy struct {
+	confirmations uint64
+}
+
+func (p confirmationsPolicy) FinalHeight(ctx context.Context, chain Chain) (uint64, error) {
+	latestHeight, err := chain.LatestHeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if latestHeight < p.confirmations {
+		return 0, errors.Errorf("latest height %d does not have %d confirmations", latestHeight, p.confirmations)
+	}
+
+	return latestHeight - p.confirmations, nil
+}
+
+type finalizedPolicy struct{}
+
+func (finalizedPolicy) FinalHeight(ctx context.Context, chain Chain) (uint64, error) {
+	return chain.FinalizedHeight(ctx)
+}
+
+// OracleResponse is the response expected from a finality oracle on GET <finality_oracle>?chain_id=<chain id>
+type OracleResponse struct {
+	FinalizedHeight uint64 `json:"finalized_height"`
+}
+
+type oraclePolicy struct {
+	url        string
+	httpClient *http.Client
+}
+
+// FinalHeight asks the oracle for the finalized height, capped at the latest height of the chain,
+// so that a misbehaving oracle can't make us attest to heights our own node has not seen
+func (p oraclePolicy) FinalHeight(ctx context.Context, chain Chain) (uint64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return 0, errors.Errorf("failed to query finality oracle: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, errors.Errorf("finality oracle responded with status %d", resp.StatusCode)
+	}
+
+	var oracleResp OracleResponse
+	if err := json.NewDecoder(resp.Body).Decode(&oracleResp); err != nil {
+		return 0, errors.Errorf("failed to decode finality oracle response: %w", err)
+	}
+
+	latestHeight, err := chain.LatestHeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if oracleResp.FinalizedHeight > latestHeight {
+		return 0, errors.Errorf("finality oracle height %d is above the latest height %d", oracleResp.FinalizedHeight, latestHeight)
+	}
+
+	return oracleResp.FinalizedHeight, nil
+}
@@ -0,0 +1,112 @@
+package finality_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/interchain-attestation/sidecar/attestators/finality"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+const mockChainID = "mockChainID"
+
+type mockChain struct {
+	latestHeight    uint64
+	finalizedHeight uint64
+}
+
+var _ finality.Chain = mockChain{}
+
+func (m mockChain) LatestHeight(context.Context) (uint64, error) {
+	return m.latestHeight, nil
+}
+
+func (m mockChain) FinalizedHeight(context.Context) (uint64, error) {
+	return m.finalizedHeight, nil
+}
+
+func TestPolicies(t *testing.T) {
+	var oracleHeight uint64
+	oracle := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/finality" || r.URL.Query().Get("chain_id") != mockChainID {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(finality.OracleResponse{FinalizedHeight: oracleHeight}))
+	}))
+	defer oracle.Close()
+
+	chain := mockChain{latestHeight: 100, finalizedHeight: 64}
+
+	tests := []struct {
+		name           string
+		finalityConfig config.FinalityConfig
+		defaultPolicy  string
+		oracleHeight   uint64
+		expHeight      uint64
+		expErr         string
+	}{
+		{
+			name:           "default confirmations",
+			finalityConfig: config.FinalityConfig{Confirmations: 12},
+			defaultPolicy:  config.FinalityPolicyConfirmations,
+			expHeight:      88,
+		},
+		{
+			name:           "default finalized",
+			finalityConfig: config.FinalityConfig{},
+			defaultPolicy:  config.FinalityPolicyFinalized,
+			expHeight:      64,
+		},
+		{
+			name:           "configured policy overrides the default",
+			finalityConfig: config.FinalityConfig{FinalityPolicy: config.FinalityPolicyConfirmations, Confirmations: 2},
+			defaultPolicy:  config.FinalityPolicyFinalized,
+			expHeight:      98,
+		},
+		{
+			name:           "not enough confirmations",
+			finalityConfig: config.FinalityConfig{Confirmations: 101},
+			defaultPolicy:  config.FinalityPolicyConfirmations,
+			expErr:         "latest height 100 does not have 101 confirmations",
+		},
+		{
+			name:           "oracle",
+			finalityConfig: config.FinalityConfig{FinalityPolicy: config.FinalityPolicyOracle, FinalityOracle: oracle.URL + "/finality"},
+			oracleHeight:   90,
+			expHeight:      90,
+		},
+		{
+			name:           "oracle ahead of the chain",
+			finalityConfig: config.FinalityConfig{FinalityPolicy: config.FinalityPolicyOracle, FinalityOracle: oracle.URL + "/finality"},
+			oracleHeight:   101,
+			expErr:         "finality oracle height 101 is above the latest height 100",
+		},
+		{
+			name:           "oracle error",
+			finalityConfig: config.FinalityConfig{FinalityPolicy: config.FinalityPolicyOracle, FinalityOracle: oracle.URL + "/missing"},
+			expErr:         "finality oracle responded with status 404",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oracleHeight = tt.oracleHeight
+
+			policy, err := finality.NewPolicy(mockChainID, tt.finalityConfig, tt.defaultPolicy)
+			require.NoError(t, err)
+
+			height, err := policy.FinalHeight(context.Background(), chain)
+			if tt.expErr != "" {
+				require.ErrorContains(t, err, tt.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expHeight, height)
+		})
+	}
+}
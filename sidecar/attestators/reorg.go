@@ -0,0 +1,61 @@
+package attestators
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/sidecar/attestators/attestator"
+)
+
+// defaultReorgCheckDepth is how many of the most recent attestations of a chain are checked for reorgs
+const defaultReorgCheckDepth = 16
+
+// attestedBlockHash returns the hash of the block the attestation was made for, from the payload if it has one
+func attestedBlockHash(ctx context.Context, chainAttestator attestator.Attestator, payload types.Payload) ([]byte, error) {
+	if blockData, ok := payload.(*types.BlockData); ok {
+		return blockData.BlockHash, nil
+	}
+
+	return chainAttestator.BlockHash(ctx, payload.AttestedHeight().RevisionHeight)
+}
+
+// checkReorgs compares the block hashes of the most recent attestations with the current ones on the chain, newest first,
+// and retracts the attestations for blocks that have been reorged out. Older blocks can't have been reorged out if a
+// newer one is still in the chain, so it stops at the first match.
+func (c *coordinator) checkReorgs(ctx context.Context, chainAttestator attestator.Attestator) error {
+	chainID := chainAttestator.ChainID()
+	stored, err := c.store.RecentBlockHashes(chainID, c.reorgCheckDepth)
+	if err != nil {
+		return err
+	}
+
+	var reorged []uint64
+	for _, s := range stored {
+		hash, err := chainAttestator.BlockHash(ctx, s.Height)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(hash, s.Hash) {
+			break
+		}
+
+		c.logger.Warn("Attested block was reorged out, retracting attestation",
+			zap.String("chain_id", chainID),
+			zap.Uint64("height", s.Height),
+			zap.String("attested_block_hash", hex.EncodeToString(s.Hash)),
+			zap.String("block_hash", hex.EncodeToString(hash)),
+		)
+		reorged = append(reorged, s.Height)
+	}
+
+	if len(reorged) == 0 {
+		return nil
+	}
+
+	// the latest attestation is now the one for the newest block that is still in the chain, if any
+	return c.store.RetractAttestations(chainID, reorged)
+}
@@ -0,0 +1,77 @@
+package attestators
+
+import (
+	"sync"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+)
+
+// subscriberBuffer is the number of attestations a subscriber can fall behind before it is dropped
+const subscriberBuffer = 100
+
+// subscriber receives the attestations of its chains, or of all chains if it has none
+type subscriber struct {
+	chainIDs map[string]bool
+	ch       chan types.Attestation
+}
+
+// subscriptions fans out signed attestations to the subscribers. A subscriber that doesn't keep up is dropped by closing
+// its channel, so that a slow subscriber never holds up the collection of attestations.
+type subscriptions struct {
+	lock        sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+func (s *subscriptions) subscribe(chainIDs []string) *subscriber {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sub := &subscriber{
+		chainIDs: make(map[string]bool, len(chainIDs)),
+		ch:       make(chan types.Attestation, subscriberBuffer),
+	}
+	for _, chainID := range chainIDs {
+		sub.chainIDs[chainID] = true
+	}
+
+	if s.subscribers == nil {
+		s.subscribers = make(map[*subscriber]struct{})
+	}
+	s.subscribers[sub] = struct{}{}
+
+	return sub
+}
+
+// unsubscribe closes the channel of the subscriber, unless it was already dropped
+func (s *subscriptions) unsubscribe(sub *subscriber) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.remove(sub)
+}
+
+func (s *subscriptions) publish(chainID string, attestation types.Attestation) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for sub := range s.subscribers {
+		if len(sub.chainIDs) > 0 && !sub.chainIDs[chainID] {
+			continue
+		}
+
+		select {
+		case sub.ch <- attestation:
+		default:
+			s.remove(sub)
+		}
+	}
+}
+
+func (s *subscriptions) remove(sub *subscriber) {
+	if _, ok := s.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(s.subscribers, sub)
+	close(sub.ch)
+}
@@ -0,0 +1,339 @@
+package attestators
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"gitlab.com/tozd/go/errors"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+	"github.com/cosmos/interchain-attestation/sidecar/metrics"
+)
+
+const (
+	// failingThreshold is the number of consecutive failed collections after which a chain is failing instead of degraded
+	failingThreshold = 3
+	// maxRestartBackoff caps the exponential backoff before a failed collection loop is restarted
+	maxRestartBackoff = 5 * time.Minute
+	// drainTimeout is how long a collection in flight may still take once its loop is stopped, before it is cancelled
+	drainTimeout = 10 * time.Second
+)
+
+// HealthState is the health of the collection loop of a chain
+type HealthState string
+
+const (
+	// HealthStateHealthy means the last collection for the chain succeeded
+	HealthStateHealthy HealthState = "healthy"
+	// HealthStateDegraded means no collection for the chain has succeeded yet, or the last few failed
+	HealthStateDegraded HealthState = "degraded"
+	// HealthStateFailing means at least failingThreshold consecutive collections for the chain failed
+	HealthStateFailing HealthState = "failing"
+	// HealthStatePaused means the collection loop of the chain is paused
+	HealthStatePaused HealthState = "paused"
+)
+
+// ChainHealth is the health of the collection loop of a chain
+type ChainHealth struct {
+	ChainID             string
+	State               HealthState
+	ConsecutiveFailures int
+	LastSuccess         time.Time // zero if no collection succeeded yet
+	LastError           string
+}
+
+// supervisedChain is a chain whose collection loop is run by the supervisor
+type supervisedChain struct {
+	chainID  string
+	schedule config.CollectionSchedule
+	collect  func(ctx context.Context) error
+}
+
+// supervisor runs the collection loop of every chain independently, each on its own schedule. A failed (or panicking)
+// collection restarts the loop of its chain after an exponential backoff, without affecting the other chains. Chains
+// can be added, removed, paused and resumed while the supervisor runs.
+type supervisor struct {
+	logger *zap.Logger
+
+	lock   sync.RWMutex
+	chains map[string]supervisedChain
+	health map[string]*ChainHealth
+	// loops are the running collection loops by chain id
+	loops map[string]*chainLoop
+	// ctx is the context of run, nil until the supervisor runs
+	ctx context.Context
+	wg  sync.WaitGroup
+}
+
+// chainLoop is a running collection loop of a chain
+type chainLoop struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newSupervisor(logger *zap.Logger, chains []supervisedChain) *supervisor {
+	s := &supervisor{
+		logger: logger,
+		chains: make(map[string]supervisedChain, len(chains)),
+		health: make(map[string]*ChainHealth, len(chains)),
+		loops:  make(map[string]*chainLoop),
+	}
+	for _, chain := range chains {
+		s.chains[chain.chainID] = chain
+		s.health[chain.chainID] = &ChainHealth{
+			ChainID: chain.chainID,
+			State:   HealthStateDegraded,
+		}
+	}
+
+	return s
+}
+
+// run runs the collection loops of all the chains that are not paused until the context is done
+func (s *supervisor) run(ctx context.Context) {
+	s.lock.Lock()
+	s.ctx = ctx
+	for chainID, health := range s.health {
+		if health.State != HealthStatePaused {
+			s.startLoop(s.chains[chainID])
+		}
+	}
+	s.lock.Unlock()
+
+	<-ctx.Done()
+	s.wg.Wait()
+}
+
+// add supervises a new chain, and starts its collection loop if the supervisor runs and the chain is not paused
+func (s *supervisor) add(chain supervisedChain, paused bool) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.chains[chain.chainID]; ok {
+		return errors.Errorf("chain id %s is already supervised", chain.chainID)
+	}
+
+	s.chains[chain.chainID] = chain
+	s.health[chain.chainID] = &ChainHealth{
+		ChainID: chain.chainID,
+		State:   HealthStateDegraded,
+	}
+	if paused {
+		s.health[chain.chainID].State = HealthStatePaused
+	} else if s.ctx != nil {
+		s.startLoop(chain)
+	}
+
+	return nil
+}
+
+// remove stops the collection loop of the chain, and waits for it to return before forgetting the chain
+func (s *supervisor) remove(chainID string) error {
+	if err := s.stopLoop(chainID); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.chains, chainID)
+	delete(s.health, chainID)
+
+	return nil
+}
+
+// pause stops the collection loop of the chain, and waits for it to return
+func (s *supervisor) pause(chainID string) error {
+	if err := s.stopLoop(chainID); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.health[chainID].State = HealthStatePaused
+
+	return nil
+}
+
+// resume restarts the collection loop of a paused chain
+func (s *supervisor) resume(chainID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	health, ok := s.health[chainID]
+	if !ok {
+		return errors.Errorf("%w %s", ErrUnknownChain, chainID)
+	}
+	if health.State != HealthStatePaused {
+		return nil
+	}
+
+	health.State = HealthStateDegraded
+	health.ConsecutiveFailures = 0
+	if s.ctx != nil {
+		s.startLoop(s.chains[chainID])
+	}
+
+	return nil
+}
+
+// startLoop starts the collection loop of the chain, the lock must be held
+func (s *supervisor) startLoop(chain supervisedChain) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	loop := &chainLoop{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	s.loops[chain.chainID] = loop
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer close(loop.done)
+		s.runChain(ctx, chain)
+	}()
+}
+
+// stopLoop stops the collection loop of the chain if it runs, and waits for it to return
+func (s *supervisor) stopLoop(chainID string) error {
+	s.lock.Lock()
+	if _, ok := s.chains[chainID]; !ok {
+		s.lock.Unlock()
+		return errors.Errorf("%w %s", ErrUnknownChain, chainID)
+	}
+	loop := s.loops[chainID]
+	delete(s.loops, chainID)
+	s.lock.Unlock()
+
+	if loop != nil {
+		loop.cancel()
+		<-loop.done
+	}
+
+	return nil
+}
+
+func (s *supervisor) runChain(ctx context.Context, chain supervisedChain) {
+	s.logger.Info("Starting chain collection loop",
+		zap.String("chain_id", chain.chainID),
+		zap.Duration("interval", chain.schedule.Interval),
+		zap.Duration("timeout", chain.schedule.Timeout),
+		zap.Duration("jitter", chain.schedule.Jitter),
+	)
+
+	for {
+		start := time.Now()
+		err := s.collectOnce(ctx, chain)
+		if ctx.Err() != nil {
+			return
+		}
+		metrics.CollectionDuration.WithLabelValues(chain.chainID).Observe(time.Since(start).Seconds())
+
+		wait := chain.schedule.Interval - time.Since(start)
+		if err != nil {
+			metrics.CollectionErrors.WithLabelValues(chain.chainID).Inc()
+			failures := s.recordFailure(chain.chainID, err)
+			wait = restartBackoff(chain.schedule.Interval, failures)
+			s.logger.Error("Chain collection failed, restarting collection loop after backoff",
+				zap.String("chain_id", chain.chainID),
+				zap.Int("consecutive_failures", failures),
+				zap.Duration("backoff", wait),
+				zap.Error(err),
+			)
+		} else {
+			s.recordSuccess(chain.chainID)
+		}
+
+		if chain.schedule.Jitter > 0 {
+			wait += time.Duration(rand.Int63n(int64(chain.schedule.Jitter) + 1))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// collectOnce runs one collection with the timeout of the chain, and turns a panic into an error. If the loop is stopped
+// during the collection, the collection is drained for up to drainTimeout before it is cancelled, so a collection is
+// rarely abandoned halfway.
+func (s *supervisor) collectOnce(ctx context.Context, chain supervisedChain) (err error) {
+	collectCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), chain.schedule.Timeout)
+	defer cancel()
+	stopDrain := context.AfterFunc(ctx, func() {
+		timer := time.NewTimer(drainTimeout)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancel()
+		case <-collectCtx.Done():
+		}
+	})
+	defer stopDrain()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("collection panicked: %v", r)
+		}
+	}()
+
+	return chain.collect(collectCtx)
+}
+
+func (s *supervisor) recordSuccess(chainID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	health := s.health[chainID]
+	health.State = HealthStateHealthy
+	health.ConsecutiveFailures = 0
+	health.LastSuccess = time.Now()
+	health.LastError = ""
+}
+
+// recordFailure returns the number of consecutive failures of the chain
+func (s *supervisor) recordFailure(chainID string, err error) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	health := s.health[chainID]
+	health.ConsecutiveFailures++
+	health.LastError = err.Error()
+	health.State = HealthStateDegraded
+	if health.ConsecutiveFailures >= failingThreshold {
+		health.State = HealthStateFailing
+	}
+
+	return health.ConsecutiveFailures
+}
+
+// chainHealth returns the health of every supervised chain
+func (s *supervisor) chainHealth() map[string]ChainHealth {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	health := make(map[string]ChainHealth, len(s.health))
+	for chainID, chainHealth := range s.health {
+		health[chainID] = *chainHealth
+	}
+
+	return health
+}
+
+// restartBackoff doubles the interval for every consecutive failure, up to maxRestartBackoff
+func restartBackoff(interval time.Duration, failures int) time.Duration {
+	backoff := interval
+	for i := 0; i < failures && backoff < maxRestartBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxRestartBackoff)
+}
@@ -0,0 +1,189 @@
+package attestators
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/tozd/go/errors"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+func TestSupervisor(t *testing.T) {
+	schedule := config.CollectionSchedule{Interval: 10 * time.Millisecond, Timeout: 50 * time.Millisecond, Jitter: 5 * time.Millisecond}
+
+	var healthyCollections, recoveringCollections atomic.Int32
+	s := newSupervisor(zap.NewNop(), []supervisedChain{
+		{
+			chainID:  "healthy",
+			schedule: schedule,
+			collect: func(_ context.Context) error {
+				healthyCollections.Add(1)
+				return nil
+			},
+		},
+		{
+			chainID:  "failing",
+			schedule: schedule,
+			collect: func(_ context.Context) error {
+				return errors.New("counterparty unavailable")
+			},
+		},
+		{
+			chainID:  "panicking",
+			schedule: schedule,
+			collect: func(_ context.Context) error {
+				panic("bad counterparty")
+			},
+		},
+		{
+			chainID:  "hanging",
+			schedule: schedule,
+			collect: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		},
+		{
+			chainID:  "recovering",
+			schedule: schedule,
+			collect: func(_ context.Context) error {
+				if recoveringCollections.Add(1) <= 2 {
+					return errors.New("not yet")
+				}
+				return nil
+			},
+		},
+	})
+
+	// nothing has been collected before the supervisor runs
+	for _, health := range s.chainHealth() {
+		require.Equal(t, HealthStateDegraded, health.State)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.run(ctx)
+		close(done)
+	}()
+	time.Sleep(500 * time.Millisecond)
+	cancel()
+	<-done
+
+	health := s.chainHealth()
+
+	// the failing chains don't stop the healthy one
+	require.Equal(t, HealthStateHealthy, health["healthy"].State)
+	require.Greater(t, healthyCollections.Load(), int32(10))
+	require.False(t, health["healthy"].LastSuccess.IsZero())
+
+	require.Equal(t, HealthStateFailing, health["failing"].State)
+	require.GreaterOrEqual(t, health["failing"].ConsecutiveFailures, failingThreshold)
+	require.Equal(t, "counterparty unavailable", health["failing"].LastError)
+
+	require.Equal(t, HealthStateFailing, health["panicking"].State)
+	require.Equal(t, "collection panicked: bad counterparty", health["panicking"].LastError)
+
+	require.NotEqual(t, HealthStateHealthy, health["hanging"].State)
+	require.Equal(t, context.DeadlineExceeded.Error(), health["hanging"].LastError)
+
+	require.Equal(t, HealthStateHealthy, health["recovering"].State)
+	require.Zero(t, health["recovering"].ConsecutiveFailures)
+	require.Empty(t, health["recovering"].LastError)
+}
+
+func TestSupervisor_AddRemovePause(t *testing.T) {
+	schedule := config.CollectionSchedule{Interval: 10 * time.Millisecond, Timeout: 50 * time.Millisecond}
+
+	var collections atomic.Int32
+	collect := func(_ context.Context) error {
+		collections.Add(1)
+		return nil
+	}
+	s := newSupervisor(zap.NewNop(), nil)
+	require.NoError(t, s.add(supervisedChain{chainID: "paused", schedule: schedule, collect: collect}, true))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.run(ctx)
+		close(done)
+	}()
+
+	// a chain added while the supervisor runs is collected right away
+	require.NoError(t, s.add(supervisedChain{chainID: "added", schedule: schedule, collect: collect}, false))
+	require.Error(t, s.add(supervisedChain{chainID: "added", schedule: schedule, collect: collect}, false))
+	require.Eventually(t, func() bool {
+		return s.chainHealth()["added"].State == HealthStateHealthy
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, HealthStatePaused, s.chainHealth()["paused"].State)
+
+	// a paused chain is not collected until it is resumed
+	require.NoError(t, s.pause("added"))
+	require.Equal(t, HealthStatePaused, s.chainHealth()["added"].State)
+	pausedCollections := collections.Load()
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, pausedCollections, collections.Load())
+
+	require.NoError(t, s.resume("added"))
+	require.NoError(t, s.resume("added"))
+	require.Eventually(t, func() bool {
+		return s.chainHealth()["added"].State == HealthStateHealthy
+	}, time.Second, 10*time.Millisecond)
+
+	// a removed chain is not collected anymore once remove returns
+	require.NoError(t, s.remove("added"))
+	require.NotContains(t, s.chainHealth(), "added")
+	removedCollections := collections.Load()
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, removedCollections, collections.Load())
+	require.ErrorIs(t, s.remove("added"), ErrUnknownChain)
+	require.ErrorIs(t, s.pause("added"), ErrUnknownChain)
+	require.ErrorIs(t, s.resume("added"), ErrUnknownChain)
+
+	cancel()
+	<-done
+}
+
+func TestSupervisor_Drain(t *testing.T) {
+	started := make(chan struct{})
+	var drained atomic.Bool
+	s := newSupervisor(zap.NewNop(), []supervisedChain{{
+		chainID:  "slow",
+		schedule: config.CollectionSchedule{Interval: time.Second, Timeout: time.Second},
+		collect: func(ctx context.Context) error {
+			close(started)
+			select {
+			case <-ctx.Done():
+			case <-time.After(200 * time.Millisecond):
+				drained.Store(true)
+			}
+			return nil
+		},
+	}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.run(ctx)
+		close(done)
+	}()
+
+	// the collection in flight is not cancelled when the supervisor stops, and run waits for it
+	<-started
+	cancel()
+	<-done
+	require.True(t, drained.Load())
+}
+
+func TestRestartBackoff(t *testing.T) {
+	require.Equal(t, 2*time.Second, restartBackoff(time.Second, 1))
+	require.Equal(t, 8*time.Second, restartBackoff(time.Second, 3))
+	require.Equal(t, maxRestartBackoff, restartBackoff(time.Second, 20))
+	require.Equal(t, maxRestartBackoff, restartBackoff(time.Hour, 1))
+}
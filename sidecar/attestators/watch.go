@@ -0,0 +1,56 @@
+package attestators
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+// WatchConfig checks the config file in the home directory for changes every interval until the context is done, and
+// applies the chains of a changed config to the coordinator. An invalid config is logged and ignored until the file
+// changes again.
+func WatchConfig(ctx context.Context, logger *zap.Logger, coordinator Coordinator, homedir string, interval time.Duration) {
+	configFilePath := config.GetConfigFilePath(homedir)
+	lastStat, err := os.Stat(configFilePath)
+	if err != nil {
+		logger.Warn("Failed to stat config file", zap.String("path", configFilePath), zap.Error(err))
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stat, err := os.Stat(configFilePath)
+		if err != nil {
+			logger.Warn("Failed to stat config file", zap.String("path", configFilePath), zap.Error(err))
+			continue
+		}
+		if lastStat != nil && stat.ModTime().Equal(lastStat.ModTime()) && stat.Size() == lastStat.Size() {
+			continue
+		}
+		lastStat = stat
+
+		logger.Info("Config file changed, applying chains", zap.String("path", configFilePath))
+		sidecarConfig, found, err := config.ReadConfig(homedir)
+		if err != nil {
+			logger.Error("Failed to read config file", zap.String("path", configFilePath), zap.Error(err))
+			continue
+		}
+		if !found {
+			continue
+		}
+		if err := coordinator.ApplyConfig(sidecarConfig); err != nil {
+			logger.Error("Failed to apply config, it is applied again once the config file changes", zap.Error(err))
+		}
+	}
+}
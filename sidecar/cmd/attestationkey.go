@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gitlab.com/tozd/go/errors"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/sidecar/attestationkey"
+	"github.com/cosmos/interchain-attestation/sidecar/attestators/cosmos"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+	"github.com/cosmos/interchain-attestation/sidecar/signer"
+)
+
+const (
+	flagKeyType            = "type"
+	flagForceGenerateKey   = "force"
+	attestationKeyNotFound = "attestation key not found, generate one with `attestation-sidecar attestation-key generate`"
+)
+
+func AttestationKeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attestation-key",
+		Short: "attestation key subcommands",
+	}
+
+	cmd.AddCommand(
+		GenerateAttestationKeyCmd(),
+		AttestationKeyRegistrationCmd(),
+	)
+
+	return cmd
+}
+
+func GenerateAttestationKeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "generate the key used to sign attestations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyType, _ := cmd.Flags().GetString(flagKeyType)
+			force, _ := cmd.Flags().GetBool(flagForceGenerateKey)
+
+			cdc := cosmos.NewCodecConfig().Marshaler
+			privKey, err := attestationkey.Generate(cdc, GetHomedir(cmd), keyType, force)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Generated %s attestation key with public key %X\n", privKey.Type(), privKey.PubKey().Bytes())
+			fmt.Println("Register it with your validator using the output of `attestation-sidecar attestation-key registration`")
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagKeyType, attestationkey.KeyTypeBLS12381, fmt.Sprintf("attestation key type (%s or %s)", attestationkey.KeyTypeBLS12381, attestationkey.KeyTypeSecp256k1))
+	cmd.Flags().Bool(flagForceGenerateKey, false, "force overwrite of existing attestation key")
+
+	return cmd
+}
+
+func AttestationKeyRegistrationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "registration",
+		Short: "print the attestator registration json to register the key of the configured signer with your validator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sidecarConfig := GetConfig(cmd)
+			if sidecarConfig.AttestatorID == "" {
+				return errors.New("attestator id must be set in the config")
+			}
+
+			attestationSigner, err := newSigner(cmd, sidecarConfig)
+			if err != nil {
+				return err
+			}
+			defer attestationSigner.Close()
+
+			registration, err := signer.Registration(sidecarConfig.AttestatorID, attestationSigner)
+			if err != nil {
+				return err
+			}
+
+			cdc := cosmos.NewCodecConfig().Marshaler
+			bz, err := types.MarshalAttestationRegistrationJSON(cdc, registration)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(bz))
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// newSigner sets up the signer configured in the config, pointing to `attestation-key generate` if the key file is missing
+func newSigner(cmd *cobra.Command, sidecarConfig config.Config) (signer.Signer, error) {
+	attestationSigner, err := signer.New(cmd.Context(), cosmos.NewCodecConfig().Marshaler, GetHomedir(cmd), sidecarConfig.Signer)
+	if err != nil {
+		if errors.Is(err, signer.ErrKeyNotFound) {
+			return nil, errors.New(attestationKeyNotFound)
+		}
+		return nil, err
+	}
+
+	return attestationSigner, nil
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/cosmos/interchain-attestation/sidecar/attestators/cosmos"
 	"github.com/cosmos/interchain-attestation/sidecar/config"
+	"github.com/cosmos/interchain-attestation/sidecar/signer"
 )
 
 type SidecarContextKey string
@@ -109,7 +110,7 @@ func RootCmd() *cobra.Command {
 				// cfg.Seal()
 				codecConfig := cosmos.NewCodecConfig()
 				keyringBackend, _ := cmd.Flags().GetString(flags.FlagKeyringBackend)
-				kr, err := keyring.New("attestation-sidecar", keyringBackend, homedir, os.Stdin, codecConfig.Marshaler)
+				kr, err := keyring.New(signer.KeyringAppName, keyringBackend, homedir, os.Stdin, codecConfig.Marshaler)
 				if err != nil {
 					return err
 				}
@@ -136,6 +137,8 @@ func RootCmd() *cobra.Command {
 		keysCmd,
 		StartCmd(),
 		ConfigCmd(),
+		AttestationKeyCmd(),
+		SignStateCmd(),
 		RelayerCmd(),
 	)
 
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/interchain-attestation/sidecar/signer"
+)
+
+func SignStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-state",
+		Short: "sign state subcommands, to move the last signed attestations between machines",
+	}
+
+	cmd.AddCommand(
+		ExportSignStateCmd(),
+		ImportSignStateCmd(),
+	)
+
+	return cmd
+}
+
+func ExportSignStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "print the last signed attestation of every chain as json",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			state, err := signer.LoadSignState(signer.SignStatePath(GetHomedir(cmd), GetConfig(cmd).Signer))
+			if err != nil {
+				return err
+			}
+
+			bz, err := json.MarshalIndent(state, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(bz))
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func ImportSignStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "import the last signed attestations exported on another machine, the sidecar must not be running",
+		Long: `Import the last signed attestations exported with sign-state export on another machine.
+The sign state of a chain is only imported if it is at a higher height than the local one, so importing never allows
+signing again at a height that was already signed. Stop the sidecar before importing.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			imported, err := signer.LoadSignState(args[0])
+			if err != nil {
+				return err
+			}
+
+			statePath := signer.SignStatePath(GetHomedir(cmd), GetConfig(cmd).Signer)
+			state, err := signer.LoadSignState(statePath)
+			if err != nil {
+				return err
+			}
+
+			merged := state.Merge(imported)
+			if err := state.Save(statePath); err != nil {
+				return err
+			}
+
+			for _, chainID := range merged {
+				fmt.Printf("Imported sign state of %s at height %d\n", chainID, state.Chains[chainID].Height)
+			}
+			if skipped := len(imported.Chains) - len(merged); skipped > 0 {
+				fmt.Printf("Kept the local sign state of %d chains that were already at the same or a higher height\n", skipped)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -1,7 +1,14 @@
 package cmd
 
 import (
+	"context"
+	"io"
+	"os"
+	"os/signal"
 	"path"
+	"slices"
+	"syscall"
+	"time"
 
 	"github.com/dgraph-io/badger/v4"
 	"github.com/spf13/cobra"
@@ -9,19 +16,37 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/cosmos/interchain-attestation/sidecar/attestators"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+	"github.com/cosmos/interchain-attestation/sidecar/ha"
+	"github.com/cosmos/interchain-attestation/sidecar/metrics"
 	"github.com/cosmos/interchain-attestation/sidecar/server"
+	"github.com/cosmos/interchain-attestation/sidecar/signer"
+	"github.com/cosmos/interchain-attestation/sidecar/store"
 )
 
 const (
-	flagListenAddr = "listen-addr"
+	flagListenAddr          = "listen-addr"
+	flagMetricsListenAddr   = "metrics-listen-addr"
+	flagConfigWatchInterval = "config-watch-interval"
+	flagShutdownTimeout     = "shutdown-timeout"
+
+	defaultConfigWatchInterval = 10 * time.Second
+	defaultShutdownTimeout     = 10 * time.Second
 )
 
 func StartCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "start",
 		Short: "Start the attestation sidecar",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			listenAddr, _ := cmd.Flags().GetString(flagListenAddr)
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			sidecarConfig := GetConfig(cmd)
+			listenAddr := sidecarConfig.Server.GetListenAddress()
+			if cmd.Flags().Changed(flagListenAddr) {
+				listenAddr, _ = cmd.Flags().GetString(flagListenAddr)
+			}
+			metricsListenAddr, _ := cmd.Flags().GetString(flagMetricsListenAddr)
+			configWatchInterval, _ := cmd.Flags().GetDuration(flagConfigWatchInterval)
+			shutdownTimeout, _ := cmd.Flags().GetDuration(flagShutdownTimeout)
 
 			logger := GetLogger(cmd)
 
@@ -29,11 +54,22 @@ func StartCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			// the database is flushed and closed, and the lock released, once everything else has stopped
+			defer func() {
+				if closeErr := coordinator.Close(); closeErr != nil {
+					logger.Error("Failed to close coordinator", zap.Error(closeErr))
+					if err == nil {
+						err = closeErr
+					}
+				}
+			}()
 
-			s := server.NewServer(logger, coordinator)
-
-			var eg errgroup.Group
+			ctx, stopSignals := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stopSignals()
+			// a component that fails shuts down all the others
+			eg, ctx := errgroup.WithContext(ctx)
 
+			s := server.NewServer(logger, coordinator, sidecarConfig.Server, GetHomedir(cmd))
 			eg.Go(func() error {
 				if err := s.Serve(listenAddr); err != nil {
 					logger.Error("server.Serve crashed", zap.Error(err))
@@ -43,8 +79,29 @@ func StartCmd() *cobra.Command {
 				return nil
 			})
 
+			var httpServer *server.HTTPServer
+			if metricsListenAddr != "" {
+				httpServer = server.NewHTTPServer(logger, coordinator)
+				eg.Go(func() error {
+					if err := httpServer.Serve(metricsListenAddr); err != nil {
+						logger.Error("httpServer.Serve crashed", zap.Error(err))
+						return err
+					}
+
+					return nil
+				})
+			}
+
+			if configWatchInterval > 0 {
+				eg.Go(func() error {
+					attestators.WatchConfig(ctx, logger, coordinator, GetHomedir(cmd), configWatchInterval)
+					return nil
+				})
+			}
+
+			// the coordinator drains the collections in flight once the context is done
 			eg.Go(func() error {
-				if err := coordinator.Run(cmd.Context()); err != nil {
+				if err := coordinator.Run(ctx); err != nil {
 					logger.Error("coordinator.Run crashed", zap.Error(err))
 					return err
 				}
@@ -52,26 +109,100 @@ func StartCmd() *cobra.Command {
 				return nil
 			})
 
-			return eg.Wait()
+			eg.Go(func() error {
+				<-ctx.Done()
+				// a second signal kills the sidecar right away
+				stopSignals()
+				logger.Info("Shutting down, stopping the servers", zap.Duration("timeout", shutdownTimeout))
+
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+				defer cancel()
+				s.Stop(shutdownCtx)
+				if httpServer != nil {
+					httpServer.Stop(shutdownCtx)
+				}
+
+				return nil
+			})
+
+			if err := eg.Wait(); err != nil {
+				return err
+			}
+			logger.Info("Sidecar stopped")
+
+			return nil
 		},
 	}
 
-	cmd.Flags().String(flagListenAddr, "localhost:6969", "Address for grpc server to listen on")
+	cmd.Flags().String(flagListenAddr, config.DefaultListenAddress, "Address for grpc server to listen on, host:port or unix:///path/to/socket, overrides listen_address in the config")
+	cmd.Flags().String(flagMetricsListenAddr, "", "Address for the http server with the prometheus metrics and health endpoints to listen on, disabled if empty")
+	cmd.Flags().Duration(flagShutdownTimeout, defaultShutdownTimeout, "Time the servers wait for requests in flight on shutdown, before cancelling them")
+	cmd.Flags().Duration(flagConfigWatchInterval, defaultConfigWatchInterval, "Interval between checks of the config file for changed chains, which are applied without a restart, disabled if 0")
 
 	return cmd
 }
 
-func setUpCoordinator(cmd *cobra.Command, logger *zap.Logger) (attestators.Coordinator, error) {
+func setUpCoordinator(cmd *cobra.Command, logger *zap.Logger) (_ attestators.Coordinator, err error) {
 	sidecarConfig := GetConfig(cmd)
 	homedir := GetHomedir(cmd)
 
+	// once set up, the coordinator closes the signer, the lock and the database, but until then they are closed here
+	var closers []io.Closer
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, closer := range slices.Backward(closers) {
+			if closeErr := closer.Close(); closeErr != nil {
+				logger.Error("Failed to close after failed set up", zap.Error(closeErr))
+			}
+		}
+	}()
+
+	var (
+		attestationSigner *signer.Guard
+		lock              ha.Lock
+	)
+	if sidecarConfig.HasAttestationChains() {
+		s, err := newSigner(cmd, sidecarConfig)
+		if err != nil {
+			return nil, err
+		}
+		closers = append(closers, s)
+
+		lock, err = ha.New(logger, homedir, sidecarConfig)
+		if err != nil {
+			return nil, err
+		}
+		if lock != nil {
+			closers = append(closers, lock)
+		}
+
+		// in a high availability group the sign state is shared through the lock
+		var stateStore signer.StateStore = signer.NewFileStateStore(signer.SignStatePath(homedir, sidecarConfig.Signer))
+		if lock != nil {
+			stateStore = lock
+		}
+		attestationSigner = signer.NewGuard(s, stateStore)
+	}
+
 	dbPath := path.Join(homedir, "db")
 	db, err := badger.Open(badger.DefaultOptions(dbPath))
 	if err != nil {
 		return nil, err
 	}
+	closers = append(closers, db)
+
+	if err := metrics.RegisterDBSize(db.Size); err != nil {
+		return nil, err
+	}
+
+	attestationStore, err := store.NewBadgerStore(db)
+	if err != nil {
+		return nil, err
+	}
 
-	coordinator, err := attestators.NewCoordinator(logger, db, sidecarConfig)
+	coordinator, err := attestators.NewCoordinator(logger, db, attestationStore, sidecarConfig, attestationSigner, lock)
 	if err != nil {
 		return nil, err
 	}
@@ -1,35 +1,170 @@
 package config
 
 import (
+	"encoding/hex"
+	"net/url"
 	"os"
 	"path"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pelletier/go-toml/v2"
 	"gitlab.com/tozd/go/errors"
 
 	clienttypes "github.com/cosmos/ibc-go/v9/modules/core/02-client/types"
+
+	"github.com/cometbft/cometbft/light"
 )
 
 const (
 	configFileName = "config.toml"
+
+	// DefaultReconcileInterval is the default number of blocks between full reconciliations of the packet commitments
+	// tracked from the events of a cosmos chain
+	DefaultReconcileInterval = 1000
+	// DefaultTopologyRefreshInterval is the default time between refreshes of the cached connections and channels of the
+	// client of a cosmos chain, besides the refreshes on handshake events
+	DefaultTopologyRefreshInterval = 10 * time.Minute
+
+	// DefaultCollectionInterval is the default time between the starts of two attestation collections for a chain
+	DefaultCollectionInterval = 1 * time.Second
+	// DefaultCollectionTimeout is the default time after which an attestation collection for a chain is abandoned
+	DefaultCollectionTimeout = 1 * time.Minute
+
+	// DefaultGCInterval is the default time between garbage collections of the attestation database
+	DefaultGCInterval = 10 * time.Minute
+
+	// DefaultRemoteSignerTimeout is the default time after which a request to a remote signer is abandoned
+	DefaultRemoteSignerTimeout = 5 * time.Second
+
+	// DefaultListenAddress is the default address of the grpc server of the sidecar
+	DefaultListenAddress = "localhost:6969"
+	// DefaultSocketMode is the default file permissions of the unix socket of the grpc server of the sidecar
+	DefaultSocketMode = 0o600
+)
+
+// Signer kinds, deciding where the attestation key is kept
+const (
+	// SignerKindFile signs with the attestation key file generated by `attestation-key generate`
+	SignerKindFile = "file"
+	// SignerKindKeyring signs with a key in the keyring managed by the `keys` commands
+	SignerKindKeyring = "keyring"
+	// SignerKindRemote signs with a remote signer over grpc
+	SignerKindRemote = "remote"
+)
+
+// High availability backends, deciding how the sidecars of a high availability group agree on which one signs
+const (
+	// HABackendFile lets the sidecar that holds a lock on a shared file sign, for sidecars on the same machine or a shared filesystem
+	HABackendFile = "file"
+	// HABackendRaft lets the leader of an embedded raft cluster sign, with the sign state replicated through the raft log
+	HABackendRaft = "raft"
+)
+
+// Finality policies, deciding which heights of a chain are final enough to be attested to
+const (
+	// FinalityPolicyConfirmations attests to the latest height minus the configured number of confirmations
+	FinalityPolicyConfirmations = "confirmations"
+	// FinalityPolicyFinalized attests to the height the chain itself reports as finalized
+	// (the latest committed block for CometBFT chains, the "finalized" block tag for EVM chains)
+	FinalityPolicyFinalized = "finalized"
+	// FinalityPolicyOracle attests to the finalized height reported by an external finality oracle
+	FinalityPolicyOracle = "oracle"
 )
 
 type Config struct {
 	AttestatorID string              `toml:"attestator_id"`
+	HostChainID  string              `toml:"host_chain_id"` // the chain the attestations are signed for
 	CosmosChains []CosmosChainConfig `toml:"cosmos_chain"`
+	EVMChains    []EVMChainConfig    `toml:"evm_chain"`
+	Retention    RetentionConfig     `toml:"retention"`
+	Signer       SignerConfig        `toml:"signer"`
+	HA           HAConfig            `toml:"ha"`
+	Server       ServerConfig        `toml:"server"`
 
 	configFilePath string
 }
 
+// SignerConfig decides how attestations are signed. If no kind is set, the attestation key file is used.
+type SignerConfig struct {
+	Kind           string `toml:"kind"`            // one of file, keyring or remote
+	KeyFile        string `toml:"key_file"`        // the attestation key file, relative to the home directory, defaults to attestation_key.json (file)
+	KeyringBackend string `toml:"keyring_backend"` // e.g. "os" or "file", defaults to os (keyring)
+	KeyName        string `toml:"key_name"`        // the name of the key in the keyring (keyring)
+	RemoteAddress  string `toml:"remote_address"`  // the host:port of the grpc server of the remote signer (remote)
+	RemoteTimeout  string `toml:"remote_timeout"`  // e.g. "5s", after which a request to the remote signer is abandoned (remote)
+	StateFile      string `toml:"state_file"`      // the last signed attestation of every chain, relative to the home directory, defaults to signer_state.json
+
+	// Without TLS and an auth token, the connection to the remote signer is neither encrypted nor authenticated
+	RemoteTLS           bool   `toml:"remote_tls"`             // connect to the remote signer with TLS (remote)
+	RemoteTLSCAFile     string `toml:"remote_tls_ca_file"`     // the CA of the certificate of the remote signer, relative to the home directory, defaults to the system roots (remote)
+	RemoteTLSCertFile   string `toml:"remote_tls_cert_file"`   // the client certificate presented to the remote signer for mTLS, relative to the home directory (remote)
+	RemoteTLSKeyFile    string `toml:"remote_tls_key_file"`    // the key of the client certificate (remote)
+	RemoteTLSServerName string `toml:"remote_tls_server_name"` // overrides the server name the certificate of the remote signer is verified against (remote)
+	RemoteAuthToken     string `toml:"remote_auth_token"`      // sent to the remote signer as "authorization: Bearer <token>" (remote)
+	RemoteAuthTokenFile string `toml:"remote_auth_token_file"` // a file with the auth token, relative to the home directory, instead of remote_auth_token (remote)
+}
+
+// ServerConfig secures the grpc server of the sidecar. Without TLS and an auth token, anyone who can reach the listen
+// address can query the sidecar.
+type ServerConfig struct {
+	ListenAddress   string `toml:"listen_address"`     // host:port or unix:///path/to/sidecar.sock, defaults to localhost:6969, overridden by --listen-addr
+	SocketMode      string `toml:"socket_mode"`        // e.g. "0660", the file permissions of the unix socket, defaults to 0600
+	TLSCertFile     string `toml:"tls_cert_file"`      // the certificate of the server, enables TLS together with the key file
+	TLSKeyFile      string `toml:"tls_key_file"`       // the key of the certificate of the server
+	TLSClientCAFile string `toml:"tls_client_ca_file"` // if set, clients must present a certificate signed by this CA (mTLS)
+	AuthToken       string `toml:"auth_token"`         // if set, clients must send it as "authorization: Bearer <token>"
+	AuthTokenFile   string `toml:"auth_token_file"`    // a file with the auth token, relative to the home directory, instead of auth_token
+}
+
+// HAConfig runs the sidecar in a high availability group, in which several sidecars share the sign state and exactly
+// one of them signs at a time. If no backend is set, the sidecar runs on its own.
+type HAConfig struct {
+	Backend     string   `toml:"backend"`      // one of file or raft
+	LockFile    string   `toml:"lock_file"`    // the shared lock file, relative to the home directory, defaults to the signer state file with a .lock suffix (file)
+	NodeID      string   `toml:"node_id"`      // the id of this sidecar in the raft cluster (raft)
+	RaftAddress string   `toml:"raft_address"` // the host:port the raft transport of this sidecar listens on (raft)
+	RaftPeers   []string `toml:"raft_peers"`   // every sidecar of the raft cluster, including this one, as "node_id=host:port" (raft)
+}
+
+// RetentionConfig decides how long attestations are kept in the database. Attestations are pruned once they are outside
+// either window, but the latest attestation of a chain is always kept.
+type RetentionConfig struct {
+	RetainHeights uint64 `toml:"retain_heights"` // the number of most recent heights to keep attestations for, 0 keeps all
+	RetainAge     string `toml:"retain_age"`     // e.g. "168h", the age of the attested block after which it is pruned, empty keeps all
+	GCInterval    string `toml:"gc_interval"`    // e.g. "10m", the time between garbage collections of the database
+}
+
+// RetentionPolicy is the parsed RetentionConfig
+type RetentionPolicy struct {
+	Heights    uint64
+	Age        time.Duration
+	GCInterval time.Duration
+}
+
 // TODO: Document the config properly in the readme with examples
 type CosmosChainConfig struct {
-	ChainID  string `toml:"chain_id"`
-	RPC      string `toml:"rpc"`
-	ClientID string `toml:"client_id"`
+	ChainID  string   `toml:"chain_id"`
+	RPC      string   `toml:"rpc"`
+	RPCs     []string `toml:"rpcs"`   // additional rpc endpoints, each read independently for attestations
+	Quorum   int      `toml:"quorum"` // the number of rpc endpoints that must return identical attestation data, defaults to a majority
+	ClientID string   `toml:"client_id"`
 
 	// Attestation related stuff
-	Attestation    bool   `toml:"attestation"`
-	ClientToUpdate string `toml:"client_to_update"`
+	Attestation             bool   `toml:"attestation"`
+	ClientToUpdate          string `toml:"client_to_update"`
+	ReconcileInterval       uint64 `toml:"reconcile_interval"`        // blocks between full reconciliations of the tracked packet commitments
+	TopologyRefreshInterval string `toml:"topology_refresh_interval"` // e.g. "10m", time between refreshes of the cached channels of the client
+	FinalityConfig
+	CollectionConfig
+
+	// Light client related stuff, everything that is attested to is verified with a light client against the app hash of the chain
+	TrustedHeight  int64    `toml:"trusted_height"`
+	TrustedHash    string   `toml:"trusted_hash"`    // hex encoded hash of the block at the trusted height
+	TrustingPeriod string   `toml:"trusting_period"` // e.g. "168h", must be shorter than the unbonding period of the chain
+	Witnesses      []string `toml:"witnesses"`       // rpc addresses to cross-check the headers from rpc against, defaults to rpc itself
 
 	// Relaying and tx related stuff
 	// TODO: Maybe put this stuff into some sub structs to make it clear it is for relaying
@@ -41,11 +176,56 @@ type CosmosChainConfig struct {
 	GasAdjustment  float64 `toml:"gas_adjustment"`
 }
 
+// EVMChainConfig is an EVM chain with a solidity IBC contract, read over JSON-RPC
+type EVMChainConfig struct {
+	ChainID string `toml:"chain_id"`
+	RPC     string `toml:"rpc"` // the JSON-RPC endpoint (http, ws or ipc)
+
+	// Attestation related stuff
+	Attestation        bool   `toml:"attestation"`
+	ClientToUpdate     string `toml:"client_to_update"`
+	IBCContractAddress string `toml:"ibc_contract_address"`
+	StartBlock         uint64 `toml:"start_block"` // the block the IBC contract was deployed in, where reading packet commitments starts
+	FinalityConfig
+	CollectionConfig
+}
+
+// FinalityConfig decides which heights of a chain are attested to. It is shared by all chain types.
+// If no policy is set, the chain type's default is used: finalized for cosmos chains and confirmations for EVM chains.
+type FinalityConfig struct {
+	FinalityPolicy string `toml:"finality_policy"` // one of confirmations, finalized or oracle
+	Confirmations  uint64 `toml:"confirmations"`   // the number of blocks on top of a block before it is attested to (confirmations policy)
+	FinalityOracle string `toml:"finality_oracle"` // the http(s) url of the finality oracle (oracle policy)
+}
+
+// CollectionConfig decides when attestations are collected for a chain. It is shared by all chain types.
+type CollectionConfig struct {
+	CollectionInterval string `toml:"collection_interval"` // e.g. "1s", the time between the starts of two collections
+	CollectionTimeout  string `toml:"collection_timeout"`  // e.g. "1m", after which a collection is abandoned
+	CollectionJitter   string `toml:"collection_jitter"`   // e.g. "100ms", the maximum random delay added to every interval
+	Paused             bool   `toml:"paused"`              // stops collecting attestations for the chain, without removing it
+}
+
+// CollectionSchedule is the parsed CollectionConfig of a chain
+type CollectionSchedule struct {
+	Interval time.Duration
+	Timeout  time.Duration
+	Jitter   time.Duration
+}
+
 func (c Config) Validate() error {
-	if len(c.CosmosChains) == 0 {
+	if len(c.CosmosChains) == 0 && len(c.EVMChains) == 0 {
 		return errors.New("at least one chain must be defined in the config")
 	}
 
+	if _, err := c.Retention.GetPolicy(); err != nil {
+		return err
+	}
+
+	if err := c.Server.Validate(); err != nil {
+		return err
+	}
+
 	anyAttestationChains := false
 	seenChainIDs := make(map[string]bool)
 	seenClientsToUpdate := make(map[string]bool)
@@ -66,6 +246,19 @@ func (c Config) Validate() error {
 		if chain.Attestation {
 			anyAttestationChains = true
 
+			rpcEndpoints := chain.GetRPCEndpoints()
+			seenRPCEndpoints := make(map[string]bool)
+			for _, rpcEndpoint := range rpcEndpoints {
+				if _, ok := seenRPCEndpoints[rpcEndpoint]; ok {
+					return errors.New("duplicate rpc endpoint")
+				}
+				seenRPCEndpoints[rpcEndpoint] = true
+			}
+
+			if chain.Quorum < 0 || chain.Quorum > len(rpcEndpoints) {
+				return errors.Errorf("quorum must be between 1 and the number of rpc endpoints (%d)", len(rpcEndpoints))
+			}
+
 			if chain.ClientID == "" {
 				return errors.New("client id cannot be empty when attestation is true")
 			}
@@ -74,6 +267,62 @@ func (c Config) Validate() error {
 				return errors.New("client to update cannot be empty when attestation is true")
 			}
 
+			if err := chain.FinalityConfig.Validate(); err != nil {
+				return err
+			}
+
+			if _, err := chain.GetSchedule(); err != nil {
+				return err
+			}
+
+			if _, ok := seenClientsToUpdate[chain.ClientToUpdate]; ok {
+				return errors.New("duplicate client to update")
+			}
+			seenClientsToUpdate[chain.ClientToUpdate] = true
+
+			if _, err := chain.GetTrustOptions(); err != nil {
+				return err
+			}
+
+			if _, err := chain.GetTopologyRefreshInterval(); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, chain := range c.EVMChains {
+		if chain.ChainID == "" {
+			return errors.New("chain id cannot be empty")
+		}
+
+		if _, ok := seenChainIDs[chain.ChainID]; ok {
+			return errors.New("duplicate chain id")
+		}
+		seenChainIDs[chain.ChainID] = true
+
+		if chain.RPC == "" {
+			return errors.New("rpc address cannot be empty")
+		}
+
+		if chain.Attestation {
+			anyAttestationChains = true
+
+			if !common.IsHexAddress(chain.IBCContractAddress) {
+				return errors.New("ibc contract address must be a hex address when attestation is true")
+			}
+
+			if chain.ClientToUpdate == "" {
+				return errors.New("client to update cannot be empty when attestation is true")
+			}
+
+			if err := chain.FinalityConfig.Validate(); err != nil {
+				return err
+			}
+
+			if _, err := chain.GetSchedule(); err != nil {
+				return err
+			}
+
 			if _, ok := seenClientsToUpdate[chain.ClientToUpdate]; ok {
 				return errors.New("duplicate client to update")
 			}
@@ -85,13 +334,240 @@ func (c Config) Validate() error {
 		if c.AttestatorID == "" {
 			return errors.New("attestator id cannot be empty if any chains have attestation true")
 		}
+
+		if c.HostChainID == "" {
+			return errors.New("host chain id cannot be empty if any chains have attestation true")
+		}
+
+		if err := c.Signer.Validate(); err != nil {
+			return err
+		}
+
+		if err := c.HA.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the finality policy and the settings it depends on
+func (f FinalityConfig) Validate() error {
+	switch f.FinalityPolicy {
+	case "", FinalityPolicyConfirmations, FinalityPolicyFinalized:
+	case FinalityPolicyOracle:
+		oracleURL, err := url.Parse(f.FinalityOracle)
+		if err != nil || (oracleURL.Scheme != "http" && oracleURL.Scheme != "https") || oracleURL.Host == "" {
+			return errors.New("finality oracle must be an http(s) url when the finality policy is oracle")
+		}
+	default:
+		return errors.Errorf("unknown finality policy %q", f.FinalityPolicy)
+	}
+
+	return nil
+}
+
+// Validate checks the signer kind and the settings it depends on
+func (s SignerConfig) Validate() error {
+	switch s.Kind {
+	case "", SignerKindFile:
+	case SignerKindKeyring:
+		if s.KeyName == "" {
+			return errors.New("key name cannot be empty when the signer kind is keyring")
+		}
+	case SignerKindRemote:
+		if s.RemoteAddress == "" {
+			return errors.New("remote address cannot be empty when the signer kind is remote")
+		}
+		if _, err := s.GetRemoteTimeout(); err != nil {
+			return err
+		}
+		if !s.RemoteTLS && (s.RemoteTLSCAFile != "" || s.RemoteTLSCertFile != "" || s.RemoteTLSKeyFile != "" || s.RemoteTLSServerName != "") {
+			return errors.New("remote tls settings are set, but remote tls is not enabled")
+		}
+		if (s.RemoteTLSCertFile == "") != (s.RemoteTLSKeyFile == "") {
+			return errors.New("both remote tls cert file and remote tls key file must be set for mTLS")
+		}
+		if s.RemoteAuthToken != "" && s.RemoteAuthTokenFile != "" {
+			return errors.New("only one of remote auth token and remote auth token file can be set")
+		}
+	default:
+		return errors.Errorf("unknown signer kind %q", s.Kind)
 	}
 
 	return nil
 }
 
+// Validate checks that the TLS and auth token settings are complete
+func (s ServerConfig) Validate() error {
+	if _, err := s.GetSocketMode(); err != nil {
+		return err
+	}
+
+	if (s.TLSCertFile == "") != (s.TLSKeyFile == "") {
+		return errors.New("both tls cert file and tls key file must be set to enable tls")
+	}
+
+	if s.TLSClientCAFile != "" && s.TLSCertFile == "" {
+		return errors.New("tls client ca file requires tls to be enabled")
+	}
+
+	if s.AuthToken != "" && s.AuthTokenFile != "" {
+		return errors.New("only one of auth token and auth token file can be set")
+	}
+
+	return nil
+}
+
+// GetListenAddress returns the listen address, or the default if it is not set
+func (s ServerConfig) GetListenAddress() string {
+	if s.ListenAddress == "" {
+		return DefaultListenAddress
+	}
+
+	return s.ListenAddress
+}
+
+// GetSocketMode parses the octal socket mode, or returns the default if it is not set
+func (s ServerConfig) GetSocketMode() (os.FileMode, error) {
+	if s.SocketMode == "" {
+		return DefaultSocketMode, nil
+	}
+
+	mode, err := strconv.ParseUint(s.SocketMode, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, errors.Errorf("invalid socket mode %q, must be octal file permissions like 0660", s.SocketMode)
+	}
+
+	return os.FileMode(mode), nil
+}
+
+// Validate checks the high availability backend and the settings it depends on
+func (h HAConfig) Validate() error {
+	switch h.Backend {
+	case "", HABackendFile:
+	case HABackendRaft:
+		if h.NodeID == "" {
+			return errors.New("node id cannot be empty when the ha backend is raft")
+		}
+		if h.RaftAddress == "" {
+			return errors.New("raft address cannot be empty when the ha backend is raft")
+		}
+		peers, err := h.GetRaftPeers()
+		if err != nil {
+			return err
+		}
+		if address, ok := peers[h.NodeID]; !ok || address != h.RaftAddress {
+			return errors.Errorf("raft peers must include this node as %s=%s", h.NodeID, h.RaftAddress)
+		}
+	default:
+		return errors.Errorf("unknown ha backend %q", h.Backend)
+	}
+
+	return nil
+}
+
+// GetRaftPeers parses the raft peers into a map from node id to address
+func (h HAConfig) GetRaftPeers() (map[string]string, error) {
+	peers := make(map[string]string, len(h.RaftPeers))
+	for _, peer := range h.RaftPeers {
+		nodeID, address, ok := strings.Cut(peer, "=")
+		if !ok || nodeID == "" || address == "" {
+			return nil, errors.Errorf("invalid raft peer %q, must be node_id=host:port", peer)
+		}
+		if _, ok := peers[nodeID]; ok {
+			return nil, errors.Errorf("duplicate raft peer %s", nodeID)
+		}
+		peers[nodeID] = address
+	}
+
+	return peers, nil
+}
+
+// GetRemoteTimeout parses the remote timeout, or returns the default if it is not set
+func (s SignerConfig) GetRemoteTimeout() (time.Duration, error) {
+	if s.RemoteTimeout == "" {
+		return DefaultRemoteSignerTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(s.RemoteTimeout)
+	if err != nil {
+		return 0, errors.Errorf("invalid remote timeout %q: %w", s.RemoteTimeout, err)
+	}
+	if timeout <= 0 {
+		return 0, errors.Errorf("remote timeout must be positive, got %s", timeout)
+	}
+
+	return timeout, nil
+}
+
+// GetSchedule parses the collection config, with the defaults for anything that is not set
+func (c CollectionConfig) GetSchedule() (CollectionSchedule, error) {
+	schedule := CollectionSchedule{
+		Interval: DefaultCollectionInterval,
+		Timeout:  DefaultCollectionTimeout,
+	}
+
+	for _, field := range []struct {
+		name     string
+		value    string
+		duration *time.Duration
+	}{
+		{"collection interval", c.CollectionInterval, &schedule.Interval},
+		{"collection timeout", c.CollectionTimeout, &schedule.Timeout},
+		{"collection jitter", c.CollectionJitter, &schedule.Jitter},
+	} {
+		if field.value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(field.value)
+		if err != nil {
+			return CollectionSchedule{}, errors.Errorf("invalid %s %q: %w", field.name, field.value, err)
+		}
+		*field.duration = duration
+	}
+
+	if schedule.Interval <= 0 || schedule.Timeout <= 0 || schedule.Jitter < 0 {
+		return CollectionSchedule{}, errors.New("collection interval and timeout must be positive, and collection jitter can't be negative")
+	}
+
+	return schedule, nil
+}
+
+// GetPolicy parses the retention config, with the defaults for anything that is not set
+func (c RetentionConfig) GetPolicy() (RetentionPolicy, error) {
+	policy := RetentionPolicy{
+		Heights:    c.RetainHeights,
+		GCInterval: DefaultGCInterval,
+	}
+
+	if c.RetainAge != "" {
+		age, err := time.ParseDuration(c.RetainAge)
+		if err != nil {
+			return RetentionPolicy{}, errors.Errorf("invalid retain age %q: %w", c.RetainAge, err)
+		}
+		if age <= 0 {
+			return RetentionPolicy{}, errors.Errorf("retain age must be positive, got %s", age)
+		}
+		policy.Age = age
+	}
+
+	if c.GCInterval != "" {
+		gcInterval, err := time.ParseDuration(c.GCInterval)
+		if err != nil {
+			return RetentionPolicy{}, errors.Errorf("invalid gc interval %q: %w", c.GCInterval, err)
+		}
+		if gcInterval <= 0 {
+			return RetentionPolicy{}, errors.Errorf("gc interval must be positive, got %s", gcInterval)
+		}
+		policy.GCInterval = gcInterval
+	}
+
+	return policy, nil
+}
+
 func ReadConfig(homedir string) (Config, bool, error) {
-	configFilePath := getConfigFilePath(homedir)
+	configFilePath := GetConfigFilePath(homedir)
 
 	// Check if config file exists
 	_, err := os.Stat(configFilePath)
@@ -117,7 +593,7 @@ func ReadConfig(homedir string) (Config, bool, error) {
 }
 
 func InitConfig(homedir string, force bool) (string, error) {
-	configFilePath := getConfigFilePath(homedir)
+	configFilePath := GetConfigFilePath(homedir)
 
 	if !force {
 		_, err := os.Stat(configFilePath)
@@ -128,6 +604,7 @@ func InitConfig(homedir string, force bool) (string, error) {
 
 	config := Config{
 		AttestatorID: "your-attestator-id",
+		HostChainID:  "your-chain-id",
 		CosmosChains: []CosmosChainConfig{
 			{
 				ChainID:        "chain-to-attest-1",
@@ -135,12 +612,19 @@ func InitConfig(homedir string, force bool) (string, error) {
 				Attestation:    true,
 				ClientID:       "example-1-client",
 				ClientToUpdate: "client-id-to-update",
-				AddressPrefix:  "",
-				KeyringBackend: "",
-				KeyName:        "",
-				Gas:            "",
-				GasPrices:      "",
-				GasAdjustment:  0,
+				FinalityConfig: FinalityConfig{
+					FinalityPolicy: FinalityPolicyFinalized,
+				},
+				ReconcileInterval: DefaultReconcileInterval,
+				TrustedHeight:     1,
+				TrustedHash:       "hex-encoded-hash-of-block-at-trusted-height",
+				TrustingPeriod:    "168h",
+				AddressPrefix:     "",
+				KeyringBackend:    "",
+				KeyName:           "",
+				Gas:               "",
+				GasPrices:         "",
+				GasAdjustment:     0,
 			},
 			{
 				ChainID:        "non-attestation-chain-1",
@@ -156,6 +640,26 @@ func InitConfig(homedir string, force bool) (string, error) {
 				GasAdjustment:  0,
 			},
 		},
+		EVMChains: []EVMChainConfig{
+			{
+				ChainID:            "evm-chain-to-attest-1",
+				RPC:                "http://localhost:8545",
+				Attestation:        true,
+				ClientToUpdate:     "evm-client-id-to-update",
+				IBCContractAddress: "0x0000000000000000000000000000000000000000",
+				StartBlock:         0,
+				FinalityConfig: FinalityConfig{
+					FinalityPolicy: FinalityPolicyConfirmations,
+					Confirmations:  12,
+				},
+			},
+		},
+		Signer: SignerConfig{
+			Kind: SignerKindFile,
+		},
+		Server: ServerConfig{
+			ListenAddress: DefaultListenAddress,
+		},
 	}
 
 	config.configFilePath = configFilePath
@@ -181,6 +685,22 @@ func (c Config) Save() error {
 	return nil
 }
 
+// HasAttestationChains returns true if attestation is enabled for any of the chains
+func (c Config) HasAttestationChains() bool {
+	for _, chain := range c.CosmosChains {
+		if chain.Attestation {
+			return true
+		}
+	}
+	for _, chain := range c.EVMChains {
+		if chain.Attestation {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c Config) GetChain(chainID string) (CosmosChainConfig, bool) {
 	var chain CosmosChainConfig
 	for _, chain := range c.CosmosChains {
@@ -192,7 +712,8 @@ func (c Config) GetChain(chainID string) (CosmosChainConfig, bool) {
 	return chain, false
 }
 
-func getConfigFilePath(homedir string) string {
+// GetConfigFilePath returns the path of the config file in the home directory
+func GetConfigFilePath(homedir string) string {
 	return path.Join(homedir, configFileName)
 }
 
@@ -201,3 +722,66 @@ func (c CosmosChainConfig) GetClientHeight(height uint64) clienttypes.Height {
 
 	return clienttypes.NewHeight(revisionNumber, height)
 }
+
+// GetRPCEndpoints returns all the rpc endpoints of the chain, starting with RPC
+func (c CosmosChainConfig) GetRPCEndpoints() []string {
+	return append([]string{c.RPC}, c.RPCs...)
+}
+
+// GetQuorum returns the number of rpc endpoints that must agree, which is a majority of them unless configured
+func (c CosmosChainConfig) GetQuorum() int {
+	if c.Quorum != 0 {
+		return c.Quorum
+	}
+
+	return len(c.GetRPCEndpoints())/2 + 1
+}
+
+// GetReconcileInterval returns the number of blocks between full reconciliations of the tracked packet commitments
+func (c CosmosChainConfig) GetReconcileInterval() uint64 {
+	if c.ReconcileInterval != 0 {
+		return c.ReconcileInterval
+	}
+
+	return DefaultReconcileInterval
+}
+
+// GetTopologyRefreshInterval returns the time between refreshes of the cached connections and channels of the client
+func (c CosmosChainConfig) GetTopologyRefreshInterval() (time.Duration, error) {
+	if c.TopologyRefreshInterval == "" {
+		return DefaultTopologyRefreshInterval, nil
+	}
+
+	interval, err := time.ParseDuration(c.TopologyRefreshInterval)
+	if err != nil {
+		return 0, errors.Errorf("invalid topology refresh interval %q: %w", c.TopologyRefreshInterval, err)
+	}
+	if interval <= 0 {
+		return 0, errors.Errorf("topology refresh interval must be positive, got %s", interval)
+	}
+
+	return interval, nil
+}
+
+// GetTrustOptions returns the options the light client of the chain is initialized with
+func (c CosmosChainConfig) GetTrustOptions() (light.TrustOptions, error) {
+	trustingPeriod, err := time.ParseDuration(c.TrustingPeriod)
+	if err != nil {
+		return light.TrustOptions{}, errors.Errorf("invalid trusting period %q: %w", c.TrustingPeriod, err)
+	}
+	trustedHash, err := hex.DecodeString(c.TrustedHash)
+	if err != nil {
+		return light.TrustOptions{}, errors.Errorf("trusted hash must be hex encoded: %w", err)
+	}
+
+	trustOptions := light.TrustOptions{
+		Period: trustingPeriod,
+		Height: c.TrustedHeight,
+		Hash:   trustedHash,
+	}
+	if err := trustOptions.ValidateBasic(); err != nil {
+		return light.TrustOptions{}, errors.Errorf("invalid light client trust options: %w", err)
+	}
+
+	return trustOptions, nil
+}
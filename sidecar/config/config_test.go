@@ -6,6 +6,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+const testTrustedHash = "5E0D8B6F9FA1C2B9B9A6DAA4B35CE4FA0DE0D0A8D5C1C7E1E3C3B8B6C5D2A1F0"
+
 func TestConfig_Validate(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -22,6 +24,9 @@ func TestConfig_Validate(t *testing.T) {
 						ClientID:       "client1",
 						Attestation:    true,
 						ClientToUpdate: "client1",
+						TrustedHeight:  1,
+						TrustedHash:    testTrustedHash,
+						TrustingPeriod: "168h",
 						AddressPrefix:  "",
 						KeyringBackend: "",
 						KeyName:        "",
@@ -44,6 +49,7 @@ func TestConfig_Validate(t *testing.T) {
 					},
 				},
 				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
 			},
 			expErr: "",
 		},
@@ -51,6 +57,7 @@ func TestConfig_Validate(t *testing.T) {
 			name: "empty chains",
 			config: Config{
 				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
 			},
 			expErr: "at least one chain must be defined in the config",
 		},
@@ -64,6 +71,7 @@ func TestConfig_Validate(t *testing.T) {
 					},
 				},
 				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
 			},
 			expErr: "chain id cannot be empty",
 		},
@@ -77,9 +85,13 @@ func TestConfig_Validate(t *testing.T) {
 						ClientID:       "",
 						Attestation:    true,
 						ClientToUpdate: "client1",
+						TrustedHeight:  1,
+						TrustedHash:    testTrustedHash,
+						TrustingPeriod: "168h",
 					},
 				},
 				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
 			},
 			expErr: "client id cannot be empty when attestation is true",
 		},
@@ -96,6 +108,7 @@ func TestConfig_Validate(t *testing.T) {
 					},
 				},
 				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
 			},
 			expErr: "client to update cannot be empty when attestation is true",
 		},
@@ -109,6 +122,7 @@ func TestConfig_Validate(t *testing.T) {
 					},
 				},
 				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
 			},
 			expErr: "rpc address cannot be empty",
 		},
@@ -122,6 +136,9 @@ func TestConfig_Validate(t *testing.T) {
 						ClientID:       "client1",
 						Attestation:    true,
 						ClientToUpdate: "client1",
+						TrustedHeight:  1,
+						TrustedHash:    testTrustedHash,
+						TrustingPeriod: "168h",
 					},
 					{
 						ClientID: "client2",
@@ -129,6 +146,7 @@ func TestConfig_Validate(t *testing.T) {
 					},
 				},
 				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
 			},
 			expErr: "duplicate chain id",
 		},
@@ -142,6 +160,9 @@ func TestConfig_Validate(t *testing.T) {
 						ClientID:       "client1",
 						Attestation:    true,
 						ClientToUpdate: "client1",
+						TrustedHeight:  1,
+						TrustedHash:    testTrustedHash,
+						TrustingPeriod: "168h",
 					},
 					{
 						ChainID:        "chain2",
@@ -149,9 +170,13 @@ func TestConfig_Validate(t *testing.T) {
 						ClientID:       "client2",
 						Attestation:    true,
 						ClientToUpdate: "client1",
+						TrustedHeight:  1,
+						TrustedHash:    testTrustedHash,
+						TrustingPeriod: "168h",
 					},
 				},
 				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
 			},
 			expErr: "duplicate client to update",
 		},
@@ -165,6 +190,9 @@ func TestConfig_Validate(t *testing.T) {
 						ClientID:       "client1",
 						Attestation:    true,
 						ClientToUpdate: "client1",
+						TrustedHeight:  1,
+						TrustedHash:    testTrustedHash,
+						TrustingPeriod: "168h",
 					},
 					{
 						ChainID:     "chain2",
@@ -173,9 +201,696 @@ func TestConfig_Validate(t *testing.T) {
 					},
 				},
 				AttestatorID: "",
+				HostChainID:  "test-host-chain-id",
 			},
 			expErr: "attestator id cannot be empty if any chains have attestation true",
 		},
+		{
+			name: "empty host chain id",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:        "chain1",
+						RPC:            "http://localhost:26657",
+						ClientID:       "client1",
+						Attestation:    true,
+						ClientToUpdate: "client1",
+						TrustedHeight:  1,
+						TrustedHash:    testTrustedHash,
+						TrustingPeriod: "168h",
+					},
+				},
+				AttestatorID: "test-attestator-id",
+			},
+			expErr: "host chain id cannot be empty if any chains have attestation true",
+		},
+		{
+			name: "valid evm chain",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+						FinalityConfig: FinalityConfig{
+							FinalityPolicy: FinalityPolicyConfirmations,
+							Confirmations:  12,
+						},
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "",
+		},
+		{
+			name: "invalid evm ibc contract address",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "not-an-address",
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "ibc contract address must be a hex address when attestation is true",
+		},
+		{
+			name: "duplicate chain id across cosmos and evm chains",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID: "chain1",
+						RPC:     "http://localhost:26657",
+					},
+				},
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID: "chain1",
+						RPC:     "http://localhost:8545",
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "duplicate chain id",
+		},
+		{
+			name: "duplicate client to update across cosmos and evm chains",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:        "chain1",
+						RPC:            "http://localhost:26657",
+						ClientID:       "client1",
+						Attestation:    true,
+						ClientToUpdate: "client1",
+						TrustedHeight:  1,
+						TrustedHash:    testTrustedHash,
+						TrustingPeriod: "168h",
+					},
+				},
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "duplicate client to update",
+		},
+		{
+			name: "valid rpc endpoints with quorum",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:        "chain1",
+						RPC:            "http://localhost:26657",
+						RPCs:           []string{"http://localhost:26658", "http://localhost:26659"},
+						Quorum:         3,
+						ClientID:       "client1",
+						Attestation:    true,
+						ClientToUpdate: "client1",
+						TrustedHeight:  1,
+						TrustedHash:    testTrustedHash,
+						TrustingPeriod: "168h",
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "",
+		},
+		{
+			name: "duplicate rpc endpoint",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:        "chain1",
+						RPC:            "http://localhost:26657",
+						RPCs:           []string{"http://localhost:26657"},
+						ClientID:       "client1",
+						Attestation:    true,
+						ClientToUpdate: "client1",
+						TrustedHeight:  1,
+						TrustedHash:    testTrustedHash,
+						TrustingPeriod: "168h",
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "duplicate rpc endpoint",
+		},
+		{
+			name: "quorum larger than the number of rpc endpoints",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:        "chain1",
+						RPC:            "http://localhost:26657",
+						RPCs:           []string{"http://localhost:26658"},
+						Quorum:         3,
+						ClientID:       "client1",
+						Attestation:    true,
+						ClientToUpdate: "client1",
+						TrustedHeight:  1,
+						TrustedHash:    testTrustedHash,
+						TrustingPeriod: "168h",
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "quorum must be between 1 and the number of rpc endpoints (2)",
+		},
+		{
+			name: "missing trusted hash",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:        "chain1",
+						RPC:            "http://localhost:26657",
+						ClientID:       "client1",
+						Attestation:    true,
+						ClientToUpdate: "client1",
+						TrustedHeight:  1,
+						TrustingPeriod: "168h",
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "invalid light client trust options: expected hash size to be 32 bytes, got 0 bytes",
+		},
+		{
+			name: "invalid trusting period",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:        "chain1",
+						RPC:            "http://localhost:26657",
+						ClientID:       "client1",
+						Attestation:    true,
+						ClientToUpdate: "client1",
+						TrustedHeight:  1,
+						TrustedHash:    testTrustedHash,
+						TrustingPeriod: "one week",
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: `invalid trusting period "one week": time: invalid duration "one week"`,
+		},
+		{
+			name: "invalid topology refresh interval",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:                 "chain1",
+						RPC:                     "http://localhost:26657",
+						ClientID:                "client1",
+						Attestation:             true,
+						ClientToUpdate:          "client1",
+						TopologyRefreshInterval: "-10m",
+						TrustedHeight:           1,
+						TrustedHash:             testTrustedHash,
+						TrustingPeriod:          "168h",
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "topology refresh interval must be positive, got -10m0s",
+		},
+		{
+			name: "valid finality oracle",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:        "chain1",
+						RPC:            "http://localhost:26657",
+						ClientID:       "client1",
+						Attestation:    true,
+						ClientToUpdate: "client1",
+						TrustedHeight:  1,
+						TrustedHash:    testTrustedHash,
+						TrustingPeriod: "168h",
+						FinalityConfig: FinalityConfig{
+							FinalityPolicy: FinalityPolicyOracle,
+							FinalityOracle: "https://oracle.example.com/finality",
+						},
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "",
+		},
+		{
+			name: "unknown finality policy",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+						FinalityConfig: FinalityConfig{
+							FinalityPolicy: "safe",
+						},
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: `unknown finality policy "safe"`,
+		},
+		{
+			name: "valid collection schedule",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:        "chain1",
+						RPC:            "http://localhost:26657",
+						ClientID:       "client1",
+						Attestation:    true,
+						ClientToUpdate: "client1",
+						CollectionConfig: CollectionConfig{
+							CollectionInterval: "5s",
+							CollectionTimeout:  "20s",
+							CollectionJitter:   "500ms",
+						},
+						TrustedHeight:  1,
+						TrustedHash:    testTrustedHash,
+						TrustingPeriod: "168h",
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+		},
+		{
+			name: "invalid collection interval",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+						CollectionConfig: CollectionConfig{
+							CollectionInterval: "fast",
+						},
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: `invalid collection interval "fast": time: invalid duration "fast"`,
+		},
+		{
+			name: "negative collection jitter",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+						CollectionConfig: CollectionConfig{
+							CollectionJitter: "-1s",
+						},
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "collection interval and timeout must be positive, and collection jitter can't be negative",
+		},
+		{
+			name: "invalid retain age",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+					},
+				},
+				Retention: RetentionConfig{
+					RetainHeights: 1000,
+					RetainAge:     "a week",
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: `invalid retain age "a week": time: invalid duration "a week"`,
+		},
+		{
+			name: "valid keyring signer",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+					},
+				},
+				Signer: SignerConfig{
+					Kind:           SignerKindKeyring,
+					KeyringBackend: "test",
+					KeyName:        "attestator",
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+		},
+		{
+			name: "missing remote signer address",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+					},
+				},
+				Signer: SignerConfig{
+					Kind: SignerKindRemote,
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "remote address cannot be empty when the signer kind is remote",
+		},
+		{
+			name: "remote signer tls files without tls",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+					},
+				},
+				Signer: SignerConfig{
+					Kind:            SignerKindRemote,
+					RemoteAddress:   "signer:7070",
+					RemoteTLSCAFile: "ca.pem",
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "remote tls settings are set, but remote tls is not enabled",
+		},
+		{
+			name: "remote signer tls cert without key",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+					},
+				},
+				Signer: SignerConfig{
+					Kind:              SignerKindRemote,
+					RemoteAddress:     "signer:7070",
+					RemoteTLS:         true,
+					RemoteTLSCertFile: "sidecar.pem",
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "both remote tls cert file and remote tls key file must be set for mTLS",
+		},
+		{
+			name: "remote signer auth token and auth token file",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+					},
+				},
+				Signer: SignerConfig{
+					Kind:                SignerKindRemote,
+					RemoteAddress:       "signer:7070",
+					RemoteAuthToken:     "secret",
+					RemoteAuthTokenFile: "auth_token",
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "only one of remote auth token and remote auth token file can be set",
+		},
+		{
+			name: "unknown signer kind",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+					},
+				},
+				Signer: SignerConfig{
+					Kind: "hsm",
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: `unknown signer kind "hsm"`,
+		},
+		{
+			name: "valid raft ha",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+					},
+				},
+				HA: HAConfig{
+					Backend:     HABackendRaft,
+					NodeID:      "sidecar1",
+					RaftAddress: "localhost:7001",
+					RaftPeers:   []string{"sidecar1=localhost:7001", "sidecar2=localhost:7002"},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+		},
+		{
+			name: "raft peers without this node",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+					},
+				},
+				HA: HAConfig{
+					Backend:     HABackendRaft,
+					NodeID:      "sidecar1",
+					RaftAddress: "localhost:7001",
+					RaftPeers:   []string{"sidecar2=localhost:7002"},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "raft peers must include this node as sidecar1=localhost:7001",
+		},
+		{
+			name: "invalid raft peer",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+					},
+				},
+				HA: HAConfig{
+					Backend:     HABackendRaft,
+					NodeID:      "sidecar1",
+					RaftAddress: "localhost:7001",
+					RaftPeers:   []string{"sidecar1=localhost:7001", "localhost:7002"},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: `invalid raft peer "localhost:7002", must be node_id=host:port`,
+		},
+		{
+			name: "unknown ha backend",
+			config: Config{
+				EVMChains: []EVMChainConfig{
+					{
+						ChainID:            "evm1",
+						RPC:                "http://localhost:8545",
+						Attestation:        true,
+						ClientToUpdate:     "client1",
+						IBCContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+					},
+				},
+				HA: HAConfig{
+					Backend: "etcd",
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: `unknown ha backend "etcd"`,
+		},
+		{
+			name: "valid server with mtls and auth token",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:  "chain1",
+						RPC:      "http://localhost:26657",
+						ClientID: "client1",
+					},
+				},
+				Server: ServerConfig{
+					ListenAddress:   "unix:///var/run/sidecar.sock",
+					SocketMode:      "0660",
+					TLSCertFile:     "server.pem",
+					TLSKeyFile:      "server-key.pem",
+					TLSClientCAFile: "ca.pem",
+					AuthTokenFile:   "auth_token",
+				},
+			},
+		},
+		{
+			name: "tls cert without key",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:  "chain1",
+						RPC:      "http://localhost:26657",
+						ClientID: "client1",
+					},
+				},
+				Server: ServerConfig{
+					TLSCertFile: "server.pem",
+				},
+			},
+			expErr: "both tls cert file and tls key file must be set to enable tls",
+		},
+		{
+			name: "client ca without tls",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:  "chain1",
+						RPC:      "http://localhost:26657",
+						ClientID: "client1",
+					},
+				},
+				Server: ServerConfig{
+					TLSClientCAFile: "ca.pem",
+				},
+			},
+			expErr: "tls client ca file requires tls to be enabled",
+		},
+		{
+			name: "auth token and auth token file",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:  "chain1",
+						RPC:      "http://localhost:26657",
+						ClientID: "client1",
+					},
+				},
+				Server: ServerConfig{
+					AuthToken:     "secret",
+					AuthTokenFile: "token",
+				},
+			},
+			expErr: "only one of auth token and auth token file can be set",
+		},
+		{
+			name: "invalid socket mode",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:  "chain1",
+						RPC:      "http://localhost:26657",
+						ClientID: "client1",
+					},
+				},
+				Server: ServerConfig{
+					ListenAddress: "unix:///var/run/sidecar.sock",
+					SocketMode:    "rw-rw----",
+				},
+			},
+			expErr: `invalid socket mode "rw-rw----", must be octal file permissions like 0660`,
+		},
+		{
+			name: "missing finality oracle",
+			config: Config{
+				CosmosChains: []CosmosChainConfig{
+					{
+						ChainID:        "chain1",
+						RPC:            "http://localhost:26657",
+						ClientID:       "client1",
+						Attestation:    true,
+						ClientToUpdate: "client1",
+						TrustedHeight:  1,
+						TrustedHash:    testTrustedHash,
+						TrustingPeriod: "168h",
+						FinalityConfig: FinalityConfig{
+							FinalityPolicy: FinalityPolicyOracle,
+						},
+					},
+				},
+				AttestatorID: "test-attestator-id",
+				HostChainID:  "test-host-chain-id",
+			},
+			expErr: "finality oracle must be an http(s) url when the finality policy is oracle",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,85 @@
+package ha
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofrs/flock"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/sidecar/signer"
+)
+
+// fileLockRetryInterval is the time between attempts of a standby sidecar to acquire the file lock
+var fileLockRetryInterval = time.Second
+
+var _ Lock = &FileLock{}
+
+// FileLock is held by the sidecar that holds an exclusive lock on a shared file. The operating system releases the lock
+// when the sidecar holding it exits, so a standby sidecar takes over within a retry interval. The sidecars share the
+// sign state file next to the lock file, so they must run on the same machine or on a filesystem with working locks.
+type FileLock struct {
+	logger     *zap.Logger
+	flock      *flock.Flock
+	stateStore signer.FileStateStore
+
+	held atomic.Bool
+}
+
+func NewFileLock(logger *zap.Logger, lockPath, statePath string) *FileLock {
+	return &FileLock{
+		logger:     logger,
+		flock:      flock.New(lockPath),
+		stateStore: signer.NewFileStateStore(statePath),
+	}
+}
+
+func (l *FileLock) Run(ctx context.Context) error {
+	ticker := time.NewTicker(fileLockRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		if !l.held.Load() {
+			locked, err := l.flock.TryLock()
+			if err != nil {
+				l.logger.Error("Failed to acquire file lock", zap.String("path", l.flock.Path()), zap.Error(err))
+			} else if locked {
+				l.logger.Info("Acquired file lock, signing attestations", zap.String("path", l.flock.Path()))
+				l.held.Store(true)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *FileLock) Held() bool {
+	return l.held.Load()
+}
+
+func (l *FileLock) Load() (signer.SignState, error) {
+	if !l.held.Load() {
+		return signer.SignState{}, signer.ErrStandby
+	}
+
+	return l.stateStore.Load()
+}
+
+func (l *FileLock) Save(state signer.SignState) error {
+	if !l.held.Load() {
+		return signer.ErrStandby
+	}
+
+	return l.stateStore.Save(state)
+}
+
+// Close releases the lock, so a standby sidecar can take over
+func (l *FileLock) Close() error {
+	l.held.Store(false)
+	return l.flock.Unlock()
+}
@@ -0,0 +1,50 @@
+package ha
+
+import (
+	"context"
+	"path/filepath"
+
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+	"github.com/cosmos/interchain-attestation/sidecar/signer"
+)
+
+// Lock decides which sidecar of a high availability group signs. The sidecar that holds the lock is the active one, the
+// others are on standby. The sign state is shared through the lock, so the active sidecar never signs an attestation
+// that conflicts with one signed by a sidecar that was active before it. Loading or saving the sign state without
+// holding the lock fails with signer.ErrStandby.
+type Lock interface {
+	signer.StateStore
+
+	// Run tries to acquire the lock, and keeps it for as long as possible, until the context is done
+	Run(ctx context.Context) error
+	// Held returns whether this sidecar holds the lock and may sign
+	Held() bool
+	Close() error
+}
+
+// New sets up the lock of the configured high availability backend, or returns nil if the sidecar runs on its own
+func New(logger *zap.Logger, homedir string, sidecarConfig config.Config) (Lock, error) {
+	statePath := signer.SignStatePath(homedir, sidecarConfig.Signer)
+
+	switch sidecarConfig.HA.Backend {
+	case config.HABackendFile:
+		lockPath := sidecarConfig.HA.LockFile
+		switch {
+		case lockPath == "":
+			lockPath = statePath + ".lock"
+		case !filepath.IsAbs(lockPath):
+			lockPath = filepath.Join(homedir, lockPath)
+		}
+		return NewFileLock(logger, lockPath, statePath), nil
+	case config.HABackendRaft:
+		raftLock, err := NewRaftLock(logger, filepath.Join(homedir, "raft"), sidecarConfig.HA)
+		if err != nil {
+			return nil, err
+		}
+		return raftLock, nil
+	default:
+		return nil, nil
+	}
+}
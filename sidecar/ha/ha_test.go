@@ -0,0 +1,153 @@
+package ha
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/sidecar/attestationkey"
+	"github.com/cosmos/interchain-attestation/sidecar/attestators/cosmos"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+	"github.com/cosmos/interchain-attestation/sidecar/signer"
+)
+
+func newTestSigner(t *testing.T) signer.Signer {
+	privKey, err := attestationkey.Generate(cosmos.NewCodecConfig().Marshaler, t.TempDir(), attestationkey.KeyTypeSecp256k1, false)
+	require.NoError(t, err)
+
+	return signer.NewKeySigner(privKey)
+}
+
+func runLock(t *testing.T, lock Lock) context.CancelFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.NoError(t, lock.Run(ctx))
+	}()
+
+	stop := func() {
+		cancel()
+		<-done
+	}
+	t.Cleanup(stop)
+
+	return stop
+}
+
+// requireHandover signs with the active sidecar, then stops it and checks that the next one holds the lock and
+// continues from its sign state
+func requireHandover(t *testing.T, active Lock, stopActive func(), standby []Lock, s signer.Signer) {
+	activeGuard := signer.NewGuard(s, active)
+	signature, err := activeGuard.SignAttestation(context.Background(), "chain-1", 10, []byte("attestation at 10"))
+	require.NoError(t, err)
+
+	for _, lock := range standby {
+		require.False(t, lock.Held())
+		_, err := signer.NewGuard(s, lock).SignAttestation(context.Background(), "chain-1", 11, []byte("attestation at 11"))
+		require.ErrorIs(t, err, signer.ErrStandby)
+	}
+
+	stopActive()
+	require.NoError(t, active.Close())
+	_, err = activeGuard.SignAttestation(context.Background(), "chain-1", 11, []byte("attestation at 11"))
+	require.ErrorIs(t, err, signer.ErrStandby)
+
+	var next Lock
+	require.Eventually(t, func() bool {
+		for _, lock := range standby {
+			if lock.Held() {
+				next = lock
+				return true
+			}
+		}
+		return false
+	}, 10*time.Second, 50*time.Millisecond)
+
+	nextGuard := signer.NewGuard(s, next)
+	_, err = nextGuard.SignAttestation(context.Background(), "chain-1", 10, []byte("forked attestation at 10"))
+	require.ErrorIs(t, err, signer.ErrConflictingAttestation)
+	resigned, err := nextGuard.SignAttestation(context.Background(), "chain-1", 10, []byte("attestation at 10"))
+	require.NoError(t, err)
+	require.Equal(t, signature, resigned)
+	_, err = nextGuard.SignAttestation(context.Background(), "chain-1", 11, []byte("attestation at 11"))
+	require.NoError(t, err)
+}
+
+func TestFileLock(t *testing.T) {
+	fileLockRetryInterval = 50 * time.Millisecond
+	homedir := t.TempDir()
+	sidecarConfig := config.Config{HA: config.HAConfig{Backend: config.HABackendFile}}
+
+	first, err := New(zap.NewNop(), homedir, sidecarConfig)
+	require.NoError(t, err)
+	stopFirst := runLock(t, first)
+	require.Eventually(t, first.Held, time.Second, 10*time.Millisecond)
+
+	second, err := New(zap.NewNop(), homedir, sidecarConfig)
+	require.NoError(t, err)
+	runLock(t, second)
+	time.Sleep(2 * fileLockRetryInterval)
+
+	requireHandover(t, first, stopFirst, []Lock{second}, newTestSigner(t))
+}
+
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
+
+func TestRaftLock(t *testing.T) {
+	haConfigs := make([]config.HAConfig, 3)
+	var peers []string
+	for i := range haConfigs {
+		haConfigs[i] = config.HAConfig{
+			Backend:     config.HABackendRaft,
+			NodeID:      fmt.Sprintf("sidecar%d", i),
+			RaftAddress: freeAddress(t),
+		}
+		peers = append(peers, fmt.Sprintf("%s=%s", haConfigs[i].NodeID, haConfigs[i].RaftAddress))
+	}
+
+	locks := make([]Lock, len(haConfigs))
+	stops := make([]func(), len(haConfigs))
+	for i, haConfig := range haConfigs {
+		haConfig.RaftPeers = peers
+		require.NoError(t, haConfig.Validate())
+
+		lock, err := NewRaftLock(zap.NewNop(), filepath.Join(t.TempDir(), "raft"), haConfig)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = lock.Close() })
+		locks[i] = lock
+		stops[i] = runLock(t, lock)
+	}
+
+	var leader int
+	require.Eventually(t, func() bool {
+		for i, lock := range locks {
+			if lock.Held() {
+				leader = i
+				return true
+			}
+		}
+		return false
+	}, 10*time.Second, 50*time.Millisecond)
+
+	var standby []Lock
+	for i, lock := range locks {
+		if i != leader {
+			standby = append(standby, lock)
+		}
+	}
+
+	requireHandover(t, locks[leader], stops[leader], standby, newTestSigner(t))
+}
@@ -0,0 +1,284 @@
+package ha
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/raft"
+	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
+	"gitlab.com/tozd/go/errors"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+	"github.com/cosmos/interchain-attestation/sidecar/signer"
+)
+
+const (
+	raftApplyTimeout   = 5 * time.Second
+	raftBarrierTimeout = 5 * time.Second
+	raftSnapshotRetain = 2
+	raftMaxPool        = 3
+)
+
+var _ Lock = &RaftLock{}
+
+// RaftLock is held by the leader of an embedded raft cluster of the sidecars. The sign state is replicated through the
+// raft log, so a new leader continues from the last attestation signed by the previous one. A majority of the cluster
+// must be up for any sidecar to sign, which also means a partitioned leader stops signing once it loses its lease.
+type RaftLock struct {
+	logger    *zap.Logger
+	raft      *raft.Raft
+	fsm       *signStateFSM
+	boltStore *raftboltdb.BoltStore
+	transport *raft.NetworkTransport
+
+	held atomic.Bool
+}
+
+// NewRaftLock starts the raft node of this sidecar, keeping its log and snapshots in the directory. Every sidecar is
+// bootstrapped with the same configured peers, so the cluster forms once a majority of them is up.
+func NewRaftLock(logger *zap.Logger, dir string, haConfig config.HAConfig) (*RaftLock, error) {
+	peers, err := haConfig.GetRaftPeers()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	raftLogger := hclog.New(&hclog.LoggerOptions{
+		Name:   "raft",
+		Level:  hclog.Info,
+		Output: zap.NewStdLog(logger.Named("raft")).Writer(),
+	})
+
+	raftConfig := raft.DefaultConfig()
+	raftConfig.LocalID = raft.ServerID(haConfig.NodeID)
+	raftConfig.Logger = raftLogger
+	// attestations are signed every few seconds, so the leader is replaced well within that
+	raftConfig.HeartbeatTimeout = 500 * time.Millisecond
+	raftConfig.ElectionTimeout = 500 * time.Millisecond
+	raftConfig.LeaderLeaseTimeout = 250 * time.Millisecond
+
+	boltStore, err := raftboltdb.New(raftboltdb.Options{Path: filepath.Join(dir, "raft.db")})
+	if err != nil {
+		return nil, errors.Errorf("failed to open raft log: %w", err)
+	}
+
+	snapshotStore, err := raft.NewFileSnapshotStoreWithLogger(dir, raftSnapshotRetain, raftLogger)
+	if err != nil {
+		_ = boltStore.Close()
+		return nil, errors.Errorf("failed to open raft snapshots: %w", err)
+	}
+
+	advertise, err := net.ResolveTCPAddr("tcp", haConfig.RaftAddress)
+	if err != nil {
+		_ = boltStore.Close()
+		return nil, errors.Errorf("invalid raft address %s: %w", haConfig.RaftAddress, err)
+	}
+	transport, err := raft.NewTCPTransportWithLogger(haConfig.RaftAddress, advertise, raftMaxPool, raftApplyTimeout, raftLogger)
+	if err != nil {
+		_ = boltStore.Close()
+		return nil, errors.Errorf("failed to listen on raft address %s: %w", haConfig.RaftAddress, err)
+	}
+
+	fsm := newSignStateFSM()
+	r, err := raft.NewRaft(raftConfig, fsm, boltStore, boltStore, snapshotStore, transport)
+	if err != nil {
+		_ = transport.Close()
+		_ = boltStore.Close()
+		return nil, errors.Errorf("failed to start raft: %w", err)
+	}
+
+	var servers []raft.Server
+	for nodeID, address := range peers {
+		servers = append(servers, raft.Server{
+			ID:      raft.ServerID(nodeID),
+			Address: raft.ServerAddress(address),
+		})
+	}
+	// bootstrapping fails with ErrCantBootstrap once this node has state, which is on every restart
+	if err := r.BootstrapCluster(raft.Configuration{Servers: servers}).Error(); err != nil && !errors.Is(err, raft.ErrCantBootstrap) {
+		_ = r.Shutdown().Error()
+		_ = transport.Close()
+		_ = boltStore.Close()
+		return nil, errors.Errorf("failed to bootstrap raft: %w", err)
+	}
+
+	return &RaftLock{
+		logger:    logger,
+		raft:      r,
+		fsm:       fsm,
+		boltStore: boltStore,
+		transport: transport,
+	}, nil
+}
+
+// Run holds the lock while this sidecar is the raft leader. A new leader only takes the lock once it has applied the
+// whole raft log, so it has the sign state of the previous leader.
+func (l *RaftLock) Run(ctx context.Context) error {
+	leaderCh := l.raft.LeaderCh()
+
+	for {
+		select {
+		case <-ctx.Done():
+			l.held.Store(false)
+			return nil
+		case isLeader := <-leaderCh:
+			if !isLeader {
+				if l.held.Swap(false) {
+					l.logger.Info("Lost raft leadership, on standby")
+				}
+				continue
+			}
+
+			if err := l.raft.Barrier(raftBarrierTimeout).Error(); err != nil {
+				l.logger.Error("Failed to catch up with the raft log after becoming leader", zap.Error(err))
+				continue
+			}
+			if l.raft.State() == raft.Leader {
+				l.logger.Info("Became raft leader, signing attestations")
+				l.held.Store(true)
+			}
+		}
+	}
+}
+
+func (l *RaftLock) Held() bool {
+	return l.held.Load() && l.raft.State() == raft.Leader
+}
+
+func (l *RaftLock) Load() (signer.SignState, error) {
+	if !l.Held() {
+		return signer.SignState{}, signer.ErrStandby
+	}
+
+	return l.fsm.signState(), nil
+}
+
+func (l *RaftLock) Save(state signer.SignState) error {
+	if !l.Held() {
+		return signer.ErrStandby
+	}
+
+	bz, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	future := l.raft.Apply(bz, raftApplyTimeout)
+	if err := future.Error(); err != nil {
+		if errors.Is(err, raft.ErrNotLeader) || errors.Is(err, raft.ErrLeadershipLost) {
+			return errors.Errorf("%w: %w", signer.ErrStandby, err)
+		}
+		return errors.Errorf("failed to replicate sign state: %w", err)
+	}
+	if err, ok := future.Response().(error); ok {
+		return errors.Errorf("failed to apply sign state: %w", err)
+	}
+
+	return nil
+}
+
+// Close leaves the raft cluster running on the other sidecars, which elect a new leader if this one was leading
+func (l *RaftLock) Close() error {
+	l.held.Store(false)
+
+	err := l.raft.Shutdown().Error()
+	if closeErr := l.transport.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := l.boltStore.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+var _ raft.FSM = &signStateFSM{}
+
+// signStateFSM is the sign state replicated through the raft log. Every log entry is a sign state, which is merged
+// into the current one, so that an entry from a deposed leader can never lower the height of a chain.
+type signStateFSM struct {
+	lock  sync.RWMutex
+	state signer.SignState
+}
+
+func newSignStateFSM() *signStateFSM {
+	return &signStateFSM{
+		state: signer.SignState{Chains: make(map[string]signer.LastSigned)},
+	}
+}
+
+// signState returns a copy of the sign state
+func (f *signStateFSM) signState() signer.SignState {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	state := signer.SignState{Chains: make(map[string]signer.LastSigned, len(f.state.Chains))}
+	state.Merge(f.state)
+
+	return state
+}
+
+func (f *signStateFSM) Apply(log *raft.Log) interface{} {
+	var state signer.SignState
+	if err := json.Unmarshal(log.Data, &state); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.state.Merge(state)
+
+	return nil
+}
+
+func (f *signStateFSM) Snapshot() (raft.FSMSnapshot, error) {
+	bz, err := json.Marshal(f.signState())
+	if err != nil {
+		return nil, err
+	}
+
+	return signStateSnapshot(bz), nil
+}
+
+func (f *signStateFSM) Restore(snapshot io.ReadCloser) error {
+	defer snapshot.Close()
+
+	state := signer.SignState{Chains: make(map[string]signer.LastSigned)}
+	if err := json.NewDecoder(snapshot).Decode(&state); err != nil {
+		return err
+	}
+	if state.Chains == nil {
+		state.Chains = make(map[string]signer.LastSigned)
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.state = state
+
+	return nil
+}
+
+type signStateSnapshot []byte
+
+func (s signStateSnapshot) Persist(sink raft.SnapshotSink) error {
+	if _, err := sink.Write(s); err != nil {
+		_ = sink.Cancel()
+		return err
+	}
+
+	return sink.Close()
+}
+
+func (s signStateSnapshot) Release() {}
@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"net/url"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "attestation_sidecar"
+
+// Registry holds all the metrics of the sidecar
+var Registry = prometheus.NewRegistry()
+
+var (
+	// EndpointReadDuration is the time it takes an rpc endpoint of a chain to serve a read (e.g. the attestation data)
+	EndpointReadDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "endpoint_read_duration_seconds",
+		Help:      "Duration of reads from the rpc endpoints of a chain",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"chain_id", "endpoint", "read"})
+	// EndpointErrors counts the reads that failed on an rpc endpoint of a chain
+	EndpointErrors = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "endpoint_errors_total",
+		Help:      "Number of failed reads from the rpc endpoints of a chain",
+	}, []string{"chain_id", "endpoint", "read"})
+	// EndpointDisagreements counts the reads where an rpc endpoint of a chain returned different data than the quorum
+	EndpointDisagreements = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "endpoint_disagreements_total",
+		Help:      "Number of reads where an rpc endpoint disagreed with the quorum of endpoints of a chain",
+	}, []string{"chain_id", "endpoint", "read"})
+	// QuorumFailures counts the reads where not enough rpc endpoints of a chain agreed
+	QuorumFailures = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "quorum_failures_total",
+		Help:      "Number of reads where not enough rpc endpoints of a chain agreed",
+	}, []string{"chain_id", "read"})
+	// PacketCommitmentDrift counts the packet commitments tracked from the events of an rpc endpoint that a full
+	// reconciliation found to be missing, stale or wrong
+	PacketCommitmentDrift = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "packet_commitment_drift_total",
+		Help:      "Number of tracked packet commitments corrected by a full reconciliation",
+	}, []string{"chain_id", "endpoint"})
+
+	// CollectionDuration is the time it takes to collect, sign and store an attestation for a chain
+	CollectionDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "collection_duration_seconds",
+		Help:      "Duration of attestation collections for a chain",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+	}, []string{"chain_id"})
+	// CollectionErrors counts the failed attestation collections for a chain
+	CollectionErrors = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "collection_errors_total",
+		Help:      "Number of failed attestation collections for a chain",
+	}, []string{"chain_id"})
+	// LatestAttestedHeight is the height of the latest signed attestation of a chain
+	LatestAttestedHeight = promauto.With(Registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "latest_attested_height",
+		Help:      "Height of the latest signed attestation of a chain",
+	}, []string{"chain_id"})
+	// LatestAttestationTime is when the latest attestation of a chain was signed, to alert on chains without new attestations
+	LatestAttestationTime = promauto.With(Registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "latest_attestation_timestamp_seconds",
+		Help:      "Unix time the latest attestation of a chain was signed",
+	}, []string{"chain_id"})
+	// PacketCommitments is the number of packet commitments in the latest signed attestation of a chain
+	PacketCommitments = promauto.With(Registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "packet_commitments",
+		Help:      "Number of packet commitments in the latest signed attestation of a chain",
+	}, []string{"chain_id"})
+
+	// GRPCRequests counts the requests to the grpc server of the sidecar by method and status code
+	GRPCRequests = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "Number of grpc requests by method and status code",
+	}, []string{"method", "code"})
+	// GRPCRequestDuration is the time it takes the grpc server of the sidecar to serve a request, or a whole stream
+	GRPCRequestDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "Duration of grpc requests by method",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"method"})
+)
+
+// RegisterDBSize registers the size of the database, as reported by the size function, which is only called when the
+// metrics are gathered
+func RegisterDBSize(size func() (lsm, vlog int64)) error {
+	for _, part := range []struct {
+		name string
+		size func() int64
+	}{
+		{"lsm", func() int64 { lsm, _ := size(); return lsm }},
+		{"vlog", func() int64 { _, vlog := size(); return vlog }},
+	} {
+		if err := Registry.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Name:        "db_size_bytes",
+			Help:        "Size of the database in bytes, by part of the database",
+			ConstLabels: prometheus.Labels{"part": part.name},
+		}, func() float64 { return float64(part.size()) })); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EndpointLabel returns the label for an rpc endpoint, without any credentials, path or query that might be part of the url
+func EndpointLabel(endpoint string) string {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil || endpointURL.Host == "" {
+		return endpoint
+	}
+
+	return endpointURL.Scheme + "://" + endpointURL.Host
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gitlab.com/tozd/go/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+const (
+	unixSocketPrefix = "unix://"
+	// authorizationHeader is the metadata key the auth token is sent in, as "Bearer <token>"
+	authorizationHeader = "authorization"
+	bearerPrefix        = "Bearer "
+)
+
+// listen listens on a host:port, or on a unix socket (unix:///path/to/socket) with the socket mode as its file
+// permissions. A socket left behind by a sidecar that didn't shut down cleanly is replaced.
+func listen(listenAddr string, socketMode os.FileMode) (net.Listener, error) {
+	socketPath, ok := strings.CutPrefix(listenAddr, unixSocketPrefix)
+	if !ok {
+		return net.Listen("tcp", listenAddr)
+	}
+
+	if info, err := os.Stat(socketPath); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(socketPath); err != nil {
+			return nil, errors.Errorf("failed to remove stale socket %s: %w", socketPath, err)
+		}
+	}
+
+	lis, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(socketPath, socketMode); err != nil {
+		_ = lis.Close()
+		return nil, errors.Errorf("failed to set the permissions of socket %s: %w", socketPath, err)
+	}
+
+	return lis, nil
+}
+
+// resolvePath returns the path relative to the home directory, unless it is absolute
+func resolvePath(homedir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(homedir, path)
+}
+
+// transportCredentials returns the TLS credentials of the server, or nil if TLS is not configured. Client certificates
+// are required and verified if a client CA is configured.
+func transportCredentials(homedir string, serverConfig config.ServerConfig) (credentials.TransportCredentials, error) {
+	if serverConfig.TLSCertFile == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(resolvePath(homedir, serverConfig.TLSCertFile), resolvePath(homedir, serverConfig.TLSKeyFile))
+	if err != nil {
+		return nil, errors.Errorf("failed to load tls certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if serverConfig.TLSClientCAFile != "" {
+		caBz, err := os.ReadFile(resolvePath(homedir, serverConfig.TLSClientCAFile))
+		if err != nil {
+			return nil, errors.Errorf("failed to read tls client ca file: %w", err)
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caBz) {
+			return nil, errors.Errorf("failed to parse tls client ca file %s", serverConfig.TLSClientCAFile)
+		}
+		tlsConfig.ClientCAs = certPool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// authToken returns the configured auth token, read from the auth token file if set, or an empty string if clients
+// don't need one
+func authToken(homedir string, serverConfig config.ServerConfig) (string, error) {
+	if serverConfig.AuthTokenFile == "" {
+		return serverConfig.AuthToken, nil
+	}
+
+	bz, err := os.ReadFile(resolvePath(homedir, serverConfig.AuthTokenFile))
+	if err != nil {
+		return "", errors.Errorf("failed to read auth token file: %w", err)
+	}
+	token := strings.TrimSpace(string(bz))
+	if token == "" {
+		return "", errors.Errorf("auth token file %s is empty", serverConfig.AuthTokenFile)
+	}
+
+	return token, nil
+}
+
+// tokenAuthenticator rejects requests without the auth token
+type tokenAuthenticator struct {
+	token string
+}
+
+func (a tokenAuthenticator) authenticate(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(authorizationHeader) {
+		token, ok := strings.CutPrefix(value, bearerPrefix)
+		if ok && subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) == 1 {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, "missing or invalid auth token")
+}
+
+func (a tokenAuthenticator) unaryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := a.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (a tokenAuthenticator) streamInterceptor(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.authenticate(stream.Context()); err != nil {
+		return err
+	}
+
+	return handler(srv, stream)
+}
@@ -0,0 +1,163 @@
+package server_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+	"github.com/cosmos/interchain-attestation/sidecar/server"
+)
+
+// serve serves the server on the listen address until the test is done
+func serve(t *testing.T, s *server.Server, listenAddr string) {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.Serve(listenAddr)
+	}()
+	t.Cleanup(func() {
+		s.Stop(context.Background())
+		require.NoError(t, <-errCh)
+	})
+
+	// wait for the server to listen
+	time.Sleep(100 * time.Millisecond)
+}
+
+func getAttestations(ctx context.Context, t *testing.T, target string, opts ...grpc.DialOption) error {
+	conn, err := grpc.NewClient(target, opts...)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = types.NewSidecarClient(conn).GetAttestations(ctx, &types.GetAttestationsRequest{})
+	return err
+}
+
+func TestServer_UnixSocketAndAuthToken(t *testing.T) {
+	homedir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(homedir, "auth_token"), []byte("secret\n"), 0o600))
+	socketPath := filepath.Join(homedir, "sidecar.sock")
+	// a socket left behind by a sidecar that crashed
+	stale, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	s := server.NewServer(zap.NewNop(), mockCoordinator{}, config.ServerConfig{
+		SocketMode:    "0660",
+		AuthTokenFile: "auth_token",
+	}, homedir)
+	serve(t, s, "unix://"+socketPath)
+
+	info, err := os.Stat(socketPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o660), info.Mode().Perm())
+
+	insecureCreds := grpc.WithTransportCredentials(insecure.NewCredentials())
+	err = getAttestations(context.Background(), t, "unix://"+socketPath, insecureCreds)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer wrong")
+	err = getAttestations(ctx, t, "unix://"+socketPath, insecureCreds)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer secret")
+	require.NoError(t, getAttestations(ctx, t, "unix://"+socketPath, insecureCreds))
+}
+
+// writeCert writes a certificate and its key as pem files, signed by the parent (self-signed if nil)
+func writeCert(t *testing.T, dir, name string, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+"-key.pem"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+
+	return cert, key
+}
+
+func TestServer_MutualTLS(t *testing.T) {
+	homedir := t.TempDir()
+	notAfter := time.Now().Add(time.Hour)
+	ca, caKey := writeCert(t, homedir, "ca", &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil, nil)
+	writeCert(t, homedir, "server", &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "sidecar"},
+		NotAfter:     notAfter,
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	writeCert(t, homedir, "client", &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "node"},
+		NotAfter:     notAfter,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	s := server.NewServer(zap.NewNop(), mockCoordinator{}, config.ServerConfig{
+		TLSCertFile:     "server.pem",
+		TLSKeyFile:      "server-key.pem",
+		TLSClientCAFile: "ca.pem",
+	}, homedir)
+	serve(t, s, addr)
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca)
+
+	// plaintext and TLS without a client certificate are both rejected
+	err = getAttestations(context.Background(), t, addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	err = getAttestations(context.Background(), t, addr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12})))
+	require.Error(t, err)
+
+	clientCert, err := tls.LoadX509KeyPair(filepath.Join(homedir, "client.pem"), filepath.Join(homedir, "client-key.pem"))
+	require.NoError(t, err)
+	require.NoError(t, getAttestations(context.Background(), t, addr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs:      rootCAs,
+		Certificates: []tls.Certificate{clientCert},
+		MinVersion:   tls.VersionTLS12,
+	}))))
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gitlab.com/tozd/go/errors"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/sidecar/attestators"
+	"github.com/cosmos/interchain-attestation/sidecar/metrics"
+)
+
+const httpReadHeaderTimeout = 10 * time.Second
+
+// HTTPServer serves the prometheus metrics of the sidecar on /metrics, and its liveness and readiness on /healthz and
+// /readyz
+type HTTPServer struct {
+	logger      *zap.Logger
+	coordinator attestators.Coordinator
+	httpServer  *http.Server
+}
+
+// Readiness is the body of the readiness endpoint
+type Readiness struct {
+	Ready   bool                `json:"ready"`
+	Standby bool                `json:"standby"`
+	Chains  []types.ChainStatus `json:"chains"`
+}
+
+func NewHTTPServer(logger *zap.Logger, coordinator attestators.Coordinator) *HTTPServer {
+	s := &HTTPServer{
+		logger:      logger,
+		coordinator: coordinator,
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/healthz", s.healthz)
+	mux.HandleFunc("/readyz", s.readyz)
+	s.httpServer = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: httpReadHeaderTimeout,
+	}
+
+	return s
+}
+
+// Handler returns the handler of all the endpoints
+func (s *HTTPServer) Handler() http.Handler {
+	return s.httpServer.Handler
+}
+
+func (s *HTTPServer) Serve(listenAddr string) error {
+	s.logger.Debug("httpServer.Serve", zap.String("listenAddr", listenAddr))
+
+	lis, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return errors.Errorf("failed to listen on %s: %w", listenAddr, err)
+	}
+
+	s.logger.Info("http server listening", zap.String("addr", lis.Addr().String()))
+	if err := s.httpServer.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Stop shuts down the metrics and health endpoints. A scrape or probe in flight gets until the context is done to
+// finish, after which its connection is closed.
+func (s *HTTPServer) Stop(ctx context.Context) {
+	s.logger.Debug("httpServer.Stop")
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		s.logger.Warn("Closing the remaining http connections", zap.Error(err))
+		if err := s.httpServer.Close(); err != nil {
+			s.logger.Error("Failed to close http server", zap.Error(err))
+		}
+	}
+}
+
+// healthz reports that the sidecar is alive, a failing chain is reported by readyz instead
+func (s *HTTPServer) healthz(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// readyz reports that the sidecar is ready once every chain that is not paused has been collected successfully, and as
+// long as no chain is failing
+func (s *HTTPServer) readyz(w http.ResponseWriter, _ *http.Request) {
+	statuses, err := s.coordinator.GetChainStatuses()
+	if err != nil {
+		s.logger.Error("Failed to get chain statuses", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	readiness := Readiness{
+		Ready:   true,
+		Standby: s.coordinator.Standby(),
+		Chains:  statuses,
+	}
+	for _, status := range statuses {
+		// a paused chain is not collected, so it doesn't count
+		if status.Health == string(attestators.HealthStatePaused) {
+			continue
+		}
+		if status.LastSuccess == nil || status.Health == string(attestators.HealthStateFailing) {
+			readiness.Ready = false
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !readiness.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(readiness); err != nil {
+		s.logger.Error("Failed to write readiness", zap.Error(err))
+	}
+}
@@ -0,0 +1,85 @@
+package server_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/sidecar/attestators"
+	"github.com/cosmos/interchain-attestation/sidecar/metrics"
+	"github.com/cosmos/interchain-attestation/sidecar/server"
+)
+
+func get(t *testing.T, url string) (int, string) {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(body)
+}
+
+func TestHTTPServer(t *testing.T) {
+	lastSuccess := time.Now()
+	healthy := types.ChainStatus{ChainId: "chain-1", Health: string(attestators.HealthStateHealthy), LastSuccess: &lastSuccess}
+
+	tests := []struct {
+		name     string
+		statuses []types.ChainStatus
+		expReady bool
+	}{
+		{
+			"every chain collected",
+			[]types.ChainStatus{healthy, {ChainId: "chain-2", Health: string(attestators.HealthStateDegraded), LastSuccess: &lastSuccess}},
+			true,
+		},
+		{
+			"chain not collected yet",
+			[]types.ChainStatus{healthy, {ChainId: "chain-2", Health: string(attestators.HealthStateDegraded)}},
+			false,
+		},
+		{
+			"chain failing",
+			[]types.ChainStatus{healthy, {ChainId: "chain-2", Health: string(attestators.HealthStateFailing), LastSuccess: &lastSuccess}},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := server.NewHTTPServer(zap.NewNop(), mockCoordinator{statuses: tt.statuses})
+			httpServer := httptest.NewServer(s.Handler())
+			defer httpServer.Close()
+
+			code, _ := get(t, httpServer.URL+"/healthz")
+			require.Equal(t, http.StatusOK, code)
+
+			code, body := get(t, httpServer.URL+"/readyz")
+			var readiness server.Readiness
+			require.NoError(t, json.Unmarshal([]byte(body), &readiness))
+			require.Equal(t, tt.expReady, readiness.Ready)
+			require.Len(t, readiness.Chains, len(tt.statuses))
+			if tt.expReady {
+				require.Equal(t, http.StatusOK, code)
+			} else {
+				require.Equal(t, http.StatusServiceUnavailable, code)
+			}
+		})
+	}
+
+	metrics.CollectionErrors.WithLabelValues("http-test-chain").Inc()
+	httpServer := httptest.NewServer(server.NewHTTPServer(zap.NewNop(), mockCoordinator{}).Handler())
+	defer httpServer.Close()
+	code, body := get(t, httpServer.URL+"/metrics")
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, body, `attestation_sidecar_collection_errors_total{chain_id="http-test-chain"}`)
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/interchain-attestation/sidecar/metrics"
+)
+
+// unaryMetricsInterceptor records the count and latency of every unary request
+func unaryMetricsInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	recordRequest(info.FullMethod, start, err)
+
+	return resp, err
+}
+
+// streamMetricsInterceptor records the count and duration of every stream
+func streamMetricsInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, stream)
+	recordRequest(info.FullMethod, start, err)
+
+	return err
+}
+
+func recordRequest(method string, start time.Time, err error) {
+	metrics.GRPCRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+	metrics.GRPCRequestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
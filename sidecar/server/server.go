@@ -2,60 +2,146 @@ package server
 
 import (
 	"context"
-	"net"
+	"sync"
 
 	"gitlab.com/tozd/go/errors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/cosmos/interchain-attestation/core/types"
 	"github.com/cosmos/interchain-attestation/sidecar/attestators"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+	"github.com/cosmos/interchain-attestation/sidecar/store"
+)
+
+const (
+	// defaultListLimit is the number of attestations ListAttestations returns if the request sets no limit
+	defaultListLimit = 100
+	// maxListLimit is the most attestations ListAttestations returns at once
+	maxListLimit = 1000
 )
 
 type Server struct {
 	types.UnimplementedSidecarServer
 
-	logger      *zap.Logger
-	coordinator attestators.Coordinator
-	grpcServer  *grpc.Server
+	logger       *zap.Logger
+	coordinator  attestators.Coordinator
+	serverConfig config.ServerConfig
+	// homedir is where relative paths of the server config are resolved from
+	homedir string
+
+	// lock guards the grpc server, which is nil until Serve, and whether the server was stopped
+	lock       sync.Mutex
+	grpcServer *grpc.Server
+	stopped    bool
 }
 
 var _ types.SidecarServer = &Server{}
 
-func NewServer(logger *zap.Logger, coordinator attestators.Coordinator) *Server {
+func NewServer(logger *zap.Logger, coordinator attestators.Coordinator, serverConfig config.ServerConfig, homedir string) *Server {
 	return &Server{
-		logger:      logger,
-		coordinator: coordinator,
+		logger:       logger,
+		coordinator:  coordinator,
+		serverConfig: serverConfig,
+		homedir:      homedir,
 	}
 }
 
+// Serve serves the grpc server on the listen address, which is a host:port or a unix socket (unix:///path/to/socket),
+// with the TLS and auth token of the server config
 func (s *Server) Serve(listenAddr string) error {
 	s.logger.Debug("server.Serve", zap.String("listenAddr", listenAddr))
 
-	lis, err := net.Listen("tcp", listenAddr)
+	unaryInterceptors := []grpc.UnaryServerInterceptor{unaryMetricsInterceptor}
+	streamInterceptors := []grpc.StreamServerInterceptor{streamMetricsInterceptor}
+	token, err := authToken(s.homedir, s.serverConfig)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		authenticator := tokenAuthenticator{token: token}
+		unaryInterceptors = append(unaryInterceptors, authenticator.unaryInterceptor)
+		streamInterceptors = append(streamInterceptors, authenticator.streamInterceptor)
+	}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	}
+
+	creds, err := transportCredentials(s.homedir, s.serverConfig)
+	if err != nil {
+		return err
+	}
+	if creds != nil {
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	socketMode, err := s.serverConfig.GetSocketMode()
+	if err != nil {
+		return err
+	}
+	lis, err := listen(listenAddr, socketMode)
 	if err != nil {
 		return errors.Errorf("failed to listen on %s: %w", listenAddr, err)
 	}
 
-	s.grpcServer = grpc.NewServer()
-	types.RegisterSidecarServer(s.grpcServer, s)
-	s.logger.Info("server listening", zap.String("addr", lis.Addr().String()))
-	if err := s.grpcServer.Serve(lis); err != nil {
+	s.lock.Lock()
+	if s.stopped {
+		s.lock.Unlock()
+		return lis.Close()
+	}
+	grpcServer := grpc.NewServer(opts...)
+	types.RegisterSidecarServer(grpcServer, s)
+	s.grpcServer = grpcServer
+	s.lock.Unlock()
+
+	s.logger.Info("server listening", zap.String("addr", lis.Addr().String()), zap.Bool("tls", creds != nil), zap.Bool("auth_token", token != ""))
+	if err := grpcServer.Serve(lis); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (s *Server) Stop() {
+// Stop gracefully stops the grpc server, which also keeps Serve from starting it if it hasn't yet. Since attestation
+// subscriptions stream until they are cancelled, whatever is still running when the context is done is cancelled with
+// a hard stop. Serve returns once the server is stopped.
+func (s *Server) Stop(ctx context.Context) {
 	s.logger.Debug("server.Stop")
 
-	s.grpcServer.GracefulStop()
+	s.lock.Lock()
+	s.stopped = true
+	grpcServer := s.grpcServer
+	s.lock.Unlock()
+	if grpcServer == nil {
+		return
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.logger.Warn("Cancelling the remaining grpc requests", zap.Error(ctx.Err()))
+		grpcServer.Stop()
+		<-stopped
+	}
 }
 
 func (s *Server) GetAttestations(_ context.Context, _ *types.GetAttestationsRequest) (*types.GetAttestationsResponse, error) {
 	s.logger.Debug("server.GetLatestAttestation")
 
+	// the node fails over to another sidecar of the high availability group, which is signing
+	if s.coordinator.Standby() {
+		return nil, status.Error(codes.Unavailable, "sidecar is on standby")
+	}
+
 	attestations, err := s.coordinator.GetLatestAttestations()
 	if err != nil {
 		return nil, err
@@ -65,3 +151,92 @@ func (s *Server) GetAttestations(_ context.Context, _ *types.GetAttestationsRequ
 		Attestations: attestations,
 	}, nil
 }
+
+func (s *Server) GetChannelTopology(_ context.Context, req *types.GetChannelTopologyRequest) (*types.GetChannelTopologyResponse, error) {
+	s.logger.Debug("server.GetChannelTopology", zap.String("chain_id", req.ChainId))
+
+	topology, err := s.coordinator.GetChannelTopology(req.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &topology, nil
+}
+
+func (s *Server) GetAttestation(_ context.Context, req *types.GetAttestationRequest) (*types.GetAttestationResponse, error) {
+	s.logger.Debug("server.GetAttestation", zap.String("chain_id", req.ChainId), zap.Uint64("height", req.Height))
+
+	attestation, err := s.coordinator.GetAttestationForHeight(req.ChainId, req.Height)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &types.GetAttestationResponse{
+		Attestation: attestation,
+	}, nil
+}
+
+func (s *Server) ListChains(_ context.Context, _ *types.ListChainsRequest) (*types.ListChainsResponse, error) {
+	s.logger.Debug("server.ListChains")
+
+	statuses, err := s.coordinator.GetChainStatuses()
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ListChainsResponse{
+		Chains: statuses,
+	}, nil
+}
+
+func (s *Server) ListAttestations(_ context.Context, req *types.ListAttestationsRequest) (*types.ListAttestationsResponse, error) {
+	s.logger.Debug("server.ListAttestations", zap.String("chain_id", req.ChainId), zap.Uint64("start_height", req.StartHeight))
+
+	if req.EndHeight != 0 && req.EndHeight < req.StartHeight {
+		return nil, status.Error(codes.InvalidArgument, "end height is lower than start height")
+	}
+	limit := defaultListLimit
+	if req.Limit > 0 {
+		limit = min(int(req.Limit), maxListLimit)
+	}
+
+	attestations, nextHeight, err := s.coordinator.ListAttestations(req.ChainId, req.StartHeight, req.EndHeight, limit)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &types.ListAttestationsResponse{
+		Attestations: attestations,
+		NextHeight:   nextHeight,
+	}, nil
+}
+
+func (s *Server) SubscribeAttestations(req *types.SubscribeAttestationsRequest, stream types.Sidecar_SubscribeAttestationsServer) error {
+	s.logger.Debug("server.SubscribeAttestations", zap.Strings("chain_ids", req.ChainIds))
+
+	attestations, err := s.coordinator.SubscribeAttestations(stream.Context(), req.ChainIds)
+	if err != nil {
+		return grpcError(err)
+	}
+
+	for attestation := range attestations {
+		if err := stream.Send(&types.SubscribeAttestationsResponse{Attestation: attestation}); err != nil {
+			return err
+		}
+	}
+
+	// the channel is also closed if the subscriber fell too far behind
+	if err := stream.Context().Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.ResourceExhausted, "subscriber fell too far behind")
+}
+
+// grpcError maps the errors of unknown chains and missing attestations to the NotFound code
+func grpcError(err error) error {
+	if errors.Is(err, attestators.ErrUnknownChain) || errors.Is(err, store.ErrNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	return err
+}
@@ -8,17 +8,23 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	clienttypes "github.com/cosmos/ibc-go/v9/modules/core/02-client/types"
 
 	"github.com/cosmos/interchain-attestation/core/types"
 	"github.com/cosmos/interchain-attestation/sidecar/attestators"
 	"github.com/cosmos/interchain-attestation/sidecar/attestators/attestator"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+	"github.com/cosmos/interchain-attestation/sidecar/metrics"
 	"github.com/cosmos/interchain-attestation/sidecar/server"
+	"github.com/cosmos/interchain-attestation/sidecar/store"
 )
 
 const (
@@ -27,7 +33,10 @@ const (
 	mockChainAttestatorID = "mockChainAttestatorID"
 )
 
-type mockCoordinator struct{}
+type mockCoordinator struct {
+	standby  bool
+	statuses []types.ChainStatus
+}
 
 type mockChainAttestator struct{}
 
@@ -44,24 +53,108 @@ func (m mockCoordinator) Run(_ context.Context) error {
 	panic("should not be called in this test")
 }
 
+func mockAttestation(height uint64) types.Attestation {
+	return types.Attestation{
+		AttestatorId: []byte(mockChainAttestatorID),
+		Payload: types.NewIBCDataPayload(types.IBCData{
+			ChainId:           mockChainID,
+			ClientId:          mockClientID,
+			Height:            clienttypes.NewHeight(1, height),
+			Timestamp:         time.Now(),
+			PacketCommitments: [][]byte{{0x01}, {0x02}, {0x03}},
+		}),
+	}
+}
+
 func (m mockCoordinator) GetLatestAttestations() ([]types.Attestation, error) {
-	return []types.Attestation{
-		{
-			AttestatorId: []byte(mockChainAttestatorID),
-			AttestedData: types.IBCData{
-				ChainId:           mockChainID,
-				ClientId:          mockClientID,
-				Height:            clienttypes.NewHeight(1, 42),
-				Timestamp:         time.Now(),
-				PacketCommitments: [][]byte{{0x01}, {0x02}, {0x03}},
+	return []types.Attestation{mockAttestation(42)}, nil
+}
+
+func (m mockCoordinator) GetAttestationForHeight(chainID string, height uint64) (types.Attestation, error) {
+	if chainID != mockChainID {
+		return types.Attestation{}, attestators.ErrUnknownChain
+	}
+	if height > 42 {
+		return types.Attestation{}, store.ErrNotFound
+	}
+
+	return mockAttestation(height), nil
+}
+
+func (m mockCoordinator) ListAttestations(chainID string, startHeight, endHeight uint64, limit int) ([]types.Attestation, uint64, error) {
+	if chainID != mockChainID {
+		return nil, 0, attestators.ErrUnknownChain
+	}
+
+	if endHeight == 0 {
+		endHeight = 42
+	}
+
+	var attestations []types.Attestation
+	for height := max(startHeight, 1); height <= min(endHeight, 42); height++ {
+		if len(attestations) == limit {
+			return attestations, height, nil
+		}
+		attestations = append(attestations, mockAttestation(height))
+	}
+
+	return attestations, 0, nil
+}
+
+func (m mockCoordinator) SubscribeAttestations(ctx context.Context, _ []string) (<-chan types.Attestation, error) {
+	attestations := make(chan types.Attestation, 2)
+	attestations <- mockAttestation(43)
+	attestations <- mockAttestation(44)
+	go func() {
+		<-ctx.Done()
+		close(attestations)
+	}()
+
+	return attestations, nil
+}
+
+func (m mockCoordinator) GetChainStatuses() ([]types.ChainStatus, error) {
+	if m.statuses != nil {
+		return m.statuses, nil
+	}
+
+	return []types.ChainStatus{{
+		ChainId:         mockChainID,
+		Health:          string(attestators.HealthStateHealthy),
+		LatestHeight:    42,
+		CollectedHeight: 42,
+	}}, nil
+}
+
+func (m mockCoordinator) GetChannelTopology(chainID string) (types.GetChannelTopologyResponse, error) {
+	return types.GetChannelTopologyResponse{
+		ChainId:  chainID,
+		ClientId: mockClientID,
+		Topologies: []types.ChannelTopology{
+			{
+				Endpoint:      "http://localhost:26657",
+				Height:        42,
+				ConnectionIds: []string{"connection-0"},
+				Channels:      []types.TopologyChannel{{PortId: "transfer", ChannelId: "channel-0", ConnectionId: "connection-0"}},
 			},
 		},
 	}, nil
 }
 
-func (m mockCoordinator) GetAttestationForHeight(chainID string, height uint64) (types.Attestation, error) {
-	// TODO implement me
-	panic("implement me")
+func (m mockCoordinator) GetChainHealth() map[string]attestators.ChainHealth {
+	panic("should not be called in this test")
+}
+
+func (m mockCoordinator) Standby() bool {
+	return m.standby
+}
+
+func (m mockCoordinator) Close() error {
+	panic("should not be called in this test")
+}
+
+func (m mockCoordinator) ApplyConfig(sidecarConfig config.Config) error {
+	panic("should not be called in this test")
 }
 
 func (m mockChainAttestator) ChainID() string {
@@ -72,9 +165,18 @@ func (m mockChainAttestator) CollectAttestation(ctx context.Context) (types.Atte
 	panic("should not be called in this test")
 }
 
+func (m mockChainAttestator) BlockHash(ctx context.Context, height uint64) ([]byte, error) {
+	panic("should not be called in this test")
+}
+
 // TestServe is mostly just a smoke test that the server can start and serve requests. Everything is mocked except the server itself.
 func TestServe(t *testing.T) {
-	s := server.NewServer(zap.NewNop(), mockCoordinator{})
+	listChainsCounter := metrics.GRPCRequests.WithLabelValues("/core.sidecar.v1.Sidecar/ListChains", codes.OK.String())
+	listChainsRequests := testutil.ToFloat64(listChainsCounter)
+	subscribeCounter := metrics.GRPCRequests.WithLabelValues("/core.sidecar.v1.Sidecar/SubscribeAttestations", codes.Canceled.String())
+	subscribeRequests := testutil.ToFloat64(subscribeCounter)
+
+	s := server.NewServer(zap.NewNop(), mockCoordinator{}, config.ServerConfig{}, "")
 	randomPort := rand.Intn(65535-49152) + 49152
 	addr := fmt.Sprintf("localhost:%d", randomPort)
 
@@ -97,10 +199,101 @@ func TestServe(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, resp.Attestations, 1)
 	require.Equal(t, []byte(mockChainAttestatorID), resp.Attestations[0].AttestatorId)
-	require.Equal(t, mockChainID, resp.Attestations[0].AttestedData.ChainId)
-	require.Equal(t, mockClientID, resp.Attestations[0].AttestedData.ClientId)
+	require.Equal(t, mockChainID, resp.Attestations[0].Payload.GetIbcDataV1().ChainId)
+	require.Equal(t, mockClientID, resp.Attestations[0].Payload.GetIbcDataV1().ClientId)
+
+	topologyResp, err := sidecarClient.GetChannelTopology(context.Background(), &types.GetChannelTopologyRequest{ChainId: mockChainID})
+	require.NoError(t, err)
+	require.Equal(t, mockClientID, topologyResp.ClientId)
+	require.Len(t, topologyResp.Topologies, 1)
+	require.Equal(t, "channel-0", topologyResp.Topologies[0].Channels[0].ChannelId)
+
+	attestationResp, err := sidecarClient.GetAttestation(context.Background(), &types.GetAttestationRequest{ChainId: mockChainID, Height: 10})
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), attestationResp.Attestation.Payload.AttestedHeight().RevisionHeight)
+	_, err = sidecarClient.GetAttestation(context.Background(), &types.GetAttestationRequest{ChainId: mockChainID, Height: 43})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = sidecarClient.GetAttestation(context.Background(), &types.GetAttestationRequest{ChainId: "unknown", Height: 10})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	chainsResp, err := sidecarClient.ListChains(context.Background(), &types.ListChainsRequest{})
+	require.NoError(t, err)
+	require.Len(t, chainsResp.Chains, 1)
+	require.Equal(t, mockChainID, chainsResp.Chains[0].ChainId)
+
+	// the first page has the default limit, and the last one ends at the latest height
+	listResp, err := sidecarClient.ListAttestations(context.Background(), &types.ListAttestationsRequest{ChainId: mockChainID})
+	require.NoError(t, err)
+	require.Len(t, listResp.Attestations, 42)
+	require.Zero(t, listResp.NextHeight)
+	listResp, err = sidecarClient.ListAttestations(context.Background(), &types.ListAttestationsRequest{ChainId: mockChainID, StartHeight: 10, EndHeight: 1000, Limit: 20})
+	require.NoError(t, err)
+	require.Len(t, listResp.Attestations, 20)
+	require.Equal(t, uint64(30), listResp.NextHeight)
+	_, err = sidecarClient.ListAttestations(context.Background(), &types.ListAttestationsRequest{ChainId: mockChainID, StartHeight: 10, EndHeight: 5})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	subscribeCtx, subscribeCancel := context.WithCancel(context.Background())
+	stream, err := sidecarClient.SubscribeAttestations(subscribeCtx, &types.SubscribeAttestationsRequest{})
+	require.NoError(t, err)
+	for _, height := range []uint64{43, 44} {
+		subscribeResp, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, height, subscribeResp.Attestation.Payload.AttestedHeight().RevisionHeight)
+	}
+	subscribeCancel()
+	_, err = stream.Recv()
+	require.Equal(t, codes.Canceled, status.Code(err))
 
-	s.Stop()
+	require.Equal(t, listChainsRequests+1, testutil.ToFloat64(listChainsCounter))
+	// the stream is only recorded once the server has seen the cancellation
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(subscribeCounter) == subscribeRequests+1
+	}, time.Second, 10*time.Millisecond)
+
+	s.Stop(context.Background())
 
 	wg.Wait()
 }
+
+func TestGetAttestations_Standby(t *testing.T) {
+	s := server.NewServer(zap.NewNop(), mockCoordinator{standby: true}, config.ServerConfig{}, "")
+
+	_, err := s.GetAttestations(context.Background(), &types.GetAttestationsRequest{})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestServer_Stop(t *testing.T) {
+	// a server stopped before it serves doesn't serve at all
+	s := server.NewServer(zap.NewNop(), mockCoordinator{}, config.ServerConfig{}, "")
+	s.Stop(context.Background())
+	require.NoError(t, s.Serve("localhost:0"))
+
+	s = server.NewServer(zap.NewNop(), mockCoordinator{}, config.ServerConfig{}, "")
+	addr := fmt.Sprintf("localhost:%d", rand.Intn(65535-49152)+49152)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.Serve(addr)
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	client, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer client.Close()
+	stream, err := types.NewSidecarClient(client).SubscribeAttestations(context.Background(), &types.SubscribeAttestationsRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	// the subscription never ends by itself, so it is cancelled once the stop deadline is reached
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	s.Stop(ctx)
+	require.NoError(t, <-errCh)
+	require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
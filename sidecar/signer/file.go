@@ -0,0 +1,61 @@
+package signer
+
+import (
+	"context"
+
+	"gitlab.com/tozd/go/errors"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+
+	"github.com/cosmos/interchain-attestation/sidecar/attestationkey"
+)
+
+var _ Signer = &FileSigner{}
+
+// FileSigner signs with an attestation key file, as generated by `attestation-key generate`
+type FileSigner struct {
+	privKey cryptotypes.PrivKey
+}
+
+// NewFileSigner reads the attestation key from the key file, or from the default key file in the home directory if
+// no key file is given
+func NewFileSigner(cdc codec.Codec, homedir string, keyFilePath string) (*FileSigner, error) {
+	var privKey cryptotypes.PrivKey
+	var found bool
+	var err error
+	if keyFilePath == "" {
+		privKey, found, err = attestationkey.Load(cdc, homedir)
+	} else {
+		privKey, found, err = attestationkey.LoadFile(cdc, keyFilePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.WithStack(ErrKeyNotFound)
+	}
+
+	return NewKeySigner(privKey), nil
+}
+
+// NewKeySigner signs with a private key that is already loaded, e.g. in tests
+func NewKeySigner(privKey cryptotypes.PrivKey) *FileSigner {
+	return &FileSigner{privKey: privKey}
+}
+
+func (s *FileSigner) PubKey() cryptotypes.PubKey {
+	return s.privKey.PubKey()
+}
+
+func (s *FileSigner) ProofOfPossession() ([]byte, error) {
+	return attestationkey.ProofOfPossession(s.privKey)
+}
+
+func (s *FileSigner) Sign(_ context.Context, signBytes []byte) ([]byte, error) {
+	return s.privKey.Sign(signBytes)
+}
+
+func (s *FileSigner) Close() error {
+	return nil
+}
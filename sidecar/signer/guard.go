@@ -0,0 +1,88 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"gitlab.com/tozd/go/errors"
+)
+
+var (
+	// ErrConflictingAttestation is returned when asked to sign a different attestation for a height that was already signed
+	ErrConflictingAttestation = errors.Base("conflicting attestation at an already signed height")
+	// ErrHeightRegression is returned when asked to sign an attestation for a lower height than the last signed one
+	ErrHeightRegression = errors.Base("attestation height is lower than the last signed height")
+	// ErrStandby is returned by the state store of a high availability group when another instance is signing
+	ErrStandby = errors.Base("another instance of the sidecar is signing")
+)
+
+// StateStore keeps the sign state. A high availability group of sidecars shares it, and only the instance that holds
+// the lock of the group can save it.
+type StateStore interface {
+	Load() (SignState, error)
+	Save(state SignState) error
+}
+
+// Guard protects against double attestation: signing two different attestations for the same height of a chain, which
+// gets the validator slashed. It only signs attestations for heights above the last signed height of the chain, and
+// returns the prior signature if asked to sign the identical attestation again. The last signed height is persisted
+// before a signature is returned.
+type Guard struct {
+	signer     Signer
+	stateStore StateStore
+
+	// lock serializes signing, so that the sign state is never loaded while another signature is being saved
+	lock sync.Mutex
+}
+
+func NewGuard(signer Signer, stateStore StateStore) *Guard {
+	return &Guard{
+		signer:     signer,
+		stateStore: stateStore,
+	}
+}
+
+// SignAttestation signs the sign bytes of the attestation for the height of the chain, see types.GetAttestationSignBytes.
+// The sign state is loaded for every signature, since another instance of a high availability group may have signed.
+func (g *Guard) SignAttestation(ctx context.Context, chainID string, height uint64, signBytes []byte) ([]byte, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	state, err := g.stateStore.Load()
+	if err != nil {
+		return nil, errors.Errorf("failed to load sign state: %w", err)
+	}
+
+	if lastSigned, ok := state.Chains[chainID]; ok {
+		switch {
+		case height < lastSigned.Height:
+			return nil, errors.Errorf("%w: %d for chain %s, last signed %d", ErrHeightRegression, height, chainID, lastSigned.Height)
+		case height == lastSigned.Height && bytes.Equal(signBytes, lastSigned.SignBytes):
+			return lastSigned.Signature, nil
+		case height == lastSigned.Height:
+			return nil, errors.Errorf("%w: %d for chain %s", ErrConflictingAttestation, height, chainID)
+		}
+	}
+
+	signature, err := g.signer.Sign(ctx, signBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// the signature is only released once the state is persisted, so that it is never signed again after a restart
+	state.Chains[chainID] = LastSigned{
+		Height:    height,
+		SignBytes: signBytes,
+		Signature: signature,
+	}
+	if err := g.stateStore.Save(state); err != nil {
+		return nil, errors.Errorf("failed to save sign state: %w", err)
+	}
+
+	return signature, nil
+}
+
+func (g *Guard) Close() error {
+	return g.signer.Close()
+}
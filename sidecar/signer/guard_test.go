@@ -0,0 +1,84 @@
+package signer_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/interchain-attestation/core/crypto/bls12381"
+	"github.com/cosmos/interchain-attestation/sidecar/signer"
+)
+
+func TestGuard(t *testing.T) {
+	privKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	statePath := filepath.Join(t.TempDir(), signer.DefaultSignStateFileName)
+	ctx := context.Background()
+
+	guard := signer.NewGuard(signer.NewKeySigner(privKey), signer.NewFileStateStore(statePath))
+
+	signature, err := guard.SignAttestation(ctx, "chain-1", 10, []byte("attestation at 10"))
+	require.NoError(t, err)
+	require.True(t, privKey.PubKey().VerifySignature([]byte("attestation at 10"), signature))
+
+	// the identical attestation gets the prior signature
+	resigned, err := guard.SignAttestation(ctx, "chain-1", 10, []byte("attestation at 10"))
+	require.NoError(t, err)
+	require.Equal(t, signature, resigned)
+
+	_, err = guard.SignAttestation(ctx, "chain-1", 10, []byte("other attestation at 10"))
+	require.ErrorIs(t, err, signer.ErrConflictingAttestation)
+	_, err = guard.SignAttestation(ctx, "chain-1", 9, []byte("attestation at 9"))
+	require.ErrorIs(t, err, signer.ErrHeightRegression)
+
+	// chains are guarded independently
+	_, err = guard.SignAttestation(ctx, "chain-2", 5, []byte("attestation at 5"))
+	require.NoError(t, err)
+	_, err = guard.SignAttestation(ctx, "chain-1", 11, []byte("attestation at 11"))
+	require.NoError(t, err)
+
+	// the state survives a restart
+	guard = signer.NewGuard(signer.NewKeySigner(privKey), signer.NewFileStateStore(statePath))
+	_, err = guard.SignAttestation(ctx, "chain-1", 11, []byte("other attestation at 11"))
+	require.ErrorIs(t, err, signer.ErrConflictingAttestation)
+	_, err = guard.SignAttestation(ctx, "chain-2", 4, []byte("attestation at 4"))
+	require.ErrorIs(t, err, signer.ErrHeightRegression)
+
+	// nothing is signed if the state can't be saved
+	guard = signer.NewGuard(signer.NewKeySigner(privKey), signer.NewFileStateStore(filepath.Join(t.TempDir(), "missing", signer.DefaultSignStateFileName)))
+	_, err = guard.SignAttestation(ctx, "chain-1", 12, []byte("attestation at 12"))
+	require.ErrorContains(t, err, "failed to save sign state")
+	// and the failed signature isn't counted as signed
+	_, err = guard.SignAttestation(ctx, "chain-1", 11, []byte("attestation at 11"))
+	require.ErrorContains(t, err, "failed to save sign state")
+	require.NotErrorIs(t, err, signer.ErrHeightRegression)
+}
+
+func TestSignState_Merge(t *testing.T) {
+	dir := t.TempDir()
+
+	state, err := signer.LoadSignState(filepath.Join(dir, "missing.json"))
+	require.NoError(t, err)
+	require.Empty(t, state.Chains)
+
+	state.Chains["chain-1"] = signer.LastSigned{Height: 10, SignBytes: []byte{1}, Signature: []byte{2}}
+	state.Chains["chain-2"] = signer.LastSigned{Height: 20, SignBytes: []byte{3}, Signature: []byte{4}}
+	statePath := filepath.Join(dir, signer.DefaultSignStateFileName)
+	require.NoError(t, state.Save(statePath))
+
+	loaded, err := signer.LoadSignState(statePath)
+	require.NoError(t, err)
+	require.Equal(t, state, loaded)
+
+	imported := signer.SignState{Chains: map[string]signer.LastSigned{
+		"chain-1": {Height: 12},
+		"chain-2": {Height: 15},
+		"chain-3": {Height: 1},
+	}}
+	require.Equal(t, []string{"chain-1", "chain-3"}, loaded.Merge(imported))
+	require.Equal(t, uint64(12), loaded.Chains["chain-1"].Height)
+	require.Equal(t, uint64(20), loaded.Chains["chain-2"].Height)
+	require.Equal(t, uint64(1), loaded.Chains["chain-3"].Height)
+}
@@ -0,0 +1,64 @@
+package signer
+
+import (
+	"context"
+	"io"
+
+	"gitlab.com/tozd/go/errors"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+var _ Signer = &KeyringSigner{}
+
+// KeyringSigner signs with a key in the keyring of the sidecar, as managed by the `keys` commands
+type KeyringSigner struct {
+	keyring keyring.Keyring
+	keyName string
+	pubKey  cryptotypes.PubKey
+}
+
+// NewKeyringSigner opens the keyring in the home directory. The input is used to prompt for the passphrase of the file
+// backend.
+func NewKeyringSigner(cdc codec.Codec, homedir string, keyringBackend string, keyName string, input io.Reader) (*KeyringSigner, error) {
+	kr, err := keyring.New(KeyringAppName, keyringBackend, homedir, input, cdc)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := kr.Key(keyName)
+	if err != nil {
+		return nil, errors.Errorf("failed to find key %s in the %s keyring: %w", keyName, keyringBackend, err)
+	}
+	pubKey, err := record.GetPubKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyringSigner{
+		keyring: kr,
+		keyName: keyName,
+		pubKey:  pubKey,
+	}, nil
+}
+
+func (s *KeyringSigner) PubKey() cryptotypes.PubKey {
+	return s.pubKey
+}
+
+// ProofOfPossession returns nil, since the keyring has no BLS12-381 keys
+func (s *KeyringSigner) ProofOfPossession() ([]byte, error) {
+	return nil, nil
+}
+
+func (s *KeyringSigner) Sign(_ context.Context, signBytes []byte) ([]byte, error) {
+	signature, _, err := s.keyring.Sign(s.keyName, signBytes, signing.SignMode_SIGN_MODE_DIRECT)
+	return signature, err
+}
+
+func (s *KeyringSigner) Close() error {
+	return nil
+}
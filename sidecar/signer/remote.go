@@ -0,0 +1,166 @@
+package signer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"strings"
+	"time"
+
+	"gitlab.com/tozd/go/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+var _ Signer = &RemoteSigner{}
+
+// RemoteSigner signs with a remote signer that implements the RemoteSigner grpc service, so the attestation key never
+// has to be on the machine of the sidecar
+type RemoteSigner struct {
+	conn    *grpc.ClientConn
+	client  types.RemoteSignerClient
+	timeout time.Duration
+
+	pubKey            cryptotypes.PubKey
+	proofOfPossession []byte
+}
+
+// NewRemoteSigner connects to the remote signer and fetches its public key, which every signature is verified against.
+// The dial options need to include the transport credentials (see RemoteDialOptions).
+func NewRemoteSigner(ctx context.Context, cdc codec.Codec, address string, timeout time.Duration, opts ...grpc.DialOption) (*RemoteSigner, error) {
+	conn, err := grpc.NewClient(address, opts...)
+	if err != nil {
+		return nil, err
+	}
+	client := types.NewRemoteSignerClient(conn)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	resp, err := client.GetPubKey(ctx, &types.GetPubKeyRequest{})
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.Errorf("failed to get public key from remote signer at %s: %w", address, err)
+	}
+
+	var pubKey cryptotypes.PubKey
+	if err := cdc.InterfaceRegistry().UnpackAny(resp.PubKey, &pubKey); err != nil {
+		_ = conn.Close()
+		return nil, errors.Errorf("invalid public key from remote signer at %s: %w", address, err)
+	}
+
+	return &RemoteSigner{
+		conn:              conn,
+		client:            client,
+		timeout:           timeout,
+		pubKey:            pubKey,
+		proofOfPossession: resp.ProofOfPossession,
+	}, nil
+}
+
+func (s *RemoteSigner) PubKey() cryptotypes.PubKey {
+	return s.pubKey
+}
+
+func (s *RemoteSigner) ProofOfPossession() ([]byte, error) {
+	return s.proofOfPossession, nil
+}
+
+func (s *RemoteSigner) Sign(ctx context.Context, signBytes []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	resp, err := s.client.Sign(ctx, &types.SignRequest{SignBytes: signBytes})
+	if err != nil {
+		return nil, errors.Errorf("remote signer failed to sign: %w", err)
+	}
+
+	// a signature that doesn't verify would only be rejected later by the host chain
+	if !s.pubKey.VerifySignature(signBytes, resp.Signature) {
+		return nil, errors.New("remote signer returned an invalid signature")
+	}
+
+	return resp.Signature, nil
+}
+
+func (s *RemoteSigner) Close() error {
+	return s.conn.Close()
+}
+
+// RemoteDialOptions returns the transport credentials and the auth token for the connection to the remote signer,
+// with the files of the signer config relative to the home directory
+func RemoteDialOptions(homedir string, signerConfig config.SignerConfig) ([]grpc.DialOption, error) {
+	creds, err := remoteTransportCredentials(homedir, signerConfig)
+	if err != nil {
+		return nil, err
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+
+	token := signerConfig.RemoteAuthToken
+	if signerConfig.RemoteAuthTokenFile != "" {
+		bz, err := os.ReadFile(resolvePath(homedir, signerConfig.RemoteAuthTokenFile))
+		if err != nil {
+			return nil, errors.Errorf("failed to read remote auth token file: %w", err)
+		}
+		token = strings.TrimSpace(string(bz))
+		if token == "" {
+			return nil, errors.Errorf("remote auth token file %s is empty", signerConfig.RemoteAuthTokenFile)
+		}
+	}
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(authToken(token)))
+	}
+
+	return opts, nil
+}
+
+func remoteTransportCredentials(homedir string, signerConfig config.SignerConfig) (credentials.TransportCredentials, error) {
+	if !signerConfig.RemoteTLS {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: signerConfig.RemoteTLSServerName,
+	}
+
+	if signerConfig.RemoteTLSCAFile != "" {
+		caBz, err := os.ReadFile(resolvePath(homedir, signerConfig.RemoteTLSCAFile))
+		if err != nil {
+			return nil, errors.Errorf("failed to read remote tls ca file: %w", err)
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caBz) {
+			return nil, errors.Errorf("failed to parse remote tls ca file %s", signerConfig.RemoteTLSCAFile)
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	if signerConfig.RemoteTLSCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(resolvePath(homedir, signerConfig.RemoteTLSCertFile), resolvePath(homedir, signerConfig.RemoteTLSKeyFile))
+		if err != nil {
+			return nil, errors.Errorf("failed to load remote tls client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// authToken sends the auth token of the remote signer with every request. It is also sent without TLS, e.g. for
+// a remote signer on the same host.
+type authToken string
+
+func (t authToken) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t authToken) RequireTransportSecurity() bool {
+	return false
+}
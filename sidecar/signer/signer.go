@@ -0,0 +1,88 @@
+package signer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"gitlab.com/tozd/go/errors"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+// KeyringAppName is the name the keyring of the sidecar is stored under, shared with the `keys` commands
+const KeyringAppName = "attestation-sidecar"
+
+// ErrKeyNotFound is returned when the attestation key of the configured signer does not exist
+var ErrKeyNotFound = errors.Base("attestation key not found")
+
+// Signer signs the attestations of the sidecar with the attestation key registered for the attestator
+type Signer interface {
+	// PubKey returns the public key of the attestation key
+	PubKey() cryptotypes.PubKey
+	// ProofOfPossession returns the proof of possession needed to register a BLS12-381 key, and nil for other key types
+	ProofOfPossession() ([]byte, error)
+	// Sign signs the sign bytes of an attestation, see types.GetAttestationSignBytes
+	Sign(ctx context.Context, signBytes []byte) ([]byte, error)
+
+	Close() error
+}
+
+// New sets up the signer of the configured kind. Key files and keyrings are looked up in the home directory.
+func New(ctx context.Context, cdc codec.Codec, homedir string, signerConfig config.SignerConfig) (Signer, error) {
+	switch signerConfig.Kind {
+	case "", config.SignerKindFile:
+		return NewFileSigner(cdc, homedir, resolvePath(homedir, signerConfig.KeyFile))
+	case config.SignerKindKeyring:
+		keyringBackend := signerConfig.KeyringBackend
+		if keyringBackend == "" {
+			keyringBackend = "os"
+		}
+		return NewKeyringSigner(cdc, homedir, keyringBackend, signerConfig.KeyName, os.Stdin)
+	case config.SignerKindRemote:
+		timeout, err := signerConfig.GetRemoteTimeout()
+		if err != nil {
+			return nil, err
+		}
+		opts, err := RemoteDialOptions(homedir, signerConfig)
+		if err != nil {
+			return nil, err
+		}
+		return NewRemoteSigner(ctx, cdc, signerConfig.RemoteAddress, timeout, opts...)
+	default:
+		return nil, errors.Errorf("unknown signer kind %q", signerConfig.Kind)
+	}
+}
+
+// resolvePath returns the path relative to the home directory, unless it is absolute
+func resolvePath(homedir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(homedir, path)
+}
+
+// Registration returns the registration of the attestator that validators submit to the attestation config module
+func Registration(attestatorID string, signer Signer) (types.AttestatorRegistration, error) {
+	pubKeyAny, err := codectypes.NewAnyWithValue(signer.PubKey())
+	if err != nil {
+		return types.AttestatorRegistration{}, err
+	}
+
+	proofOfPossession, err := signer.ProofOfPossession()
+	if err != nil {
+		return types.AttestatorRegistration{}, err
+	}
+
+	return types.AttestatorRegistration{
+		AttestatorID:         []byte(attestatorID),
+		AttestationPublicKey: pubKeyAny,
+		ProofOfPossession:    proofOfPossession,
+	}, nil
+}
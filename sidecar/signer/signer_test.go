@@ -0,0 +1,311 @@
+package signer_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/sidecar/attestationkey"
+	"github.com/cosmos/interchain-attestation/sidecar/attestators/cosmos"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+	"github.com/cosmos/interchain-attestation/sidecar/signer"
+)
+
+var signBytes = []byte("attestation sign bytes")
+
+// remoteSigner serves the RemoteSigner service with a local signer, optionally corrupting the signatures
+type remoteSigner struct {
+	signer  signer.Signer
+	corrupt bool
+}
+
+func (r *remoteSigner) GetPubKey(_ context.Context, _ *types.GetPubKeyRequest) (*types.GetPubKeyResponse, error) {
+	pubKeyAny, err := codectypes.NewAnyWithValue(r.signer.PubKey())
+	if err != nil {
+		return nil, err
+	}
+	proofOfPossession, err := r.signer.ProofOfPossession()
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetPubKeyResponse{PubKey: pubKeyAny, ProofOfPossession: proofOfPossession}, nil
+}
+
+func (r *remoteSigner) Sign(ctx context.Context, req *types.SignRequest) (*types.SignResponse, error) {
+	signature, err := r.signer.Sign(ctx, req.SignBytes)
+	if err != nil {
+		return nil, err
+	}
+	if r.corrupt {
+		signature[0] ^= 0xff
+	}
+
+	return &types.SignResponse{Signature: signature}, nil
+}
+
+func serveRemoteSigner(t *testing.T, remote *remoteSigner, opts ...grpc.ServerOption) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	grpcServer := grpc.NewServer(opts...)
+	types.RegisterRemoteSignerServer(grpcServer, remote)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	return listener.Addr().String()
+}
+
+func requireSigns(t *testing.T, s signer.Signer) {
+	signature, err := s.Sign(context.Background(), signBytes)
+	require.NoError(t, err)
+	require.True(t, s.PubKey().VerifySignature(signBytes, signature))
+
+	registration, err := signer.Registration("attestator-id", s)
+	require.NoError(t, err)
+	require.NoError(t, registration.Validate())
+	require.Equal(t, []byte("attestator-id"), registration.AttestatorID)
+}
+
+func TestFileSigner(t *testing.T) {
+	cdc := cosmos.NewCodecConfig().Marshaler
+	homedir := t.TempDir()
+
+	_, err := signer.New(context.Background(), cdc, homedir, config.SignerConfig{})
+	require.ErrorIs(t, err, signer.ErrKeyNotFound)
+
+	privKey, err := attestationkey.Generate(cdc, homedir, attestationkey.KeyTypeBLS12381, false)
+	require.NoError(t, err)
+
+	s, err := signer.New(context.Background(), cdc, homedir, config.SignerConfig{Kind: config.SignerKindFile})
+	require.NoError(t, err)
+	require.True(t, privKey.PubKey().Equals(s.PubKey()))
+	requireSigns(t, s)
+
+	// a key file relative to the home directory
+	_, err = signer.New(context.Background(), cdc, homedir, config.SignerConfig{KeyFile: "other_key.json"})
+	require.ErrorIs(t, err, signer.ErrKeyNotFound)
+	s, err = signer.New(context.Background(), cdc, homedir, config.SignerConfig{KeyFile: "attestation_key.json"})
+	require.NoError(t, err)
+	require.True(t, privKey.PubKey().Equals(s.PubKey()))
+}
+
+func TestKeyringSigner(t *testing.T) {
+	cdc := cosmos.NewCodecConfig().Marshaler
+	homedir := t.TempDir()
+	signerConfig := config.SignerConfig{
+		Kind:           config.SignerKindKeyring,
+		KeyringBackend: keyring.BackendTest,
+		KeyName:        "attestator",
+	}
+
+	_, err := signer.New(context.Background(), cdc, homedir, signerConfig)
+	require.ErrorContains(t, err, "failed to find key attestator in the test keyring")
+
+	kr, err := keyring.New(signer.KeyringAppName, keyring.BackendTest, homedir, nil, cdc)
+	require.NoError(t, err)
+	record, _, err := kr.NewMnemonic("attestator", keyring.English, "", "", hd.Secp256k1)
+	require.NoError(t, err)
+	pubKey, err := record.GetPubKey()
+	require.NoError(t, err)
+
+	s, err := signer.New(context.Background(), cdc, homedir, signerConfig)
+	require.NoError(t, err)
+	require.True(t, pubKey.Equals(s.PubKey()))
+	requireSigns(t, s)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	cdc := cosmos.NewCodecConfig().Marshaler
+
+	_, err := signer.New(context.Background(), cdc, "", config.SignerConfig{
+		Kind:          config.SignerKindRemote,
+		RemoteAddress: "localhost:1",
+		RemoteTimeout: "100ms",
+	})
+	require.ErrorContains(t, err, "failed to get public key from remote signer at localhost:1")
+
+	for _, keyType := range []string{attestationkey.KeyTypeBLS12381, attestationkey.KeyTypeSecp256k1} {
+		t.Run(keyType, func(t *testing.T) {
+			privKey, err := attestationkey.Generate(cdc, t.TempDir(), keyType, false)
+			require.NoError(t, err)
+			remote := &remoteSigner{signer: signer.NewKeySigner(privKey)}
+			address := serveRemoteSigner(t, remote)
+
+			s, err := signer.New(context.Background(), cdc, "", config.SignerConfig{
+				Kind:          config.SignerKindRemote,
+				RemoteAddress: address,
+			})
+			require.NoError(t, err)
+			defer s.Close()
+			require.True(t, privKey.PubKey().Equals(s.PubKey()))
+			requireSigns(t, s)
+
+			remote.corrupt = true
+			_, err = s.Sign(context.Background(), signBytes)
+			require.ErrorContains(t, err, "remote signer returned an invalid signature")
+		})
+	}
+}
+
+func TestRemoteSigner_Timeout(t *testing.T) {
+	cdc := cosmos.NewCodecConfig().Marshaler
+	privKey, err := attestationkey.Generate(cdc, t.TempDir(), attestationkey.KeyTypeSecp256k1, false)
+	require.NoError(t, err)
+	address := serveRemoteSigner(t, &remoteSigner{signer: slowSigner{signer.NewKeySigner(privKey)}})
+
+	s, err := signer.NewRemoteSigner(context.Background(), cdc, address, 100*time.Millisecond, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer s.Close()
+
+	_, err = s.Sign(context.Background(), signBytes)
+	require.ErrorContains(t, err, "DeadlineExceeded")
+}
+
+// writeCert writes a certificate and its key as pem files, signed by the parent (self-signed if nil)
+func writeCert(t *testing.T, dir, name string, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+"-key.pem"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+
+	return cert, key
+}
+
+func TestRemoteSigner_MutualTLSAndAuthToken(t *testing.T) {
+	cdc := cosmos.NewCodecConfig().Marshaler
+	homedir := t.TempDir()
+	notAfter := time.Now().Add(time.Hour)
+	ca, caKey := writeCert(t, homedir, "ca", &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil, nil)
+	writeCert(t, homedir, "signer", &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "remote signer"},
+		NotAfter:     notAfter,
+		DNSNames:     []string{"signer.internal"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	writeCert(t, homedir, "sidecar", &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "sidecar"},
+		NotAfter:     notAfter,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+	require.NoError(t, os.WriteFile(filepath.Join(homedir, "auth_token"), []byte("secret\n"), 0o600))
+
+	serverCert, err := tls.LoadX509KeyPair(filepath.Join(homedir, "signer.pem"), filepath.Join(homedir, "signer-key.pem"))
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+	checkAuthToken := func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get("authorization"); len(values) != 1 || values[0] != "Bearer secret" {
+			return nil, status.Error(codes.Unauthenticated, "missing or invalid auth token")
+		}
+		return handler(ctx, req)
+	}
+
+	privKey, err := attestationkey.Generate(cdc, t.TempDir(), attestationkey.KeyTypeBLS12381, false)
+	require.NoError(t, err)
+	address := serveRemoteSigner(t, &remoteSigner{signer: signer.NewKeySigner(privKey)},
+		grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{serverCert},
+			ClientCAs:    clientCAs,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			MinVersion:   tls.VersionTLS12,
+		})),
+		grpc.UnaryInterceptor(checkAuthToken),
+	)
+
+	signerConfig := config.SignerConfig{
+		Kind:                config.SignerKindRemote,
+		RemoteAddress:       address,
+		RemoteTimeout:       "1s",
+		RemoteTLS:           true,
+		RemoteTLSCAFile:     "ca.pem",
+		RemoteTLSCertFile:   "sidecar.pem",
+		RemoteTLSKeyFile:    "sidecar-key.pem",
+		RemoteTLSServerName: "signer.internal",
+		RemoteAuthTokenFile: "auth_token",
+	}
+	require.NoError(t, signerConfig.Validate())
+
+	s, err := signer.New(context.Background(), cdc, homedir, signerConfig)
+	require.NoError(t, err)
+	defer s.Close()
+	require.True(t, privKey.PubKey().Equals(s.PubKey()))
+	requireSigns(t, s)
+
+	// plaintext, TLS without a client certificate and a missing or wrong auth token are all rejected
+	for name, modify := range map[string]func(*config.SignerConfig){
+		"plaintext": func(c *config.SignerConfig) {
+			*c = config.SignerConfig{Kind: c.Kind, RemoteAddress: c.RemoteAddress, RemoteTimeout: c.RemoteTimeout, RemoteAuthTokenFile: c.RemoteAuthTokenFile}
+		},
+		"no client certificate": func(c *config.SignerConfig) { c.RemoteTLSCertFile, c.RemoteTLSKeyFile = "", "" },
+		"no auth token":         func(c *config.SignerConfig) { c.RemoteAuthTokenFile = "" },
+		"wrong auth token":      func(c *config.SignerConfig) { c.RemoteAuthTokenFile, c.RemoteAuthToken = "", "wrong" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalidConfig := signerConfig
+			modify(&invalidConfig)
+			_, err := signer.New(context.Background(), cdc, homedir, invalidConfig)
+			require.ErrorContains(t, err, "failed to get public key from remote signer")
+		})
+	}
+}
+
+type slowSigner struct {
+	signer.Signer
+}
+
+func (s slowSigner) Sign(ctx context.Context, signBytes []byte) ([]byte, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(time.Second):
+	}
+	return s.Signer.Sign(ctx, signBytes)
+}
@@ -0,0 +1,145 @@
+package signer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gitlab.com/tozd/go/errors"
+
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+// DefaultSignStateFileName is the file in the home directory the sign state is kept in, unless configured otherwise
+const DefaultSignStateFileName = "signer_state.json"
+
+// SignState is the last attestation signed for every chain, like the priv_validator_state.json of CometBFT. It is kept
+// outside of the database, so that wiping the database doesn't allow signing a different attestation for a height.
+type SignState struct {
+	Chains map[string]LastSigned `json:"chains"`
+}
+
+// LastSigned is the last attestation signed for a chain
+type LastSigned struct {
+	Height    uint64 `json:"height,string"`
+	SignBytes []byte `json:"sign_bytes"`
+	Signature []byte `json:"signature"`
+}
+
+// SignStatePath returns the path of the sign state file, relative to the home directory unless it is absolute
+func SignStatePath(homedir string, signerConfig config.SignerConfig) string {
+	stateFile := signerConfig.StateFile
+	if stateFile == "" {
+		stateFile = DefaultSignStateFileName
+	}
+
+	return resolvePath(homedir, stateFile)
+}
+
+// LoadSignState reads the sign state from the file, or returns an empty sign state if the file does not exist
+func LoadSignState(path string) (SignState, error) {
+	state := SignState{Chains: make(map[string]LastSigned)}
+
+	bz, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return SignState{}, err
+	}
+
+	if err := json.Unmarshal(bz, &state); err != nil {
+		return SignState{}, errors.Errorf("failed to unmarshal sign state from %s: %w", path, err)
+	}
+	if state.Chains == nil {
+		state.Chains = make(map[string]LastSigned)
+	}
+
+	return state, nil
+}
+
+// Save writes the sign state to the file. It is written to a temporary file first and then renamed, so that the file
+// is never left half written.
+func (s SignState) Save(path string) error {
+	bz, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(bz); err != nil {
+		_ = tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Sync(); err != nil {
+		_ = tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return err
+	}
+
+	// the rename is only durable once the directory entry is synced as well
+	return syncDir(filepath.Dir(path))
+}
+
+// syncDir fsyncs a directory, so that files created or renamed in it survive a crash
+func syncDir(dir string) (err error) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := d.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	return d.Sync()
+}
+
+var _ StateStore = FileStateStore{}
+
+// FileStateStore keeps the sign state in a file
+type FileStateStore struct {
+	Path string
+}
+
+func NewFileStateStore(path string) FileStateStore {
+	return FileStateStore{Path: path}
+}
+
+func (s FileStateStore) Load() (SignState, error) {
+	return LoadSignState(s.Path)
+}
+
+func (s FileStateStore) Save(state SignState) error {
+	return state.Save(s.Path)
+}
+
+// Merge takes the chains of the other sign state that are at a higher height, and returns the ids of the chains it
+// took. The sign state of a chain never goes back to a lower height, since that would allow signing again at the
+// heights in between.
+func (s SignState) Merge(other SignState) []string {
+	var merged []string
+	for chainID, lastSigned := range other.Chains {
+		if current, ok := s.Chains[chainID]; ok && current.Height >= lastSigned.Height {
+			continue
+		}
+		s.Chains[chainID] = lastSigned
+		merged = append(merged, chainID)
+	}
+	slices.Sort(merged)
+
+	return merged
+}
@@ -0,0 +1,294 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"gitlab.com/tozd/go/errors"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+var _ AttestationStore = &BadgerStore{}
+
+// gcDiscardRatio is the fraction of a value log file that has to be garbage before badger rewrites it
+const gcDiscardRatio = 0.5
+
+// BadgerStore keeps the attestations in the badger database of the sidecar. All keys of a chain are prefixed with its
+// chain id, and heights are big endian encoded, so that the keys of a chain are ordered by height:
+//
+//	<chain id>/attestations/<height>  the attestation
+//	<chain id>/blockhashes/<height>   the hash of the attested block
+//	<chain id>/retracted/<height>     an attestation that was retracted after a reorg
+type BadgerStore struct {
+	db *badger.DB
+}
+
+// NewBadgerStore migrates the database to the current schema version if needed
+func NewBadgerStore(db *badger.DB) (*BadgerStore, error) {
+	if err := migrate(db); err != nil {
+		return nil, err
+	}
+
+	return &BadgerStore{db: db}, nil
+}
+
+func (s *BadgerStore) SetAttestation(chainID string, height uint64, blockHash []byte, attestation types.Attestation) error {
+	aBz, err := attestation.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(txn *badger.Txn) error {
+		if err := txn.Set(attestationKey(chainID, height), aBz); err != nil {
+			return err
+		}
+		return txn.Set(blockHashKey(chainID, height), blockHash)
+	})
+}
+
+func (s *BadgerStore) GetAttestation(chainID string, height uint64) (types.Attestation, error) {
+	return s.get(attestationKey(chainID, height))
+}
+
+func (s *BadgerStore) GetLatestAttestation(chainID string) (types.Attestation, error) {
+	var attestation types.Attestation
+	found := false
+	if err := s.iterate(attestationPrefix(chainID), 0, math.MaxUint64, true, func(_ uint64, val []byte) (bool, error) {
+		found = true
+		return false, attestation.Unmarshal(val)
+	}); err != nil {
+		return types.Attestation{}, err
+	}
+	if !found {
+		return types.Attestation{}, errors.WithStack(ErrNotFound)
+	}
+
+	return attestation, nil
+}
+
+func (s *BadgerStore) IterateAttestations(chainID string, fromHeight, toHeight uint64, fn func(height uint64, attestation types.Attestation) bool) error {
+	return s.iterate(attestationPrefix(chainID), fromHeight, toHeight, false, func(height uint64, val []byte) (bool, error) {
+		var attestation types.Attestation
+		if err := attestation.Unmarshal(val); err != nil {
+			return false, err
+		}
+		return fn(height, attestation), nil
+	})
+}
+
+func (s *BadgerStore) RecentBlockHashes(chainID string, limit int) ([]BlockHash, error) {
+	var blockHashes []BlockHash
+	if err := s.iterate(blockHashPrefix(chainID), 0, math.MaxUint64, true, func(height uint64, val []byte) (bool, error) {
+		if len(blockHashes) >= limit {
+			return false, nil
+		}
+		blockHashes = append(blockHashes, BlockHash{
+			Height: height,
+			Hash:   val,
+		})
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return blockHashes, nil
+}
+
+func (s *BadgerStore) RetractAttestations(chainID string, heights []uint64) error {
+	return s.db.Update(func(txn *badger.Txn) error {
+		for _, height := range heights {
+			item, err := txn.Get(attestationKey(chainID, height))
+			if err != nil && !errors.Is(err, badger.ErrKeyNotFound) {
+				return err
+			}
+			if err == nil {
+				aBz, err := item.ValueCopy(nil)
+				if err != nil {
+					return err
+				}
+				if err := txn.Set(retractedKey(chainID, height), aBz); err != nil {
+					return err
+				}
+				if err := txn.Delete(attestationKey(chainID, height)); err != nil {
+					return err
+				}
+			}
+
+			if err := txn.Delete(blockHashKey(chainID, height)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *BadgerStore) GetRetractedAttestation(chainID string, height uint64) (types.Attestation, error) {
+	return s.get(retractedKey(chainID, height))
+}
+
+func (s *BadgerStore) Prune(chainID string, policy config.RetentionPolicy) (int, error) {
+	var latestHeight uint64
+	found := false
+	if err := s.iterate(attestationPrefix(chainID), 0, math.MaxUint64, true, func(height uint64, _ []byte) (bool, error) {
+		latestHeight, found = height, true
+		return false, nil
+	}); err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, nil
+	}
+
+	keepHeight, err := pruneHeight(policy, latestHeight, time.Now(), func(fn func(height uint64, attestation types.Attestation) bool) error {
+		return s.IterateAttestations(chainID, 0, latestHeight, fn)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if keepHeight == 0 {
+		return 0, nil
+	}
+
+	// the keys are collected first and then deleted in a write batch, which splits them over as many transactions as needed
+	var keys [][]byte
+	for _, prefix := range [][]byte{attestationPrefix(chainID), blockHashPrefix(chainID), retractedPrefix(chainID)} {
+		if err := s.iterate(prefix, 0, keepHeight-1, false, func(height uint64, _ []byte) (bool, error) {
+			keys = append(keys, heightKey(prefix, height))
+			return true, nil
+		}); err != nil {
+			return 0, err
+		}
+	}
+	pruned := 0
+	for _, key := range keys {
+		if bytes.HasPrefix(key, attestationPrefix(chainID)) {
+			pruned++
+		}
+	}
+
+	batch := s.db.NewWriteBatch()
+	defer batch.Cancel()
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return 0, err
+		}
+	}
+	if err := batch.Flush(); err != nil {
+		return 0, err
+	}
+
+	return pruned, nil
+}
+
+// CollectGarbage rewrites the value log files of the database that are mostly garbage, until there are none left. An in
+// memory database has no value log, so there is nothing to collect.
+func (s *BadgerStore) CollectGarbage() error {
+	for {
+		if err := s.db.RunValueLogGC(gcDiscardRatio); err != nil {
+			if errors.Is(err, badger.ErrNoRewrite) || errors.Is(err, badger.ErrGCInMemoryMode) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (s *BadgerStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *BadgerStore) get(key []byte) (types.Attestation, error) {
+	var attestation types.Attestation
+	if err := s.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		return item.Value(attestation.Unmarshal)
+	}); err != nil {
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return types.Attestation{}, errors.WithStack(ErrNotFound)
+		}
+		return types.Attestation{}, err
+	}
+
+	return attestation, nil
+}
+
+// iterate calls fn with a copy of the values under the prefix for the heights from fromHeight up to and including
+// toHeight, in order of height or in reverse, until fn returns false
+func (s *BadgerStore) iterate(prefix []byte, fromHeight, toHeight uint64, reverse bool, fn func(height uint64, val []byte) (bool, error)) error {
+	return s.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		opts.Reverse = reverse
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		seek := heightKey(prefix, fromHeight)
+		if reverse {
+			seek = heightKey(prefix, toHeight)
+		}
+		for it.Seek(seek); it.Valid(); it.Next() {
+			item := it.Item()
+			heightBz := item.Key()[len(prefix):]
+			if len(heightBz) != 8 {
+				continue
+			}
+			height := binary.BigEndian.Uint64(heightBz)
+			if height < fromHeight || height > toHeight {
+				break
+			}
+
+			val, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			cont, err := fn(height, val)
+			if err != nil {
+				return err
+			}
+			if !cont {
+				break
+			}
+		}
+
+		return nil
+	})
+}
+
+func attestationPrefix(chainID string) []byte {
+	return []byte(fmt.Sprintf("%s/attestations/", chainID))
+}
+
+func blockHashPrefix(chainID string) []byte {
+	return []byte(fmt.Sprintf("%s/blockhashes/", chainID))
+}
+
+func retractedPrefix(chainID string) []byte {
+	return []byte(fmt.Sprintf("%s/retracted/", chainID))
+}
+
+func attestationKey(chainID string, height uint64) []byte {
+	return heightKey(attestationPrefix(chainID), height)
+}
+
+func blockHashKey(chainID string, height uint64) []byte {
+	return heightKey(blockHashPrefix(chainID), height)
+}
+
+func retractedKey(chainID string, height uint64) []byte {
+	return heightKey(retractedPrefix(chainID), height)
+}
+
+// heightKey has the height big endian encoded, so that the keys are ordered by height
+func heightKey(prefix []byte, height uint64) []byte {
+	return binary.BigEndian.AppendUint64(append([]byte{}, prefix...), height)
+}
@@ -0,0 +1,199 @@
+package store
+
+import (
+	"bytes"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"gitlab.com/tozd/go/errors"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+var _ AttestationStore = &MemStore{}
+
+type memAttestation struct {
+	attestation types.Attestation
+	blockHash   []byte
+}
+
+type memChain struct {
+	attestations map[uint64]memAttestation
+	retracted    map[uint64]types.Attestation
+}
+
+// MemStore keeps the attestations in memory, e.g. for tests
+type MemStore struct {
+	lock   sync.RWMutex
+	chains map[string]*memChain
+}
+
+func NewMemStore() *MemStore {
+	return &MemStore{
+		chains: make(map[string]*memChain),
+	}
+}
+
+// readChain returns the chain without adding it, for reads under the read lock
+func (s *MemStore) readChain(chainID string) *memChain {
+	if chain, ok := s.chains[chainID]; ok {
+		return chain
+	}
+	return &memChain{}
+}
+
+func (s *MemStore) chain(chainID string) *memChain {
+	chain, ok := s.chains[chainID]
+	if !ok {
+		chain = &memChain{
+			attestations: make(map[uint64]memAttestation),
+			retracted:    make(map[uint64]types.Attestation),
+		}
+		s.chains[chainID] = chain
+	}
+	return chain
+}
+
+func (s *MemStore) SetAttestation(chainID string, height uint64, blockHash []byte, attestation types.Attestation) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.chain(chainID).attestations[height] = memAttestation{
+		attestation: attestation,
+		blockHash:   bytes.Clone(blockHash),
+	}
+	return nil
+}
+
+func (s *MemStore) GetAttestation(chainID string, height uint64) (types.Attestation, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	stored, ok := s.readChain(chainID).attestations[height]
+	if !ok {
+		return types.Attestation{}, errors.WithStack(ErrNotFound)
+	}
+	return stored.attestation, nil
+}
+
+func (s *MemStore) GetLatestAttestation(chainID string) (types.Attestation, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	chain := s.readChain(chainID)
+	if len(chain.attestations) == 0 {
+		return types.Attestation{}, errors.WithStack(ErrNotFound)
+	}
+	return chain.attestations[slices.Max(slices.Collect(maps.Keys(chain.attestations)))].attestation, nil
+}
+
+func (s *MemStore) IterateAttestations(chainID string, fromHeight, toHeight uint64, fn func(height uint64, attestation types.Attestation) bool) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	chain := s.readChain(chainID)
+	for _, height := range slices.Sorted(maps.Keys(chain.attestations)) {
+		if height < fromHeight || height > toHeight {
+			continue
+		}
+		if !fn(height, chain.attestations[height].attestation) {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *MemStore) RecentBlockHashes(chainID string, limit int) ([]BlockHash, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	chain := s.readChain(chainID)
+	heights := slices.Sorted(maps.Keys(chain.attestations))
+	slices.Reverse(heights)
+
+	var blockHashes []BlockHash
+	for _, height := range heights[:min(limit, len(heights))] {
+		blockHashes = append(blockHashes, BlockHash{
+			Height: height,
+			Hash:   bytes.Clone(chain.attestations[height].blockHash),
+		})
+	}
+	return blockHashes, nil
+}
+
+func (s *MemStore) RetractAttestations(chainID string, heights []uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	chain := s.chain(chainID)
+	for _, height := range heights {
+		stored, ok := chain.attestations[height]
+		if !ok {
+			continue
+		}
+		chain.retracted[height] = stored.attestation
+		delete(chain.attestations, height)
+	}
+	return nil
+}
+
+func (s *MemStore) GetRetractedAttestation(chainID string, height uint64) (types.Attestation, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	attestation, ok := s.readChain(chainID).retracted[height]
+	if !ok {
+		return types.Attestation{}, errors.WithStack(ErrNotFound)
+	}
+	return attestation, nil
+}
+
+func (s *MemStore) Prune(chainID string, policy config.RetentionPolicy) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	chain := s.chain(chainID)
+	if len(chain.attestations) == 0 {
+		return 0, nil
+	}
+	heights := slices.Sorted(maps.Keys(chain.attestations))
+
+	keepHeight, err := pruneHeight(policy, heights[len(heights)-1], time.Now(), func(fn func(height uint64, attestation types.Attestation) bool) error {
+		for _, height := range heights {
+			if !fn(height, chain.attestations[height].attestation) {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	pruned := 0
+	for _, height := range heights {
+		if height >= keepHeight {
+			break
+		}
+		delete(chain.attestations, height)
+		pruned++
+	}
+	for height := range chain.retracted {
+		if height < keepHeight {
+			delete(chain.retracted, height)
+		}
+	}
+	return pruned, nil
+}
+
+// CollectGarbage does nothing, since pruned attestations are freed right away
+func (s *MemStore) CollectGarbage() error {
+	return nil
+}
+
+func (s *MemStore) Close() error {
+	return nil
+}
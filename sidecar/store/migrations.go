@@ -0,0 +1,198 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strconv"
+
+	"github.com/dgraph-io/badger/v4"
+	"gitlab.com/tozd/go/errors"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+)
+
+// SchemaVersion is the version of the key layout written by this version of the sidecar
+const SchemaVersion = 3
+
+// schemaVersionKey holds the schema version of the database. Databases written before the schema was versioned don't
+// have it, and are at version 1.
+var schemaVersionKey = []byte("schema_version")
+
+// migrations upgrade the database from the version at their index + 1 to the next one. A migration has to be safe to
+// run again if the sidecar stopped before the new version was stored.
+var migrations = []func(db *badger.DB) error{
+	migrateHeightKeys,
+	dropLegacyAttestations,
+}
+
+// migrate runs the migrations from the stored schema version up to SchemaVersion
+func migrate(db *badger.DB) error {
+	version, err := schemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if version > SchemaVersion {
+		return errors.Errorf("database schema version %d is newer than the supported version %d", version, SchemaVersion)
+	}
+
+	for ; version < SchemaVersion; version++ {
+		if err := migrations[version-1](db); err != nil {
+			return errors.Errorf("failed to migrate database to schema version %d: %w", version+1, err)
+		}
+		if err := db.Update(func(txn *badger.Txn) error {
+			return txn.Set(schemaVersionKey, binary.BigEndian.AppendUint64(nil, version+1))
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func schemaVersion(db *badger.DB) (uint64, error) {
+	version := uint64(1)
+	if err := db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(schemaVersionKey)
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			if len(val) != 8 {
+				return errors.Errorf("invalid schema version %x", val)
+			}
+			version = binary.BigEndian.Uint64(val)
+			return nil
+		})
+	}); err != nil && !errors.Is(err, badger.ErrKeyNotFound) {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// migrateHeightKeys moves the attestations and retracted attestations from keys with decimal heights to keys with big
+// endian heights, so they are ordered by height, and drops the copy of the latest attestation, which is now the one at
+// the highest height
+func migrateHeightKeys(db *badger.DB) error {
+	type rename struct {
+		from, to []byte
+		value    []byte
+	}
+
+	var renames []rename
+	var deletes [][]byte
+	if err := db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			key := item.KeyCopy(nil)
+			prefix, suffix, ok := splitHeightKey(key)
+			if !ok {
+				continue
+			}
+
+			if bytes.HasSuffix(prefix, []byte("/attestations/")) && string(suffix) == "latest" {
+				deletes = append(deletes, key)
+				continue
+			}
+			// keys that were already migrated don't parse, since big endian heights start with a zero byte
+			height, err := strconv.ParseUint(string(suffix), 10, 64)
+			if err != nil {
+				continue
+			}
+			value, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			renames = append(renames, rename{
+				from:  key,
+				to:    heightKey(prefix, height),
+				value: value,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	batch := db.NewWriteBatch()
+	defer batch.Cancel()
+	for _, r := range renames {
+		if err := batch.Set(r.to, r.value); err != nil {
+			return err
+		}
+		if err := batch.Delete(r.from); err != nil {
+			return err
+		}
+	}
+	for _, key := range deletes {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	return batch.Flush()
+}
+
+// dropLegacyAttestations deletes the attestations and retracted attestations written before attestations carried a
+// versioned payload. Their attested data is in a field that is now reserved, so they decode with an empty payload and
+// can't be verified. The block hash of a dropped attestation is deleted with it, like when an attestation is retracted.
+func dropLegacyAttestations(db *badger.DB) error {
+	var deletes [][]byte
+	if err := db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			key := item.KeyCopy(nil)
+			prefix, suffix, ok := splitHeightKey(key)
+			if !ok || len(suffix) != 8 {
+				continue
+			}
+
+			var attestation types.Attestation
+			if err := item.Value(attestation.Unmarshal); err != nil {
+				return errors.Errorf("failed to decode attestation %q: %w", key, err)
+			}
+			if attestation.Payload.Unpack() != nil {
+				continue
+			}
+
+			deletes = append(deletes, key)
+			if bytes.HasSuffix(prefix, []byte("/attestations/")) {
+				chainID := prefix[:len(prefix)-len("/attestations/")]
+				deletes = append(deletes, blockHashKey(string(chainID), binary.BigEndian.Uint64(suffix)))
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	batch := db.NewWriteBatch()
+	defer batch.Cancel()
+	for _, key := range deletes {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	return batch.Flush()
+}
+
+// splitHeightKey splits a key of the attestations or retracted attestations of a chain into its prefix and height
+func splitHeightKey(key []byte) ([]byte, []byte, bool) {
+	for _, segment := range [][]byte{[]byte("/attestations/"), []byte("/retracted/")} {
+		if i := bytes.Index(key, segment); i > 0 {
+			end := i + len(segment)
+			return key[:end], key[end:], true
+		}
+	}
+
+	return nil, nil, false
+}
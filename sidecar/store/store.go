@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/tozd/go/errors"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+// ErrNotFound is returned when there is no attestation for a chain at a height
+var ErrNotFound = errors.Base("attestation not found")
+
+// BlockHash is the hash of the block an attestation was made for, used to detect reorgs
+type BlockHash struct {
+	Height uint64
+	Hash   []byte
+}
+
+// AttestationStore stores the signed attestations of the attested chains by height, together with the hashes of the
+// attested blocks. The latest attestation of a chain is the one for the highest height.
+type AttestationStore interface {
+	// SetAttestation stores the attestation for the height, with the hash of the attested block
+	SetAttestation(chainID string, height uint64, blockHash []byte, attestation types.Attestation) error
+	// GetAttestation returns the attestation for the height, or ErrNotFound
+	GetAttestation(chainID string, height uint64) (types.Attestation, error)
+	// GetLatestAttestation returns the attestation for the highest height, or ErrNotFound
+	GetLatestAttestation(chainID string) (types.Attestation, error)
+	// IterateAttestations calls fn with the attestations for the heights from fromHeight up to and including toHeight, in
+	// order of height, until fn returns false
+	IterateAttestations(chainID string, fromHeight, toHeight uint64, fn func(height uint64, attestation types.Attestation) bool) error
+
+	// RecentBlockHashes returns the block hashes of at most limit of the most recent attestations, newest first
+	RecentBlockHashes(chainID string, limit int) ([]BlockHash, error)
+	// RetractAttestations moves the attestations for the heights to the retracted attestations, so they are no longer served
+	RetractAttestations(chainID string, heights []uint64) error
+	// GetRetractedAttestation returns the retracted attestation for the height, or ErrNotFound
+	GetRetractedAttestation(chainID string, height uint64) (types.Attestation, error)
+
+	// Prune deletes everything stored for the heights of the chain outside the retention window, and returns the number
+	// of pruned attestations
+	Prune(chainID string, policy config.RetentionPolicy) (int, error)
+	// CollectGarbage reclaims the space freed by pruning, for stores that need it
+	CollectGarbage() error
+
+	Close() error
+}
+
+// pruneHeight returns the lowest height to keep for the retention policy, given the attestations of the chain in order
+// of height. The latest attestation is always kept.
+func pruneHeight(policy config.RetentionPolicy, latestHeight uint64, now time.Time, iterate func(fn func(height uint64, attestation types.Attestation) bool) error) (uint64, error) {
+	keepHeight := uint64(0)
+	if policy.Heights > 0 && latestHeight >= policy.Heights {
+		keepHeight = latestHeight - policy.Heights + 1
+	}
+
+	if policy.Age > 0 {
+		// attestations are ordered by height, which is also the order of their block times
+		cutoff := now.Add(-policy.Age)
+		if err := iterate(func(height uint64, attestation types.Attestation) bool {
+			if height >= latestHeight {
+				return false
+			}
+			payload := attestation.Payload.Unpack()
+			if payload != nil && !payload.GetTimestamp().Before(cutoff) {
+				return false
+			}
+			keepHeight = max(keepHeight, height+1)
+			return true
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	return min(keepHeight, latestHeight), nil
+}
+
+// RunGarbageCollection prunes the attestations of the chains outside the retention window and reclaims the freed space,
+// every GC interval of the policy until the context is done. The chains are listed again for every garbage collection,
+// since they can change at runtime.
+func RunGarbageCollection(ctx context.Context, logger *zap.Logger, s AttestationStore, chainIDs func() []string, policy config.RetentionPolicy) {
+	ticker := time.NewTicker(policy.GCInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, chainID := range chainIDs() {
+			pruned, err := s.Prune(chainID, policy)
+			if err != nil {
+				logger.Error("Failed to prune attestations", zap.String("chain_id", chainID), zap.Error(err))
+				continue
+			}
+			if pruned > 0 {
+				logger.Debug("Pruned attestations", zap.String("chain_id", chainID), zap.Int("pruned", pruned))
+			}
+		}
+
+		if err := s.CollectGarbage(); err != nil {
+			logger.Error("Failed to collect garbage in the attestation database", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/require"
+
+	clienttypes "github.com/cosmos/ibc-go/v9/modules/core/02-client/types"
+
+	"github.com/cosmos/interchain-attestation/core/types"
+	"github.com/cosmos/interchain-attestation/sidecar/config"
+)
+
+const testChainID = "testChainID"
+
+func testAttestation(height uint64, timestamp time.Time) types.Attestation {
+	return types.Attestation{
+		AttestatorId: []byte("testAttestatorID"),
+		Payload: types.NewIBCDataPayload(types.IBCData{
+			ChainId:   testChainID,
+			ClientId:  "testClientID",
+			Height:    clienttypes.NewHeight(1, height),
+			Timestamp: timestamp,
+		}),
+		Signature: []byte{byte(height)},
+	}
+}
+
+func newBadgerStore(t *testing.T) *BadgerStore {
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()).WithLogger(nil))
+	require.NoError(t, err)
+	s, err := NewBadgerStore(db)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+	})
+	return s
+}
+
+func TestAttestationStore(t *testing.T) {
+	for name, newStore := range map[string]func(t *testing.T) AttestationStore{
+		"badger": func(t *testing.T) AttestationStore { return newBadgerStore(t) },
+		"memory": func(_ *testing.T) AttestationStore { return NewMemStore() },
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Run("set and get", func(t *testing.T) {
+				s := newStore(t)
+
+				_, err := s.GetLatestAttestation(testChainID)
+				require.ErrorIs(t, err, ErrNotFound)
+
+				// stored out of order, and across the boundary where decimal keys would sort wrong
+				for _, height := range []uint64{9, 10, 2, 100} {
+					require.NoError(t, s.SetAttestation(testChainID, height, []byte{byte(height)}, testAttestation(height, time.Now())))
+				}
+				require.NoError(t, s.SetAttestation("otherChainID", 1000, []byte{1}, testAttestation(1000, time.Now())))
+
+				attestation, err := s.GetAttestation(testChainID, 10)
+				require.NoError(t, err)
+				require.Equal(t, uint64(10), attestation.Payload.AttestedHeight().RevisionHeight)
+				_, err = s.GetAttestation(testChainID, 11)
+				require.ErrorIs(t, err, ErrNotFound)
+
+				latest, err := s.GetLatestAttestation(testChainID)
+				require.NoError(t, err)
+				require.Equal(t, uint64(100), latest.Payload.AttestedHeight().RevisionHeight)
+
+				var heights []uint64
+				require.NoError(t, s.IterateAttestations(testChainID, 3, 100, func(height uint64, attestation types.Attestation) bool {
+					require.Equal(t, height, attestation.Payload.AttestedHeight().RevisionHeight)
+					heights = append(heights, height)
+					return true
+				}))
+				require.Equal(t, []uint64{9, 10, 100}, heights)
+
+				heights = nil
+				require.NoError(t, s.IterateAttestations(testChainID, 0, 1000, func(height uint64, _ types.Attestation) bool {
+					heights = append(heights, height)
+					return len(heights) < 2
+				}))
+				require.Equal(t, []uint64{2, 9}, heights)
+
+				blockHashes, err := s.RecentBlockHashes(testChainID, 3)
+				require.NoError(t, err)
+				require.Equal(t, []BlockHash{
+					{Height: 100, Hash: []byte{100}},
+					{Height: 10, Hash: []byte{10}},
+					{Height: 9, Hash: []byte{9}},
+				}, blockHashes)
+			})
+
+			t.Run("retract", func(t *testing.T) {
+				s := newStore(t)
+				for height := uint64(1); height <= 5; height++ {
+					require.NoError(t, s.SetAttestation(testChainID, height, []byte{byte(height)}, testAttestation(height, time.Now())))
+				}
+
+				require.NoError(t, s.RetractAttestations(testChainID, []uint64{5, 4}))
+
+				for height := uint64(4); height <= 5; height++ {
+					_, err := s.GetAttestation(testChainID, height)
+					require.ErrorIs(t, err, ErrNotFound)
+					retracted, err := s.GetRetractedAttestation(testChainID, height)
+					require.NoError(t, err)
+					require.Equal(t, height, retracted.Payload.AttestedHeight().RevisionHeight)
+				}
+				_, err := s.GetRetractedAttestation(testChainID, 3)
+				require.ErrorIs(t, err, ErrNotFound)
+
+				latest, err := s.GetLatestAttestation(testChainID)
+				require.NoError(t, err)
+				require.Equal(t, uint64(3), latest.Payload.AttestedHeight().RevisionHeight)
+
+				blockHashes, err := s.RecentBlockHashes(testChainID, 1)
+				require.NoError(t, err)
+				require.Equal(t, []BlockHash{{Height: 3, Hash: []byte{3}}}, blockHashes)
+			})
+
+			t.Run("prune by heights", func(t *testing.T) {
+				s := newStore(t)
+				for height := uint64(1); height <= 10; height++ {
+					require.NoError(t, s.SetAttestation(testChainID, height, []byte{byte(height)}, testAttestation(height, time.Now())))
+				}
+				require.NoError(t, s.RetractAttestations(testChainID, []uint64{10}))
+
+				pruned, err := s.Prune(testChainID, config.RetentionPolicy{Heights: 3})
+				require.NoError(t, err)
+				require.Equal(t, 6, pruned)
+
+				for height := uint64(1); height <= 6; height++ {
+					_, err := s.GetAttestation(testChainID, height)
+					require.ErrorIs(t, err, ErrNotFound)
+				}
+				for height := uint64(7); height <= 9; height++ {
+					_, err := s.GetAttestation(testChainID, height)
+					require.NoError(t, err)
+				}
+				_, err = s.GetRetractedAttestation(testChainID, 10)
+				require.NoError(t, err)
+
+				pruned, err = s.Prune(testChainID, config.RetentionPolicy{Heights: 3})
+				require.NoError(t, err)
+				require.Zero(t, pruned)
+				require.NoError(t, s.CollectGarbage())
+			})
+
+			t.Run("prune by age", func(t *testing.T) {
+				s := newStore(t)
+				now := time.Now()
+				for height := uint64(1); height <= 10; height++ {
+					require.NoError(t, s.SetAttestation(testChainID, height, []byte{byte(height)}, testAttestation(height, now.Add(-time.Duration(10-height)*time.Hour))))
+				}
+
+				pruned, err := s.Prune(testChainID, config.RetentionPolicy{Age: 150 * time.Minute})
+				require.NoError(t, err)
+				require.Equal(t, 7, pruned)
+
+				var heights []uint64
+				require.NoError(t, s.IterateAttestations(testChainID, 0, 100, func(height uint64, _ types.Attestation) bool {
+					heights = append(heights, height)
+					return true
+				}))
+				require.Equal(t, []uint64{8, 9, 10}, heights)
+
+				// the latest attestation is kept, however old it is
+				pruned, err = s.Prune(testChainID, config.RetentionPolicy{Age: time.Minute, Heights: 1})
+				require.NoError(t, err)
+				require.Equal(t, 2, pruned)
+				latest, err := s.GetLatestAttestation(testChainID)
+				require.NoError(t, err)
+				require.Equal(t, uint64(10), latest.Payload.AttestedHeight().RevisionHeight)
+			})
+		})
+	}
+}
+
+func TestBadgerStore_Migrate(t *testing.T) {
+	db, err := badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	require.NoError(t, err)
+	defer db.Close()
+
+	// the layout before the schema was versioned, with decimal heights and a copy of the latest attestation
+	trackerKey := []byte(testChainID + "/commitmenttracker/0011223344556677/state")
+	require.NoError(t, db.Update(func(txn *badger.Txn) error {
+		for _, height := range []uint64{9, 10, 11} {
+			attestation := testAttestation(height, time.Now())
+			aBz, err := attestation.Marshal()
+			if err != nil {
+				return err
+			}
+			if height == 11 {
+				if err := txn.Set([]byte(testChainID+"/retracted/"+fmt.Sprint(height)), aBz); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := txn.Set([]byte(testChainID+"/attestations/"+fmt.Sprint(height)), aBz); err != nil {
+				return err
+			}
+			if err := txn.Set(blockHashKey(testChainID, height), []byte{byte(height)}); err != nil {
+				return err
+			}
+			if err := txn.Set([]byte(testChainID+"/attestations/latest"), aBz); err != nil {
+				return err
+			}
+		}
+		return txn.Set(trackerKey, []byte("{}"))
+	}))
+
+	s, err := NewBadgerStore(db)
+	require.NoError(t, err)
+
+	version, err := schemaVersion(db)
+	require.NoError(t, err)
+	require.Equal(t, uint64(SchemaVersion), version)
+
+	latest, err := s.GetLatestAttestation(testChainID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), latest.Payload.AttestedHeight().RevisionHeight)
+	for _, height := range []uint64{9, 10} {
+		attestation, err := s.GetAttestation(testChainID, height)
+		require.NoError(t, err)
+		require.Equal(t, height, attestation.Payload.AttestedHeight().RevisionHeight)
+	}
+	_, err = s.GetRetractedAttestation(testChainID, 11)
+	require.NoError(t, err)
+
+	require.NoError(t, db.View(func(txn *badger.Txn) error {
+		for _, key := range []string{"/attestations/9", "/attestations/10", "/attestations/latest", "/retracted/11"} {
+			_, err := txn.Get([]byte(testChainID + key))
+			require.ErrorIs(t, err, badger.ErrKeyNotFound)
+		}
+		_, err := txn.Get(trackerKey)
+		return err
+	}))
+
+	// migrating again does nothing
+	_, err = NewBadgerStore(db)
+	require.NoError(t, err)
+	attestation, err := s.GetAttestation(testChainID, 10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), attestation.Payload.AttestedHeight().RevisionHeight)
+
+	// a database from a newer sidecar is not touched
+	require.NoError(t, db.Update(func(txn *badger.Txn) error {
+		return txn.Set(schemaVersionKey, binary.BigEndian.AppendUint64(nil, SchemaVersion+1))
+	}))
+	_, err = NewBadgerStore(db)
+	require.ErrorContains(t, err, "newer than the supported version")
+}
+
+func TestBadgerStore_MigrateLegacyAttestations(t *testing.T) {
+	db, err := badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	require.NoError(t, err)
+	defer db.Close()
+
+	// attestations written before attestations carried a versioned payload have the attested data in field 2
+	legacyAttestation := func(height uint64) []byte {
+		data := types.IBCData{
+			ChainId:  testChainID,
+			ClientId: "testClientID",
+			Height:   clienttypes.NewHeight(1, height),
+		}
+		dataBz, err := data.Marshal()
+		require.NoError(t, err)
+
+		var bz []byte
+		bz = append(bz, 0x0a, byte(len("testAttestatorID")))
+		bz = append(bz, "testAttestatorID"...)
+		bz = append(bz, 0x12, byte(len(dataBz)))
+		bz = append(bz, dataBz...)
+		bz = append(bz, 0x1a, 1, byte(height))
+		return bz
+	}
+
+	attestation := testAttestation(7, time.Now())
+	aBz, err := attestation.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, db.Update(func(txn *badger.Txn) error {
+		if err := txn.Set(schemaVersionKey, binary.BigEndian.AppendUint64(nil, 2)); err != nil {
+			return err
+		}
+		if err := txn.Set(attestationKey(testChainID, 5), legacyAttestation(5)); err != nil {
+			return err
+		}
+		if err := txn.Set(blockHashKey(testChainID, 5), []byte{5}); err != nil {
+			return err
+		}
+		if err := txn.Set(retractedKey(testChainID, 6), legacyAttestation(6)); err != nil {
+			return err
+		}
+		if err := txn.Set(attestationKey(testChainID, 7), aBz); err != nil {
+			return err
+		}
+		return txn.Set(blockHashKey(testChainID, 7), []byte{7})
+	}))
+
+	// the legacy record decodes, but without a payload
+	var legacy types.Attestation
+	require.NoError(t, legacy.Unmarshal(legacyAttestation(5)))
+	require.Nil(t, legacy.Payload.Unpack())
+
+	s, err := NewBadgerStore(db)
+	require.NoError(t, err)
+
+	version, err := schemaVersion(db)
+	require.NoError(t, err)
+	require.Equal(t, uint64(SchemaVersion), version)
+
+	_, err = s.GetAttestation(testChainID, 5)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = s.GetRetractedAttestation(testChainID, 6)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	latest, err := s.GetLatestAttestation(testChainID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), latest.Payload.AttestedHeight().RevisionHeight)
+
+	blockHashes, err := s.RecentBlockHashes(testChainID, 10)
+	require.NoError(t, err)
+	require.Equal(t, []BlockHash{{Height: 7, Hash: []byte{7}}}, blockHashes)
+}
@@ -3,9 +3,9 @@ package suite
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"testing"
 
 	"github.com/pelletier/go-toml/v2"
@@ -21,16 +21,15 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v8/chain/ethereum"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	ictestutil "github.com/strangelove-ventures/interchaintest/v8/testutil"
 
-	attestationve "github.com/cosmos/interchain-attestation/core/voteextension"
 	"github.com/cosmos/interchain-attestation/sidecar/config"
 )
 
 const (
-	relayerKeyName  = "relayer"
-	relayerMnemonic = "worry enable range three surprise skull arctic flame swear crush bunker panel stumble nature strike candy mango junior jealous add sea title unaware alpha"
-
-	nodeSidecarConfigFileName = "sidecar-config.json"
+	relayerKeyName   = "relayer"
+	relayerMnemonic  = "worry enable range three surprise skull arctic flame swear crush bunker panel stumble nature strike candy mango junior jealous add sea title unaware alpha"
+	sidecarAuthToken = "interchaintest-sidecar-token"
 )
 
 // Not const because we need to give them as pointers later
@@ -159,6 +158,11 @@ func (s *E2ETestSuite) setupSidecars(ctx context.Context) {
 		sidecarConfig := config.Config{
 			CosmosChains: chainConfigs,
 			AttestatorID: attestorID,
+			HostChainID:  simappChainID,
+			Server: config.ServerConfig{
+				ListenAddress: "0.0.0.0:6969",
+				AuthToken:     sidecarAuthToken,
+			},
 		}
 
 		byteWriter := new(bytes.Buffer)
@@ -176,20 +180,41 @@ func (s *E2ETestSuite) setupSidecars(ctx context.Context) {
 		}, []string{})
 		s.Require().NoError(err, string(stdOut), string(stdErr))
 
+		// Generate the attestation key and register it with the validator
+		stdOut, stdErr, err = sidecar.Exec(ctx, []string{"attestation-sidecar", "attestation-key", "generate", "--home", "/home/sidecar"}, []string{})
+		s.Require().NoError(err, string(stdOut), string(stdErr))
+
+		registrationJSON, stdErr, err := sidecar.Exec(ctx, []string{"attestation-sidecar", "attestation-key", "registration", "--home", "/home/sidecar"}, []string{})
+		s.Require().NoError(err, string(stdErr))
+		err = val.WriteFile(ctx, registrationJSON, "attestator-registration.json")
+		s.Require().NoError(err)
+		_, err = val.ExecTx(ctx, "validator", "attestationconfig", "register-attestator", path.Join(val.HomeDir(), "attestator-registration.json"))
+		s.Require().NoError(err)
+
 		err = sidecar.CreateContainer(ctx)
 		s.Require().NoError(err)
 
 		err = sidecar.StartContainer(ctx)
 		s.Require().NoError(err)
 
-		nodeSidecarConfig := attestationve.SidecarConfig{
-			SidecarAddress: fmt.Sprintf("%s:6969", sidecar.HostName()),
-		}
-		nodeSidecarConfigBz, err := json.Marshal(nodeSidecarConfig)
+		err = ictestutil.ModifyTomlConfigFile(ctx, zaptest.NewLogger(s.T()), val.DockerClient, val.TestName, val.VolumeName, "config/app.toml", ictestutil.Toml{
+			"attestation-sidecar": ictestutil.Toml{
+				"enabled":    true,
+				"address":    fmt.Sprintf("%s:6969", sidecar.HostName()),
+				"auth-token": sidecarAuthToken,
+			},
+		})
+		s.Require().NoError(err)
+
+		// Restart the node so it picks up the sidecar config
+		err = val.StopContainer(ctx)
 		s.Require().NoError(err)
-		err = val.WriteFile(ctx, nodeSidecarConfigBz, nodeSidecarConfigFileName)
+		err = val.StartContainer(ctx)
 		s.Require().NoError(err)
 	}
+
+	err := ictestutil.WaitForBlocks(ctx, 2, s.Simapp)
+	s.Require().NoError(err)
 }
 
 func (s *E2ETestSuite) TearDownSuite() {
@@ -233,9 +258,6 @@ func (s *E2ETestSuite) getChainFactory() *interchaintest.BuiltinChainFactory {
 				ConfigFileOverrides: nil,
 				EncodingConfig:      getEncodingConfig(),
 				ModifyGenesis:       cosmos.ModifyGenesis(genesis),
-				Env: []string{
-					fmt.Sprintf("%s=/var/cosmos-chain/simapp/%s", attestationve.SidecarConfigPathEnv, nodeSidecarConfigFileName),
-				},
 				SidecarConfigs: []ibc.SidecarConfig{
 					{
 						ProcessName: "attestationsidecar",
@@ -246,7 +268,7 @@ func (s *E2ETestSuite) getChainFactory() *interchaintest.BuiltinChainFactory {
 						},
 						HomeDir:          "",
 						Ports:            []string{"6969/tcp"},
-						StartCmd:         []string{"/usr/bin/attestation-sidecar", "--verbose", "start", "--home", "/home/sidecar", "--listen-addr", "0.0.0.0:6969"},
+						StartCmd:         []string{"/usr/bin/attestation-sidecar", "--verbose", "start", "--home", "/home/sidecar"},
 						Env:              nil,
 						PreStart:         false,
 						ValidatorProcess: true,
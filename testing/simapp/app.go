@@ -62,6 +62,12 @@ import (
 // DefaultNodeHome default home directories for the application daemon
 var DefaultNodeHome string
 
+// voteExtensionOrder is the order of the vote extension modules, which decides the index of the tx each of them
+// injects into a proposal
+var voteExtensionOrder = []string{
+	attestationve.ModuleName,
+}
+
 var (
 	_ runtime.AppI            = (*SimApp)(nil)
 	_ servertypes.Application = (*SimApp)(nil)
@@ -106,7 +112,8 @@ type SimApp struct {
 	ScopedIBCKeeper         capabilitykeeper.ScopedKeeper
 	ScopedIBCTransferKeeper capabilitykeeper.ScopedKeeper
 
-	AttestationConfigKeeper attestationconfigkeeper.Keeper
+	AttestationConfigKeeper        attestationconfigkeeper.Keeper
+	AttestationVoteExtensionKeeper *attestationve.Keeper
 
 	// simulation manager
 	sm *module.SimulationManager
@@ -244,21 +251,11 @@ func NewSimApp(
 
 	app.App = appBuilder.Build(db, traceStore, baseAppOptions...)
 
-	app.App.SetExtendVoteHandler(ve.ExtendVoteHandler(app.App.ModuleManager, []string{
-		attestationve.ModuleName,
-	}))
-	app.App.SetVerifyVoteExtensionHandler(ve.VerifyExtensionHandler(app.App.ModuleManager, []string{
-		attestationve.ModuleName,
-	}))
-	app.App.SetPrepareProposal(ve.PrepareProposalHandler(app.App.ModuleManager, []string{
-		attestationve.ModuleName,
-	}))
-	app.App.SetProcessProposal(ve.ProcessProposalHandler(app.App.ModuleManager, []string{
-		attestationve.ModuleName,
-	}))
-	app.App.SetPreBlocker(ve.PreBlocker(app.App.ModuleManager, []string{
-		attestationve.ModuleName,
-	}))
+	app.App.SetExtendVoteHandler(ve.ExtendVoteHandler(app.App.ModuleManager, voteExtensionOrder))
+	app.App.SetVerifyVoteExtensionHandler(ve.VerifyExtensionHandler(app.App.ModuleManager, voteExtensionOrder))
+	app.App.SetPrepareProposal(ve.PrepareProposalHandler(app.App.ModuleManager, voteExtensionOrder))
+	app.App.SetProcessProposal(ve.ProcessProposalHandler(app.App.ModuleManager, voteExtensionOrder))
+	app.App.SetPreBlocker(ve.PreBlocker(app.App.ModuleManager, voteExtensionOrder))
 
 	// Register legacy modules
 	if err := app.registerIBCModules(appOpts); err != nil {
@@ -399,6 +396,15 @@ func (app *SimApp) RegisterAPIRoutes(apiSvr *api.Server, apiConfig config.APICon
 	}
 }
 
+// Close closes the connection to the attestation sidecar and the underlying app.
+func (app *SimApp) Close() error {
+	if err := app.AttestationVoteExtensionKeeper.Close(); err != nil {
+		return err
+	}
+
+	return app.App.Close()
+}
+
 // GetMaccPerms returns a copy of the module account permissions
 //
 // NOTE: This is solely to be used for testing purposes.
@@ -36,7 +36,7 @@ import (
 )
 
 // registerIBCModules register IBC keepers and non dependency inject modules.
-func (app *SimApp) registerIBCModules(_ servertypes.AppOptions) error {
+func (app *SimApp) registerIBCModules(appOpts servertypes.AppOptions) error {
 	// set up non depinject support modules store keys
 	if err := app.RegisterStores(
 		storetypes.NewKVStoreKey(capabilitytypes.StoreKey),
@@ -132,6 +132,15 @@ func (app *SimApp) registerIBCModules(_ servertypes.AppOptions) error {
 	)
 	clientKeeper.AddRoute(attestationlightclient.ModuleName, &attestationLightClientModule)
 
+	attestationSidecarConfig, err := attestationve.ReadConfig(appOpts)
+	if err != nil {
+		return err
+	}
+	app.AttestationVoteExtensionKeeper, err = attestationve.NewKeeper(attestationSidecarConfig)
+	if err != nil {
+		return err
+	}
+
 	// register IBC modules
 	if err := app.RegisterModules(
 		ibc.NewAppModule(app.IBCKeeper),
@@ -141,7 +150,8 @@ func (app *SimApp) registerIBCModules(_ servertypes.AppOptions) error {
 		ibctm.NewAppModule(tmLightClientModule),
 		solomachine.NewAppModule(smLightClientModule),
 		attestationlightclient.NewAppModule(attestationLightClientModule),
-		attestationve.NewAppModule(trustedUpdateClientFunc, app.appCodec),
+		attestationve.NewAppModule(app.AttestationVoteExtensionKeeper, &attestationLightClientModule, trustedUpdateClientFunc, app.appCodec).
+			WithVoteExtensionOrder(voteExtensionOrder),
 	); err != nil {
 		return err
 	}
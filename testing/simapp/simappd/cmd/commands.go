@@ -28,6 +28,7 @@ import (
 
 	cmtcfg "github.com/cometbft/cometbft/config"
 
+	attestationve "github.com/cosmos/interchain-attestation/core/voteextension"
 	"github.com/cosmos/interchain-attestation/simapp"
 )
 
@@ -60,6 +61,8 @@ func initAppConfig() (string, interface{}) {
 		serverconfig.Config `mapstructure:",squash"`
 
 		Custom CustomConfig `mapstructure:"custom"`
+
+		AttestationSidecar attestationve.Config `mapstructure:"attestation-sidecar"`
 	}
 
 	// Optionally allow the chain developer to overwrite the SDK's default
@@ -86,6 +89,7 @@ func initAppConfig() (string, interface{}) {
 		Custom: CustomConfig{
 			CustomField: "anything",
 		},
+		AttestationSidecar: attestationve.DefaultConfig(),
 	}
 
 	// The default SDK app template is defined in serverconfig.DefaultConfigTemplate.
@@ -95,7 +99,8 @@ func initAppConfig() (string, interface{}) {
 [custom]
 # That field will be parsed by server.InterceptConfigsPreRunHandler and held by viper.
 # Do not forget to add quotes around the value if it is a string.
-custom-field = "{{ .Custom.CustomField }}"`
+custom-field = "{{ .Custom.CustomField }}"
+` + attestationve.DefaultConfigTemplate
 
 	return customAppTemplate, customAppConfig
 }